@@ -0,0 +1,250 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// BinSpec describes how two table columns are histogrammed into an image.
+//
+// When the range of an axis is not specified (ie: Min == Max), it is taken
+// from the TLMINn/TLMAXn keywords of the column, if present, or from the
+// range of the column values otherwise.
+type BinSpec struct {
+	XMin, XMax float64 // range of the X axis
+	YMin, YMax float64 // range of the Y axis
+	XBin, YBin float64 // size of the bins along each axis (default: 1)
+
+	// Weight is the name of an optional column holding the weight of
+	// each row. Rows have a weight of 1 otherwise.
+	Weight string
+}
+
+// BinTable histograms the values of the columns xcol and ycol of the table t
+// into a 2-dimensional image.
+//
+// Values v are binned in [min, max], the last bin including max.
+// Rows with values outside of that range, or NaN values, are ignored.
+//
+// The image holds 32-bit integer counts, or 64-bit floating point values if
+// a weight column is specified.
+// The CTYPEn, CUNITn, CRPIXn, CRVALn and CDELTn keywords describe the
+// mapping between image pixels and column values.
+func BinTable(t *Table, xcol, ycol string, spec BinSpec) (Image, error) {
+	if t == nil {
+		return nil, fmt.Errorf("fitsio: nil table")
+	}
+
+	var icols [3]int
+	for i, name := range []string{xcol, ycol, spec.Weight} {
+		if i == 2 && name == "" {
+			icols[i] = -1
+			continue
+		}
+		icol := t.Index(name)
+		if icol < 0 {
+			return nil, fmt.Errorf("fitsio: no column %q in table %q", name, t.Name())
+		}
+		kind := t.cols[icol].Type().Kind()
+		if !isIntKind(kind) && !isFloatKind(kind) {
+			return nil, fmt.Errorf("fitsio: column %q is not a scalar numerical column", name)
+		}
+		icols[i] = icol
+	}
+
+	xs, err := readFloatColumn(t, icols[0])
+	if err != nil {
+		return nil, err
+	}
+	ys, err := readFloatColumn(t, icols[1])
+	if err != nil {
+		return nil, err
+	}
+	var ws []float64
+	if icols[2] >= 0 {
+		ws, err = readFloatColumn(t, icols[2])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	xaxis, err := newBinAxis(t, icols[0], xs, spec.XMin, spec.XMax, spec.XBin)
+	if err != nil {
+		return nil, err
+	}
+	yaxis, err := newBinAxis(t, icols[1], ys, spec.YMin, spec.YMax, spec.YBin)
+	if err != nil {
+		return nil, err
+	}
+
+	hist := make([]float64, xaxis.n*yaxis.n)
+	for i := range xs {
+		ix, ok := xaxis.index(xs[i])
+		if !ok {
+			continue
+		}
+		iy, ok := yaxis.index(ys[i])
+		if !ok {
+			continue
+		}
+		w := 1.0
+		if ws != nil {
+			w = ws[i]
+		}
+		hist[iy*xaxis.n+ix] += w
+	}
+
+	bitpix := 32
+	if ws != nil {
+		bitpix = -64
+	}
+	img := NewImage(bitpix, []int{xaxis.n, yaxis.n})
+
+	cards := make([]Card, 0, 10)
+	for i, axis := range []binAxis{xaxis, yaxis} {
+		col := &t.cols[icols[i]]
+		n := i + 1
+		cards = append(cards,
+			Card{
+				Name:    fmt.Sprintf("CTYPE%d", n),
+				Value:   col.Name,
+				Comment: fmt.Sprintf("name of the binned column for axis %d", n),
+			},
+		)
+		if col.Unit != "" {
+			cards = append(cards,
+				Card{
+					Name:    fmt.Sprintf("CUNIT%d", n),
+					Value:   col.Unit,
+					Comment: fmt.Sprintf("unit of axis %d", n),
+				},
+			)
+		}
+		cards = append(cards,
+			Card{
+				Name:    fmt.Sprintf("CRPIX%d", n),
+				Value:   1.0,
+				Comment: fmt.Sprintf("reference pixel of axis %d", n),
+			},
+			Card{
+				Name:    fmt.Sprintf("CRVAL%d", n),
+				Value:   axis.min + 0.5*axis.bin,
+				Comment: fmt.Sprintf("value at the reference pixel of axis %d", n),
+			},
+			Card{
+				Name:    fmt.Sprintf("CDELT%d", n),
+				Value:   axis.bin,
+				Comment: fmt.Sprintf("bin size of axis %d", n),
+			},
+		)
+	}
+	err = img.Header().Append(cards...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch bitpix {
+	case 32:
+		data := make([]int32, len(hist))
+		for i, v := range hist {
+			data[i] = int32(v)
+		}
+		err = img.Write(data)
+	default:
+		err = img.Write(hist)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+// readFloatColumn reads all the values of the i-th (numerical) column of t
+// as float64 values.
+func readFloatColumn(t *Table, icol int) ([]float64, error) {
+	rv := reflect.New(reflect.SliceOf(t.cols[icol].Type()))
+	err := t.ReadColumn(icol, rv.Interface())
+	if err != nil {
+		return nil, err
+	}
+	rv = rv.Elem()
+	vs := make([]float64, rv.Len())
+	for i := range vs {
+		vs[i] = toFloat(rv.Index(i))
+	}
+	return vs, nil
+}
+
+// binAxis describes the binning of a table column.
+type binAxis struct {
+	min, max float64
+	bin      float64
+	n        int // number of bins
+}
+
+func newBinAxis(t *Table, icol int, vs []float64, min, max, bin float64) (binAxis, error) {
+	name := t.cols[icol].Name
+	if bin == 0 {
+		bin = 1
+	}
+	if !(bin > 0) {
+		return binAxis{}, fmt.Errorf("fitsio: invalid bin size (%v) for column %q", bin, name)
+	}
+
+	if min == max {
+		min, max = math.Inf(+1), math.Inf(-1)
+		if card := t.hdr.Get(fmt.Sprintf("TLMIN%d", icol+1)); card != nil {
+			min, _ = cardFloat(card)
+		}
+		if card := t.hdr.Get(fmt.Sprintf("TLMAX%d", icol+1)); card != nil {
+			max, _ = cardFloat(card)
+		}
+		if math.IsInf(min, 0) || math.IsInf(max, 0) {
+			lo, hi := math.Inf(+1), math.Inf(-1)
+			for _, v := range vs {
+				if math.IsNaN(v) {
+					continue
+				}
+				lo = math.Min(lo, v)
+				hi = math.Max(hi, v)
+			}
+			if math.IsInf(min, 0) {
+				min = lo
+			}
+			if math.IsInf(max, 0) {
+				max = hi
+			}
+		}
+	}
+
+	switch {
+	case math.IsInf(min, 0) || math.IsInf(max, 0):
+		return binAxis{}, fmt.Errorf("fitsio: no value to bin for column %q", name)
+	case min > max:
+		return binAxis{}, fmt.Errorf("fitsio: invalid range [%v, %v] for column %q", min, max, name)
+	}
+
+	n := int(math.Ceil((max - min) / bin))
+	if n == 0 {
+		n = 1
+	}
+	return binAxis{min: min, max: max, bin: bin, n: n}, nil
+}
+
+// index returns the index of the bin holding v.
+func (axis binAxis) index(v float64) (int, bool) {
+	if !(v >= axis.min && v <= axis.max) {
+		return 0, false
+	}
+	i := int((v - axis.min) / axis.bin)
+	if i >= axis.n {
+		i = axis.n - 1
+	}
+	return i, true
+}
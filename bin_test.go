@@ -0,0 +1,96 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBinTableHistogram(t *testing.T) {
+	type Event struct {
+		X float32 `fits:"X"`
+		Y int16   `fits:"Y"`
+		W float64 `fits:"W"`
+	}
+
+	tbl, err := NewTableFrom("events", Event{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	tbl.Col(0).Unit = "pixel"
+
+	for _, evt := range []Event{
+		{0, 0, 1},
+		{0.5, 0, 2},
+		{1.5, 1, 3},
+		{3, 2, 4},    // max value: included in the last bin
+		{3.5, 0, 5},  // out of range
+		{2.2, -1, 6}, // out of range
+	} {
+		err = tbl.Write(&evt)
+		if err != nil {
+			t.Fatalf("could not write event: %v", err)
+		}
+	}
+
+	img, err := BinTable(tbl, "X", "Y", BinSpec{XMin: 0, XMax: 3, YMin: 0, YMax: 2})
+	if err != nil {
+		t.Fatalf("could not bin table: %v", err)
+	}
+	defer img.Close()
+
+	hdr := img.Header()
+	if got, want := hdr.Axes(), []int{3, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid axes. got=%v, want=%v", got, want)
+	}
+	if got, want := hdr.Bitpix(), 32; got != want {
+		t.Fatalf("invalid bitpix. got=%d, want=%d", got, want)
+	}
+	for _, card := range []Card{
+		{Name: "CTYPE1", Value: "X"},
+		{Name: "CUNIT1", Value: "pixel"},
+		{Name: "CRVAL1", Value: 0.5},
+		{Name: "CDELT1", Value: 1.0},
+		{Name: "CTYPE2", Value: "Y"},
+		{Name: "CRPIX2", Value: 1.0},
+	} {
+		got := hdr.Get(card.Name)
+		if got == nil || got.Value != card.Value {
+			t.Fatalf("invalid %s card. got=%v, want=%v", card.Name, got, card.Value)
+		}
+	}
+
+	counts := make([]int32, 6)
+	err = img.Read(&counts)
+	if err != nil {
+		t.Fatalf("could not read image: %v", err)
+	}
+	if want := []int32{2, 0, 0, 0, 1, 1}; !reflect.DeepEqual(counts, want) {
+		t.Fatalf("invalid counts.\ngot= %v\nwant=%v", counts, want)
+	}
+
+	img, err = BinTable(tbl, "X", "Y", BinSpec{XBin: 2, YBin: 4, Weight: "W"})
+	if err != nil {
+		t.Fatalf("could not bin table: %v", err)
+	}
+	if got, want := img.Header().Axes(), []int{2, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid axes. got=%v, want=%v", got, want)
+	}
+	sums := make([]float64, 2)
+	err = img.Read(&sums)
+	if err != nil {
+		t.Fatalf("could not read image: %v", err)
+	}
+	if want := []float64{1 + 2 + 3, 4 + 5 + 6}; !reflect.DeepEqual(sums, want) {
+		t.Fatalf("invalid weighted sums.\ngot= %v\nwant=%v", sums, want)
+	}
+
+	_, err = BinTable(tbl, "X", "NONE", BinSpec{})
+	if err == nil {
+		t.Fatalf("expected an error for a missing column")
+	}
+}
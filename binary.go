@@ -28,19 +28,21 @@ func (r *rbuf) Read(p []byte) (int, error) {
 	return n, nil
 }
 
+// next returns the next n bytes of the buffer and advances past them.
+func (r *rbuf) next(n int) []byte {
+	beg := r.c
+	r.c += n
+	return r.p[beg:r.c:r.c]
+}
+
 func (r *rbuf) readByte(v *byte) {
 	*v = r.p[r.c]
 	r.c++
 }
 
 func (r *rbuf) readBool(v *bool) {
-	b := r.p[r.c]
+	*v = decodeBool(r.p[r.c])
 	r.c++
-	if b == 0 {
-		*v = false
-	} else {
-		*v = true
-	}
 }
 
 func (r *rbuf) readI8(v *int8) {
@@ -138,80 +140,100 @@ func (r *rbuf) readC128(v *complex128) {
 }
 
 func (r *rbuf) readBools(vs []bool) {
+	p := r.next(len(vs))
 	for i := range vs {
-		r.readBool(&vs[i])
+		vs[i] = decodeBool(p[i])
 	}
 }
 
 func (r *rbuf) readI8s(vs []int8) {
+	p := r.next(len(vs))
 	for i := range vs {
-		r.readI8(&vs[i])
+		vs[i] = int8(p[i])
 	}
 }
 
 func (r *rbuf) readI16s(vs []int16) {
+	p := r.next(2 * len(vs))
 	for i := range vs {
-		r.readI16(&vs[i])
+		vs[i] = int16(binary.BigEndian.Uint16(p))
+		p = p[2:]
 	}
 }
 
 func (r *rbuf) readI32s(vs []int32) {
+	p := r.next(4 * len(vs))
 	for i := range vs {
-		r.readI32(&vs[i])
+		vs[i] = int32(binary.BigEndian.Uint32(p))
+		p = p[4:]
 	}
 }
 
 func (r *rbuf) readI64s(vs []int64) {
+	p := r.next(8 * len(vs))
 	for i := range vs {
-		r.readI64(&vs[i])
+		vs[i] = int64(binary.BigEndian.Uint64(p))
+		p = p[8:]
 	}
 }
 
 func (r *rbuf) readInts(vs []int) {
+	p := r.next(8 * len(vs))
 	for i := range vs {
-		r.readInt(&vs[i])
+		vs[i] = int(int64(binary.BigEndian.Uint64(p)))
+		p = p[8:]
 	}
 }
 
 func (r *rbuf) readU8s(vs []uint8) {
-	for i := range vs {
-		r.readU8(&vs[i])
-	}
+	copy(vs, r.next(len(vs)))
 }
 
 func (r *rbuf) readU16s(vs []uint16) {
+	p := r.next(2 * len(vs))
 	for i := range vs {
-		r.readU16(&vs[i])
+		vs[i] = binary.BigEndian.Uint16(p)
+		p = p[2:]
 	}
 }
 
 func (r *rbuf) readU32s(vs []uint32) {
+	p := r.next(4 * len(vs))
 	for i := range vs {
-		r.readU32(&vs[i])
+		vs[i] = binary.BigEndian.Uint32(p)
+		p = p[4:]
 	}
 }
 
 func (r *rbuf) readU64s(vs []uint64) {
+	p := r.next(8 * len(vs))
 	for i := range vs {
-		r.readU64(&vs[i])
+		vs[i] = binary.BigEndian.Uint64(p)
+		p = p[8:]
 	}
 }
 
 func (r *rbuf) readUints(vs []uint) {
+	p := r.next(8 * len(vs))
 	for i := range vs {
-		r.readUint(&vs[i])
+		vs[i] = uint(binary.BigEndian.Uint64(p))
+		p = p[8:]
 	}
 }
 
 func (r *rbuf) readF32s(vs []float32) {
+	p := r.next(4 * len(vs))
 	for i := range vs {
-		r.readF32(&vs[i])
+		vs[i] = math.Float32frombits(binary.BigEndian.Uint32(p))
+		p = p[4:]
 	}
 }
 
 func (r *rbuf) readF64s(vs []float64) {
+	p := r.next(8 * len(vs))
 	for i := range vs {
-		r.readF64(&vs[i])
+		vs[i] = math.Float64frombits(binary.BigEndian.Uint64(p))
+		p = p[8:]
 	}
 }
 
@@ -249,17 +271,20 @@ func (w *wbuf) bytes() []byte {
 	return w.p
 }
 
+// next returns the next n bytes of the buffer and advances past them.
+func (w *wbuf) next(n int) []byte {
+	beg := w.c
+	w.c += n
+	return w.p[beg:w.c:w.c]
+}
+
 func (w *wbuf) writeByte(v byte) {
 	w.p[w.c] = v
 	w.c++
 }
 
 func (w *wbuf) writeBool(v bool) {
-	var b byte
-	if v {
-		b = 1
-	}
-	w.p[w.c] = b
+	w.p[w.c] = encodeBool(v)
 	w.c++
 }
 
@@ -352,8 +377,9 @@ func (w *wbuf) writeC128(v complex128) {
 }
 
 func (w *wbuf) writeBools(vs []bool) {
-	for _, v := range vs {
-		w.writeBool(v)
+	p := w.next(len(vs))
+	for i, v := range vs {
+		p[i] = encodeBool(v)
 	}
 }
 
@@ -364,56 +390,70 @@ func (w *wbuf) writeI8s(vs []int8) {
 }
 
 func (w *wbuf) writeI16s(vs []int16) {
+	p := w.next(2 * len(vs))
 	for _, v := range vs {
-		w.writeI16(v)
+		binary.BigEndian.PutUint16(p, uint16(v))
+		p = p[2:]
 	}
 }
 
 func (w *wbuf) writeI32s(vs []int32) {
+	p := w.next(4 * len(vs))
 	for _, v := range vs {
-		w.writeI32(v)
+		binary.BigEndian.PutUint32(p, uint32(v))
+		p = p[4:]
 	}
 }
 
 func (w *wbuf) writeI64s(vs []int64) {
+	p := w.next(8 * len(vs))
 	for _, v := range vs {
-		w.writeI64(v)
+		binary.BigEndian.PutUint64(p, uint64(v))
+		p = p[8:]
 	}
 }
 
 func (w *wbuf) writeU8s(vs []uint8) {
-	for _, v := range vs {
-		w.writeU8(v)
-	}
+	copy(w.next(len(vs)), vs)
 }
 
 func (w *wbuf) writeU16s(vs []uint16) {
+	p := w.next(2 * len(vs))
 	for _, v := range vs {
-		w.writeU16(v)
+		binary.BigEndian.PutUint16(p, v)
+		p = p[2:]
 	}
 }
 
 func (w *wbuf) writeU32s(vs []uint32) {
+	p := w.next(4 * len(vs))
 	for _, v := range vs {
-		w.writeU32(v)
+		binary.BigEndian.PutUint32(p, v)
+		p = p[4:]
 	}
 }
 
 func (w *wbuf) writeU64s(vs []uint64) {
+	p := w.next(8 * len(vs))
 	for _, v := range vs {
-		w.writeU64(v)
+		binary.BigEndian.PutUint64(p, v)
+		p = p[8:]
 	}
 }
 
 func (w *wbuf) writeF32s(vs []float32) {
+	p := w.next(4 * len(vs))
 	for _, v := range vs {
-		w.writeF32(v)
+		binary.BigEndian.PutUint32(p, math.Float32bits(v))
+		p = p[4:]
 	}
 }
 
 func (w *wbuf) writeF64s(vs []float64) {
+	p := w.next(8 * len(vs))
 	for _, v := range vs {
-		w.writeF64(v)
+		binary.BigEndian.PutUint64(p, math.Float64bits(v))
+		p = p[8:]
 	}
 }
 
@@ -430,13 +470,33 @@ func (w *wbuf) writeC128s(vs []complex128) {
 }
 
 func (w *wbuf) writeUints(vs []uint) {
+	p := w.next(8 * len(vs))
 	for _, v := range vs {
-		w.writeUint(v)
+		binary.BigEndian.PutUint64(p, uint64(v))
+		p = p[8:]
 	}
 }
 
 func (w *wbuf) writeInts(vs []int) {
+	p := w.next(8 * len(vs))
 	for _, v := range vs {
-		w.writeInt(v)
+		binary.BigEndian.PutUint64(p, uint64(v))
+		p = p[8:]
 	}
 }
+
+// encodeBool returns the byte of a logical value, as stored in a binary
+// table: 'T' for true and 'F' for false.
+func encodeBool(v bool) byte {
+	if v {
+		return 'T'
+	}
+	return 'F'
+}
+
+// decodeBool returns the logical value stored as b in a binary table.
+// Besides 'T' and 'F', the 1 and 0 bytes written by older versions of
+// fitsio are accepted. The 0 byte of undefined values decodes as false.
+func decodeBool(b byte) bool {
+	return b != 0 && b != 'F'
+}
@@ -0,0 +1,155 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestBulkConversions(t *testing.T) {
+	const n = 17
+	var (
+		i16s = make([]int16, n)
+		i32s = make([]int32, n)
+		i64s = make([]int64, n)
+		u16s = make([]uint16, n)
+		u32s = make([]uint32, n)
+		u64s = make([]uint64, n)
+		f32s = make([]float32, n)
+		f64s = make([]float64, n)
+	)
+	for i := 0; i < n; i++ {
+		i16s[i] = int16(-i * 1001)
+		i32s[i] = int32(-i * 100001)
+		i64s[i] = int64(-i) << 40
+		u16s[i] = uint16(i * 3001)
+		u32s[i] = uint32(i) << 24
+		u64s[i] = uint64(i) << 56
+		f32s[i] = float32(i) * -1.5
+		f64s[i] = math.Pi * float64(i)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		vs    interface{}
+		size  int
+		bulkw func(w *wbuf, vs interface{})
+		scalw func(w *wbuf, vs interface{})
+		bulkr func(r *rbuf, n int) interface{}
+	}{
+		{
+			name: "int16", vs: i16s, size: 2,
+			bulkw: func(w *wbuf, vs interface{}) { w.writeI16s(vs.([]int16)) },
+			scalw: func(w *wbuf, vs interface{}) {
+				for _, v := range vs.([]int16) {
+					w.writeI16(v)
+				}
+			},
+			bulkr: func(r *rbuf, n int) interface{} { vs := make([]int16, n); r.readI16s(vs); return vs },
+		},
+		{
+			name: "int32", vs: i32s, size: 4,
+			bulkw: func(w *wbuf, vs interface{}) { w.writeI32s(vs.([]int32)) },
+			scalw: func(w *wbuf, vs interface{}) {
+				for _, v := range vs.([]int32) {
+					w.writeI32(v)
+				}
+			},
+			bulkr: func(r *rbuf, n int) interface{} { vs := make([]int32, n); r.readI32s(vs); return vs },
+		},
+		{
+			name: "int64", vs: i64s, size: 8,
+			bulkw: func(w *wbuf, vs interface{}) { w.writeI64s(vs.([]int64)) },
+			scalw: func(w *wbuf, vs interface{}) {
+				for _, v := range vs.([]int64) {
+					w.writeI64(v)
+				}
+			},
+			bulkr: func(r *rbuf, n int) interface{} { vs := make([]int64, n); r.readI64s(vs); return vs },
+		},
+		{
+			name: "uint16", vs: u16s, size: 2,
+			bulkw: func(w *wbuf, vs interface{}) { w.writeU16s(vs.([]uint16)) },
+			scalw: func(w *wbuf, vs interface{}) {
+				for _, v := range vs.([]uint16) {
+					w.writeU16(v)
+				}
+			},
+			bulkr: func(r *rbuf, n int) interface{} { vs := make([]uint16, n); r.readU16s(vs); return vs },
+		},
+		{
+			name: "uint32", vs: u32s, size: 4,
+			bulkw: func(w *wbuf, vs interface{}) { w.writeU32s(vs.([]uint32)) },
+			scalw: func(w *wbuf, vs interface{}) {
+				for _, v := range vs.([]uint32) {
+					w.writeU32(v)
+				}
+			},
+			bulkr: func(r *rbuf, n int) interface{} { vs := make([]uint32, n); r.readU32s(vs); return vs },
+		},
+		{
+			name: "uint64", vs: u64s, size: 8,
+			bulkw: func(w *wbuf, vs interface{}) { w.writeU64s(vs.([]uint64)) },
+			scalw: func(w *wbuf, vs interface{}) {
+				for _, v := range vs.([]uint64) {
+					w.writeU64(v)
+				}
+			},
+			bulkr: func(r *rbuf, n int) interface{} { vs := make([]uint64, n); r.readU64s(vs); return vs },
+		},
+		{
+			name: "float32", vs: f32s, size: 4,
+			bulkw: func(w *wbuf, vs interface{}) { w.writeF32s(vs.([]float32)) },
+			scalw: func(w *wbuf, vs interface{}) {
+				for _, v := range vs.([]float32) {
+					w.writeF32(v)
+				}
+			},
+			bulkr: func(r *rbuf, n int) interface{} { vs := make([]float32, n); r.readF32s(vs); return vs },
+		},
+		{
+			name: "float64", vs: f64s, size: 8,
+			bulkw: func(w *wbuf, vs interface{}) { w.writeF64s(vs.([]float64)) },
+			scalw: func(w *wbuf, vs interface{}) {
+				for _, v := range vs.([]float64) {
+					w.writeF64(v)
+				}
+			},
+			bulkr: func(r *rbuf, n int) interface{} { vs := make([]float64, n); r.readF64s(vs); return vs },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// leave a byte before and after the values to check offsets.
+			bulk := newWriter(make([]byte, n*tc.size+2))
+			bulk.writeU8(0xff)
+			tc.bulkw(bulk, tc.vs)
+			bulk.writeU8(0xfe)
+
+			scal := newWriter(make([]byte, n*tc.size+2))
+			scal.writeU8(0xff)
+			tc.scalw(scal, tc.vs)
+			scal.writeU8(0xfe)
+
+			if !bytes.Equal(bulk.bytes(), scal.bytes()) {
+				t.Fatalf("bulk and scalar encodings differ")
+			}
+
+			r := newReader(bulk.bytes())
+			var beg, end uint8
+			r.readU8(&beg)
+			got := tc.bulkr(r, n)
+			r.readU8(&end)
+			if beg != 0xff || end != 0xfe {
+				t.Fatalf("invalid offsets: beg=0x%x end=0x%x", beg, end)
+			}
+			if !reflect.DeepEqual(got, tc.vs) {
+				t.Fatalf("invalid round-trip:\ngot= %v\nwant=%v", got, tc.vs)
+			}
+		})
+	}
+}
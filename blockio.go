@@ -0,0 +1,143 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// AlignBlock returns sz rounded up to a whole number of FITS blocks.
+func AlignBlock(sz int) int {
+	padding := PadBlock(sz)
+	return sz + padding
+}
+
+// PadBlock returns the number of bytes needed to complete sz bytes to
+// a whole number of FITS blocks.
+func PadBlock(sz int) int {
+	padding := (BlockSize - (sz % BlockSize)) % BlockSize
+	return padding
+}
+
+// BlockReader reads a FITS stream block by block.
+// It does not interpret the blocks: it is meant for tools working on the
+// raw structure of FITS files, such as custom HDU decoders or repair tools.
+type BlockReader struct {
+	r io.Reader
+	n int64 // number of bytes read so far
+}
+
+// NewBlockReader returns a BlockReader reading blocks from r.
+func NewBlockReader(r io.Reader) *BlockReader {
+	return &BlockReader{r: r}
+}
+
+// Offset returns the number of bytes read so far, a multiple of BlockSize.
+func (br *BlockReader) Offset() int64 {
+	return br.n
+}
+
+// ReadBlock reads the next block.
+// It returns io.EOF when the stream ends on a block boundary, and an error
+// wrapping ErrShortData when it ends in the middle of a block.
+func (br *BlockReader) ReadBlock() ([]byte, error) {
+	block := make([]byte, BlockSize)
+	_, err := br.ReadBlocks(block)
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// ReadBlocks fills p, whose length must be a multiple of BlockSize, with
+// the next blocks of the stream, and returns the number of bytes read.
+// It returns io.EOF when the stream ends on a block boundary before any
+// byte was read, and an error wrapping ErrShortData when it ends before p
+// is filled.
+// On error, n is rounded down to the last whole block read, so that the
+// reader stays aligned.
+func (br *BlockReader) ReadBlocks(p []byte) (n int, err error) {
+	if len(p)%BlockSize != 0 {
+		return 0, fmt.Errorf("%w: can not read %d bytes", ErrNotAligned, len(p))
+	}
+	n, err = io.ReadFull(br.r, p)
+	switch {
+	case err == io.EOF:
+		return 0, io.EOF
+	case err != nil:
+		err = shortRead(err, ErrShortData, n, len(p))
+		n -= n % BlockSize
+		br.n += int64(n)
+		return n, err
+	}
+	br.n += int64(n)
+	return n, nil
+}
+
+// BlockWriter writes a FITS stream block by block.
+// It keeps track of the number of bytes written, so that the stream can be
+// completed to a whole number of blocks with Pad.
+type BlockWriter struct {
+	w io.Writer
+	n int64 // number of bytes written so far
+}
+
+// NewBlockWriter returns a BlockWriter writing blocks to w.
+func NewBlockWriter(w io.Writer) *BlockWriter {
+	return &BlockWriter{w: w}
+}
+
+// Offset returns the number of bytes written so far.
+func (bw *BlockWriter) Offset() int64 {
+	return bw.n
+}
+
+// Aligned returns whether the bytes written so far make a whole number of
+// blocks.
+func (bw *BlockWriter) Aligned() bool {
+	return bw.n%BlockSize == 0
+}
+
+// Write writes p, of any length, to the underlying io.Writer.
+// The stream must be completed with Pad once a header or a data unit has
+// been written.
+func (bw *BlockWriter) Write(p []byte) (int, error) {
+	n, err := bw.w.Write(p)
+	bw.n += int64(n)
+	return n, err
+}
+
+// WriteBlocks writes p, whose length must be a multiple of BlockSize, at
+// a block boundary.
+func (bw *BlockWriter) WriteBlocks(p []byte) error {
+	if len(p)%BlockSize != 0 {
+		return fmt.Errorf("%w: can not write %d bytes", ErrNotAligned, len(p))
+	}
+	if !bw.Aligned() {
+		return fmt.Errorf("%w: can not write blocks at offset %d", ErrNotAligned, bw.n)
+	}
+	_, err := bw.Write(p)
+	if err != nil {
+		return fmt.Errorf("fitsio: could not write %d blocks: %w", len(p)/BlockSize, err)
+	}
+	return nil
+}
+
+// Pad completes the bytes written so far to a whole number of blocks with
+// fill: spaces after a header or the data unit of an ASCII table, and
+// zeros after other data units.
+func (bw *BlockWriter) Pad(fill byte) error {
+	n := PadBlock(int(bw.n % BlockSize))
+	if n == 0 {
+		return nil
+	}
+	_, err := bw.Write(bytes.Repeat([]byte{fill}, n))
+	if err != nil {
+		return fmt.Errorf("fitsio: could not pad block: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestBlockIO(t *testing.T) {
+	for _, tc := range []struct {
+		sz, pad int
+	}{
+		{0, 0},
+		{1, BlockSize - 1},
+		{BlockSize, 0},
+		{BlockSize + 80, BlockSize - 80},
+	} {
+		if got := PadBlock(tc.sz); got != tc.pad {
+			t.Errorf("PadBlock(%d): got=%d, want=%d", tc.sz, got, tc.pad)
+		}
+		if got, want := AlignBlock(tc.sz), tc.sz+tc.pad; got != want {
+			t.Errorf("AlignBlock(%d): got=%d, want=%d", tc.sz, got, want)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := NewBlockWriter(buf)
+	_, err := w.Write([]byte("SIMPLE"))
+	if err != nil {
+		t.Fatalf("could not write: %v", err)
+	}
+	if w.Aligned() {
+		t.Fatalf("writer should not be aligned")
+	}
+	err = w.WriteBlocks(make([]byte, BlockSize))
+	if !errors.Is(err, ErrNotAligned) {
+		t.Fatalf("invalid error writing at offset %d: %v", w.Offset(), err)
+	}
+	err = w.Pad(' ')
+	if err != nil {
+		t.Fatalf("could not pad: %v", err)
+	}
+	if got, want := w.Offset(), int64(BlockSize); got != want {
+		t.Fatalf("invalid offset: got=%d, want=%d", got, want)
+	}
+	err = w.WriteBlocks(make([]byte, 10))
+	if !errors.Is(err, ErrNotAligned) {
+		t.Fatalf("invalid error writing a partial block: %v", err)
+	}
+	err = w.WriteBlocks(bytes.Repeat([]byte{1}, 2*BlockSize))
+	if err != nil {
+		t.Fatalf("could not write blocks: %v", err)
+	}
+
+	r := NewBlockReader(bytes.NewReader(append(buf.Bytes(), 2, 2)))
+	block, err := r.ReadBlock()
+	if err != nil {
+		t.Fatalf("could not read block: %v", err)
+	}
+	if want := append([]byte("SIMPLE"), bytes.Repeat([]byte(" "), BlockSize-6)...); !bytes.Equal(block, want) {
+		t.Fatalf("invalid first block")
+	}
+	_, err = r.ReadBlocks(make([]byte, 10))
+	if !errors.Is(err, ErrNotAligned) {
+		t.Fatalf("invalid error reading a partial block: %v", err)
+	}
+	n, err := r.ReadBlocks(make([]byte, 3*BlockSize))
+	if !errors.Is(err, ErrShortData) {
+		t.Fatalf("invalid error reading past the last block: %v", err)
+	}
+	if got, want := n, 2*BlockSize; got != want {
+		t.Fatalf("invalid number of bytes read: got=%d, want=%d", got, want)
+	}
+	if got, want := r.Offset(), int64(3*BlockSize); got != want {
+		t.Fatalf("invalid offset: got=%d, want=%d", got, want)
+	}
+
+	r = NewBlockReader(bytes.NewReader(buf.Bytes()[:BlockSize]))
+	_, err = r.ReadBlock()
+	if err != nil {
+		t.Fatalf("could not read block: %v", err)
+	}
+	_, err = r.ReadBlock()
+	if err != io.EOF {
+		t.Fatalf("invalid error at end of stream: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// WithCanonicalHeaders makes Create and NewEncoder write the headers of the
+// HDUs in their canonical order, as returned by Header.Canonicalize.
+// The headers of the encoded HDUs are not modified.
+func WithCanonicalHeaders() Option {
+	return func(cfg *config) {
+		cfg.canonical = true
+	}
+}
+
+// WithSortedKeywords makes Header.Canonicalize (and encoders created with
+// WithCanonicalHeaders) sort the non-mandatory keywords by name.
+func WithSortedKeywords() Option {
+	return func(cfg *config) {
+		cfg.sortKeys = true
+	}
+}
+
+// Canonicalize reorders the cards of the header in the order mandated by
+// the FITS standard:
+//   - the SIMPLE (or XTENSION) card,
+//   - the BITPIX, NAXIS and NAXISn cards,
+//   - the GROUPS, PCOUNT, GCOUNT and TFIELDS cards, and the EXTEND card,
+//     when present,
+//   - the other cards, in their original order.
+//
+// With the WithSortedKeywords option, the other keywords are sorted by name
+// and followed by the commentary (COMMENT, HISTORY and blank) cards, in
+// their original order.
+//
+// END cards are removed: encoders write the END card of the header.
+func (hdr *Header) Canonicalize(opts ...Option) {
+	cfg := newConfig(opts)
+	hdr.canonicalize(cfg.sortKeys)
+}
+
+func (hdr *Header) canonicalize(sortKeys bool) {
+	rank := make(map[string]int)
+	for _, key := range []string{"SIMPLE", "XTENSION", "BITPIX", "NAXIS"} {
+		rank[key] = len(rank)
+	}
+	naxis := len(hdr.axes)
+	if card := hdr.Get("NAXIS"); card != nil {
+		if n, err := cardInt(card); err == nil && n > naxis && n <= 999 {
+			naxis = n
+		}
+	}
+	for i := 1; i <= naxis; i++ {
+		rank["NAXIS"+strconv.Itoa(i)] = len(rank)
+	}
+	for _, key := range []string{"GROUPS", "PCOUNT", "GCOUNT", "TFIELDS", "EXTEND"} {
+		rank[key] = len(rank)
+	}
+
+	var (
+		mandatory  = make([]Card, 0, len(rank))
+		keys       = make([]Card, 0, len(hdr.cards))
+		commentary []Card
+	)
+	for _, card := range hdr.cards {
+		switch card.Name {
+		case "END":
+			continue
+		case "", "COMMENT", "HISTORY":
+			if sortKeys {
+				commentary = append(commentary, card)
+				continue
+			}
+		}
+		if _, ok := rank[card.Name]; ok {
+			mandatory = append(mandatory, card)
+			continue
+		}
+		keys = append(keys, card)
+	}
+	slices.SortStableFunc(mandatory, func(a, b Card) int {
+		return rank[a.Name] - rank[b.Name]
+	})
+	if sortKeys {
+		slices.SortStableFunc(keys, func(a, b Card) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+
+	hdr.cards = append(append(mandatory, keys...), commentary...)
+}
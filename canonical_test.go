@@ -0,0 +1,102 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	newHeader := func() *Header {
+		return &Header{
+			htype:  IMAGE_HDU,
+			bitpix: 16,
+			axes:   []int{3, 2},
+			cards: []Card{
+				{Name: "OBJECT", Value: "M31"},
+				{Name: "NAXIS2", Value: 2},
+				{Name: "COMMENT", Comment: "about the observer"},
+				{Name: "OBSERVER", Value: "me"},
+				{Name: "END"},
+				{Name: "EXTEND", Value: true},
+				{Name: "NAXIS", Value: 2},
+				{Name: "BITPIX", Value: 16},
+				{Name: "DATE", Value: "2026-10-15"},
+				{Name: "NAXIS1", Value: 3},
+				{Name: "SIMPLE", Value: true},
+				{Name: "END"},
+			},
+		}
+	}
+	names := func(hdr *Header) []string {
+		var names []string
+		for card := range hdr.Cards() {
+			names = append(names, card.Name)
+		}
+		return names
+	}
+
+	hdr := newHeader()
+	hdr.Canonicalize()
+	want := []string{
+		"SIMPLE", "BITPIX", "NAXIS", "NAXIS1", "NAXIS2", "EXTEND",
+		"OBJECT", "COMMENT", "OBSERVER", "DATE",
+	}
+	if got := names(hdr); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid canonical order:\ngot= %q\nwant=%q", got, want)
+	}
+
+	hdr = newHeader()
+	hdr.Canonicalize(WithSortedKeywords())
+	want = []string{
+		"SIMPLE", "BITPIX", "NAXIS", "NAXIS1", "NAXIS2", "EXTEND",
+		"DATE", "OBJECT", "OBSERVER", "COMMENT",
+	}
+	if got := names(hdr); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid sorted canonical order:\ngot= %q\nwant=%q", got, want)
+	}
+
+	tbl, err := NewTable("events", []Column{{Name: "X", Format: "D"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	err = tbl.Header().Append(Card{Name: "AUTHOR", Value: "me"})
+	if err != nil {
+		t.Fatalf("could not append card: %v", err)
+	}
+	cards := tbl.Header().cards
+	cards[0], cards[len(cards)-1] = cards[len(cards)-1], cards[0]
+
+	buf := new(bytes.Buffer)
+	w, err := Create(buf, WithCanonicalHeaders(), WithSortedKeywords())
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for _, hdu := range []HDU{NewImage(8, nil), tbl} {
+		err = w.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+	f, err := Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+	want = []string{
+		"XTENSION", "BITPIX", "NAXIS", "NAXIS1", "NAXIS2", "PCOUNT", "GCOUNT", "TFIELDS",
+		"AUTHOR", "EXTNAME", "TBCOL1", "TFORM1", "THEAP", "TSCAL1", "TTYPE1", "TZERO1", "END",
+	}
+	if got := names(f.HDU(1).Header()); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid encoded order:\ngot= %q\nwant=%q", got, want)
+	}
+}
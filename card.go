@@ -4,6 +4,8 @@
 
 package fitsio
 
+import "strings"
+
 type Value interface{}
 
 // Card is a record block in a Header
@@ -12,3 +14,29 @@ type Card struct {
 	Value   Value
 	Comment string
 }
+
+// RawCard is the value of a header card which could not be parsed, as
+// kept by decoders created with the WithRawCards option.
+// RawCard holds the original 80 bytes of the card, which are written back
+// verbatim by encoders.
+//
+// Cards holding a RawCard have an empty name: they are listed by
+// Header.RawCards, from which they can be fixed (by setting the name and
+// value of the card) or dropped with Header.DropRawCards.
+type RawCard [80]byte
+
+// Name returns the keyword of the card, from its first 8 bytes.
+func (raw RawCard) Name() string {
+	return strings.TrimRight(string(raw[:8]), " ")
+}
+
+// String returns the original content of the card.
+func (raw RawCard) String() string {
+	return string(raw[:])
+}
+
+// Err returns the error encountered while parsing the card.
+func (raw RawCard) Err() error {
+	_, err := parseHeaderLine(raw[:])
+	return err
+}
@@ -0,0 +1,118 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+// onesSum computes the 32-bit ones' complement sum of a stream of bytes,
+// as defined by the FITS checksum convention.
+// The stream is interpreted as a sequence of big-endian 32-bit words.
+type onesSum struct {
+	hi, lo uint64 // sums of the high and low 16-bit halves of the words
+
+	n   int // number of pending bytes in buf
+	buf [4]byte
+}
+
+// Write adds p to the checksum. It never returns an error.
+func (cs *onesSum) Write(p []byte) (int, error) {
+	n := len(p)
+	if cs.n > 0 {
+		i := copy(cs.buf[cs.n:], p)
+		cs.n += i
+		p = p[i:]
+		if cs.n < 4 {
+			return n, nil
+		}
+		cs.add(cs.buf[:])
+		cs.n = 0
+	}
+	for len(p) >= 4 {
+		cs.add(p[:4])
+		p = p[4:]
+	}
+	cs.n = copy(cs.buf[:], p)
+	return n, nil
+}
+
+func (cs *onesSum) add(p []byte) {
+	cs.hi += uint64(p[0])<<8 | uint64(p[1])
+	cs.lo += uint64(p[2])<<8 | uint64(p[3])
+}
+
+// Sum32 returns the ones' complement sum of the bytes written so far.
+// Pending bytes of an incomplete word are padded with zeros.
+func (cs *onesSum) Sum32() uint32 {
+	hi, lo := cs.hi, cs.lo
+	if cs.n > 0 {
+		var buf [4]byte
+		copy(buf[:], cs.buf[:cs.n])
+		hi += uint64(buf[0])<<8 | uint64(buf[1])
+		lo += uint64(buf[2])<<8 | uint64(buf[3])
+	}
+	for {
+		hicarry := hi >> 16
+		locarry := lo >> 16
+		if hicarry == 0 && locarry == 0 {
+			break
+		}
+		hi = hi&0xffff + locarry
+		lo = lo&0xffff + hicarry
+	}
+	return uint32(hi<<16 | lo)
+}
+
+// addOnes returns the ones' complement sum of a and b.
+func addOnes(a, b uint32) uint32 {
+	sum := uint64(a) + uint64(b)
+	return uint32(sum&0xffffffff + sum>>32)
+}
+
+// encodeChecksum returns the 16-character ASCII encoding of the
+// complement of sum, as stored in the CHECKSUM keyword.
+func encodeChecksum(sum uint32) string {
+	const offset = 0x30 // ASCII '0'
+	exclude := [...]byte{
+		0x3a, 0x3b, 0x3c, 0x3d, 0x3e, 0x3f, 0x40,
+		0x5b, 0x5c, 0x5d, 0x5e, 0x5f, 0x60,
+	}
+
+	value := ^sum
+	var asc [16]byte
+	for i := 0; i < 4; i++ {
+		b := byte(value >> (24 - 8*i))
+		quotient := b/4 + offset
+		remainder := b % 4
+
+		var ch [4]byte
+		for j := range ch {
+			ch[j] = quotient
+		}
+		ch[0] += remainder
+
+		// avoid ASCII punctuation characters.
+		for check := true; check; {
+			check = false
+			for _, x := range exclude {
+				for j := 0; j < 4; j += 2 {
+					if ch[j] == x || ch[j+1] == x {
+						ch[j]++
+						ch[j+1]--
+						check = true
+					}
+				}
+			}
+		}
+
+		for j := range ch {
+			asc[4*j+i] = ch[j]
+		}
+	}
+
+	// rotate the encoded string by one character to the right.
+	var out [16]byte
+	for i := range out {
+		out[i] = asc[(i+15)%16]
+	}
+	return string(out[:])
+}
@@ -0,0 +1,66 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command fits is a tool to inspect and manipulate FITS files.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/astrogo/fitsio/internal/fitscmd"
+)
+
+var cmds = []struct {
+	name  string
+	short string
+	run   func(prog string, args []string) int
+}{
+	{"header", "list the header keywords of a FITS file", fitscmd.Header},
+	{"describe", "describe the HDUs and table columns of a FITS file", fitscmd.Describe},
+	{"stat", "print statistics about the images and tables of a FITS file", fitscmd.Stat},
+	{"table", "list the content of FITS tables", fitscmd.Table},
+	{"copy", "copy a FITS file", fitscmd.Copy},
+	{"merge", "merge FITS tables into a single file", fitscmd.Merge},
+	{"convert", "convert tables between FITS and CSV/TSV", fitscmd.Convert},
+	{"compress", "compress the images of a FITS file", fitscmd.Compress},
+	{"decompress", "decompress the images and tables of a FITS file", fitscmd.Decompress},
+	{"verify", "check that FITS files follow the FITS standard", fitscmd.Verify},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) < 1 {
+		usage()
+		return 1
+	}
+
+	name := args[0]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		usage()
+		return 0
+	}
+
+	for _, cmd := range cmds {
+		if cmd.name == name {
+			return cmd.run("fits "+name, args[1:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "fits: unknown command %q\n\n", name)
+	usage()
+	return 1
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: fits <command> [arguments]\n\nThe commands are:\n\n")
+	for _, cmd := range cmds {
+		fmt.Fprintf(os.Stderr, "\t%-10s %s\n", cmd.name, cmd.short)
+	}
+	fmt.Fprintf(os.Stderr, "\nUse \"fits <command> -h\" for more information about a command.\n")
+}
@@ -0,0 +1,138 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ColumnMeta holds the metadata of a table column, as stored in the
+// column keywords of the table header.
+type ColumnMeta struct {
+	Unit    string // physical unit, corresponding to ``TUNIT`` keyword
+	UCD     string // unified content descriptor, corresponding to ``TUCD`` keyword
+	Comment string // description of the column, corresponding to ``TCOMM`` keyword
+
+	// Keys holds the other column keywords (such as TLMIN, TLMAX, TDMIN
+	// or TDMAX), indexed by their root, without the column number.
+	Keys map[string]Value
+}
+
+// ColMeta returns the metadata of the i-th column.
+// ColMeta panics if the index is out of range.
+func (t *Table) ColMeta(i int) ColumnMeta {
+	col := &t.cols[i]
+	meta := ColumnMeta{Unit: col.Unit}
+	n := strconv.Itoa(i + 1)
+	for _, card := range t.hdr.cards {
+		root, ok := strings.CutSuffix(card.Name, n)
+		if !ok || !isColumnMetaRoot(root) {
+			continue
+		}
+		switch root {
+		case "TUCD":
+			meta.UCD, _ = card.Value.(string)
+		case "TCOMM":
+			meta.Comment, _ = card.Value.(string)
+		default:
+			if meta.Keys == nil {
+				meta.Keys = make(map[string]Value)
+			}
+			meta.Keys[root] = card.Value
+		}
+	}
+	return meta
+}
+
+// SetColMeta stores the metadata of the i-th column in the table header.
+// The non-empty Unit, UCD and Comment fields, and the keywords of Keys,
+// are added to the header or replace the existing ones.
+// SetColMeta panics if the index is out of range.
+func (t *Table) SetColMeta(i int, meta ColumnMeta) error {
+	n := strconv.Itoa(i + 1)
+	cards := make([]Card, 0, 3+len(meta.Keys))
+	if meta.Unit != "" {
+		cards = append(cards, Card{Name: "TUNIT" + n, Value: meta.Unit, Comment: "unit for column " + n})
+	}
+	if meta.UCD != "" {
+		cards = append(cards, Card{Name: "TUCD" + n, Value: meta.UCD, Comment: "UCD for column " + n})
+	}
+	if meta.Comment != "" {
+		cards = append(cards, Card{Name: "TCOMM" + n, Value: meta.Comment, Comment: "description of column " + n})
+	}
+	for _, root := range sortedKeys(meta.Keys) {
+		if !isColumnMetaRoot(root) || root == "TUCD" || root == "TCOMM" {
+			return fmt.Errorf("fitsio: invalid column keyword %q", root)
+		}
+		if len(root+n) > 8 {
+			return fmt.Errorf("fitsio: column keyword %q too long", root+n)
+		}
+		v, err := cardValue(root+n, meta.Keys[root])
+		if err != nil {
+			return err
+		}
+		cards = append(cards, Card{Name: root + n, Value: v})
+	}
+
+	for _, card := range cards {
+		if old := t.hdr.Get(card.Name); old != nil {
+			old.Value = card.Value
+			continue
+		}
+		err := t.hdr.Append(card)
+		if err != nil {
+			return err
+		}
+	}
+	if meta.Unit != "" {
+		t.cols[i].Unit = meta.Unit
+	}
+	return nil
+}
+
+// copyColMeta copies the metadata of the columns of src into the columns
+// of dst with the same name, for the keywords dst does not define yet.
+func copyColMeta(dst, src *Table) error {
+	for j := range dst.cols {
+		i := src.Index(dst.cols[j].Name)
+		if i < 0 {
+			continue
+		}
+		meta := src.ColMeta(i)
+		cur := dst.ColMeta(j)
+		if cur.Unit != "" {
+			meta.Unit = ""
+		}
+		if cur.UCD != "" {
+			meta.UCD = ""
+		}
+		if cur.Comment != "" {
+			meta.Comment = ""
+		}
+		for k := range cur.Keys {
+			delete(meta.Keys, k)
+		}
+		err := dst.SetColMeta(j, meta)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isColumnMetaRoot returns whether root is the root of a column keyword
+// holding metadata, rather than the layout of the column.
+func isColumnMetaRoot(root string) bool {
+	if len(root) < 2 || root[0] != 'T' || strings.Trim(root, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return false
+	}
+	switch root {
+	case "TTYPE", "TFORM", "TUNIT", "TNULL", "TSCAL", "TZERO", "TDISP", "TDIM", "TBCOL":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestColumnMeta(t *testing.T) {
+	cols := []Column{
+		{Name: "RA", Format: "D", Unit: "deg"},
+		{Name: "MAG", Format: "E"},
+	}
+	tbl, err := NewTable("CAT", cols, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %+v", err)
+	}
+
+	mag := ColumnMeta{
+		Unit:    "mag",
+		UCD:     "phot.mag;em.opt.V",
+		Comment: "visual magnitude",
+		Keys:    map[string]Value{"TLMIN": -2, "TLMAX": 30.5},
+	}
+	err = tbl.SetColMeta(1, mag)
+	if err != nil {
+		t.Fatalf("could not set column metadata: %+v", err)
+	}
+	err = tbl.SetColMeta(0, ColumnMeta{UCD: "pos.eq.ra;meta.main"})
+	if err != nil {
+		t.Fatalf("could not set column metadata: %+v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err = tbl.Write(&map[string]interface{}{"RA": float64(i), "MAG": float32(i)})
+		if err != nil {
+			t.Fatalf("could not write row: %+v", err)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	f, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	err = f.Write(NewImage(8, nil))
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %+v", err)
+	}
+	err = f.Write(tbl)
+	if err != nil {
+		t.Fatalf("could not write table: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+	src := r.HDU(1).(*Table)
+
+	want := []ColumnMeta{
+		{Unit: "deg", UCD: "pos.eq.ra;meta.main"},
+		mag,
+	}
+	for i, want := range want {
+		if got := src.ColMeta(i); !reflect.DeepEqual(got, want) {
+			t.Fatalf("col #%d: invalid metadata:\ngot= %#v\nwant=%#v", i, got, want)
+		}
+	}
+	if got, want := src.Col(1).Unit, "mag"; got != want {
+		t.Fatalf("invalid unit: got=%q, want=%q", got, want)
+	}
+
+	// metadata is preserved by CopyTable.
+	dst, err := NewTable("COPY", []Column{
+		{Name: "MAG", Format: "E", Unit: "Vmag"},
+		{Name: "RA", Format: "D"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %+v", err)
+	}
+	err = CopyTable(dst, src)
+	if err != nil {
+		t.Fatalf("could not copy table: %+v", err)
+	}
+	want = []ColumnMeta{
+		{Unit: "Vmag", UCD: mag.UCD, Comment: mag.Comment, Keys: mag.Keys},
+		{Unit: "deg", UCD: "pos.eq.ra;meta.main"},
+	}
+	for i, want := range want {
+		if got := dst.ColMeta(i); !reflect.DeepEqual(got, want) {
+			t.Fatalf("copy col #%d: invalid metadata:\ngot= %#v\nwant=%#v", i, got, want)
+		}
+	}
+}
+
+func TestColumnMetaErrors(t *testing.T) {
+	tbl, err := NewTable("CAT", []Column{{Name: "X", Format: "J"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %+v", err)
+	}
+	for _, root := range []string{"TFORM", "TUCD", "LMIN", "TLMIN2", "TLONGKEY"} {
+		err = tbl.SetColMeta(0, ColumnMeta{Keys: map[string]Value{root: 1}})
+		if err == nil {
+			t.Fatalf("expected an error for keyword %q", root)
+		}
+	}
+}
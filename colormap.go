@@ -0,0 +1,138 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Colormap maps display intensities in [0, 1] to colors.
+type Colormap struct {
+	Name     string
+	Inverted bool // whether the intensities are inverted before the mapping
+
+	// colors are the anchors of the colormap, evenly spaced over [0, 1].
+	// intermediate intensities are linearly interpolated.
+	colors []color.RGBA
+}
+
+// Predefined colormaps.
+var (
+	GrayColormap = Colormap{
+		Name: "gray",
+		colors: []color.RGBA{
+			{0x00, 0x00, 0x00, 0xff},
+			{0xff, 0xff, 0xff, 0xff},
+		},
+	}
+
+	ViridisColormap = Colormap{
+		Name: "viridis",
+		colors: []color.RGBA{
+			{0x44, 0x01, 0x54, 0xff},
+			{0x47, 0x2c, 0x7a, 0xff},
+			{0x3b, 0x51, 0x8b, 0xff},
+			{0x2c, 0x71, 0x8e, 0xff},
+			{0x21, 0x90, 0x8d, 0xff},
+			{0x27, 0xad, 0x81, 0xff},
+			{0x5c, 0xc8, 0x63, 0xff},
+			{0xaa, 0xdc, 0x32, 0xff},
+			{0xfd, 0xe7, 0x25, 0xff},
+		},
+	}
+
+	InfernoColormap = Colormap{
+		Name: "inferno",
+		colors: []color.RGBA{
+			{0x00, 0x00, 0x04, 0xff},
+			{0x1f, 0x0c, 0x48, 0xff},
+			{0x55, 0x0f, 0x6d, 0xff},
+			{0x88, 0x22, 0x6a, 0xff},
+			{0xba, 0x36, 0x55, 0xff},
+			{0xe3, 0x59, 0x33, 0xff},
+			{0xf9, 0x8c, 0x0a, 0xff},
+			{0xf9, 0xc9, 0x32, 0xff},
+			{0xfc, 0xff, 0xa4, 0xff},
+		},
+	}
+
+	HeatColormap = Colormap{
+		Name: "heat",
+		colors: []color.RGBA{
+			{0x00, 0x00, 0x00, 0xff},
+			{0xff, 0x00, 0x00, 0xff},
+			{0xff, 0xff, 0x00, 0xff},
+			{0xff, 0xff, 0xff, 0xff},
+		},
+	}
+)
+
+// Colormaps returns the list of predefined colormaps.
+func Colormaps() []Colormap {
+	return []Colormap{GrayColormap, ViridisColormap, InfernoColormap, HeatColormap}
+}
+
+// Invert returns the inverted version of the colormap.
+func (cm Colormap) Invert() Colormap {
+	cm.Inverted = !cm.Inverted
+	return cm
+}
+
+// At returns the color associated with the intensity x.
+// x is clamped to [0, 1].
+func (cm Colormap) At(x float64) color.RGBA {
+	switch {
+	case len(cm.colors) == 0:
+		return color.RGBA{}
+	case len(cm.colors) == 1:
+		return cm.colors[0]
+	}
+
+	x = math.Max(0, math.Min(1, x))
+	if cm.Inverted {
+		x = 1 - x
+	}
+
+	pos := x * float64(len(cm.colors)-1)
+	i := int(pos)
+	if i >= len(cm.colors)-1 {
+		return cm.colors[len(cm.colors)-1]
+	}
+	frac := pos - float64(i)
+	c0 := cm.colors[i]
+	c1 := cm.colors[i+1]
+	lerp := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + frac*(float64(b)-float64(a))))
+	}
+	return color.RGBA{
+		R: lerp(c0.R, c1.R),
+		G: lerp(c0.G, c1.G),
+		B: lerp(c0.B, c1.B),
+		A: lerp(c0.A, c1.A),
+	}
+}
+
+// ImageWithColormap returns an RGBA image.Image value, where the physical
+// pixel values have been mapped to display intensities according to s and
+// then to colors according to cmap.
+// Blank and NaN pixels are rendered as transparent.
+func (img *imageHDU) ImageWithColormap(s Scaling, cmap Colormap) (image.Image, error) {
+	w, h, err := img.planeSize()
+	if err != nil {
+		return nil, err
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, w, h))
+	err = img.render(s, func(i int, x float64) {
+		c := cmap.At(x)
+		copy(rgba.Pix[4*i:4*i+4], []uint8{c.R, c.G, c.B, c.A})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rgba, nil
+}
@@ -6,7 +6,9 @@ package fitsio
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -33,6 +35,9 @@ type Column struct {
 
 	// write function (binary/ascii)
 	write func(table *Table, icol int, irow int64, ptr interface{}) error
+
+	// decoder of the values of fixed-size scalar binary columns, or nil.
+	scanner *binScanner
 }
 
 // NewColumn creates a new Column with name `name` and Format inferred from the type of value
@@ -45,12 +50,38 @@ func (col *Column) Type() reflect.Type {
 	return col.dtype.gotype
 }
 
+// DataType returns the type of the values held by the column, as
+// described by its TFORM value.
+func (col *Column) DataType() Type {
+	return col.dtype
+}
+
 // readBin reads the value at column number icol and row irow, into ptr.
 func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{}) error {
 	var err error
 
+	if col.scanner != nil {
+		beg := table.rowsz*int(irow) + col.offset
+		if col.scanner.scan(table.data[beg:beg+col.dtype.dsize], ptr) {
+			return nil
+		}
+	}
+
 	rv := reflect.Indirect(reflect.ValueOf(ptr))
+	if rv.Kind() == reflect.Ptr {
+		return col.readNullable(table, icol, irow, rv)
+	}
+	if gt := col.dtype.gotype; col.dtype.tc > 0 && col.dtype.len == 1 && gt != nil && rv.Type() != gt {
+		// scalar column read into a value of another type.
+		src := reflect.New(gt)
+		err = col.readBin(table, icol, irow, src.Interface())
+		if err != nil {
+			return err
+		}
+		return promote(col.Name, rv, src.Elem())
+	}
 	rt := reflect.TypeOf(rv.Interface())
+	zsz := col.zeroSize(rt)
 
 	switch rt.Kind() {
 	case reflect.Slice:
@@ -60,38 +91,43 @@ func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{})
 		row := table.data[beg:end]
 		r := newReader(row)
 		slice := reflect.ValueOf(ptr).Elem()
-		nmax := 0
+		var n, offset int64
 
 		switch col.dtype.dsize {
 		case 8:
-			var n int32
-			var offset int32
-			r.readI32(&n)
-			r.readI32(&offset)
-			beg = int(offset)
-			end = beg + int(n)*int(col.dtype.gotype.Elem().Size())
-			nmax = int(n)
+			var n32, off32 int32
+			r.readI32(&n32)
+			r.readI32(&off32)
+			n, offset = int64(n32), int64(off32)
 
 		case 16:
-			var n int64
-			var offset int64
 			r.readI64(&n)
 			r.readI64(&offset)
-			beg = int(offset)
-			end = beg + int(n)*int(col.dtype.gotype.Elem().Size())
-			nmax = int(n)
 		}
+
+		// the array descriptor comes from the data: check it against
+		// the heap before allocating anything.
+		esz := int64(col.dtype.gotype.Elem().Size())
+		if n < 0 || offset < 0 || offset > int64(len(table.heap)) || n > (int64(len(table.heap))-offset)/esz {
+			return fmt.Errorf(
+				"fitsio: invalid array descriptor (n=%d, offset=%d) for column %q (heap size=%d)",
+				n, offset, col.Name, len(table.heap),
+			)
+		}
+		nmax := int(n)
+		beg = int(offset)
+		end = beg + nmax*int(esz)
 		if slice.Len() < nmax {
 			slice = reflect.MakeSlice(rt, nmax, nmax)
 		}
 
-		r = newReader(table.heap[beg:end])
+		r = newReader(signFlipped(table.heap[beg:end], zsz))
 		switch slice := slice.Interface().(type) {
 		case []bool:
 			r.readBools(slice[:nmax])
 
 		case []byte:
-			copy(slice[:nmax], r.p)
+			r.readU8s(slice[:nmax])
 
 		case []int8:
 			r.readI8s(slice[:nmax])
@@ -133,22 +169,22 @@ func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{})
 			r.readC128s(slice[:nmax])
 
 		default:
-			panic(fmt.Errorf("fitsio: not implemented %T", slice))
+			return fmt.Errorf("fitsio: binary-table can not read/write %T", slice)
 		}
-		rv.Set(slice)
+		rv.Set(slice.Slice(0, nmax))
 
 	case reflect.Array:
 
 		beg := table.rowsz*int(irow) + col.offset
 		end := beg + (col.dtype.dsize * col.dtype.len)
-		row := table.data[beg:end]
+		row := signFlipped(table.data[beg:end], zsz)
 		r := newReader(row)
 		switch slice := rv.Slice(0, rv.Len()).Interface().(type) {
 		case []bool:
 			r.readBools(slice)
 
 		case []byte:
-			copy(slice, r.p)
+			r.readU8s(slice)
 
 		case []int8:
 			r.readI8s(slice)
@@ -190,7 +226,7 @@ func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{})
 			r.readC128s(slice)
 
 		default:
-			panic(fmt.Errorf("fitsio: not implemented %T", slice))
+			return fmt.Errorf("fitsio: binary-table can not read/write %T", slice)
 		}
 
 	case reflect.Bool:
@@ -206,7 +242,7 @@ func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{})
 		beg := table.rowsz*int(irow) + col.offset
 		end := beg + col.dtype.dsize
 
-		r := newReader(table.data[beg:end])
+		r := newReader(signFlipped(table.data[beg:end], zsz))
 		r.readI8(ptr.(*int8))
 
 	case reflect.Int16:
@@ -254,7 +290,7 @@ func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{})
 		beg := table.rowsz*int(irow) + col.offset
 		end := beg + col.dtype.dsize
 
-		r := newReader(table.data[beg:end])
+		r := newReader(signFlipped(table.data[beg:end], zsz))
 		r.readU16(ptr.(*uint16))
 
 	case reflect.Uint32:
@@ -262,7 +298,7 @@ func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{})
 		beg := table.rowsz*int(irow) + col.offset
 		end := beg + col.dtype.dsize
 
-		r := newReader(table.data[beg:end])
+		r := newReader(signFlipped(table.data[beg:end], zsz))
 		r.readU32(ptr.(*uint32))
 
 	case reflect.Uint64:
@@ -270,7 +306,7 @@ func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{})
 		beg := table.rowsz*int(irow) + col.offset
 		end := beg + col.dtype.dsize
 
-		r := newReader(table.data[beg:end])
+		r := newReader(signFlipped(table.data[beg:end], zsz))
 		r.readU64(ptr.(*uint64))
 
 	case reflect.Uint:
@@ -278,7 +314,7 @@ func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{})
 		beg := table.rowsz*int(irow) + col.offset
 		end := beg + col.dtype.dsize
 
-		r := newReader(table.data[beg:end])
+		r := newReader(signFlipped(table.data[beg:end], zsz))
 		r.readUint(ptr.(*uint))
 
 	case reflect.Float32:
@@ -317,16 +353,7 @@ func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{})
 
 		beg := table.rowsz*int(irow) + col.offset
 		end := beg + col.dtype.dsize
-		row := table.data[beg:end]
-		str := ""
-		if row[0] == '\x00' {
-			str = string(row[1:])
-			str = strings.TrimRight(str, string([]byte("\x00")))
-		} else {
-			str = string(row)
-		}
-
-		rv.SetString(str)
+		rv.SetString(decodeString(table.data[beg:end]))
 
 	default:
 		return fmt.Errorf("fitsio: binary-table can not read/write %v", rt.Kind())
@@ -336,84 +363,102 @@ func (col *Column) readBin(table *Table, icol int, irow int64, ptr interface{})
 
 // writeBin writes the value at column number icol and row irow, from ptr.
 func (col *Column) writeBin(table *Table, icol int, irow int64, ptr interface{}) error {
+	if rv := reflect.Indirect(reflect.ValueOf(ptr)); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return col.writeNull(table, irow)
+		}
+		return col.writeBin(table, icol, irow, rv.Interface())
+	}
+	if src := reflect.ValueOf(reflect.Indirect(reflect.ValueOf(ptr)).Interface()); src.IsValid() {
+		if gt := col.dtype.gotype; col.dtype.tc > 0 && col.dtype.len == 1 && gt != nil && src.Type() != gt {
+			// scalar value of another type than the column's: convert it
+			// with the rules of Rows.Scan, so out-of-range values are
+			// rejected rather than stored truncated.
+			dst := reflect.New(gt)
+			err := promote(col.Name, dst.Elem(), src)
+			if err != nil {
+				var mismatch *ErrTypeMismatch
+				if errors.As(err, &mismatch) {
+					// promote reports the type of its source, the written
+					// value, as the wanted one.
+					mismatch.Want, mismatch.Got = mismatch.Got, mismatch.Want
+				}
+				return err
+			}
+			return col.writeBin(table, icol, irow, dst.Interface())
+		}
+	}
+
 	var (
 		err error
 		rv  = reflect.Indirect(reflect.ValueOf(ptr))
 		rvi = rv.Interface()
 		rt  = reflect.TypeOf(rvi)
+		zsz = col.zeroSize(rt)
 	)
 
 	switch rt.Kind() {
 	case reflect.Slice:
 
-		beg := table.rowsz*int(irow) + col.offset
-		end := beg + col.dtype.dsize
-
-		w := newWriter(table.data[beg:end])
 		nmax := rv.Len()
+		mark := len(table.heap)
+		off := table.heapReserve(nmax * col.dtype.hsize)
+		w := newWriter(table.heap[off:])
+		switch slice := rvi.(type) {
+		case []bool:
+			w.writeBools(slice)
 
-		switch col.dtype.dsize {
-		case 8:
-			w.writeI32(int32(nmax))
-			w.writeI32(int32(len(table.heap)))
-
-		case 16:
-			w.writeI64(int64(nmax))
-			w.writeI64(int64(len(table.heap)))
-		}
-
-		{
-			w := newWriter(make([]byte, nmax*col.dtype.hsize))
-			switch slice := rvi.(type) {
-			case []bool:
-				w.writeBools(slice)
+		case []byte:
+			w.writeU8s(slice)
 
-			case []byte:
-				copy(w.p, slice)
+		case []int8:
+			w.writeI8s(slice)
 
-			case []int8:
-				w.writeI8s(slice)
+		case []int16:
+			w.writeI16s(slice)
 
-			case []int16:
-				w.writeI16s(slice)
+		case []int32:
+			w.writeI32s(slice)
 
-			case []int32:
-				w.writeI32s(slice)
+		case []int64:
+			w.writeI64s(slice)
 
-			case []int64:
-				w.writeI64s(slice)
+		case []int:
+			w.writeInts(slice)
 
-			case []int:
-				w.writeInts(slice)
+		case []uint16:
+			w.writeU16s(slice)
 
-			case []uint16:
-				w.writeU16s(slice)
+		case []uint32:
+			w.writeU32s(slice)
 
-			case []uint32:
-				w.writeU32s(slice)
+		case []uint64:
+			w.writeU64s(slice)
 
-			case []uint64:
-				w.writeU64s(slice)
+		case []uint:
+			w.writeUints(slice)
 
-			case []uint:
-				w.writeUints(slice)
+		case []float32:
+			w.writeF32s(slice)
 
-			case []float32:
-				w.writeF32s(slice)
+		case []float64:
+			w.writeF64s(slice)
 
-			case []float64:
-				w.writeF64s(slice)
+		case []complex64:
+			w.writeC64s(slice)
 
-			case []complex64:
-				w.writeC64s(slice)
+		case []complex128:
+			w.writeC128s(slice)
 
-			case []complex128:
-				w.writeC128s(slice)
+		default:
+			return fmt.Errorf("fitsio: binary-table can not read/write %T", slice)
+		}
+		flipSigns(table.heap[off:off+nmax*col.dtype.hsize], zsz)
+		off = table.heapDedup(mark, off)
 
-			default:
-				panic(fmt.Errorf("fitsio: not implemented %T", slice))
-			}
-			table.heap = append(table.heap, w.bytes()...)
+		err = table.writeDescriptor(icol, irow, nmax, off)
+		if err != nil {
+			return err
 		}
 
 	case reflect.Array:
@@ -427,7 +472,7 @@ func (col *Column) writeBin(table *Table, icol int, irow int64, ptr interface{})
 			w.writeBools(slice)
 
 		case []byte:
-			copy(w.p, slice)
+			w.writeU8s(slice)
 
 		case []int8:
 			w.writeI8s(slice)
@@ -469,8 +514,9 @@ func (col *Column) writeBin(table *Table, icol int, irow int64, ptr interface{})
 			w.writeC128s(slice)
 
 		default:
-			panic(fmt.Errorf("fitsio: not implemented %T", slice))
+			return fmt.Errorf("fitsio: binary-table can not read/write %T", slice)
 		}
+		flipSigns(table.data[beg:end], zsz)
 
 	case reflect.Bool:
 
@@ -487,6 +533,7 @@ func (col *Column) writeBin(table *Table, icol int, irow int64, ptr interface{})
 
 		w := newWriter(table.data[beg:end])
 		w.writeI8(rvi.(int8))
+		flipSigns(table.data[beg:end], zsz)
 
 	case reflect.Int16:
 
@@ -535,6 +582,7 @@ func (col *Column) writeBin(table *Table, icol int, irow int64, ptr interface{})
 
 		w := newWriter(table.data[beg:end])
 		w.writeU16(rvi.(uint16))
+		flipSigns(table.data[beg:end], zsz)
 
 	case reflect.Uint32:
 
@@ -543,6 +591,7 @@ func (col *Column) writeBin(table *Table, icol int, irow int64, ptr interface{})
 
 		w := newWriter(table.data[beg:end])
 		w.writeU32(rvi.(uint32))
+		flipSigns(table.data[beg:end], zsz)
 
 	case reflect.Uint64:
 
@@ -551,6 +600,7 @@ func (col *Column) writeBin(table *Table, icol int, irow int64, ptr interface{})
 
 		w := newWriter(table.data[beg:end])
 		w.writeU64(rvi.(uint64))
+		flipSigns(table.data[beg:end], zsz)
 
 	case reflect.Uint:
 
@@ -559,6 +609,7 @@ func (col *Column) writeBin(table *Table, icol int, irow int64, ptr interface{})
 
 		w := newWriter(table.data[beg:end])
 		w.writeUint(rvi.(uint))
+		flipSigns(table.data[beg:end], zsz)
 
 	case reflect.Float32:
 
@@ -597,24 +648,7 @@ func (col *Column) writeBin(table *Table, icol int, irow int64, ptr interface{})
 		beg := table.rowsz*int(irow) + col.offset
 		end := beg + col.dtype.dsize
 
-		buf := newWriter(table.data[beg:end])
-		str := rv.String()
-		data := make([]byte, 0, len(str)+1)
-		data = append(data, '\x00')
-		data = append(data, []byte(str)...)
-		n := len(data)
-		if n > end-beg {
-			n = end - beg
-		}
-		if n < end-beg {
-			data = append(data, bytes.Repeat([]byte("\x00"), end-beg-n)...)
-			n = len(data)
-		}
-		_, err = buf.Write(data[:n])
-
-		if err != nil {
-			return fmt.Errorf("fitsio: %v\n", err)
-		}
+		encodeString(table.data[beg:end], rv.String())
 
 	default:
 		return fmt.Errorf("fitsio: binary-table can not read/write %v", rt.Kind())
@@ -628,6 +662,9 @@ func (col *Column) readTxt(table *Table, icol int, irow int64, ptr interface{})
 	var err error
 
 	rv := reflect.Indirect(reflect.ValueOf(ptr))
+	if rv.Kind() == reflect.Ptr {
+		return col.readNullable(table, icol, irow, rv)
+	}
 	rt := reflect.TypeOf(rv.Interface())
 
 	beg := table.rowsz*int(irow) + col.offset
@@ -635,6 +672,15 @@ func (col *Column) readTxt(table *Table, icol int, irow int64, ptr interface{})
 	buf := table.data[beg:end]
 	str := strings.TrimSpace(string(buf))
 
+	switch rt.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Float32, reflect.Float64:
+		if col.isNull(table, irow) {
+			return col.readNull(table, irow, rv)
+		}
+	}
+
 	switch rt.Kind() {
 	case reflect.Slice:
 
@@ -651,14 +697,14 @@ func (col *Column) readTxt(table *Table, icol int, irow int64, ptr interface{})
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		v, err := strconv.ParseUint(str, 10, 64)
 		if err != nil {
-			return fmt.Errorf("fitsio: error parsing %q into a uint: %v", str, err)
+			return fmt.Errorf("fitsio: error parsing %q into a uint: %w", str, err)
 		}
 		rv.SetUint(v)
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		v, err := strconv.ParseInt(str, 10, 64)
 		if err != nil {
-			return fmt.Errorf("fitsio: error parsing %q into an int: %v", str, err)
+			return fmt.Errorf("fitsio: error parsing %q into an int: %w", str, err)
 		}
 		rv.SetInt(v)
 
@@ -666,7 +712,7 @@ func (col *Column) readTxt(table *Table, icol int, irow int64, ptr interface{})
 
 		v, err := strconv.ParseFloat(str, 64)
 		if err != nil {
-			return fmt.Errorf("fitsio: error parsing %q into a float: %v", str, err)
+			return fmt.Errorf("fitsio: error parsing %q into a float: %w", str, err)
 		}
 		rv.SetFloat(v)
 
@@ -735,3 +781,210 @@ func (col *Column) writeTxt(table *Table, icol int, irow int64, ptr interface{})
 
 	return err
 }
+
+// decodeString decodes the fixed-width character field buf of a binary
+// table: the string ends at the first NUL character, and trailing spaces
+// are removed.
+// Fields starting with a NUL character, as written by previous versions of
+// this package, hold the string following that NUL character.
+func decodeString(buf []byte) string {
+	if len(buf) > 0 && buf[0] == '\x00' {
+		buf = buf[1:]
+	}
+	if i := bytes.IndexByte(buf, '\x00'); i >= 0 {
+		buf = buf[:i]
+	}
+	return string(bytes.TrimRight(buf, " "))
+}
+
+// encodeString encodes str into the fixed-width character field buf of
+// a binary table, truncating it or padding it with spaces.
+func encodeString(buf []byte, str string) {
+	n := copy(buf, str)
+	for i := n; i < len(buf); i++ {
+		buf[i] = ' '
+	}
+}
+
+// readNullable reads the value at column number icol and row irow into
+// rv, a pointer: rv is set to nil if the value is null (see isNull), and
+// to a new value otherwise.
+func (col *Column) readNullable(table *Table, icol int, irow int64, rv reflect.Value) error {
+	if col.isNull(table, irow) {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+	v := reflect.New(rv.Type().Elem())
+	err := col.read(table, icol, irow, v.Interface())
+	if err != nil {
+		return err
+	}
+	rv.Set(v)
+	return nil
+}
+
+// readNull reads the undefined value at row irow of an ASCII table into
+// rv, an integer or floating-point value, following the NullPolicy of the
+// table.
+func (col *Column) readNull(table *Table, irow int64, rv reflect.Value) error {
+	switch {
+	case table.nulls == NullAsError:
+		return fmt.Errorf("%w at row %d of column %q", ErrNull, irow, col.Name)
+	case table.nulls == NullAsNaN && (rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64):
+		rv.SetFloat(math.NaN())
+	default:
+		rv.Set(reflect.Zero(rv.Type()))
+	}
+	return nil
+}
+
+// isNull returns whether the value at row irow of a scalar column is
+// undefined: the TNULL value of integer columns of binary tables and of
+// ASCII tables, a blank field in numeric columns of ASCII tables, a NaN in
+// floating-point columns of binary tables, or a 0 byte in logical columns.
+func (col *Column) isNull(table *Table, irow int64) bool {
+	beg := table.rowsz*int(irow) + col.offset
+	buf := table.data[beg : beg+col.dtype.dsize]
+	null := strings.TrimSpace(col.Null)
+
+	if !table.binary {
+		str := strings.TrimSpace(string(buf))
+		if str == "" && col.dtype.tc != tcString {
+			// blank fields of numeric columns are undefined.
+			return true
+		}
+		return null != "" && str == null
+	}
+	if col.dtype.len != 1 {
+		return false
+	}
+
+	r := newReader(buf)
+	var v int64
+	switch col.dtype.tc {
+	case tcBool:
+		return buf[0] == 0
+	case tcFloat32:
+		var f float32
+		r.readF32(&f)
+		return math.IsNaN(float64(f))
+	case tcFloat64:
+		var f float64
+		r.readF64(&f)
+		return math.IsNaN(f)
+	case tcByte, tcInt8:
+		v = int64(buf[0])
+	case tcInt16, tcUint16:
+		var i int16
+		r.readI16(&i)
+		v = int64(i)
+	case tcInt32, tcUint32:
+		var i int32
+		r.readI32(&i)
+		v = int64(i)
+	case tcInt64, tcUint64:
+		r.readI64(&v)
+	default:
+		return false
+	}
+	if null == "" {
+		return false
+	}
+	// TNULL applies to the stored integers, before the TZERO offset.
+	tnull, err := strconv.ParseInt(null, 10, 64)
+	return err == nil && v == tnull
+}
+
+// writeNull writes the undefined value of the scalar binary-table column
+// at row irow: a 0 byte for logical columns, a NaN for floating-point
+// columns, or the TNULL value of integer columns.
+func (col *Column) writeNull(table *Table, irow int64) error {
+	beg := table.rowsz*int(irow) + col.offset
+	w := newWriter(table.data[beg : beg+col.dtype.dsize])
+	if col.dtype.len != 1 {
+		return fmt.Errorf("fitsio: column %q has no undefined value", col.Name)
+	}
+
+	switch col.dtype.tc {
+	case tcBool:
+		w.writeU8(0)
+		return nil
+	case tcFloat32:
+		w.writeF32(float32(math.NaN()))
+		return nil
+	case tcFloat64:
+		w.writeF64(math.NaN())
+		return nil
+	}
+
+	null := strings.TrimSpace(col.Null)
+	if null == "" {
+		return fmt.Errorf("fitsio: column %q has no undefined value (missing TNULL)", col.Name)
+	}
+	// TNULL applies to the stored integers, before the TZERO offset.
+	v, err := strconv.ParseInt(null, 10, 64)
+	if err != nil {
+		return fmt.Errorf("fitsio: invalid TNULL value %q for column %q: %w", null, col.Name, err)
+	}
+	switch col.dtype.tc {
+	case tcByte, tcInt8:
+		w.writeU8(uint8(v))
+	case tcInt16, tcUint16:
+		w.writeI16(int16(v))
+	case tcInt32, tcUint32:
+		w.writeI32(int32(v))
+	case tcInt64, tcUint64:
+		w.writeI64(v)
+	default:
+		return fmt.Errorf("fitsio: column %q has no undefined value", col.Name)
+	}
+	return nil
+}
+
+// zeroSize returns the size of the integers of the column, when values of
+// type rt are stored with a TZERO offset flipping their sign bit, or 0.
+// Values of the storage type of the column are read and written as is.
+func (col *Column) zeroSize(rt reflect.Type) int {
+	switch rt.Kind() {
+	case reflect.Array, reflect.Slice:
+		rt = rt.Elem()
+	}
+	tc := col.dtype.tc
+	if tc < 0 {
+		tc = -tc
+	}
+	switch k := rt.Kind(); {
+	case tc == tcInt8 && k == reflect.Int8:
+		return 1
+	case tc == tcUint16 && k == reflect.Uint16:
+		return 2
+	case tc == tcUint32 && k == reflect.Uint32:
+		return 4
+	case tc == tcUint64 && (k == reflect.Uint64 || k == reflect.Uint):
+		return 8
+	}
+	return 0
+}
+
+// flipSigns flips the sign bits of the big-endian integers of size esz
+// held by buf. flipSigns is a no-op if esz is 0.
+func flipSigns(buf []byte, esz int) {
+	if esz == 0 {
+		return
+	}
+	for i := 0; i < len(buf); i += esz {
+		buf[i] ^= 0x80
+	}
+}
+
+// signFlipped returns a copy of buf with the sign bits of its integers of
+// size esz flipped, or buf if esz is 0.
+func signFlipped(buf []byte, esz int) []byte {
+	if esz == 0 {
+		return buf
+	}
+	out := make([]byte, len(buf))
+	copy(out, buf)
+	flipSigns(out, esz)
+	return out
+}
@@ -0,0 +1,1020 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Compression is a tile compression algorithm of the FITS tiled image
+// compression convention.
+type Compression int
+
+const (
+	Rice1      Compression = iota // RICE_1, Rice coding of the pixel differences
+	GZIP1                         // GZIP_1, gzip compression of the pixel bytes
+	GZIP2                         // GZIP_2, gzip compression of the shuffled pixel bytes
+	NoCompress                    // NOCOMPRESS, uncompressed tiles
+)
+
+func (c Compression) String() string {
+	switch c {
+	case Rice1:
+		return "RICE_1"
+	case GZIP1:
+		return "GZIP_1"
+	case GZIP2:
+		return "GZIP_2"
+	case NoCompress:
+		return "NOCOMPRESS"
+	}
+	return fmt.Sprintf("Compression(%d)", int(c))
+}
+
+// Quantization is a method of quantization of floating point pixels, as
+// described by the ZQUANTIZ keyword of tile-compressed images.
+type Quantization int
+
+const (
+	SubtractiveDither1 Quantization = iota // SUBTRACTIVE_DITHER_1
+	SubtractiveDither2                     // SUBTRACTIVE_DITHER_2, preserving zero-valued pixels
+	NoDither                               // NO_DITHER
+)
+
+func (q Quantization) String() string {
+	switch q {
+	case SubtractiveDither1:
+		return "SUBTRACTIVE_DITHER_1"
+	case SubtractiveDither2:
+		return "SUBTRACTIVE_DITHER_2"
+	case NoDither:
+		return "NO_DITHER"
+	}
+	return fmt.Sprintf("Quantization(%d)", int(q))
+}
+
+// WithCompression selects the algorithm used by CompressImage.
+// The default is RICE_1.
+func WithCompression(c Compression) Option {
+	return func(cfg *config) {
+		cfg.compression = c
+	}
+}
+
+// WithTileSize sets the dimensions of the tiles of images compressed by
+// CompressImage, one value per axis.
+// Missing dimensions default to 1, and a dimension of 0 spans the whole axis.
+// The default is to compress the image row by row.
+func WithTileSize(dims ...int) Option {
+	return func(cfg *config) {
+		cfg.tile = append([]int(nil), dims...)
+	}
+}
+
+// WithQuantization sets how CompressImage quantizes floating point pixels
+// into integers.
+//
+// A positive level sets the quantization step of each tile to its
+// estimated noise divided by level: larger values preserve more
+// precision but compress less.
+// A negative level sets the quantization step to -level for all tiles.
+// A zero level compresses floating point pixels losslessly, with the GZIP_1,
+// GZIP_2 or NOCOMPRESS algorithms.
+//
+// The default is SUBTRACTIVE_DITHER_1 with a level of 4, as fpack.
+// The starting point of the dithering sequence, stored in ZDITHER0, is set
+// with WithDither.
+func WithQuantization(method Quantization, level float64) Option {
+	return func(cfg *config) {
+		cfg.quantize = method
+		cfg.qlevel = level
+		cfg.qset = true
+	}
+}
+
+const (
+	zblankValue = math.MinInt32 + 1 // quantized value of undefined pixels
+	zeroValue   = math.MinInt32 + 2 // quantized value of zeros, with SUBTRACTIVE_DITHER_2
+	nReserved   = 10                // number of reserved quantized values
+	nRandom     = 10000             // length of the dithering sequence
+
+	// compressedImageName is the EXTNAME of compressed unnamed images.
+	compressedImageName = "COMPRESSED_IMAGE"
+)
+
+// ditherRand is the sequence of random numbers used by subtractive
+// dithering, as defined by the FITS tiled image compression convention.
+var ditherRand = func() []float32 {
+	const (
+		a = 16807.0
+		m = 2147483647.0
+	)
+	vs := make([]float32, nRandom)
+	seed := 1.0
+	for i := range vs {
+		tmp := a * seed
+		seed = tmp - m*math.Trunc(tmp/m)
+		vs[i] = float32(seed / m)
+	}
+	return vs
+}()
+
+// dither iterates over the dithering sequence of a tile.
+type dither struct {
+	iseed int
+	next  int
+}
+
+// newDither returns the dithering sequence of the itile-th tile (0-based),
+// for a ZDITHER0 value of seed.
+func newDither(itile, seed int) dither {
+	iseed := (itile + seed - 1) % nRandom
+	return dither{iseed: iseed, next: int(ditherRand[iseed] * 500)}
+}
+
+// value returns the next random value of the sequence.
+func (d *dither) value() float64 {
+	v := float64(ditherRand[d.next])
+	d.next++
+	if d.next == nRandom {
+		d.iseed++
+		if d.iseed == nRandom {
+			d.iseed = 0
+		}
+		d.next = int(ditherRand[d.iseed] * 500)
+	}
+	return v
+}
+
+// IsCompressedImage returns whether hdu is a binary table holding a
+// tile-compressed image (ZIMAGE=T).
+func IsCompressedImage(hdu HDU) bool {
+	t, ok := hdu.(*Table)
+	if !ok || !t.binary {
+		return false
+	}
+	card := t.hdr.Get("ZIMAGE")
+	return card != nil && card.Value == true
+}
+
+// tileGrid describes the tiling of an image.
+type tileGrid struct {
+	axes   []int
+	tile   []int
+	ntiles []int // number of tiles along each axis
+}
+
+func newTileGrid(axes, tile []int) (tileGrid, error) {
+	g := tileGrid{
+		axes:   axes,
+		tile:   make([]int, len(axes)),
+		ntiles: make([]int, len(axes)),
+	}
+	for i, dim := range axes {
+		sz := 1
+		switch {
+		case i < len(tile) && tile[i] < 0:
+			return g, fmt.Errorf("fitsio: invalid tile dimensions %v", tile)
+		case i < len(tile) && tile[i] > 0:
+			sz = min(tile[i], dim)
+		case i < len(tile) && tile[i] == 0, i == 0 && len(tile) == 0:
+			sz = dim
+		}
+		if dim == 0 {
+			sz = 1
+		}
+		g.tile[i] = sz
+		g.ntiles[i] = (dim + sz - 1) / sz
+	}
+	return g, nil
+}
+
+// len returns the number of tiles.
+func (g tileGrid) len() int {
+	if len(g.axes) == 0 {
+		return 0
+	}
+	n := 1
+	for _, v := range g.ntiles {
+		n *= v
+	}
+	return n
+}
+
+// pixels returns the indices, in the image, of the pixels of the i-th tile,
+// and the width of the tile.
+func (g tileGrid) pixels(i int) ([]int, int) {
+	var (
+		ndim   = len(g.axes)
+		start  = make([]int, ndim)
+		size   = make([]int, ndim)
+		stride = make([]int, ndim)
+		n      = 1
+	)
+	for k := 0; k < ndim; k++ {
+		start[k] = (i % g.ntiles[k]) * g.tile[k]
+		i /= g.ntiles[k]
+		size[k] = min(g.tile[k], g.axes[k]-start[k])
+		n *= size[k]
+		if k == 0 {
+			stride[k] = 1
+		} else {
+			stride[k] = stride[k-1] * g.axes[k-1]
+		}
+	}
+
+	idx := make([]int, 0, n)
+	pos := make([]int, ndim)
+	for len(idx) < n {
+		j := 0
+		for k := range pos {
+			j += (start[k] + pos[k]) * stride[k]
+		}
+		idx = append(idx, j)
+		for k := range pos {
+			pos[k]++
+			if pos[k] < size[k] {
+				break
+			}
+			pos[k] = 0
+		}
+	}
+	return idx, size[0]
+}
+
+// CompressImage returns a binary table holding the pixels of img,
+// compressed according to the FITS tiled image compression convention.
+//
+// Floating point pixels are quantized into integers, unless a zero level
+// was given to WithQuantization.
+// Tiles which can not be quantized (e.g. tiles with a constant value) are
+// compressed losslessly, in the GZIP_COMPRESSED_DATA column.
+//
+// The header cards of img are copied to the table, with the cards
+// describing the image layout renamed as ZSIMPLE, ZTENSION, ZBITPIX,
+// ZNAXIS and ZNAXISn.
+// The primary HDU of a FITS file can not be a table: a compressed primary
+// image must be written after an empty primary HDU.
+func CompressImage(img Image, opts ...Option) (*Table, error) {
+	cfg := newConfig(opts)
+	if !cfg.qset {
+		cfg.qlevel = 4
+	}
+
+	src := img.Header()
+	bitpix := src.Bitpix()
+	axes := src.Axes()
+	pixsz := bitpix / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
+
+	grid, err := newTileGrid(axes, cfg.tile)
+	if err != nil {
+		return nil, err
+	}
+
+	nelmts := 0
+	if len(axes) > 0 {
+		nelmts = 1
+		for _, dim := range axes {
+			nelmts *= dim
+		}
+	}
+	raw := img.Raw()
+	if len(raw) < nelmts*pixsz {
+		return nil, fmt.Errorf(
+			"fitsio: image raw data too short (got=%d bytes, want=%d bytes)",
+			len(raw), nelmts*pixsz,
+		)
+	}
+
+	quantize := bitpix < 0 && cfg.qlevel != 0
+	switch {
+	case bitpix == 64 && cfg.compression == Rice1:
+		return nil, fmt.Errorf("fitsio: RICE_1 can not compress 64-bit integer pixels")
+	case bitpix < 0 && !quantize && cfg.compression == Rice1:
+		return nil, fmt.Errorf("fitsio: RICE_1 can not compress floating point pixels losslessly")
+	}
+
+	seed := 1
+	if cfg.dither {
+		seed = int((cfg.ditherSeed%nRandom+nRandom)%nRandom) + 1
+	}
+
+	cols := []Column{{Name: "COMPRESSED_DATA", Format: "1PB", Bscale: 1}}
+	if quantize {
+		cols = append(cols,
+			Column{Name: "GZIP_COMPRESSED_DATA", Format: "1PB", Bscale: 1},
+			Column{Name: "ZSCALE", Format: "1D", Bscale: 1},
+			Column{Name: "ZZERO", Format: "1D", Bscale: 1},
+		)
+	}
+
+	// primary HDUs are named "PRIMARY" even without an EXTNAME card.
+	name := compressedImageName
+	if card := src.Get("EXTNAME"); card != nil {
+		if v, ok := card.Value.(string); ok && v != "" {
+			name = v
+		}
+	}
+	tbl, err := NewTable(name, cols, BINARY_TBL)
+	if err != nil {
+		return nil, err
+	}
+
+	bytepix := pixsz
+	if quantize {
+		bytepix = 4
+	}
+
+	nulls := false
+	for i := 0; i < grid.len(); i++ {
+		idx, width := grid.pixels(i)
+		var (
+			data   []byte
+			gzdata []byte
+			zscale = 1.0
+			zzero  = 0.0
+		)
+		switch {
+		case quantize:
+			vs := make([]float64, len(idx))
+			for j, k := range idx {
+				if bitpix == -32 {
+					vs[j] = float64(math.Float32frombits(binary.BigEndian.Uint32(raw[4*k:])))
+				} else {
+					vs[j] = math.Float64frombits(binary.BigEndian.Uint64(raw[8*k:]))
+				}
+			}
+			ivs, scale, zero, ok := quantizeTile(vs, width, i, seed, &cfg)
+			if !ok {
+				gzdata, err = compressTileBytes(floatTileBytes(raw, idx, pixsz), GZIP1, pixsz)
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+			for _, v := range ivs {
+				if v == zblankValue {
+					nulls = true
+					break
+				}
+			}
+			zscale, zzero = scale, zero
+			data, err = compressTile(ivs, cfg.compression, bytepix)
+			if err != nil {
+				return nil, err
+			}
+
+		case bitpix < 0:
+			data, err = compressTileBytes(floatTileBytes(raw, idx, pixsz), cfg.compression, pixsz)
+			if err != nil {
+				return nil, err
+			}
+
+		default:
+			ivs := make([]int64, len(idx))
+			for j, k := range idx {
+				switch bitpix {
+				case 8:
+					ivs[j] = int64(raw[k])
+				case 16:
+					ivs[j] = int64(int16(binary.BigEndian.Uint16(raw[2*k:])))
+				case 32:
+					ivs[j] = int64(int32(binary.BigEndian.Uint32(raw[4*k:])))
+				case 64:
+					ivs[j] = int64(binary.BigEndian.Uint64(raw[8*k:]))
+				}
+			}
+			data, err = compressTile(ivs, cfg.compression, bytepix)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if quantize {
+			err = tbl.Write(&data, &gzdata, &zscale, &zzero)
+		} else {
+			err = tbl.Write(&data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not write tile #%d: %w", i, err)
+		}
+	}
+
+	zbitpix := bitpix
+	cards := []Card{
+		{Name: "ZIMAGE", Value: true, Comment: "extension contains compressed image"},
+	}
+	if src.Get("SIMPLE") != nil {
+		cards = append(cards, Card{Name: "ZSIMPLE", Value: true, Comment: "file does conform to FITS standard"})
+	} else {
+		cards = append(cards, Card{Name: "ZTENSION", Value: "IMAGE", Comment: "image extension"})
+	}
+	cards = append(cards,
+		Card{Name: "ZBITPIX", Value: zbitpix, Comment: "data type of original image"},
+		Card{Name: "ZNAXIS", Value: len(axes), Comment: "dimension of original image"},
+	)
+	for i, dim := range axes {
+		cards = append(cards, Card{
+			Name:    fmt.Sprintf("ZNAXIS%d", i+1),
+			Value:   dim,
+			Comment: fmt.Sprintf("length of original image axis %d", i+1),
+		})
+	}
+	for i, dim := range grid.tile {
+		cards = append(cards, Card{
+			Name:    fmt.Sprintf("ZTILE%d", i+1),
+			Value:   dim,
+			Comment: fmt.Sprintf("size of tiles to be compressed along axis %d", i+1),
+		})
+	}
+	cards = append(cards, Card{Name: "ZCMPTYPE", Value: cfg.compression.String(), Comment: "compression algorithm"})
+	if cfg.compression == Rice1 {
+		cards = append(cards,
+			Card{Name: "ZNAME1", Value: "BLOCKSIZE", Comment: "compression block size"},
+			Card{Name: "ZVAL1", Value: 32, Comment: "pixels per block"},
+			Card{Name: "ZNAME2", Value: "BYTEPIX", Comment: "bytes per pixel (1, 2, 4, or 8)"},
+			Card{Name: "ZVAL2", Value: bytepix, Comment: "bytes per pixel (1, 2, 4, or 8)"},
+		)
+	}
+	if quantize {
+		cards = append(cards, Card{Name: "ZQUANTIZ", Value: cfg.quantize.String(), Comment: "quantization method"})
+		if cfg.quantize != NoDither {
+			cards = append(cards, Card{Name: "ZDITHER0", Value: seed, Comment: "dithering offset when quantizing floats"})
+		}
+		if nulls {
+			cards = append(cards, Card{Name: "ZBLANK", Value: zblankValue, Comment: "null value in the compressed integer array"})
+		}
+	}
+
+	for _, card := range src.cards {
+		switch card.Name {
+		case "SIMPLE", "XTENSION", "EXTEND", "BITPIX", "NAXIS", "PCOUNT", "GCOUNT",
+			"EXTNAME", "CHECKSUM", "DATASUM", "END":
+			continue
+		}
+		if strings.HasPrefix(card.Name, "NAXIS") {
+			continue
+		}
+		cards = append(cards, card)
+	}
+
+	err = tbl.hdr.Append(cards...)
+	if err != nil {
+		return nil, err
+	}
+	return tbl, nil
+}
+
+// quantizeTile quantizes the floating point values vs of the itile-th tile,
+// whose rows are width pixels wide.
+// quantizeTile returns false if the values can not be quantized.
+func quantizeTile(vs []float64, width, itile, seed int, cfg *config) ([]int64, float64, float64, bool) {
+	lo, hi := math.Inf(+1), math.Inf(-1)
+	for _, v := range vs {
+		if math.IsNaN(v) {
+			continue
+		}
+		if math.IsInf(v, 0) {
+			return nil, 0, 0, false
+		}
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	var delta float64
+	switch {
+	case lo > hi:
+		// only undefined pixels.
+		delta = 1
+	case cfg.qlevel < 0:
+		delta = -cfg.qlevel
+	default:
+		delta = tileNoise(vs, width) / cfg.qlevel
+	}
+	if delta == 0 || (hi-lo)/delta >= 2*(math.MaxInt32-nReserved) {
+		return nil, 0, 0, false
+	}
+
+	zero := 0.0
+	if lo <= hi {
+		// center the quantized values around zero, on a multiple of delta.
+		zero = math.Round((lo+hi)/2/delta) * delta
+	}
+
+	var (
+		ivs = make([]int64, len(vs))
+		rnd = newDither(itile, seed)
+	)
+	for i, v := range vs {
+		r := 0.5
+		if cfg.quantize != NoDither {
+			r = rnd.value()
+		}
+		switch {
+		case math.IsNaN(v):
+			ivs[i] = zblankValue
+		case v == 0 && cfg.quantize == SubtractiveDither2:
+			ivs[i] = zeroValue
+		default:
+			ivs[i] = int64(math.Round((v-zero)/delta + r - 0.5))
+		}
+	}
+	return ivs, delta, zero, true
+}
+
+// tileNoise estimates the standard deviation of the noise of the values vs,
+// from the median of their second order differences along rows of width
+// pixels.
+func tileNoise(vs []float64, width int) float64 {
+	var (
+		meds  []float64
+		diffs []float64
+		row   []float64
+	)
+	for beg := 0; beg < len(vs); beg += width {
+		row = row[:0]
+		for _, v := range vs[beg:min(beg+width, len(vs))] {
+			if !math.IsNaN(v) {
+				row = append(row, v)
+			}
+		}
+		if len(row) < 5 {
+			continue
+		}
+		diffs = diffs[:0]
+		for i := 2; i < len(row)-2; i++ {
+			diffs = append(diffs, math.Abs(2*row[i]-row[i-2]-row[i+2]))
+		}
+		sort.Float64s(diffs)
+		meds = append(meds, diffs[len(diffs)/2])
+	}
+	if len(meds) == 0 {
+		return 0
+	}
+	sort.Float64s(meds)
+	return 0.6052 * meds[len(meds)/2]
+}
+
+// floatTileBytes returns the raw bytes of the floating point pixels idx.
+func floatTileBytes(raw []byte, idx []int, pixsz int) []byte {
+	buf := make([]byte, 0, len(idx)*pixsz)
+	for _, k := range idx {
+		buf = append(buf, raw[k*pixsz:(k+1)*pixsz]...)
+	}
+	return buf
+}
+
+// compressTile compresses the integer pixels vs, stored with bytepix bytes.
+func compressTile(vs []int64, c Compression, bytepix int) ([]byte, error) {
+	if c == Rice1 {
+		return riceEncode(vs, bytepix, 32)
+	}
+	buf := make([]byte, len(vs)*bytepix)
+	for i, v := range vs {
+		switch bytepix {
+		case 1:
+			buf[i] = byte(v)
+		case 2:
+			binary.BigEndian.PutUint16(buf[2*i:], uint16(v))
+		case 4:
+			binary.BigEndian.PutUint32(buf[4*i:], uint32(v))
+		case 8:
+			binary.BigEndian.PutUint64(buf[8*i:], uint64(v))
+		}
+	}
+	return compressTileBytes(buf, c, bytepix)
+}
+
+// compressTileBytes compresses the big-endian pixels of bytepix bytes
+// held by buf.
+func compressTileBytes(buf []byte, c Compression, bytepix int) ([]byte, error) {
+	switch c {
+	case NoCompress:
+		return buf, nil
+	case GZIP1:
+	case GZIP2:
+		buf = shuffle(buf, bytepix)
+	default:
+		return nil, fmt.Errorf("fitsio: %v can not compress raw pixel bytes", c)
+	}
+
+	var out bytes.Buffer
+	zw := gzip.NewWriter(&out)
+	_, err := zw.Write(buf)
+	if err != nil {
+		return nil, err
+	}
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// shuffle groups the bytes of the pixels of bytepix bytes held by buf by
+// significance, most significant first.
+func shuffle(buf []byte, bytepix int) []byte {
+	n := len(buf) / bytepix
+	out := make([]byte, len(buf))
+	for i := 0; i < n; i++ {
+		for j := 0; j < bytepix; j++ {
+			out[j*n+i] = buf[i*bytepix+j]
+		}
+	}
+	return out
+}
+
+// unshuffle reverses shuffle.
+func unshuffle(buf []byte, bytepix int) []byte {
+	n := len(buf) / bytepix
+	out := make([]byte, len(buf))
+	for i := 0; i < n; i++ {
+		for j := 0; j < bytepix; j++ {
+			out[i*bytepix+j] = buf[j*n+i]
+		}
+	}
+	return out
+}
+
+// decompressTileBytes decompresses the n big-endian pixels of bytepix
+// bytes held by buf.
+func decompressTileBytes(buf []byte, c Compression, n, bytepix int) ([]byte, error) {
+	switch c {
+	case NoCompress:
+	case GZIP1, GZIP2:
+		zr, err := gzip.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not open gzip tile: %w", err)
+		}
+		buf, err = io.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not decompress gzip tile: %w", err)
+		}
+		if c == GZIP2 {
+			buf = unshuffle(buf, bytepix)
+		}
+	default:
+		return nil, fmt.Errorf("fitsio: %v can not decompress raw pixel bytes", c)
+	}
+	if len(buf) != n*bytepix {
+		return nil, fmt.Errorf("fitsio: invalid tile size (got=%d bytes, want=%d bytes)", len(buf), n*bytepix)
+	}
+	return buf, nil
+}
+
+// decompressTile decompresses the n integer pixels, stored with bytepix
+// bytes, held by buf.
+func decompressTile(buf []byte, c Compression, n, bytepix, blocksize int) ([]int64, error) {
+	if c == Rice1 {
+		return riceDecode(buf, n, bytepix, blocksize)
+	}
+	buf, err := decompressTileBytes(buf, c, n, bytepix)
+	if err != nil {
+		return nil, err
+	}
+	vs := make([]int64, n)
+	for i := range vs {
+		switch bytepix {
+		case 1:
+			vs[i] = int64(buf[i])
+		case 2:
+			vs[i] = int64(int16(binary.BigEndian.Uint16(buf[2*i:])))
+		case 4:
+			vs[i] = int64(int32(binary.BigEndian.Uint32(buf[4*i:])))
+		case 8:
+			vs[i] = int64(binary.BigEndian.Uint64(buf[8*i:]))
+		}
+	}
+	return vs, nil
+}
+
+// compressionFrom returns the compression algorithm named by a ZCMPTYPE value.
+func compressionFrom(name string) (Compression, error) {
+	switch strings.TrimSpace(name) {
+	case "RICE_1", "RICE_ONE":
+		return Rice1, nil
+	case "GZIP_1":
+		return GZIP1, nil
+	case "GZIP_2":
+		return GZIP2, nil
+	case "NOCOMPRESS":
+		return NoCompress, nil
+	}
+	return 0, fmt.Errorf("fitsio: unsupported compression algorithm %q", name)
+}
+
+// quantizationFrom returns the quantization method named by a ZQUANTIZ value.
+func quantizationFrom(name string) (Quantization, error) {
+	switch strings.TrimSpace(name) {
+	case "SUBTRACTIVE_DITHER_1":
+		return SubtractiveDither1, nil
+	case "SUBTRACTIVE_DITHER_2":
+		return SubtractiveDither2, nil
+	case "NO_DITHER", "NONE":
+		return NoDither, nil
+	}
+	return 0, fmt.Errorf("fitsio: unsupported quantization method %q", name)
+}
+
+// DecompressImage returns the image held by t, a binary table holding a
+// tile-compressed image.
+//
+// The header cards of t are copied to the image, except for those
+// describing the table and the compression.
+func DecompressImage(t *Table) (Image, error) {
+	if !IsCompressedImage(t) {
+		return nil, fmt.Errorf("fitsio: HDU is not a compressed image")
+	}
+	hdr := t.Header()
+
+	intCard := func(name string, def int) (int, error) {
+		card := hdr.Get(name)
+		if card == nil {
+			if def < 0 {
+				return 0, fmt.Errorf("fitsio: missing %q card", name)
+			}
+			return def, nil
+		}
+		return cardInt(card)
+	}
+
+	bitpix, err := intCard("ZBITPIX", -1)
+	if err != nil {
+		return nil, err
+	}
+	switch bitpix {
+	case 8, 16, 32, 64, -32, -64:
+	default:
+		return nil, fmt.Errorf("fitsio: invalid ZBITPIX value (%d)", bitpix)
+	}
+	naxis, err := intCard("ZNAXIS", -1)
+	if err != nil {
+		return nil, err
+	}
+	if naxis < 0 || naxis > 999 {
+		return nil, fmt.Errorf("fitsio: invalid ZNAXIS value (%d)", naxis)
+	}
+	axes := make([]int, naxis)
+	tile := make([]int, naxis)
+	for i := range axes {
+		axes[i], err = intCard(fmt.Sprintf("ZNAXIS%d", i+1), -1)
+		if err != nil {
+			return nil, err
+		}
+		def := 1
+		if i == 0 {
+			def = axes[0]
+		}
+		tile[i], err = intCard(fmt.Sprintf("ZTILE%d", i+1), def)
+		if err != nil {
+			return nil, err
+		}
+		if axes[i] < 0 || tile[i] <= 0 && axes[i] > 0 {
+			return nil, fmt.Errorf("fitsio: invalid ZNAXIS%d/ZTILE%d values (%d/%d)", i+1, i+1, axes[i], tile[i])
+		}
+	}
+	grid, err := newTileGrid(axes, tile)
+	if err != nil {
+		return nil, err
+	}
+	if got, want := t.NumRows(), int64(grid.len()); got != want {
+		return nil, fmt.Errorf("fitsio: invalid number of tiles (got=%d, want=%d)", got, want)
+	}
+
+	card := hdr.Get("ZCMPTYPE")
+	if card == nil {
+		return nil, fmt.Errorf("fitsio: missing %q card", "ZCMPTYPE")
+	}
+	name, err := cardString(card)
+	if err != nil {
+		return nil, err
+	}
+	cmp, err := compressionFrom(name)
+	if err != nil {
+		return nil, err
+	}
+
+	pixsz := bitpix / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
+	bytepix, blocksize := pixsz, 32
+	for i := 1; ; i++ {
+		card := hdr.Get(fmt.Sprintf("ZNAME%d", i))
+		if card == nil {
+			break
+		}
+		val := hdr.Get(fmt.Sprintf("ZVAL%d", i))
+		if val == nil {
+			continue
+		}
+		switch card.Value {
+		case "BLOCKSIZE":
+			blocksize, err = cardInt(val)
+		case "BYTEPIX":
+			bytepix, err = cardInt(val)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if blocksize <= 0 {
+		return nil, fmt.Errorf("fitsio: invalid RICE_1 BLOCKSIZE value (%d)", blocksize)
+	}
+
+	var (
+		icmp    = t.Index("COMPRESSED_DATA")
+		igzip   = t.Index("GZIP_COMPRESSED_DATA")
+		iscale  = t.Index("ZSCALE")
+		izero   = t.Index("ZZERO")
+		iblank  = t.Index("ZBLANK")
+		method  = NoDither
+		seed    = 1
+		zblank  = int64(zblankValue)
+		hasNull = false
+	)
+	if icmp < 0 {
+		return nil, fmt.Errorf("fitsio: missing COMPRESSED_DATA column")
+	}
+	quantized := bitpix < 0 && iscale >= 0 && izero >= 0
+	if quantized {
+		if card := hdr.Get("ZQUANTIZ"); card != nil {
+			name, err := cardString(card)
+			if err != nil {
+				return nil, err
+			}
+			method, err = quantizationFrom(name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if method != NoDither {
+			seed, err = intCard("ZDITHER0", 1)
+			if err != nil {
+				return nil, err
+			}
+			if seed < 1 || seed > nRandom {
+				return nil, fmt.Errorf("fitsio: invalid ZDITHER0 value (%d)", seed)
+			}
+		}
+		if card := hdr.Get("ZBLANK"); card != nil {
+			v, err := cardInt(card)
+			if err != nil {
+				return nil, err
+			}
+			zblank = int64(v)
+			hasNull = true
+		}
+		bytepix = 4
+	} else if cmp != Rice1 {
+		bytepix = pixsz
+	}
+
+	img := NewImage(bitpix, axes)
+	img.raw = make([]byte, int(img.DataSize()))
+	for i := 0; i < grid.len(); i++ {
+		idx, _ := grid.pixels(i)
+		irow := int64(i)
+
+		var data []byte
+		err = t.cols[icmp].read(t, icmp, irow, &data)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not read tile #%d: %w", i, err)
+		}
+
+		if bitpix < 0 && (!quantized || len(data) == 0) {
+			// tile of floating point pixels compressed losslessly.
+			c := cmp
+			if quantized {
+				if igzip < 0 {
+					return nil, fmt.Errorf("fitsio: tile #%d has no data", i)
+				}
+				err = t.cols[igzip].read(t, igzip, irow, &data)
+				if err != nil {
+					return nil, fmt.Errorf("fitsio: could not read tile #%d: %w", i, err)
+				}
+				c = GZIP1
+			}
+			buf, err := decompressTileBytes(data, c, len(idx), pixsz)
+			if err != nil {
+				return nil, fmt.Errorf("fitsio: could not decompress tile #%d: %w", i, err)
+			}
+			for j, k := range idx {
+				copy(img.raw[k*pixsz:(k+1)*pixsz], buf[j*pixsz:(j+1)*pixsz])
+			}
+			continue
+		}
+
+		ivs, err := decompressTile(data, cmp, len(idx), bytepix, blocksize)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not decompress tile #%d: %w", i, err)
+		}
+
+		if !quantized {
+			for j, k := range idx {
+				switch bitpix {
+				case 8:
+					img.raw[k] = byte(ivs[j])
+				case 16:
+					binary.BigEndian.PutUint16(img.raw[2*k:], uint16(ivs[j]))
+				case 32:
+					binary.BigEndian.PutUint32(img.raw[4*k:], uint32(ivs[j]))
+				case 64:
+					binary.BigEndian.PutUint64(img.raw[8*k:], uint64(ivs[j]))
+				}
+			}
+			continue
+		}
+
+		var zscale, zzero float64
+		err = t.cols[iscale].read(t, iscale, irow, &zscale)
+		if err != nil {
+			return nil, err
+		}
+		err = t.cols[izero].read(t, izero, irow, &zzero)
+		if err != nil {
+			return nil, err
+		}
+		blank, tileNull := zblank, hasNull
+		if iblank >= 0 {
+			var v int32
+			err = t.cols[iblank].read(t, iblank, irow, &v)
+			if err != nil {
+				return nil, err
+			}
+			blank, tileNull = int64(v), true
+		}
+
+		rnd := newDither(i, seed)
+		for j, k := range idx {
+			r := 0.5
+			if method != NoDither {
+				r = rnd.value()
+			}
+			var v float64
+			switch iv := ivs[j]; {
+			case tileNull && iv == blank:
+				v = math.NaN()
+			case method == SubtractiveDither2 && iv == zeroValue:
+				v = 0
+			default:
+				v = (float64(iv)-r+0.5)*zscale + zzero
+			}
+			if bitpix == -32 {
+				binary.BigEndian.PutUint32(img.raw[4*k:], math.Float32bits(float32(v)))
+			} else {
+				binary.BigEndian.PutUint64(img.raw[8*k:], math.Float64bits(v))
+			}
+		}
+	}
+
+	cards := make([]Card, 0, len(hdr.cards))
+	for _, card := range hdr.cards {
+		if isCompressionKey(card.Name) {
+			continue
+		}
+		if card.Name == "EXTNAME" && (card.Value == compressedImageName || card.Value == "") {
+			continue
+		}
+		cards = append(cards, card)
+	}
+	err = img.hdr.Append(cards...)
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// isCompressionKey returns whether the keyword name of the header of a
+// tile-compressed image describes the table or the compression.
+func isCompressionKey(name string) bool {
+	switch name {
+	case "XTENSION", "BITPIX", "NAXIS", "PCOUNT", "GCOUNT", "TFIELDS", "THEAP",
+		"ZIMAGE", "ZSIMPLE", "ZTENSION", "ZEXTEND", "ZBLOCKED", "ZPCOUNT", "ZGCOUNT",
+		"ZBITPIX", "ZNAXIS", "ZCMPTYPE", "ZQUANTIZ", "ZDITHER0", "ZBLANK", "ZMASKCMP",
+		"ZHECKSUM", "ZDATASUM", "CHECKSUM", "DATASUM", "END",
+		"ZTABLE", "ZTILELEN", "ZTHEAP":
+		return true
+	}
+	for _, prefix := range []string{
+		"NAXIS", "ZNAXIS", "ZTILE", "ZNAME", "ZVAL", "ZFORM", "ZCTYP",
+		"TTYPE", "TFORM", "TUNIT", "TNULL", "TSCAL", "TZERO", "TDISP", "TDIM", "TBCOL",
+	} {
+		if len(name) > len(prefix) && strings.HasPrefix(name, prefix) && strings.Trim(name[len(prefix):], "0123456789") == "" {
+			return true
+		}
+	}
+	return false
+}
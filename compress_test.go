@@ -0,0 +1,231 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDitherSequence(t *testing.T) {
+	// the last seed of the sequence is given by the FITS standard.
+	if got, want := ditherRand[nRandom-1], float32(1043618065.0/2147483647.0); got != want {
+		t.Fatalf("invalid dithering sequence: got=%v, want=%v", got, want)
+	}
+}
+
+func TestRice(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, bytepix := range []int{1, 2, 4} {
+		bits := 8 * bytepix
+		lo, hi := -int64(1)<<(bits-1), int64(1)<<(bits-1)-1
+		for _, vs := range [][]int64{
+			nil,
+			{42},
+			make([]int64, 100), // low entropy
+			{lo, hi, lo, hi, 0, -1, 1, lo, hi},
+			func() []int64 {
+				vs := make([]int64, 1000)
+				for i := range vs {
+					switch {
+					case i < 300:
+						vs[i] = int64(rnd.NormFloat64() * 10)
+					case i < 600:
+						vs[i] = lo + rnd.Int63n(hi-lo) // high entropy
+					default:
+						vs[i] = int64(i / 100)
+					}
+				}
+				return vs
+			}(),
+		} {
+			buf, err := riceEncode(vs, bytepix, 32)
+			if err != nil {
+				t.Fatalf("bytepix=%d: could not encode: %v", bytepix, err)
+			}
+			got, err := riceDecode(buf, len(vs), bytepix, 32)
+			if err != nil {
+				t.Fatalf("bytepix=%d: could not decode: %v", bytepix, err)
+			}
+			if len(vs) == 0 {
+				vs = []int64{}
+			}
+			if !reflect.DeepEqual(got, vs) {
+				t.Fatalf("bytepix=%d: invalid round trip:\ngot= %v\nwant=%v", bytepix, got, vs)
+			}
+		}
+	}
+
+	_, err := riceDecode([]byte{1, 2}, 10, 4, 32)
+	if err == nil {
+		t.Fatalf("expected an error decoding truncated data")
+	}
+}
+
+// roundTripCompressed writes tbl to a FITS file after an empty primary HDU,
+// reads it back and decompresses it.
+func roundTripCompressed(t *testing.T, tbl *Table) Image {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := Create(&buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for _, hdu := range []HDU{NewImage(8, nil), tbl} {
+		err = w.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	f, err := Open(&buf)
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+	if !IsCompressedImage(f.HDU(1)) || IsCompressedImage(f.HDU(0)) {
+		t.Fatalf("invalid compressed image detection")
+	}
+	img, err := DecompressImage(f.HDU(1).(*Table))
+	if err != nil {
+		t.Fatalf("could not decompress image: %v", err)
+	}
+	return img
+}
+
+func TestCompressImageLossless(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	for _, bitpix := range []int{8, 16, 32, 64, -32, -64} {
+		for _, cmp := range []Compression{Rice1, GZIP1, GZIP2, NoCompress} {
+			for _, tile := range [][]int{nil, {3, 2}, {0, 0, 1}} {
+				src := NewImage(bitpix, []int{7, 5, 2})
+				src.Header().Append(Card{Name: "OBJECT", Value: "M31"})
+				src.raw = make([]byte, src.DataSize())
+				rnd.Read(src.raw)
+
+				opts := []Option{WithCompression(cmp), WithTileSize(tile...)}
+				if bitpix < 0 {
+					opts = append(opts, WithQuantization(NoDither, 0))
+				}
+				tbl, err := CompressImage(src, opts...)
+				if (bitpix == 64 || bitpix < 0) && cmp == Rice1 {
+					if err == nil {
+						t.Fatalf("bitpix=%d, %v: expected an error", bitpix, cmp)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("bitpix=%d, %v: could not compress image: %v", bitpix, cmp, err)
+				}
+				if got, want := tbl.Header().Get("ZCMPTYPE").Value, cmp.String(); got != want {
+					t.Fatalf("invalid ZCMPTYPE: got=%v, want=%v", got, want)
+				}
+
+				img := roundTripCompressed(t, tbl)
+				if got, want := img.Header().Bitpix(), bitpix; got != want {
+					t.Fatalf("invalid bitpix: got=%d, want=%d", got, want)
+				}
+				if got, want := img.Header().Axes(), src.Header().Axes(); !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid axes: got=%v, want=%v", got, want)
+				}
+				if card := img.Header().Get("OBJECT"); card == nil || card.Value != "M31" {
+					t.Fatalf("invalid OBJECT card: %v", card)
+				}
+				if img.Header().Get("ZIMAGE") != nil || img.Header().Get("TFORM1") != nil {
+					t.Fatalf("compression cards were not removed:\n%s", img.Header().Text())
+				}
+				if !bytes.Equal(img.Raw(), src.Raw()) {
+					t.Fatalf("bitpix=%d, %v, tile=%v: invalid pixels", bitpix, cmp, tile)
+				}
+			}
+		}
+	}
+}
+
+func TestCompressImageQuantized(t *testing.T) {
+	const (
+		nx = 64
+		ny = 16
+	)
+	rnd := rand.New(rand.NewSource(3))
+	vs := make([]float32, nx*ny)
+	for i := range vs {
+		vs[i] = 100 + float32(i%nx) + float32(rnd.NormFloat64())
+	}
+	vs[5] = float32(math.NaN())
+	vs[6] = 0
+	for i := 2 * nx; i < 3*nx; i++ {
+		vs[i] = 42 // constant row: lossless.
+	}
+
+	src := NewImage(-32, []int{nx, ny})
+	err := src.Write(vs)
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+
+	for _, method := range []Quantization{SubtractiveDither1, SubtractiveDither2, NoDither} {
+		opts := []Option{WithQuantization(method, 4), WithDither(1233)}
+		tbl, err := CompressImage(src, opts...)
+		if err != nil {
+			t.Fatalf("%v: could not compress image: %v", method, err)
+		}
+		hdr := tbl.Header()
+		if got, want := hdr.Get("ZQUANTIZ").Value, method.String(); got != want {
+			t.Fatalf("invalid ZQUANTIZ: got=%v, want=%v", got, want)
+		}
+		switch card := hdr.Get("ZDITHER0"); method {
+		case NoDither:
+			if card != nil {
+				t.Fatalf("unexpected ZDITHER0 card")
+			}
+		default:
+			if card == nil || card.Value != 1234 {
+				t.Fatalf("invalid ZDITHER0 card: %v", card)
+			}
+		}
+
+		img := roundTripCompressed(t, tbl)
+		got := make([]float32, len(vs))
+		err = img.Read(&got)
+		if err != nil {
+			t.Fatalf("could not read image: %v", err)
+		}
+
+		iscale := tbl.Index("ZSCALE")
+		for i, v := range got {
+			// tiles are rows.
+			var zscale float64
+			err = tbl.cols[iscale].read(tbl, iscale, int64(i/nx), &zscale)
+			if err != nil {
+				t.Fatalf("could not read ZSCALE: %v", err)
+			}
+			want := vs[i]
+			switch {
+			case math.IsNaN(float64(want)):
+				if !math.IsNaN(float64(v)) {
+					t.Fatalf("%v: pixel %d: got=%v, want=NaN", method, i, v)
+				}
+			case i/nx == 2, want == 0 && method == SubtractiveDither2:
+				if v != want {
+					t.Fatalf("%v: pixel %d: got=%v, want=%v", method, i, v, want)
+				}
+			case math.Abs(float64(v-want)) > 0.5*zscale+1e-4:
+				t.Fatalf("%v: pixel %d: got=%v, want=%v (zscale=%v)", method, i, v, want, zscale)
+			}
+		}
+
+		// compression is reproducible.
+		again, err := CompressImage(src, opts...)
+		if err != nil {
+			t.Fatalf("could not compress image: %v", err)
+		}
+		if !bytes.Equal(again.heap, tbl.heap) {
+			t.Fatalf("%v: compression is not reproducible", method)
+		}
+	}
+}
@@ -5,5 +5,7 @@
 package fitsio
 
 const (
-	blockSize = 2880 // size in bytes of a FITS block
+	// BlockSize is the size in bytes of a FITS block. Headers and data
+	// units of HDUs are made of whole blocks.
+	BlockSize = 2880
 )
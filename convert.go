@@ -0,0 +1,441 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+)
+
+// WriteConverted writes the physical values held by data to the HDU.
+// data is a slice or an array (or a pointer to a slice or an array) of any
+// integer or floating point type.
+//
+// Values are converted to the pixel type described by BITPIX, after the
+// inverse of the BSCALE/BZERO rescaling has been applied: a []uint16 can
+// thus be stored in a BITPIX=16 image with BZERO=32768, and a []int in a
+// BITPIX=64 image.
+// WriteConverted returns an error if a value can not be stored exactly.
+func (img *imageHDU) WriteConverted(data interface{}) error {
+	rv := reflect.ValueOf(data)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("fitsio: invalid image type (%T). expected array or slice", data)
+	}
+
+	hdr := img.Header()
+	axes := hdr.Axes()
+	if len(axes) == 0 {
+		return nil
+	}
+	nelmts := 1
+	for _, dim := range axes {
+		nelmts *= dim
+	}
+	if rv.Len() > nelmts {
+		return fmt.Errorf("fitsio: too many values (got=%d, want=%d)", rv.Len(), nelmts)
+	}
+
+	cnv, err := newPixelConv(hdr)
+	if err != nil {
+		return err
+	}
+
+	pixsz := hdr.Bitpix() / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
+	raw := make([]byte, pixsz*nelmts)
+	w := newWriter(raw)
+
+	var put func(i int) bool
+	switch rv.Type().Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		put = func(i int) bool { return cnv.writeInt(w, rv.Index(i).Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		put = func(i int) bool { return cnv.writeUint(w, rv.Index(i).Uint()) }
+	case reflect.Float32, reflect.Float64:
+		put = func(i int) bool { return cnv.writeFloat(w, rv.Index(i).Float()) }
+	default:
+		return fmt.Errorf("fitsio: invalid image element type (%v)", rv.Type().Elem())
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if !put(i) {
+			return fmt.Errorf(
+				"fitsio: value %v (index=%d) can not be stored exactly (bitpix=%d, bscale=%v, bzero=%v)",
+				rv.Index(i), i, cnv.bitpix, cnv.bscale, cnv.bzero,
+			)
+		}
+	}
+
+	img.raw = raw
+	return nil
+}
+
+// pixelConv converts physical values into the pixel type of an image.
+type pixelConv struct {
+	bitpix int
+	bscale float64
+	bzero  float64
+
+	// offset is true when the rescaling reduces to the subtraction of
+	// the integer izero, which is then performed without rounding.
+	offset bool
+	izero  int64
+
+	// u64 is true for the BZERO=2^63 convention of unsigned 64-bit pixels.
+	u64 bool
+}
+
+func newPixelConv(hdr *Header) (pixelConv, error) {
+	cnv := pixelConv{
+		bitpix: hdr.Bitpix(),
+		bscale: 1,
+	}
+	switch cnv.bitpix {
+	case 8, 16, 32, 64, -32, -64:
+	default:
+		return cnv, fmt.Errorf("fitsio: invalid image type [bitpix=%d]", cnv.bitpix)
+	}
+
+	var err error
+	if card := hdr.Get("BSCALE"); card != nil {
+		cnv.bscale, err = cardFloat(card)
+		if err != nil {
+			return cnv, err
+		}
+		if cnv.bscale == 0 {
+			return cnv, fmt.Errorf("fitsio: invalid BSCALE value (0)")
+		}
+	}
+	if card := hdr.Get("BZERO"); card != nil {
+		cnv.bzero, err = cardFloat(card)
+		if err != nil {
+			return cnv, err
+		}
+	}
+
+	if cnv.bitpix > 0 && cnv.bscale == 1 && cnv.bzero == math.Trunc(cnv.bzero) {
+		switch {
+		case cnv.bzero == 1<<63:
+			cnv.u64 = cnv.bitpix == 64
+			cnv.offset = cnv.u64
+		case math.Abs(cnv.bzero) < 1<<63:
+			cnv.izero = int64(cnv.bzero)
+			cnv.offset = true
+		}
+	}
+	return cnv, nil
+}
+
+func (cnv *pixelConv) writeInt(w *wbuf, v int64) bool {
+	if !cnv.offset {
+		x := float64(v)
+		if x == 1<<63 || int64(x) != v {
+			return false
+		}
+		return cnv.writeFloat(w, x)
+	}
+	if cnv.u64 {
+		if v < 0 {
+			return false
+		}
+		return cnv.put(w, v+math.MinInt64)
+	}
+	p := v - cnv.izero
+	if (cnv.izero > 0 && p > v) || (cnv.izero < 0 && p < v) {
+		return false
+	}
+	return cnv.put(w, p)
+}
+
+func (cnv *pixelConv) writeUint(w *wbuf, v uint64) bool {
+	switch {
+	case cnv.u64:
+		return cnv.put(w, int64(v^1<<63))
+	case v <= math.MaxInt64:
+		return cnv.writeInt(w, int64(v))
+	}
+	x := float64(v)
+	if x == 1<<64 || uint64(x) != v {
+		return false
+	}
+	return cnv.writeFloat(w, x)
+}
+
+func (cnv *pixelConv) writeFloat(w *wbuf, v float64) bool {
+	p := (v - cnv.bzero) / cnv.bscale
+	switch cnv.bitpix {
+	case -32:
+		f := float32(p)
+		if math.IsNaN(v) {
+			f = float32(math.NaN())
+		} else if cnv.bzero+cnv.bscale*float64(f) != v {
+			return false
+		}
+		w.writeF32(f)
+		return true
+	case -64:
+		if !math.IsNaN(v) && cnv.bzero+cnv.bscale*p != v {
+			return false
+		}
+		w.writeF64(p)
+		return true
+	}
+
+	if p != math.Trunc(p) || p < math.MinInt64 || p >= 1<<63 {
+		return false
+	}
+	i := int64(p)
+	if cnv.bzero+cnv.bscale*float64(i) != v {
+		return false
+	}
+	return cnv.put(w, i)
+}
+
+// put writes the integer pixel value v, if it fits in the pixel type.
+func (cnv *pixelConv) put(w *wbuf, v int64) bool {
+	switch cnv.bitpix {
+	case 8:
+		if v < 0 || v > math.MaxUint8 {
+			return false
+		}
+		w.writeU8(uint8(v))
+	case 16:
+		if v < math.MinInt16 || v > math.MaxInt16 {
+			return false
+		}
+		w.writeI16(int16(v))
+	case 32:
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return false
+		}
+		w.writeI32(int32(v))
+	case 64:
+		w.writeI64(v)
+	}
+	return true
+}
+
+// WithDither makes ConvertImage add a uniform random noise, in the range of
+// plus or minus half a quantization step, to the values it can not store
+// exactly before rounding them.
+// The noise is drawn from a random number generator initialized with seed.
+// Dithering avoids the bias and banding introduced by the quantization of
+// smoothly varying values, at the cost of some noise.
+//
+// For CompressImage, seed selects the starting point of the dithering
+// sequence of subtractive dithering: the ZDITHER0 value is seed modulo
+// 10000, plus one.
+// Using the same seed makes lossy compression reproducible.
+func WithDither(seed int64) Option {
+	return func(cfg *config) {
+		cfg.dither = true
+		cfg.ditherSeed = seed
+	}
+}
+
+// ConvertImage returns a new image holding the pixels of img converted to
+// the bitpix representation.
+//
+// Converting to a floating point bitpix stores the physical values of the
+// pixels, blank pixels becoming NaNs.
+// Converting to an integer bitpix stores integral physical values exactly
+// when their range fits in the pixel type, offset with BZERO if needed.
+// Other values are quantized linearly over the range of the pixel type,
+// with computed BSCALE and BZERO values, and may be dithered (see
+// WithDither.)
+// Blank and NaN pixels are stored as the BLANK value, the smallest value
+// of the pixel type.
+//
+// The header cards of img are copied to the new image, except for those
+// describing the layout and scaling of the pixels.
+func ConvertImage(img Image, bitpix int, opts ...Option) (Image, error) {
+	cfg := newConfig(opts)
+
+	pixsz := bitpix / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
+	switch bitpix {
+	case 8, 16, 32, 64, -32, -64:
+	default:
+		return nil, fmt.Errorf("fitsio: invalid bitpix value (%d)", bitpix)
+	}
+
+	src := img.Header()
+	pix, err := newPixels(src, img.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		vs       = make([]float64, pix.n)
+		lo       = math.Inf(+1)
+		hi       = math.Inf(-1)
+		integral = true
+		nblank   = 0
+	)
+	for i := range vs {
+		v, ok := pix.at(i)
+		vs[i] = v
+		if !ok {
+			nblank++
+			continue
+		}
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+		if integral && v != math.Trunc(v) {
+			integral = false
+		}
+	}
+
+	cards := make([]Card, 0, len(src.cards))
+	for _, card := range src.cards {
+		switch card.Name {
+		case "SIMPLE", "XTENSION", "EXTEND", "BITPIX", "NAXIS", "BSCALE", "BZERO", "BLANK":
+			continue
+		}
+		if strings.HasPrefix(card.Name, "NAXIS") {
+			continue
+		}
+		cards = append(cards, card)
+	}
+
+	out := &imageHDU{
+		hdr: *NewHeader(cards, IMAGE_HDU, bitpix, src.Axes()),
+		raw: make([]byte, pixsz*pix.n),
+	}
+	w := newWriter(out.raw)
+
+	switch bitpix {
+	case -32:
+		for _, v := range vs {
+			w.writeF32(float32(v))
+		}
+		return out, nil
+	case -64:
+		for _, v := range vs {
+			w.writeF64(v)
+		}
+		return out, nil
+	}
+
+	q := newQuantizer(bitpix, lo, hi, integral, nblank > 0)
+	if cfg.dither && !q.exact {
+		q.rnd = rand.New(rand.NewSource(cfg.ditherSeed))
+	}
+	cnv := pixelConv{bitpix: bitpix}
+	for _, v := range vs {
+		cnv.put(w, q.pixel(v))
+	}
+
+	var scaling []Card
+	if q.bscale != 1 {
+		scaling = append(scaling, Card{Name: "BSCALE", Value: q.bscale, Comment: "data scaling factor"})
+	}
+	if q.bzero != 0 {
+		scaling = append(scaling, Card{Name: "BZERO", Value: q.bzero, Comment: "data offset"})
+	}
+	if nblank > 0 {
+		scaling = append(scaling, Card{Name: "BLANK", Value: int(q.blank), Comment: "value of undefined pixels"})
+	}
+	err = out.hdr.Append(scaling...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// quantizer maps physical values to the integer pixel values of an image,
+// according to its BSCALE and BZERO values.
+type quantizer struct {
+	bscale float64
+	bzero  float64
+	exact  bool // whether all values are stored exactly
+
+	blank  int64   // pixel value of undefined pixels
+	lo, hi float64 // range of the pixel values of defined pixels
+
+	rnd *rand.Rand // dithering noise, if any
+}
+
+// newQuantizer returns the quantizer of the valid physical values in the
+// [lo, hi] range into bitpix pixels, reserving a BLANK value if needed.
+// integral tells whether all the valid values are integers.
+func newQuantizer(bitpix int, lo, hi float64, integral, blank bool) quantizer {
+	var (
+		tmin, tmax float64
+		off        float64 // conventional offset for unsigned values
+	)
+	switch bitpix {
+	case 8:
+		tmin, tmax, off = 0, math.MaxUint8, -1<<7
+	case 16:
+		tmin, tmax, off = math.MinInt16, math.MaxInt16, 1<<15
+	case 32:
+		tmin, tmax, off = math.MinInt32, math.MaxInt32, 1<<31
+	case 64:
+		tmin, tmax, off = math.MinInt64, math.MaxInt64, 1<<63
+	}
+
+	q := quantizer{bscale: 1, blank: int64(tmin), lo: tmin, hi: tmax}
+	if blank {
+		q.lo++
+	}
+	if lo > hi {
+		// no valid value.
+		return q
+	}
+
+	if integral && hi-lo <= q.hi-q.lo {
+		q.exact = true
+		switch {
+		case lo >= q.lo && hi <= q.hi:
+			q.bzero = 0
+		case lo-off >= q.lo && hi-off <= q.hi:
+			q.bzero = off
+		default:
+			q.bzero = lo - q.lo
+		}
+		return q
+	}
+
+	if hi > lo {
+		q.bscale = (hi - lo) / (q.hi - q.lo)
+	}
+	q.bzero = lo - q.bscale*q.lo
+	return q
+}
+
+// pixel returns the pixel value of the physical value v.
+// NaN values are mapped to the BLANK value.
+func (q *quantizer) pixel(v float64) int64 {
+	if math.IsNaN(v) {
+		return q.blank
+	}
+	p := (v - q.bzero) / q.bscale
+	if q.rnd != nil {
+		p += q.rnd.Float64() - 0.5
+	}
+	p = math.Round(p)
+	switch {
+	case p <= q.lo:
+		p = q.lo
+	case p >= q.hi:
+		if q.hi == math.MaxInt64 {
+			return math.MaxInt64
+		}
+		p = q.hi
+	}
+	return int64(p)
+}
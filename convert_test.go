@@ -0,0 +1,302 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestImageWriteConverted(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		bitpix int
+		cards  []Card
+		data   interface{}
+		want   interface{} // stored pixels, nil if the conversion must fail
+	}{
+		{
+			name:   "u16-bzero",
+			bitpix: 16,
+			cards:  []Card{{Name: "BZERO", Value: 32768}},
+			data:   []uint16{0, 1, 32768, math.MaxUint16},
+			want:   []int16{math.MinInt16, math.MinInt16 + 1, 0, math.MaxInt16},
+		},
+		{
+			name:   "u16-no-bzero",
+			bitpix: 16,
+			data:   []uint16{0, 1, 32768},
+		},
+		{
+			name:   "int-64",
+			bitpix: 64,
+			data:   &[]int{math.MinInt64, -1, 0, math.MaxInt64},
+			want:   []int64{math.MinInt64, -1, 0, math.MaxInt64},
+		},
+		{
+			name:   "u64-bzero",
+			bitpix: 64,
+			cards:  []Card{{Name: "BZERO", Value: float64(1 << 63)}},
+			data:   []uint64{0, 1 << 63, math.MaxUint64},
+			want:   []int64{math.MinInt64, 0, math.MaxInt64, 0},
+		},
+		{
+			name:   "u8-8",
+			bitpix: 8,
+			data:   [4]uint8{0, 1, 2, 255},
+			want:   []uint8{0, 1, 2, 255},
+		},
+		{
+			name:   "i16-8-overflow",
+			bitpix: 8,
+			data:   []int16{0, 256},
+		},
+		{
+			name:   "f32-64",
+			bitpix: -64,
+			data:   []float32{0, -1.5, 3.25, float32(math.Inf(1))},
+			want:   []float64{0, -1.5, 3.25, math.Inf(1)},
+		},
+		{
+			name:   "f64-32-lossy",
+			bitpix: -32,
+			data:   []float64{0.1},
+		},
+		{
+			name:   "i32-32-float",
+			bitpix: -32,
+			data:   []int32{-16777216, 0, 16777216},
+			want:   []float32{-16777216, 0, 16777216, 0},
+		},
+		{
+			name:   "i32-32-float-lossy",
+			bitpix: -32,
+			data:   []int32{16777217},
+		},
+		{
+			name:   "f64-16-bscale",
+			bitpix: 16,
+			cards:  []Card{{Name: "BSCALE", Value: 0.5}, {Name: "BZERO", Value: 10.0}},
+			data:   []float64{10, 10.5, -6},
+			want:   []int16{0, 1, -32, 0},
+		},
+		{
+			name:   "f64-16-bscale-lossy",
+			bitpix: 16,
+			cards:  []Card{{Name: "BSCALE", Value: 0.5}, {Name: "BZERO", Value: 10.0}},
+			data:   []float64{10.25},
+		},
+		{
+			name:   "f64-32-nonint",
+			bitpix: 32,
+			data:   []float64{1.5},
+		},
+		{
+			name:   "too-many",
+			bitpix: 16,
+			data:   make([]int16, 5),
+		},
+		{
+			name:   "not-numeric",
+			bitpix: 16,
+			data:   []string{"a"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			img := NewImage(tc.bitpix, []int{2, 2})
+			defer img.Close()
+			err := img.Header().Append(tc.cards...)
+			if err != nil {
+				t.Fatalf("could not append cards: %v", err)
+			}
+
+			err = img.WriteConverted(tc.data)
+			if tc.want == nil {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("could not write data: %v", err)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			got.Elem().Set(reflect.MakeSlice(got.Elem().Type(), 4, 4))
+			err = img.Read(got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %v", err)
+			}
+			if !reflect.DeepEqual(got.Elem().Interface(), tc.want) {
+				t.Fatalf("invalid pixels.\ngot= %v\nwant=%v", got.Elem().Interface(), tc.want)
+			}
+		})
+	}
+}
+
+func TestImageUint64RoundTrip(t *testing.T) {
+	var bzero big.Int
+	bzero.SetUint64(1 << 63)
+
+	for _, tc := range []struct {
+		name  string
+		bzero Value
+	}{
+		{"float", float64(1 << 63)},
+		{"big-int", bzero},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := []uint64{0, 1, 1 << 63, math.MaxUint64}
+			img := NewImage(64, []int{len(want)})
+			defer img.Close()
+			err := img.Header().Append(Card{Name: "BZERO", Value: tc.bzero})
+			if err != nil {
+				t.Fatalf("could not append BZERO: %v", err)
+			}
+			err = img.WriteConverted(want)
+			if err != nil {
+				t.Fatalf("could not write data: %v", err)
+			}
+
+			var buf bytes.Buffer
+			w, err := Create(&buf)
+			if err != nil {
+				t.Fatalf("could not create file: %v", err)
+			}
+			err = w.Write(img)
+			if err != nil {
+				t.Fatalf("could not write image: %v", err)
+			}
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close file: %v", err)
+			}
+
+			f, err := Open(&buf)
+			if err != nil {
+				t.Fatalf("could not reopen file: %v", err)
+			}
+			defer f.Close()
+
+			hdu := f.HDU(0).(Image)
+			v, err := cardFloat(hdu.Header().Get("BZERO"))
+			if err != nil || v != 1<<63 {
+				t.Fatalf("invalid BZERO value %v: %v", v, err)
+			}
+			got := make([]int64, len(want))
+			err = hdu.Read(&got)
+			if err != nil {
+				t.Fatalf("could not read data: %v", err)
+			}
+			for i, v := range got {
+				if u := uint64(v) ^ 1<<63; u != want[i] {
+					t.Fatalf("invalid pixel %d: got=%d, want=%d", i, u, want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConvertImage(t *testing.T) {
+	// physical returns the physical pixel values of img.
+	physical := func(img Image) []float64 {
+		t.Helper()
+		pix, err := newPixels(img.Header(), img.Raw())
+		if err != nil {
+			t.Fatalf("could not access pixels: %v", err)
+		}
+		vs := make([]float64, pix.n)
+		for i := range vs {
+			vs[i], _ = pix.at(i)
+		}
+		return vs
+	}
+
+	src := NewImage(-32, []int{4, 2})
+	src.Header().Append(Card{Name: "OBJECT", Value: "M31"})
+	f32s := []float32{-1.5, 0, 0.125, 1, 2.75, float32(math.NaN()), 1e3, 3}
+	err := src.Write(f32s)
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+
+	for _, tc := range []struct {
+		bitpix int
+		opts   []Option
+	}{
+		{bitpix: 8},
+		{bitpix: 16},
+		{bitpix: 32},
+		{bitpix: 16, opts: []Option{WithDither(1234)}},
+	} {
+		dst, err := ConvertImage(src, tc.bitpix, tc.opts...)
+		if err != nil {
+			t.Fatalf("bitpix=%d: could not convert image: %v", tc.bitpix, err)
+		}
+		hdr := dst.Header()
+		if got := hdr.Bitpix(); got != tc.bitpix {
+			t.Fatalf("invalid bitpix: got=%d, want=%d", got, tc.bitpix)
+		}
+		if hdr.Get("OBJECT") == nil || hdr.Get("BLANK") == nil {
+			t.Fatalf("bitpix=%d: missing cards:\n%s", tc.bitpix, hdr.Text())
+		}
+		bscale, err := cardFloat(hdr.Get("BSCALE"))
+		if err != nil {
+			t.Fatalf("bitpix=%d: invalid BSCALE: %v", tc.bitpix, err)
+		}
+		for i, v := range physical(dst) {
+			want := float64(f32s[i])
+			switch {
+			case math.IsNaN(want):
+				if !math.IsNaN(v) {
+					t.Fatalf("bitpix=%d: pixel %d: got=%v, want=NaN", tc.bitpix, i, v)
+				}
+			case math.Abs(v-want) > bscale:
+				t.Fatalf("bitpix=%d: pixel %d: got=%v, want=%v (bscale=%v)", tc.bitpix, i, v, want, bscale)
+			}
+		}
+	}
+
+	// integral values are stored exactly.
+	u16 := NewImage(-64, []int{3})
+	err = u16.Write([]float64{0, 1, 65535})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	dst, err := ConvertImage(u16, 16)
+	if err != nil {
+		t.Fatalf("could not convert image: %v", err)
+	}
+	if got, want := physical(dst), []float64{0, 1, 65535}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid pixels: got=%v, want=%v", got, want)
+	}
+	if card := dst.Header().Get("BZERO"); card == nil || card.Value != float64(1<<15) {
+		t.Fatalf("invalid BZERO card: %v", card)
+	}
+	if dst.Header().Get("BSCALE") != nil {
+		t.Fatalf("unexpected BSCALE card")
+	}
+
+	back, err := ConvertImage(dst, -32)
+	if err != nil {
+		t.Fatalf("could not convert image: %v", err)
+	}
+	got := make([]float32, 3)
+	err = back.Read(&got)
+	if err != nil {
+		t.Fatalf("could not read image: %v", err)
+	}
+	if want := []float32{0, 1, 65535}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid pixels: got=%v, want=%v", got, want)
+	}
+
+	_, err = ConvertImage(u16, 12)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
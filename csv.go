@@ -0,0 +1,417 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ArrayPolicy describes how array and variable-length array columns
+// are flattened when exporting a Table to a text format.
+type ArrayPolicy int
+
+const (
+	ArrayJoin   ArrayPolicy = iota // write all elements in a single field, separated by spaces
+	ArrayExpand                    // write one field per element (fixed-size arrays only)
+)
+
+// CSVOptions configures the export of a Table to CSV/TSV.
+type CSVOptions struct {
+	Comma  rune        // field delimiter (defaults to ','. use '\t' for TSV)
+	Header bool        // whether to write a first row with the column names
+	Units  bool        // whether to write a row with the column units
+	Arrays ArrayPolicy // flattening policy for array columns
+}
+
+// WriteCSV writes the content of the table t to w, in CSV format.
+// Quoting of fields is performed according to RFC 4180.
+func WriteCSV(w io.Writer, t *Table, opts CSVOptions) error {
+	var err error
+	if t == nil {
+		return fmt.Errorf("fitsio: nil table")
+	}
+
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+
+	ncols := len(t.cols)
+	width := make([]int, ncols) // number of CSV fields for each column
+	for i := range t.cols {
+		width[i] = 1
+		col := &t.cols[i]
+		if opts.Arrays == ArrayExpand && col.dtype.gotype.Kind() == reflect.Array {
+			width[i] = col.dtype.gotype.Len()
+		}
+	}
+
+	if opts.Header {
+		names := make([]string, 0, ncols)
+		for i := range t.cols {
+			col := &t.cols[i]
+			if width[i] == 1 {
+				names = append(names, col.Name)
+				continue
+			}
+			for j := 0; j < width[i]; j++ {
+				names = append(names, fmt.Sprintf("%s_%d", col.Name, j+1))
+			}
+		}
+		err = cw.Write(names)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Units {
+		units := make([]string, 0, ncols)
+		for i := range t.cols {
+			for j := 0; j < width[i]; j++ {
+				units = append(units, t.cols[i].Unit)
+			}
+		}
+		err = cw.Write(units)
+		if err != nil {
+			return err
+		}
+	}
+
+	rows, err := t.Read(0, t.NumRows())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	data := make([]interface{}, ncols)
+	for i := range t.cols {
+		data[i] = reflect.New(t.cols[i].Type()).Interface()
+	}
+
+	record := make([]string, 0, ncols)
+	for rows.Next() {
+		err = rows.Scan(data...)
+		if err != nil {
+			return err
+		}
+		record = record[:0]
+		for i := range data {
+			rv := reflect.ValueOf(data[i]).Elem()
+			if width[i] > 1 {
+				for j := 0; j < rv.Len(); j++ {
+					record = append(record, formatValue(rv.Index(j)))
+				}
+				continue
+			}
+			record = append(record, formatValue(rv))
+		}
+		err = cw.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// formatValue formats a column value into a string.
+// array and slice values are formatted as space separated elements.
+func formatValue(rv reflect.Value) string {
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return "T"
+		}
+		return "F"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case reflect.Complex64:
+		return strconv.FormatComplex(rv.Complex(), 'g', -1, 64)
+	case reflect.Complex128:
+		return strconv.FormatComplex(rv.Complex(), 'g', -1, 128)
+	case reflect.String:
+		return rv.String()
+	case reflect.Array, reflect.Slice:
+		elems := make([]string, rv.Len())
+		for i := range elems {
+			elems[i] = formatValue(rv.Index(i))
+		}
+		return strings.Join(elems, " ")
+	default:
+		return fmt.Sprintf("%v", rv.Interface())
+	}
+}
+
+// ReadCSV reads CSV data from r and creates a new binary table from it.
+// The first record of the CSV data must hold the column names.
+// If schema is nil, the column formats are inferred from the data.
+func ReadCSV(r io.Reader, schema []Column) (*Table, error) {
+	return ReadCSVWith(r, schema, CSVOptions{Header: true})
+}
+
+// ReadCSVWith reads CSV data from r and creates a new binary table from it,
+// according to the provided options.
+//
+// If opts.Header is true, the first record holds the column names.
+// If opts.Units is true, the next record holds the column units.
+// If schema is nil, the column formats are inferred from the data:
+// integers are stored as 'K', floating point values as 'D', T/F values
+// as 'L' and anything else as fixed-width strings.
+// Otherwise, CSV fields are associated to the schema columns by position.
+func ReadCSVWith(r io.Reader, schema []Column, opts CSVOptions) (*Table, error) {
+	var err error
+
+	cr := csv.NewReader(r)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.FieldsPerRecord = -1
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("fitsio: error reading CSV data: %v", err)
+	}
+
+	var names, units []string
+	if opts.Header {
+		if len(records) == 0 {
+			return nil, fmt.Errorf("fitsio: missing CSV header")
+		}
+		names = records[0]
+		records = records[1:]
+	}
+	if opts.Units {
+		if len(records) == 0 {
+			return nil, fmt.Errorf("fitsio: missing CSV units")
+		}
+		units = records[0]
+		records = records[1:]
+	}
+
+	var cols []Column
+	switch schema {
+	case nil:
+		nfields := len(names)
+		if nfields == 0 && len(records) > 0 {
+			nfields = len(records[0])
+		}
+		cols = make([]Column, nfields)
+		for i := range cols {
+			name := fmt.Sprintf("COL%d", i+1)
+			if i < len(names) {
+				name = names[i]
+			}
+			cols[i] = Column{
+				Name:   name,
+				Format: inferCSVFormat(records, i),
+			}
+		}
+	default:
+		cols = make([]Column, len(schema))
+		copy(cols, schema)
+	}
+
+	for i := range cols {
+		if i < len(units) && cols[i].Unit == "" {
+			cols[i].Unit = units[i]
+		}
+	}
+
+	tbl, err := NewTable("", cols, BINARY_TBL)
+	if err != nil {
+		return nil, err
+	}
+
+	width := make([]int, len(cols))
+	nfields := 0
+	data := make([]interface{}, len(cols))
+	for i := range tbl.cols {
+		rt := tbl.cols[i].Type()
+		width[i] = 1
+		if opts.Arrays == ArrayExpand && rt.Kind() == reflect.Array {
+			width[i] = rt.Len()
+		}
+		nfields += width[i]
+		data[i] = reflect.New(rt).Interface()
+	}
+
+	for irec, rec := range records {
+		if len(rec) != nfields {
+			return nil, fmt.Errorf(
+				"fitsio: invalid number of CSV fields in record %d (got=%d, want=%d)",
+				irec, len(rec), nfields,
+			)
+		}
+		ifield := 0
+		for i := range data {
+			rv := reflect.ValueOf(data[i]).Elem()
+			switch {
+			case width[i] > 1:
+				for j := 0; j < width[i]; j++ {
+					err = parseValue(rv.Index(j), rec[ifield+j])
+					if err != nil {
+						break
+					}
+				}
+			default:
+				err = parseValue(rv, rec[ifield])
+			}
+			if err != nil {
+				return nil, fmt.Errorf(
+					"fitsio: error parsing CSV record %d, column %q: %v",
+					irec, tbl.cols[i].Name, err,
+				)
+			}
+			ifield += width[i]
+		}
+		err = tbl.Write(data...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tbl, err
+}
+
+// inferCSVFormat returns a binary table TFORM suitable for the i-th field
+// of all the provided records.
+func inferCSVFormat(records [][]string, i int) string {
+	var (
+		isint  = true
+		isflt  = true
+		isbool = true
+		maxlen = 1
+	)
+	for _, rec := range records {
+		if i >= len(rec) {
+			continue
+		}
+		str := strings.TrimSpace(rec[i])
+		if len(rec[i]) > maxlen {
+			maxlen = len(rec[i])
+		}
+		if str == "" {
+			continue
+		}
+		if _, err := strconv.ParseInt(str, 10, 64); err != nil {
+			isint = false
+		}
+		if _, err := strconv.ParseFloat(str, 64); err != nil {
+			isflt = false
+		}
+		if _, err := parseBool(str); err != nil {
+			isbool = false
+		}
+	}
+
+	switch {
+	case isint:
+		return "K"
+	case isflt:
+		return "D"
+	case isbool:
+		return "L"
+	}
+	return fmt.Sprintf("%dA", maxlen)
+}
+
+// parseBool parses a FITS-like logical value.
+func parseBool(str string) (bool, error) {
+	switch str {
+	case "T", "t", "true", "TRUE", "True":
+		return true, nil
+	case "F", "f", "false", "FALSE", "False":
+		return false, nil
+	}
+	return false, fmt.Errorf("fitsio: invalid logical value %q", str)
+}
+
+// parseValue parses str into the value rv.
+// it is the inverse of formatValue.
+func parseValue(rv reflect.Value, str string) error {
+	if rv.Kind() != reflect.String {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		v, err := parseBool(str)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(str, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(str, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(str, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(v)
+	case reflect.Complex64, reflect.Complex128:
+		v, err := strconv.ParseComplex(str, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetComplex(v)
+	case reflect.String:
+		rv.SetString(str)
+	case reflect.Array:
+		toks := strings.Fields(str)
+		if len(toks) != rv.Len() {
+			return fmt.Errorf("fitsio: invalid number of array elements (got=%d, want=%d)", len(toks), rv.Len())
+		}
+		for i, tok := range toks {
+			err := parseValue(rv.Index(i), tok)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		toks := strings.Fields(str)
+		slice := reflect.MakeSlice(rv.Type(), len(toks), len(toks))
+		for i, tok := range toks {
+			err := parseValue(slice.Index(i), tok)
+			if err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+	default:
+		return fmt.Errorf("fitsio: can not parse values of type %v", rv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	type Row struct {
+		ID   int64      `fits:"ID"`
+		Name string     `fits:"NAME"`
+		Pos  [2]float64 `fits:"POS"`
+		Flag bool       `fits:"FLAG"`
+	}
+
+	tbl, err := NewTableFrom("csv", Row{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	tbl.Col(2).Unit = "deg"
+
+	for _, row := range []Row{
+		{ID: 1, Name: "a", Pos: [2]float64{1.5, 2}, Flag: true},
+		{ID: 2, Name: "b,c", Pos: [2]float64{-1, 0.25}, Flag: false},
+		{ID: 3, Name: `say "hi"`, Pos: [2]float64{0, 0}, Flag: true},
+	} {
+		err = tbl.Write(&row)
+		if err != nil {
+			t.Fatalf("could not write row: %v", err)
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		opts CSVOptions
+		want string
+	}{
+		{
+			name: "default",
+			want: `1,a,1.5 2,T
+2,"b,c",-1 0.25,F
+3,"say ""hi""",0 0,T
+`,
+		},
+		{
+			name: "header-units",
+			opts: CSVOptions{Header: true, Units: true},
+			want: `ID,NAME,POS,FLAG
+,,deg,
+1,a,1.5 2,T
+2,"b,c",-1 0.25,F
+3,"say ""hi""",0 0,T
+`,
+		},
+		{
+			name: "tsv-expand",
+			opts: CSVOptions{Comma: '\t', Header: true, Arrays: ArrayExpand},
+			want: "ID\tNAME\tPOS_1\tPOS_2\tFLAG\n" +
+				"1\ta\t1.5\t2\tT\n" +
+				"2\tb,c\t-1\t0.25\tF\n" +
+				"3\t\"say \"\"hi\"\"\"\t0\t0\tT\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteCSV(buf, tbl, test.opts)
+			if err != nil {
+				t.Fatalf("could not write CSV: %v", err)
+			}
+			if got, want := buf.String(), test.want; got != want {
+				t.Fatalf("invalid CSV output.\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	const data = `ID,X,NAME,OK
+1,1.5,alpha,T
+2,-2,"b,c",F
+3,1e3,,T
+`
+	tbl, err := ReadCSV(strings.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("could not read CSV: %v", err)
+	}
+	defer tbl.Close()
+
+	if got, want := tbl.NumRows(), int64(3); got != want {
+		t.Fatalf("invalid number of rows. got=%d, want=%d", got, want)
+	}
+
+	var forms []string
+	for _, col := range tbl.Cols() {
+		forms = append(forms, col.Format)
+	}
+	if got, want := forms, []string{"K", "D", "5A", "L"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid inferred formats.\ngot= %q\nwant=%q", got, want)
+	}
+
+	type Row struct {
+		ID   int64   `fits:"ID"`
+		X    float64 `fits:"X"`
+		Name string  `fits:"NAME"`
+		OK   bool    `fits:"OK"`
+	}
+
+	var got []Row
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read rows: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row Row
+		err = rows.Scan(&row)
+		if err != nil {
+			t.Fatalf("could not scan row: %v", err)
+		}
+		got = append(got, row)
+	}
+
+	want := []Row{
+		{1, 1.5, "alpha", true},
+		{2, -2, "b,c", false},
+		{3, 1000, "", true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows.\ngot= %+v\nwant=%+v", got, want)
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	schema := []Column{
+		{Name: "I16", Format: "I"},
+		{Name: "F32", Format: "E", Unit: "m"},
+		{Name: "ARR", Format: "3J"},
+		{Name: "VLA", Format: "QD"},
+	}
+	csv := "I16,F32,ARR_1,ARR_2,ARR_3,VLA\n" +
+		",m,,,,\n" +
+		"1,2.5,1,2,3,\n" +
+		"-4,0.125,4,5,6,1 2 3\n"
+
+	opts := CSVOptions{Header: true, Units: true, Arrays: ArrayExpand}
+	tbl, err := ReadCSVWith(strings.NewReader(csv), schema, opts)
+	if err != nil {
+		t.Fatalf("could not read CSV: %v", err)
+	}
+	defer tbl.Close()
+
+	buf := new(bytes.Buffer)
+	err = WriteCSV(buf, tbl, opts)
+	if err != nil {
+		t.Fatalf("could not write CSV: %v", err)
+	}
+
+	if got, want := buf.String(), csv; got != want {
+		t.Fatalf("round-trip failed.\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// IsCompressedTable returns whether hdu is a binary table holding a
+// tile-compressed binary table (ZTABLE=T).
+func IsCompressedTable(hdu HDU) bool {
+	t, ok := hdu.(*Table)
+	if !ok || !t.binary {
+		return false
+	}
+	card := t.hdr.Get("ZTABLE")
+	return card != nil && card.Value == true
+}
+
+// CompressTable returns a binary table holding the rows of the binary
+// table t, compressed according to the FITS tiled table compression
+// convention.
+//
+// The rows of t are split into tiles of WithTileSize(n) rows (by default,
+// about 4 MiB of rows per tile), each tile being stored in a row of the
+// returned table.
+// The values of each column of a tile are compressed with the algorithm
+// given to WithCompression: RICE_1 only applies to integer columns (B, I
+// and J), other columns being compressed with GZIP_2.
+// NOCOMPRESS is not part of the convention.
+//
+// The header cards of t are copied to the returned table, with the TFORMn
+// cards renamed as ZFORMn.
+// Tables with variable length array columns can not be compressed.
+func CompressTable(t *Table, opts ...Option) (*Table, error) {
+	cfg := newConfig(opts)
+	if !t.binary {
+		return nil, fmt.Errorf("fitsio: can not compress ASCII table %q", t.Name())
+	}
+	if cfg.compression == NoCompress {
+		return nil, fmt.Errorf("fitsio: %v can not compress tables", cfg.compression)
+	}
+
+	nrows := int(t.nrows)
+	tilelen := max(1, (4<<20)/max(t.rowsz, 1))
+	if len(cfg.tile) > 0 && cfg.tile[0] > 0 {
+		tilelen = cfg.tile[0]
+	}
+	if len(cfg.tile) > 1 {
+		return nil, fmt.Errorf("fitsio: invalid tile size %v for a table", cfg.tile)
+	}
+
+	var (
+		cols  = make([]Column, len(t.cols))
+		algos = make([]Compression, len(t.cols))
+	)
+	for i, col := range t.cols {
+		if col.dtype.tc < 0 {
+			return nil, fmt.Errorf("fitsio: can not compress variable length array column %q", col.Name)
+		}
+		algos[i] = cfg.compression
+		if algos[i] == Rice1 && !isRiceForm(col.Format) {
+			algos[i] = GZIP2
+		}
+		cols[i] = Column{
+			Name:    col.Name,
+			Format:  "1PB",
+			Unit:    col.Unit,
+			Null:    col.Null,
+			Bscale:  col.Bscale,
+			Bzero:   col.Bzero,
+			Display: col.Display,
+			Dim:     col.Dim,
+		}
+	}
+
+	tbl, err := NewTable(t.Name(), cols, BINARY_TBL)
+	if err != nil {
+		return nil, err
+	}
+
+	cells := make([][]byte, len(cols))
+	args := make([]interface{}, len(cols))
+	for beg := 0; beg < nrows; beg += tilelen {
+		end := min(beg+tilelen, nrows)
+		for i, col := range t.cols {
+			cells[i], err = compressColumnTile(t, &col, beg, end, algos[i])
+			if err != nil {
+				return nil, fmt.Errorf("fitsio: could not compress column %q of tile #%d: %w", col.Name, beg/tilelen, err)
+			}
+			args[i] = &cells[i]
+		}
+		err = tbl.Write(args...)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not write tile #%d: %w", beg/tilelen, err)
+		}
+	}
+
+	cards := []Card{
+		{Name: "ZTABLE", Value: true, Comment: "extension contains compressed binary table"},
+		{Name: "ZTILELEN", Value: tilelen, Comment: "number of rows in each tile"},
+		{Name: "ZNAXIS1", Value: t.rowsz, Comment: "length of uncompressed rows"},
+		{Name: "ZNAXIS2", Value: nrows, Comment: "number of uncompressed rows"},
+		{Name: "ZPCOUNT", Value: 0, Comment: "size of heap in uncompressed table"},
+	}
+	for i, col := range t.cols {
+		cards = append(cards,
+			Card{
+				Name:    fmt.Sprintf("ZFORM%d", i+1),
+				Value:   col.Format,
+				Comment: fmt.Sprintf("format of uncompressed column %d", i+1),
+			},
+			Card{
+				Name:    fmt.Sprintf("ZCTYP%d", i+1),
+				Value:   algos[i].String(),
+				Comment: fmt.Sprintf("compression algorithm for column %d", i+1),
+			},
+		)
+	}
+	for _, card := range t.hdr.cards {
+		if card.Name == "EXTNAME" || isCompressionKey(card.Name) {
+			continue
+		}
+		cards = append(cards, card)
+	}
+
+	err = tbl.hdr.Append(cards...)
+	if err != nil {
+		return nil, err
+	}
+	return tbl, nil
+}
+
+// DecompressTable returns the binary table held by t, a binary table
+// holding a tile-compressed binary table.
+//
+// The header cards of t are copied to the returned table, except for
+// those describing the compression.
+func DecompressTable(t *Table) (*Table, error) {
+	if !IsCompressedTable(t) {
+		return nil, fmt.Errorf("fitsio: HDU is not a compressed table")
+	}
+	hdr := t.Header()
+
+	intCard := func(name string) (int, error) {
+		card := hdr.Get(name)
+		if card == nil {
+			return 0, fmt.Errorf("fitsio: missing %s card", name)
+		}
+		v, err := cardInt(card)
+		if err != nil {
+			return 0, err
+		}
+		if v < 0 {
+			return 0, fmt.Errorf("fitsio: invalid %s value (%d)", name, v)
+		}
+		return v, nil
+	}
+
+	rowsz, err := intCard("ZNAXIS1")
+	if err != nil {
+		return nil, err
+	}
+	nrows, err := intCard("ZNAXIS2")
+	if err != nil {
+		return nil, err
+	}
+	tilelen, err := intCard("ZTILELEN")
+	if err != nil {
+		return nil, err
+	}
+	if tilelen == 0 {
+		return nil, fmt.Errorf("fitsio: invalid ZTILELEN value (0)")
+	}
+	if card := hdr.Get("ZPCOUNT"); card != nil {
+		v, err := cardInt(card)
+		if err != nil {
+			return nil, err
+		}
+		if v != 0 {
+			return nil, fmt.Errorf("fitsio: compressed variable length array columns are not supported")
+		}
+	}
+	if ntiles := (int64(nrows) + int64(tilelen) - 1) / int64(tilelen); ntiles != t.nrows {
+		return nil, fmt.Errorf("fitsio: invalid number of tiles (got=%d, want=%d)", t.nrows, ntiles)
+	}
+	datasz := mulSize(rowsz, nrows)
+	if datasz < 0 {
+		return nil, fmt.Errorf("fitsio: compressed table too large: %w", ErrHDUTooLarge)
+	}
+
+	var (
+		cols  = make([]Column, len(t.cols))
+		algos = make([]Compression, len(t.cols))
+	)
+	for i, col := range t.cols {
+		if col.dtype.tc != tcByteVLA {
+			return nil, fmt.Errorf("fitsio: invalid format %q for compressed column %q", col.Format, col.Name)
+		}
+		card := hdr.Get(fmt.Sprintf("ZFORM%d", i+1))
+		if card == nil {
+			return nil, fmt.Errorf("fitsio: missing ZFORM%d card", i+1)
+		}
+		form, err := cardString(card)
+		if err != nil {
+			return nil, err
+		}
+		algos[i] = GZIP1
+		if card := hdr.Get(fmt.Sprintf("ZCTYP%d", i+1)); card != nil {
+			name, err := cardString(card)
+			if err != nil {
+				return nil, err
+			}
+			algos[i], err = compressionFrom(name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		cols[i] = Column{
+			Name:    col.Name,
+			Format:  strings.TrimSpace(form),
+			Unit:    col.Unit,
+			Null:    col.Null,
+			Bscale:  col.Bscale,
+			Bzero:   col.Bzero,
+			Display: col.Display,
+			Dim:     col.Dim,
+		}
+	}
+
+	tbl, err := NewTable(t.Name(), cols, BINARY_TBL)
+	if err != nil {
+		return nil, err
+	}
+	if tbl.rowsz != rowsz {
+		return nil, fmt.Errorf("fitsio: invalid ZNAXIS1 value (got=%d, want=%d)", rowsz, tbl.rowsz)
+	}
+	for i, col := range tbl.cols {
+		if col.dtype.tc < 0 {
+			return nil, fmt.Errorf("fitsio: compressed variable length array column %q is not supported", col.Name)
+		}
+		if algos[i] == Rice1 && !isRiceForm(col.Format) {
+			return nil, fmt.Errorf("fitsio: RICE_1 can not compress column %q (TFORM=%q)", col.Name, col.Format)
+		}
+	}
+
+	tbl.data = make([]byte, datasz)
+	tbl.nrows = int64(nrows)
+	for itile := range int(t.nrows) {
+		beg := itile * tilelen
+		end := min(beg+tilelen, nrows)
+		for i := range tbl.cols {
+			var buf []byte
+			err = t.cols[i].read(t, i, int64(itile), &buf)
+			if err != nil {
+				return nil, fmt.Errorf("fitsio: could not read column %q of tile #%d: %w", cols[i].Name, itile, err)
+			}
+			err = decompressColumnTile(tbl, &tbl.cols[i], beg, end, algos[i], buf)
+			if err != nil {
+				return nil, fmt.Errorf("fitsio: could not decompress column %q of tile #%d: %w", cols[i].Name, itile, err)
+			}
+		}
+	}
+
+	cards := make([]Card, 0, len(hdr.cards))
+	for _, card := range hdr.cards {
+		if card.Name == "EXTNAME" || isCompressionKey(card.Name) {
+			continue
+		}
+		cards = append(cards, card)
+	}
+	err = tbl.hdr.Append(cards...)
+	if err != nil {
+		return nil, err
+	}
+	return tbl, nil
+}
+
+// isRiceForm returns whether the values of a column of format form are
+// integers which can be compressed with RICE_1.
+func isRiceForm(form string) bool {
+	form = strings.TrimLeft(strings.TrimSpace(form), "0123456789")
+	switch form {
+	case "B", "I", "J":
+		return true
+	}
+	return false
+}
+
+// columnElemSize returns the size in bytes of the elements of a column of
+// format form, as used to shuffle bytes.
+func columnElemSize(form string) int {
+	form = strings.TrimLeft(strings.TrimSpace(form), "0123456789")
+	if form == "" {
+		return 1
+	}
+	switch form[0] {
+	case 'I':
+		return 2
+	case 'J', 'E', 'C':
+		return 4
+	case 'K', 'D', 'M':
+		return 8
+	}
+	return 1
+}
+
+// compressColumnTile compresses the values of col for the rows [beg, end)
+// of t.
+func compressColumnTile(t *Table, col *Column, beg, end int, c Compression) ([]byte, error) {
+	var (
+		width = col.dtype.dsize * col.dtype.len
+		esz   = columnElemSize(col.Format)
+		buf   = make([]byte, 0, (end-beg)*width)
+	)
+	for irow := beg; irow < end; irow++ {
+		off := irow*t.rowsz + col.offset
+		buf = append(buf, t.data[off:off+width]...)
+	}
+
+	if c != Rice1 {
+		return compressTileBytes(buf, c, esz)
+	}
+	vs := make([]int64, len(buf)/esz)
+	for i := range vs {
+		switch esz {
+		case 1:
+			vs[i] = int64(buf[i])
+		case 2:
+			vs[i] = int64(int16(binary.BigEndian.Uint16(buf[2*i:])))
+		case 4:
+			vs[i] = int64(int32(binary.BigEndian.Uint32(buf[4*i:])))
+		}
+	}
+	return riceEncode(vs, esz, 32)
+}
+
+// decompressColumnTile decompresses the values of col for the rows
+// [beg, end) of t, held by buf.
+func decompressColumnTile(t *Table, col *Column, beg, end int, c Compression, buf []byte) error {
+	var (
+		width = col.dtype.dsize * col.dtype.len
+		esz   = columnElemSize(col.Format)
+		n     = (end - beg) * width / esz
+	)
+
+	var (
+		raw []byte
+		err error
+	)
+	switch c {
+	case Rice1:
+		vs, err := decompressTile(buf, c, n, esz, 32)
+		if err != nil {
+			return err
+		}
+		raw = make([]byte, n*esz)
+		for i, v := range vs {
+			switch esz {
+			case 1:
+				raw[i] = byte(v)
+			case 2:
+				binary.BigEndian.PutUint16(raw[2*i:], uint16(v))
+			case 4:
+				binary.BigEndian.PutUint32(raw[4*i:], uint32(v))
+			}
+		}
+	default:
+		raw, err = decompressTileBytes(buf, c, n, esz)
+		if err != nil {
+			return err
+		}
+	}
+
+	for irow := beg; irow < end; irow++ {
+		off := irow*t.rowsz + col.offset
+		copy(t.data[off:off+width], raw[(irow-beg)*width:])
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCompressTable(t *testing.T) {
+	const nrows = 50
+	newTable := func() *Table {
+		tbl, err := NewTable("EVENTS", []Column{
+			{Name: "B", Format: "B", Bscale: 1},
+			{Name: "I", Format: "I", Unit: "adu", Bscale: 1},
+			{Name: "J", Format: "3J", Bscale: 1},
+			{Name: "U", Format: "I", Bscale: 1, Bzero: 32768},
+			{Name: "K", Format: "K", Bscale: 1},
+			{Name: "E", Format: "E", Bscale: 1},
+			{Name: "D", Format: "D", Bscale: 1},
+			{Name: "L", Format: "L", Bscale: 1},
+			{Name: "A", Format: "10A", Bscale: 1},
+		}, BINARY_TBL)
+		if err != nil {
+			t.Fatalf("could not create table: %v", err)
+		}
+		err = tbl.Header().Append(Card{Name: "TELESCOP", Value: "HST"})
+		if err != nil {
+			t.Fatalf("could not append card: %v", err)
+		}
+		for i := 0; i < nrows; i++ {
+			var (
+				b = uint8(i * 7)
+				v = int16(i*i - 100)
+				j = [3]int32{int32(i), -int32(i) << 20, 42}
+				u = uint16(60000 - i)
+				k = int64(i) << 40
+				e = float32(i) * 1.5
+				d = float64(i) / 3
+				l = i%3 == 0
+				a = fmt.Sprintf("row-%d", i)
+			)
+			err = tbl.Write(&b, &v, &j, &u, &k, &e, &d, &l, &a)
+			if err != nil {
+				t.Fatalf("could not write row %d: %v", i, err)
+			}
+		}
+		return tbl
+	}
+
+	for _, cmp := range []Compression{Rice1, GZIP1, GZIP2} {
+		for _, tile := range [][]int{nil, {7}, {nrows}} {
+			src := newTable()
+			ztbl, err := CompressTable(src, WithCompression(cmp), WithTileSize(tile...))
+			if err != nil {
+				t.Fatalf("%v: could not compress table: %v", cmp, err)
+			}
+			if got, want := ztbl.Header().Get("ZCTYP2").Value, cmp.String(); got != want {
+				t.Fatalf("invalid ZCTYP2: got=%v, want=%v", got, want)
+			}
+			if cmp == Rice1 {
+				if got, want := ztbl.Header().Get("ZCTYP6").Value, GZIP2.String(); got != want {
+					t.Fatalf("invalid ZCTYP6: got=%v, want=%v", got, want)
+				}
+			}
+
+			var buf bytes.Buffer
+			w, err := Create(&buf)
+			if err != nil {
+				t.Fatalf("could not create file: %v", err)
+			}
+			for _, hdu := range []HDU{NewImage(8, nil), ztbl} {
+				err = w.Write(hdu)
+				if err != nil {
+					t.Fatalf("could not write HDU: %v", err)
+				}
+			}
+			f, err := Open(&buf)
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			hdu := f.HDU(1)
+			if !IsCompressedTable(hdu) || IsCompressedImage(hdu) {
+				t.Fatalf("invalid compressed table detection")
+			}
+
+			tbl, err := DecompressTable(hdu.(*Table))
+			if err != nil {
+				t.Fatalf("%v, tile=%v: could not decompress table: %v", cmp, tile, err)
+			}
+			f.Close()
+
+			if got, want := tbl.Name(), "EVENTS"; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			if got, want := tbl.NumRows(), int64(nrows); got != want {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+			}
+			for i, col := range tbl.Cols() {
+				want := src.Col(i)
+				if col.Name != want.Name || col.Format != want.Format || col.Unit != want.Unit || col.Bzero != want.Bzero {
+					t.Fatalf("invalid column %d:\ngot= %#v\nwant=%#v", i, col, *want)
+				}
+			}
+			if card := tbl.Header().Get("TELESCOP"); card == nil || card.Value != "HST" {
+				t.Fatalf("invalid TELESCOP card: %v", card)
+			}
+			for _, name := range []string{"ZTABLE", "ZFORM1", "ZCTYP1", "ZTILELEN"} {
+				if tbl.Header().Get(name) != nil {
+					t.Fatalf("compression card %s was not removed", name)
+				}
+			}
+			if !bytes.Equal(tbl.data, src.data) {
+				t.Fatalf("%v, tile=%v: invalid table data", cmp, tile)
+			}
+
+			var got, want []float64
+			for _, v := range []struct {
+				tbl *Table
+				ptr *[]float64
+			}{{tbl, &got}, {src, &want}} {
+				err = v.tbl.ReadColumn(v.tbl.Index("D"), v.ptr)
+				if err != nil {
+					t.Fatalf("could not read column: %v", err)
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid column values:\ngot= %v\nwant=%v", got, want)
+			}
+		}
+	}
+
+	vla, err := NewTable("VLA", []Column{{Name: "V", Format: "PJ", Bscale: 1}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	_, err = CompressTable(vla)
+	if err == nil {
+		t.Fatalf("expected an error compressing variable length arrays")
+	}
+
+	_, err = CompressTable(newTable(), WithCompression(NoCompress))
+	if err == nil {
+		t.Fatalf("expected an error compressing with NOCOMPRESS")
+	}
+}
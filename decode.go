@@ -5,10 +5,13 @@
 package fitsio
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"reflect"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -17,25 +20,148 @@ type Decoder interface {
 	DecodeHDU() (HDU, error)
 }
 
+// ContextDecoder is a Decoder whose decoding may be aborted.
+// The Decoders returned by NewDecoder implement ContextDecoder.
+type ContextDecoder interface {
+	Decoder
+
+	// DecodeHDUContext is like DecodeHDU, but aborts the decoding
+	// when ctx is done, returning the context error.
+	DecodeHDUContext(ctx context.Context) (HDU, error)
+}
+
+// decodeHDU decodes the next HDU with dec, aborting the decoding when ctx
+// is done if dec is a ContextDecoder.
+func decodeHDU(ctx context.Context, dec Decoder) (HDU, error) {
+	if dec, ok := dec.(ContextDecoder); ok {
+		return dec.DecodeHDUContext(ctx)
+	}
+	err := ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+	return dec.DecodeHDU()
+}
+
 // NewDecoder creates a new Decoder according to the capabilities of the underlying io.Reader
-func NewDecoder(r io.Reader) Decoder {
+func NewDecoder(r io.Reader, opts ...Option) Decoder {
 	// FIXME(sbinet)
 	// if rr, ok := r.(io.ReadSeeker); ok {
 	// 	return &seekDecoder{r: rr}
 	// }
-	return &streamDecoder{r: r}
+	return &streamDecoder{r: &countReader{r: r}, cfg: newConfig(opts)}
 }
 
 // streamDecoder is a decoder which can not perform random access
 // into the underlying Reader
 type streamDecoder struct {
+	r   *countReader
+	cfg config
+
+	nhdus int        // number of HDUs decoded so far
+	total int64      // size of the data units decoded so far
+	cur   HDUOffsets // location of the HDU being (or last) decoded
+}
+
+// countReader counts the bytes read from an io.Reader.
+type countReader struct {
 	r io.Reader
+	n int64
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
 }
 
 func (dec *streamDecoder) DecodeHDU() (HDU, error) {
-	var err error
+	return dec.DecodeHDUContext(context.Background())
+}
+
+func (dec *streamDecoder) DecodeHDUContext(ctx context.Context) (HDU, error) {
+	dec.cur = HDUOffsets{Header: dec.r.n, Data: -1, End: -1}
+	hdu, err := dec.decodeHDU(ctx)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err == nil {
+		dec.cur.End = dec.r.n
+		dec.nhdus++
+	}
+	return hdu, err
+}
+
+// offsets returns the location of the last decoded HDU.
+func (dec *streamDecoder) offsets() HDUOffsets {
+	return dec.cur
+}
+
+func (dec *streamDecoder) decodeHDU(ctx context.Context) (HDU, error) {
 	var hdu HDU
 
+	hdr, primary, err := dec.decodeHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch htype := hdr.Type(); htype {
+	case IMAGE_HDU:
+		var data []byte
+		data, err = dec.loadImage(ctx, hdr)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: error loading image: %w", err)
+		}
+
+		switch primary {
+		case true:
+			hdu = &primaryHDU{
+				imageHDU: imageHDU{
+					hdr:     *hdr,
+					raw:     data,
+					scaling: dec.cfg.scaling,
+				},
+			}
+		case false:
+			hdu = &imageHDU{
+				hdr:     *hdr,
+				raw:     data,
+				scaling: dec.cfg.scaling,
+			}
+		}
+
+	case BINARY_TBL:
+		hdu, err = dec.loadTable(ctx, hdr, htype)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: error loading binary table: %w", err)
+		}
+
+	case ASCII_TBL:
+		hdu, err = dec.loadTable(ctx, hdr, htype)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: error loading ascii table: %w", err)
+		}
+
+	case UNKNOWN_HDU:
+		hdu, err = dec.loadRaw(ctx, hdr)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: error loading %q extension: %w", xtension(hdr), err)
+		}
+
+	case ANY_HDU:
+		fallthrough
+	default:
+		return nil, fmt.Errorf("fitsio: invalid HDU Type (%v)", htype)
+	}
+
+	return hdu, err
+}
+
+// decodeHeader decodes the header of the next HDU, and returns whether it
+// is the header of a primary HDU.
+func (dec *streamDecoder) decodeHeader(ctx context.Context) (*Header, bool, error) {
+	var err error
+
 	cards := make(map[string]int, 30)
 	slice := make([]Card, 0, 1)
 
@@ -47,54 +173,85 @@ func (dec *streamDecoder) DecodeHDU() (HDU, error) {
 		return Card{}, ok
 	}
 
-	add_card := func(c *Card) {
+	add_card := func(c *Card) error {
 		n := c.Name
 		if n == "COMMENT" || n == "HISTORY" || n == "" {
 			slice = append(slice, *c)
-			return
+			return nil
 		}
-		// For compatibility with C FITSIO, silently swallow duplicate card keys.
+		// For compatibility with C FITSIO, duplicate card keys are
+		// silently kept by default (see WithDuplicateKeys.)
 		// See:
 		//  https://github.com/astrogo/fitsio/issues/38
-		//
-		// _, dup := cards[n]
-		// if dup {
-		// 	panic(fmt.Errorf("fitsio: duplicate keyword [%s]", n))
-		// }
+		if i, dup := cards[n]; dup {
+			switch dec.cfg.dupKeys {
+			case KeepFirstKey:
+				return nil
+			case KeepLastKey:
+				slice[i] = *c
+				return nil
+			case RejectDuplicateKeys:
+				return fmt.Errorf("%w [%s]", ErrDuplicateKey, n)
+			}
+		}
 		cards[n] = len(slice)
 		slice = append(slice, *c)
+		return nil
 	}
 
 	axes := []int{}
-	buf := make([]byte, blockSize)
+	buf := make([]byte, BlockSize)
+
+	var raw []byte // original header blocks (see WithRawHeaders)
 
 	iblock := -1
 blocks_loop:
 	for {
 		iblock += 1
-		_, err = io.ReadFull(dec.r, buf)
+		err = ctx.Err()
 		if err != nil {
-			return nil, err
+			return nil, false, err
+		}
+		n, err := io.ReadFull(dec.r, buf)
+		if err != nil {
+			if iblock == 0 && err == io.EOF {
+				return nil, false, err
+			}
+			return nil, false, fmt.Errorf(
+				"fitsio: header at offset %d: %w",
+				dec.cur.Header, shortRead(err, ErrShortHeader, n, len(buf)),
+			)
+		}
+		if dec.cfg.rawHeaders {
+			raw = append(raw, buf...)
 		}
 
 		// each FITS header block is comprised of up to 36 80-byte lines
 		const maxlines = 36
 		for i := 0; i < maxlines; i++ {
-			card, err := parseHeaderLine(buf[i*80 : (i+1)*80])
-			if err != nil {
-				return nil, err
+			line := buf[i*80 : (i+1)*80]
+			card, err := parseHeaderLine(line)
+			switch {
+			case err != nil && dec.cfg.rawCards:
+				card = &Card{Value: RawCard(line)}
+			case err != nil:
+				return nil, false, fmt.Errorf("fitsio: could not parse card %q: %w", bytes.TrimSpace(line[:8]), err)
 			}
-			if card.Name == "CONTINUE" {
+			if card.Name == "CONTINUE" && len(slice) > 0 {
 				idx := len(slice) - 1
 				last := slice[idx]
-				str := last.Value.(string)
-				if len(str) > 0 {
-					last.Value = str[:len(str)-1] + card.Comment
+				if str, ok := last.Value.(string); ok {
+					if len(str) > 0 {
+						last.Value = str[:len(str)-1] + card.Comment
+					}
+					slice[idx] = last
+					continue
 				}
-				slice[idx] = last
-				continue
 			}
-			add_card(card)
+			err = add_card(card)
+			if err != nil {
+				return nil, false, fmt.Errorf("fitsio: header at offset %d: %w", dec.cur.Header, err)
+			}
 			if card.Name == "END" {
 				break
 			}
@@ -104,118 +261,201 @@ blocks_loop:
 		if ends {
 			card, ok := get_card("NAXIS")
 			if ok {
-				n := card.Value.(int)
+				n, err := cardInt(&card)
+				if err != nil {
+					return nil, false, err
+				}
+				if n < 0 || n > 999 {
+					return nil, false, fmt.Errorf("fitsio: invalid NAXIS value (%d)", n)
+				}
 				axes = make([]int, n)
 				for i := 0; i < n; i++ {
 					k := fmt.Sprintf("NAXIS%d", i+1)
 					c, ok := get_card(k)
 					if !ok {
-						return nil, fmt.Errorf("fitsio: missing '%s' key", k)
+						return nil, false, fmt.Errorf("fitsio: missing '%s' key", k)
+					}
+					axes[i], err = cardInt(&c)
+					if err != nil {
+						return nil, false, err
+					}
+					if axes[i] < 0 {
+						return nil, false, fmt.Errorf("fitsio: invalid %s value (%d)", k, axes[i])
 					}
-					axes[i] = c.Value.(int)
 				}
 			}
 			break blocks_loop
 		}
 	}
+	dec.cur.Data = dec.r.n
 
 	htype, primary, err := hduTypeFrom(slice)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	bitpix := 0
 	if card, ok := get_card("BITPIX"); ok {
-		bitpix = int(reflect.ValueOf(card.Value).Int())
+		bitpix, err = cardInt(&card)
+		if err != nil {
+			return nil, false, err
+		}
 	} else {
-		return nil, fmt.Errorf("fitsio: missing 'BITPIX' card")
+		return nil, false, fmt.Errorf("fitsio: missing 'BITPIX' card")
+	}
+	switch bitpix {
+	case 8, 16, 32, 64, -32, -64:
+	default:
+		return nil, false, fmt.Errorf("fitsio: invalid BITPIX value (%d)", bitpix)
 	}
 
 	hdr := NewHeader(slice, htype, bitpix, axes)
-	switch htype {
-	case IMAGE_HDU:
-		var data []byte
-		data, err = dec.loadImage(hdr)
-		if err != nil {
-			return nil, fmt.Errorf("fitsio: error loading image: %v", err)
-		}
+	if raw != nil {
+		hdr.raw = raw
+		hdr.rawCards = slices.Clone(hdr.cards)
+		hdr.rawAxes = slices.Clone(hdr.axes)
+	}
+	return hdr, primary, nil
+}
 
-		switch primary {
-		case true:
-			hdu = &primaryHDU{
-				imageHDU: imageHDU{
-					hdr: *hdr,
-					raw: data,
-				},
-			}
-		case false:
-			hdu = &imageHDU{
-				hdr: *hdr,
-				raw: data,
-			}
-		}
+func (dec *streamDecoder) loadImage(ctx context.Context, hdr *Header) ([]byte, error) {
+	pixsz := hdr.Bitpix() / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
 
-	case BINARY_TBL:
-		hdu, err = dec.loadTable(hdr, htype)
-		if err != nil {
-			return nil, fmt.Errorf("fitsio: error loading binary table: %v", err)
+	for _, key := range []string{"BSCALE", "BZERO"} {
+		card := hdr.Get(key)
+		if card == nil {
+			continue
 		}
-
-	case ASCII_TBL:
-		hdu, err = dec.loadTable(hdr, htype)
-		if err != nil {
-			return nil, fmt.Errorf("fitsio: error loading ascii table: %v", err)
+		if _, err := cardFloat(card); err != nil {
+			return nil, fmt.Errorf("fitsio: invalid %s value %v (%T): expected a number", key, card.Value, card.Value)
 		}
+	}
 
-	case ANY_HDU:
-		fallthrough
-	default:
-		return nil, fmt.Errorf("fitsio: invalid HDU Type (%v)", htype)
+	size := 0
+	if len(hdr.Axes()) > 0 {
+		size = pixsz
+		for _, dim := range hdr.Axes() {
+			size = mulSize(size, dim)
+		}
+	}
+	err := dec.checkSize(size)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return make([]byte, 0), nil
 	}
 
-	return hdu, err
+	return dec.readUnit(ctx, size, nil)
 }
 
-func (dec *streamDecoder) loadImage(hdr *Header) ([]byte, error) {
-	var err error
-	var buf []byte
-
-	nelmts := 1
-	for _, dim := range hdr.Axes() {
-		nelmts *= dim
+// checkSize checks the size of a data unit against the limits of the decoder.
+// A negative size denotes an overflow.
+func (dec *streamDecoder) checkSize(size int) error {
+	if size < 0 {
+		return fmt.Errorf("%w (size overflow)", ErrHDUTooLarge)
 	}
-
-	if len(hdr.Axes()) <= 0 {
-		nelmts = 0
+	if max := dec.cfg.maxHDUSize; max > 0 && int64(size) > max {
+		return fmt.Errorf("%w (size=%d bytes, max=%d bytes)", ErrHDUTooLarge, size, max)
 	}
-
-	pixsz := hdr.Bitpix() / 8
-	if pixsz < 0 {
-		pixsz = -pixsz
+	if max := dec.cfg.maxTotal; max > 0 && dec.total+int64(size) > max {
+		return fmt.Errorf("%w (total size=%d bytes, max=%d bytes)", ErrHDUTooLarge, dec.total+int64(size), max)
 	}
+	dec.total += int64(size)
+	return nil
+}
 
-	buf = make([]byte, nelmts*pixsz)
-	if nelmts == 0 {
+// readUnit reads the size bytes of the data unit of the current HDU and
+// skips the padding to the next FITS block.
+// The data unit is read into buf if it is large enough.
+// A data unit cut short by the end of the input is reported with an
+// ErrTruncatedHDU.
+func (dec *streamDecoder) readUnit(ctx context.Context, size int, buf []byte) ([]byte, error) {
+	buf, err := dec.readData(ctx, size, buf[:0])
+	if err == nil {
+		// data array is also aligned at 2880-bytes blocks
+		if pad := PadBlock(size); pad > 0 {
+			_, err = io.CopyN(ioutil.Discard, dec.r, int64(pad))
+		}
+	}
+	switch err {
+	case nil:
 		return buf, nil
+	case io.EOF, io.ErrUnexpectedEOF:
+		return nil, &ErrTruncatedHDU{
+			HDU:    dec.nhdus,
+			Offset: dec.cur.Data,
+			Want:   int64(AlignBlock(size)),
+			Got:    dec.r.n - dec.cur.Data,
+		}
+	default:
+		return nil, fmt.Errorf("fitsio: error reading data unit at offset %d: %w", dec.cur.Data, err)
 	}
+}
 
-	n, err := io.ReadFull(dec.r, buf)
-	if err != nil {
-		return nil, fmt.Errorf("fitsio: error reading %d bytes (got %d): %v", len(buf), n, err)
+// readData reads the n bytes of a data unit, appending them to buf.
+// Large data units are read into a buffer growing with the data actually
+// read, so that a corrupted or malicious header describing a large data unit
+// does not trigger a large allocation when the input is short.
+func (dec *streamDecoder) readData(ctx context.Context, n int, buf []byte) ([]byte, error) {
+	const chunk = 64 << 20
+
+	if cap(buf) < n {
+		buf = make([]byte, 0, min(n, chunk))
+	}
+	for len(buf) < n {
+		if len(buf) == cap(buf) {
+			grown := make([]byte, len(buf), min(n, 2*cap(buf)))
+			copy(grown, buf)
+			buf = grown
+		}
+		nn, err := readFull(ctx, dec.r, buf[len(buf):min(cap(buf), n)], int64(len(buf)), int64(n), dec.cfg.progress)
+		buf = buf[:len(buf)+nn]
+		if err != nil {
+			return nil, err
+		}
 	}
+	return buf, nil
+}
 
-	// data array is also aligned at 2880-bytes blocks
-	pad := padBlock(n)
-	if pad > 0 {
-		if n, err := io.CopyN(ioutil.Discard, dec.r, int64(pad)); err != nil {
-			return nil, fmt.Errorf("fitsio: error reading %d bytes (got %d): %v", pad, n, err)
+// mulSize returns a*b, for non-negative a and b, or -1 if the product
+// overflows or if a or b is negative.
+func mulSize(a, b int) int {
+	if a < 0 || b < 0 {
+		return -1
+	}
+	if a != 0 && b > math.MaxInt/a {
+		return -1
+	}
+	return a * b
+}
+
+// cardInt returns the value of an integer card.
+func cardInt(card *Card) (int, error) {
+	switch v := card.Value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		if v >= math.MinInt && v <= math.MaxInt {
+			return int(v), nil
 		}
 	}
+	return 0, fmt.Errorf("fitsio: invalid %s value %v (%T): expected an integer", card.Name, card.Value, card.Value)
+}
 
-	return buf, err
+// cardString returns the value of a string card.
+func cardString(card *Card) (string, error) {
+	v, ok := card.Value.(string)
+	if !ok {
+		return "", fmt.Errorf("fitsio: invalid %s value %v (%T): expected a string", card.Name, card.Value, card.Value)
+	}
+	return v, nil
 }
 
-func (dec *streamDecoder) loadTable(hdr *Header, htype HDUType) (*Table, error) {
+func (dec *streamDecoder) loadTable(ctx context.Context, hdr *Header, htype HDUType) (*Table, error) {
 	var err error
 	var table *Table
 
@@ -229,34 +469,85 @@ func (dec *streamDecoder) loadTable(hdr *Header, htype HDUType) (*Table, error)
 		return nil, fmt.Errorf("fitsio: invalid HDU type (%v)", htype)
 	}
 
+	if len(hdr.Axes()) != 2 {
+		return nil, fmt.Errorf("fitsio: invalid NAXIS value for a table (%d)", len(hdr.Axes()))
+	}
 	rowsz := hdr.Axes()[0]
 	nrows := int64(hdr.Axes()[1])
 	ncols := 0
 	if card := hdr.Get("TFIELDS"); card != nil && card.Value != nil {
-		ncols = card.Value.(int)
+		ncols, err = cardInt(card)
+		if err != nil {
+			return nil, err
+		}
+		if ncols < 0 || ncols > 999 {
+			return nil, fmt.Errorf("fitsio: invalid TFIELDS value (%d)", ncols)
+		}
 	}
 
-	datasz := int(nrows) * rowsz
+	datasz := mulSize(int(nrows), rowsz)
 	heapsz := 0
 	if card := hdr.Get("PCOUNT"); card != nil && card.Value != nil {
-		heapsz = card.Value.(int)
+		heapsz, err = cardInt(card)
+		if err != nil {
+			return nil, err
+		}
+		if heapsz < 0 {
+			return nil, fmt.Errorf("fitsio: invalid PCOUNT value (%d)", heapsz)
+		}
 	}
 
-	blocksz := alignBlock(datasz + heapsz)
+	size := datasz + heapsz
+	if datasz < 0 || size < datasz {
+		size = -1
+	}
+	err = dec.checkSize(size)
+	if err != nil {
+		return nil, err
+	}
 
-	block := make([]byte, blocksz)
-	n, err := io.ReadFull(dec.r, block)
+	var (
+		pooled *[]byte
+		buf    []byte
+	)
+	if dec.cfg.pooled {
+		pooled = getBuffer(size)
+		if pooled != nil {
+			buf = *pooled
+		}
+	}
+	block, err := dec.readUnit(ctx, size, buf)
 	if err != nil {
-		return nil, fmt.Errorf("fitsio: error reading %d bytes (got %d): %v", len(block), n, err)
+		if pooled != nil {
+			putBuffer(pooled)
+		}
+		return nil, err
 	}
 
-	gapsz := 0
+	// THEAP is the offset of the heap from the start of the data.
+	// A zero THEAP, as written by previous versions of this package,
+	// denotes a heap starting right after the data.
+	theap := datasz
 	if card := hdr.Get("THEAP"); card != nil && card.Value != nil {
-		gapsz = card.Value.(int)
+		v, err := cardInt(card)
+		if err != nil {
+			return nil, err
+		}
+		if v != 0 {
+			theap = v
+		}
+	}
+	if theap < datasz || theap > datasz+heapsz {
+		return nil, fmt.Errorf(
+			"fitsio: invalid THEAP value %d (data size=%d, PCOUNT=%d)",
+			theap, datasz, heapsz,
+		)
 	}
 
-	data := block[:datasz]
-	heap := block[datasz+gapsz:]
+	// the data and the heap share the block: rows appended to the data
+	// must not overwrite the heap.
+	data := block[:datasz:datasz]
+	heap := block[theap : datasz+heapsz]
 
 	cols := make([]Column, ncols)
 	colidx := make(map[string]int, ncols)
@@ -283,18 +574,29 @@ func (dec *streamDecoder) loadTable(hdr *Header, htype HDUType) (*Table, error)
 		col.offset = offset
 
 		card := get("TTYPE", i)
-		col.Name = card.Value.(string)
+		if card != nil {
+			col.Name, err = cardString(card)
+			if err != nil {
+				return nil, err
+			}
+		}
 
 		card = get("TFORM", i)
 		if card == nil {
 			return nil, fmt.Errorf("fitsio: missing 'TFORM%d' for column '%s'", i+1, col.Name)
 		} else {
-			col.Format = card.Value.(string)
+			col.Format, err = cardString(card)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		card = get("TUNIT", i)
 		if card != nil && card.Value != nil {
-			col.Unit = card.Value.(string)
+			col.Unit, err = cardString(card)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		card = get("TNULL", i)
@@ -304,15 +606,9 @@ func (dec *streamDecoder) loadTable(hdr *Header, htype HDUType) (*Table, error)
 
 		card = get("TSCAL", i)
 		if card != nil && card.Value != nil {
-			switch vv := card.Value.(type) {
-			case float64:
-				col.Bscale = vv
-			case int64:
-				col.Bscale = float64(vv)
-			case int:
-				col.Bscale = float64(vv)
-			default:
-				return nil, fmt.Errorf("fitsio: unhandled type [%T]", vv)
+			col.Bscale, err = cardFloat(card)
+			if err != nil {
+				return nil, err
 			}
 		} else {
 			col.Bscale = 1.0
@@ -320,15 +616,9 @@ func (dec *streamDecoder) loadTable(hdr *Header, htype HDUType) (*Table, error)
 
 		card = get("TZERO", i)
 		if card != nil && card.Value != nil {
-			switch vv := card.Value.(type) {
-			case float64:
-				col.Bzero = vv
-			case int64:
-				col.Bzero = float64(vv)
-			case int:
-				col.Bzero = float64(vv)
-			default:
-				return nil, fmt.Errorf("fitsio: unhandled type [%T]", vv)
+			col.Bzero, err = cardFloat(card)
+			if err != nil {
+				return nil, err
 			}
 		} else {
 			col.Bzero = 0.0
@@ -336,12 +626,18 @@ func (dec *streamDecoder) loadTable(hdr *Header, htype HDUType) (*Table, error)
 
 		card = get("TDISP", i)
 		if card != nil && card.Value != nil {
-			col.Display = card.Value.(string)
+			col.Display, err = cardString(card)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		card = get("TDIM", i)
 		if card != nil && card.Value != nil {
-			dims := card.Value.(string)
+			dims, err := cardString(card)
+			if err != nil {
+				return nil, err
+			}
 			dims = strings.Replace(dims, "(", "", -1)
 			dims = strings.Replace(dims, ")", "", -1)
 			toks := make([]string, 0)
@@ -364,14 +660,46 @@ func (dec *streamDecoder) loadTable(hdr *Header, htype HDUType) (*Table, error)
 
 		card = get("TBCOL", i)
 		if card != nil && card.Value != nil {
-			col.Start = int64(card.Value.(int))
+			start, err := cardInt(card)
+			if err != nil {
+				return nil, err
+			}
+			col.Start = int64(start)
 		}
 
 		col.dtype, err = typeFromForm(col.Format, htype)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("fitsio: invalid card TFORM%d: %w", i+1, err)
+		}
+		if htype == BINARY_TBL {
+			col.dtype = zeroType(col.dtype, col.Bscale, col.Bzero)
+			col.scanner = scannerFor(col)
 		}
-		offset += col.dtype.dsize * col.dtype.len
+
+		width := mulSize(col.dtype.dsize, col.dtype.len)
+		switch htype {
+		case ASCII_TBL:
+			// fields of ASCII tables may be separated by spacer
+			// characters: TBCOLn is authoritative.
+			if col.Start > 0 {
+				col.offset = int(col.Start) - 1
+				offset = col.offset
+			}
+			if width < 0 || col.offset+width > rowsz {
+				return nil, fmt.Errorf(
+					"fitsio: column %q (TBCOL%d=%d, TFORM%d=%q) exceeds the row size (%d)",
+					col.Name, i+1, col.offset+1, i+1, col.Format, rowsz,
+				)
+			}
+		default:
+			if width < 0 || col.offset+width > rowsz {
+				return nil, fmt.Errorf(
+					"fitsio: column %q (TFORM%d=%q) exceeds the row size (%d)",
+					col.Name, i+1, col.Format, rowsz,
+				)
+			}
+		}
+		offset += width
 		if htype == ASCII_TBL {
 			col.txtfmt = txtfmtFromForm(col.Format)
 		}
@@ -387,6 +715,9 @@ func (dec *streamDecoder) loadTable(hdr *Header, htype HDUType) (*Table, error)
 		nrows:  nrows,
 		cols:   cols,
 		colidx: colidx,
+		gap:    theap - datasz,
+		pooled: pooled,
+		nulls:  dec.cfg.nulls,
 	}
 
 	return table, err
@@ -402,8 +733,11 @@ func (dec *seekDecoder) DecodeHDU() (HDU, error) {
 	panic("not implemented")
 }
 
+func (dec *seekDecoder) DecodeHDUContext(ctx context.Context) (HDU, error) {
+	panic("not implemented")
+}
+
 func hduTypeFrom(cards []Card) (HDUType, bool, error) {
-	var err error
 	var htype HDUType = -1
 	var primary bool
 
@@ -415,7 +749,10 @@ func hduTypeFrom(cards []Card) (HDUType, bool, error) {
 			primary = true
 			return IMAGE_HDU, primary, nil
 		case "XTENSION":
-			str := card.Value.(string)
+			str, err := cardString(&card)
+			if err != nil {
+				return htype, primary, err
+			}
 			switch str {
 			case "IMAGE":
 				htype = IMAGE_HDU
@@ -425,11 +762,14 @@ func hduTypeFrom(cards []Card) (HDUType, bool, error) {
 				htype = BINARY_TBL
 			case "ANY", "ANY_HDU":
 				htype = ANY_HDU
-			default:
+			case "":
 				return htype, primary, fmt.Errorf("fitsio: invalid 'XTENSION' value: %q", str)
+			default:
+				// a conforming extension: its data unit is kept as is.
+				htype = UNKNOWN_HDU
 			}
 
-			return htype, primary, err
+			return htype, primary, nil
 		}
 	}
 
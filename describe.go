@@ -0,0 +1,135 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ColumnSchema describes a column of a table.
+type ColumnSchema struct {
+	Name   string       // column name, from the ``TTYPE`` keyword
+	Format string       // column format, from the ``TFORM`` keyword
+	Type   reflect.Type // Go type of the values of the column
+	Unit   string       // column unit, from the ``TUNIT`` keyword
+	Dim    []int64      // column dimensions, from the ``TDIM`` keyword
+	Null   string       // null value, from the ``TNULL`` keyword
+}
+
+// Schema describes the columns of a table.
+type Schema []ColumnSchema
+
+// Schema returns the description of the columns of the table.
+func (t *Table) Schema() Schema {
+	s := make(Schema, len(t.cols))
+	for i := range t.cols {
+		col := &t.cols[i]
+		s[i] = ColumnSchema{
+			Name:   col.Name,
+			Format: col.Format,
+			Type:   col.Type(),
+			Unit:   col.Unit,
+			Dim:    col.Dim,
+			Null:   col.Null,
+		}
+	}
+	return s
+}
+
+// String returns the description of the columns, one column per line,
+// with the fields of the columns aligned.
+func (s Schema) String() string {
+	rows := [][]string{{"NAME", "TFORM", "TYPE", "UNIT", "DIM", "NULL"}}
+	for _, col := range s {
+		typ := ""
+		if col.Type != nil {
+			typ = col.Type.String()
+		}
+		dim := ""
+		if len(col.Dim) > 0 {
+			dims := make([]string, len(col.Dim))
+			for i, d := range col.Dim {
+				dims[i] = fmt.Sprintf("%d", d)
+			}
+			dim = "(" + strings.Join(dims, ",") + ")"
+		}
+		rows = append(rows, []string{col.Name, col.Format, typ, col.Unit, dim, col.Null})
+	}
+
+	width := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, v := range row {
+			width[i] = max(width[i], len(v))
+		}
+	}
+
+	var o strings.Builder
+	for _, row := range rows {
+		var line strings.Builder
+		for i, v := range row {
+			if i > 0 {
+				line.WriteString("  ")
+			}
+			fmt.Fprintf(&line, "%-*s", width[i], v)
+		}
+		o.WriteString(strings.TrimRight(line.String(), " "))
+		o.WriteString("\n")
+	}
+	return o.String()
+}
+
+// Describe writes a description of the layout of the file to w: the type,
+// name and dimensions of each HDU, and the schema of the tables.
+func (f *File) Describe(w io.Writer) error {
+	for i, hdu := range f.HDUs() {
+		var err error
+		switch hdu := hdu.(type) {
+		case nil:
+			_, err = fmt.Fprintf(w, "HDU #%d: <not loaded>\n", i)
+		case Image:
+			_, err = fmt.Fprintf(
+				w, "HDU #%d: %s %s, BITPIX=%d, NAXES=%v\n",
+				i, hduName(i, hdu), hdu.Type(), hdu.Header().Bitpix(), hdu.Header().Axes(),
+			)
+		case *Table:
+			_, err = fmt.Fprintf(
+				w, "HDU #%d: %s %s, %d rows x %d columns\n",
+				i, hduName(i, hdu), hdu.Type(), hdu.NumRows(), hdu.NumCols(),
+			)
+			if err != nil {
+				return err
+			}
+			for _, line := range strings.SplitAfter(hdu.Schema().String(), "\n") {
+				if line == "" {
+					continue
+				}
+				_, err = io.WriteString(w, "    "+line)
+				if err != nil {
+					return err
+				}
+			}
+		default:
+			_, err = fmt.Fprintf(w, "HDU #%d: %s %s\n", i, hduName(i, hdu), hdu.Type())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hduName returns the name of the i-th HDU of a file, for display.
+func hduName(i int, hdu HDU) string {
+	switch name := hdu.Name(); {
+	case name != "":
+		return fmt.Sprintf("%q", name)
+	case i == 0:
+		return "PRIMARY"
+	}
+	return `""`
+}
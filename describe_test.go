@@ -0,0 +1,101 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	tbl, err := NewTable("events", []Column{
+		{Name: "ID", Format: "J", Null: "-1"},
+		{Name: "ENERGY", Format: "E", Unit: "keV"},
+		{Name: "PSF", Format: "6D", Dim: []int64{2, 3}},
+		{Name: "NAME", Format: "8A"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	schema := tbl.Schema()
+	want := Schema{
+		{Name: "ID", Format: "J", Type: reflect.TypeOf(int32(0)), Null: "-1"},
+		{Name: "ENERGY", Format: "E", Type: reflect.TypeOf(float32(0)), Unit: "keV"},
+		{Name: "PSF", Format: "6D", Type: reflect.TypeOf([6]float64{}), Dim: []int64{2, 3}},
+		{Name: "NAME", Format: "8A", Type: reflect.TypeOf("")},
+	}
+	if !reflect.DeepEqual(schema, want) {
+		t.Fatalf("invalid schema:\ngot= %+v\nwant=%+v", schema, want)
+	}
+
+	const str = `NAME    TFORM  TYPE        UNIT  DIM    NULL
+ID      J      int32                    -1
+ENERGY  E      float32     keV
+PSF     6D     [6]float64        (2,3)
+NAME    8A     string
+`
+	if got := schema.String(); got != str {
+		t.Fatalf("invalid schema string:\ngot:\n%s\nwant:\n%s", got, str)
+	}
+
+	var (
+		id   = int32(1)
+		e    = float32(1.5)
+		psf  [6]float64
+		name = "src"
+	)
+	err = tbl.Write(&id, &e, &psf, &name)
+	if err != nil {
+		t.Fatalf("could not write row: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	f, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	img := NewImage(16, []int{3, 2})
+	err = img.Write(make([]int16, 6))
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	for _, hdu := range []HDU{img, tbl} {
+		err = f.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	f, err = Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	out := new(strings.Builder)
+	err = f.Describe(out)
+	if err != nil {
+		t.Fatalf("could not describe file: %v", err)
+	}
+	desc := `HDU #0: "PRIMARY" IMAGE, BITPIX=16, NAXES=[3 2]
+HDU #1: "events" BINTABLE, 1 rows x 4 columns
+`
+	for _, line := range strings.SplitAfter(str, "\n") {
+		if line != "" {
+			desc += "    " + line
+		}
+	}
+	if got := out.String(); got != desc {
+		t.Fatalf("invalid description:\ngot:\n%s\nwant:\n%s", got, desc)
+	}
+}
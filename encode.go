@@ -6,31 +6,201 @@ package fitsio
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"slices"
 )
 
 type Encoder interface {
 	EncodeHDU(hdu HDU) error
 }
 
+// ContextEncoder is an Encoder whose encoding may be aborted.
+// The Encoders returned by NewEncoder implement ContextEncoder.
+type ContextEncoder interface {
+	Encoder
+
+	// EncodeHDUContext is like EncodeHDU, but aborts the encoding
+	// when ctx is done, returning the context error.
+	EncodeHDUContext(ctx context.Context, hdu HDU) error
+}
+
+// encodeHDU encodes hdu with enc, aborting the encoding when ctx is done
+// if enc is a ContextEncoder.
+func encodeHDU(ctx context.Context, enc Encoder, hdu HDU) error {
+	if enc, ok := enc.(ContextEncoder); ok {
+		return enc.EncodeHDUContext(ctx, hdu)
+	}
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+	return enc.EncodeHDU(hdu)
+}
+
 // NewEncoder creates a new Encoder according to the capabilities of the underlying io.Writer
-func NewEncoder(w io.Writer) Encoder {
-	// FIXME(sbinet)
-	// if ww, ok := w.(io.WriteSeeker); ok {
-	// 	return &seekWriter{w: ww}
-	// }
-	return &streamEncoder{w: w}
+//
+// Encoders writing to an io.WriteSeeker may stream the rows of tables
+// (see File.NewTableWriter.)
+func NewEncoder(w io.Writer, opts ...Option) Encoder {
+	enc := &streamEncoder{w: &countWriter{w: w}, cfg: newConfig(opts)}
+	if isWriteSeeker(w) {
+		ws := w.(io.WriteSeeker)
+		base, _ := ws.Seek(0, io.SeekCurrent)
+		return &seekEncoder{streamEncoder: enc, ws: ws, base: base}
+	}
+	return enc
 }
 
 // streamEncoder is a encoder which can not perform random access
 // into the underlying Writer
 type streamEncoder struct {
+	w   *countWriter
+	cfg config
+
+	cur HDUOffsets // location of the HDU being (or last) encoded
+}
+
+// countWriter counts the bytes written to an io.Writer.
+type countWriter struct {
 	w io.Writer
+	n int64
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
 }
 
 func (enc *streamEncoder) EncodeHDU(hdu HDU) error {
-	var err error
+	return enc.EncodeHDUContext(context.Background(), hdu)
+}
+
+func (enc *streamEncoder) EncodeHDUContext(ctx context.Context, hdu HDU) error {
+	enc.cur = HDUOffsets{Header: enc.w.n, Data: -1, End: -1}
+	err := enc.encodeHDU(ctx, hdu)
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err == nil {
+		enc.cur.End = enc.w.n
+	}
+	return err
+}
+
+// offsets returns the location of the last encoded HDU.
+func (enc *streamEncoder) offsets() HDUOffsets {
+	return enc.cur
+}
+
+func (enc *streamEncoder) encodeHDU(ctx context.Context, hdu HDU) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+	hdr := hdu.Header()
+	err = enc.validate(hdr)
+	if err != nil {
+		return err
+	}
+
+	block := hdr.Raw()
+	if !enc.cfg.rawHeaders || block == nil {
+		block, err = enc.encodeHeader(hdr)
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := enc.w.Write(block)
+	if err != nil {
+		return fmt.Errorf("fitsio: error writing header block: %v", err)
+	}
+	if n != len(block) {
+		return fmt.Errorf("fitsio: wrote %d bytes. expected %d", n, len(block))
+	}
+	enc.cur.Data = enc.w.n
+
+	// write payload
+	switch hdr.Type() {
+	case IMAGE_HDU:
+		img := hdu.(Image)
+		err = enc.saveImage(ctx, img)
+		if err != nil {
+			return fmt.Errorf("fitsio: error encoding image: %v", err)
+		}
+
+	case BINARY_TBL:
+		tbl := hdu.(*Table)
+		err = enc.saveTable(ctx, tbl)
+		if err != nil {
+			return fmt.Errorf("fitsio: error encoding binary table: %v", err)
+		}
+
+	case ASCII_TBL:
+		tbl := hdu.(*Table)
+		err = enc.saveTable(ctx, tbl)
+		if err != nil {
+			return fmt.Errorf("fitsio: error encoding ascii table: %v", err)
+		}
+
+	case UNKNOWN_HDU:
+		err = enc.saveRaw(ctx, hdu.(*rawHDU))
+		if err != nil {
+			return fmt.Errorf("fitsio: error encoding %q extension: %v", xtension(hdr), err)
+		}
+
+	case ANY_HDU:
+		fallthrough
+	default:
+		return fmt.Errorf("fitsio: encoding for HDU [%v] not implemented", hdr.Type())
+	}
+	return err
+}
+
+// validate checks hdr against the schemas of the encoder.
+func (enc *streamEncoder) validate(hdr *Header) error {
+	for i := range enc.cfg.schemas {
+		schema := &enc.cfg.schemas[i]
+		if !schema.applies(hdr) {
+			continue
+		}
+		err := schema.Validate(hdr)
+		if err != nil {
+			return fmt.Errorf("fitsio: header does not follow schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// beginHDU writes the header hdr of a new HDU, whose data unit is then
+// written by the caller, and returns the size of the header blocks.
+func (enc *streamEncoder) beginHDU(ctx context.Context, hdr *Header) (int, error) {
+	enc.cur = HDUOffsets{Header: enc.w.n, Data: -1, End: -1}
+	err := ctx.Err()
+	if err != nil {
+		return 0, err
+	}
+	err = enc.validate(hdr)
+	if err != nil {
+		return 0, err
+	}
+	block, err := enc.encodeHeader(hdr)
+	if err != nil {
+		return 0, err
+	}
+	_, err = enc.w.Write(block)
+	if err != nil {
+		return 0, fmt.Errorf("fitsio: error writing header block: %v", err)
+	}
+	enc.cur.Data = enc.w.n
+	return len(block), nil
+}
+
+// encodeHeader serializes the cards of hdr into header blocks.
+func (enc *streamEncoder) encodeHeader(hdr *Header) ([]byte, error) {
 	const (
 		valind  = "= "
 		nKEY    = 8
@@ -40,93 +210,71 @@ func (enc *streamEncoder) EncodeHDU(hdu HDU) error {
 		nLINE   = 80
 	)
 
-	hdr := hdu.Header()
+	if enc.cfg.canonical {
+		canon := *hdr
+		canon.cards = slices.Clone(hdr.cards)
+		canon.canonicalize(enc.cfg.sortKeys)
+		hdr = &canon
+	}
+
 	nkeys := len(hdr.cards)
 	buf := new(bytes.Buffer)
 
 	buf.Grow(nkeys * nLINE)
 
+	exp := byte('E')
+	if enc.cfg.dexp {
+		exp = 'D'
+	}
 	for i := range hdr.cards {
 		card := &hdr.cards[i]
-		bline, err := makeHeaderLine(card)
+		if card.Name == "END" {
+			// decoded headers hold their END card: it is written below.
+			continue
+		}
+		bline, err := formatHeaderLine(card, exp)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		_, err = buf.Write(bline)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	{ // END
 		bline, err := makeHeaderLine(&Card{Name: "END"})
 		if err != nil {
-			return err
+			return nil, err
 		}
 		_, err = buf.Write(bline)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	padsz := padBlock(buf.Len())
+	padsz := PadBlock(buf.Len())
 	if padsz > 0 {
 		n, err := buf.Write(bytes.Repeat([]byte(" "), padsz))
 		if err != nil {
-			return fmt.Errorf("fitsio: error while padding header block: %v", err)
+			return nil, fmt.Errorf("fitsio: error while padding header block: %v", err)
 		}
 		if n != padsz {
-			return fmt.Errorf("fitsio: wrote %d bytes. expected %d. (padding)", n, padsz)
+			return nil, fmt.Errorf("fitsio: wrote %d bytes. expected %d. (padding)", n, padsz)
 		}
 	}
 
-	alignsz := alignBlock(buf.Len())
+	alignsz := AlignBlock(buf.Len())
 	if alignsz != buf.Len() {
-		return fmt.Errorf("fitsio: header not aligned (%d). expected %d.", buf.Len(), alignsz)
+		return nil, fmt.Errorf("fitsio: header not aligned (%d). expected %d.", buf.Len(), alignsz)
 	}
 
-	n, err := io.Copy(enc.w, buf)
-	if err != nil {
-		return fmt.Errorf("fitsio: error writing header block: %v", err)
-	}
-	if n != int64(alignsz) {
-		return fmt.Errorf("fitsio: wrote %d bytes. expected %d", n, alignsz)
-	}
-
-	// write payload
-	switch hdr.Type() {
-	case IMAGE_HDU:
-		img := hdu.(Image)
-		err = enc.saveImage(img)
-		if err != nil {
-			return fmt.Errorf("fitsio: error encoding image: %v", err)
-		}
-
-	case BINARY_TBL:
-		tbl := hdu.(*Table)
-		err = enc.saveTable(tbl)
-		if err != nil {
-			return fmt.Errorf("fitsio: error encoding binary table: %v", err)
-		}
-
-	case ASCII_TBL:
-		tbl := hdu.(*Table)
-		err = enc.saveTable(tbl)
-		if err != nil {
-			return fmt.Errorf("fitsio: error encoding ascii table: %v", err)
-		}
-
-	case ANY_HDU:
-		fallthrough
-	default:
-		return fmt.Errorf("fitsio: encoding for HDU [%v] not implemented", hdr.Type())
-	}
-	return err
+	return buf.Bytes(), nil
 }
 
-func (enc *streamEncoder) saveImage(img Image) error {
+func (enc *streamEncoder) saveImage(ctx context.Context, img Image) error {
 	raw := img.Raw()
-	n, err := enc.w.Write(raw)
+	n, err := writeFull(ctx, enc.w, raw, 0, int64(len(raw)), enc.cfg.progress)
 	if err != nil {
 		return err
 	}
@@ -134,7 +282,7 @@ func (enc *streamEncoder) saveImage(img Image) error {
 		return fmt.Errorf("fitsio: wrote %d bytes. expected %d", n, len(raw))
 	}
 
-	padsz := padBlock(n)
+	padsz := PadBlock(n)
 	if padsz > 0 {
 		n, err := enc.w.Write(make([]byte, padsz))
 		if err != nil {
@@ -148,8 +296,9 @@ func (enc *streamEncoder) saveImage(img Image) error {
 	return err
 }
 
-func (enc *streamEncoder) saveTable(table *Table) error {
-	ndata, err := enc.w.Write(table.data)
+func (enc *streamEncoder) saveTable(ctx context.Context, table *Table) error {
+	total := int64(len(table.data) + table.gap + len(table.heap))
+	ndata, err := writeFull(ctx, enc.w, table.data, 0, total, enc.cfg.progress)
 	if err != nil {
 		return fmt.Errorf("fitsio: error writing table-data: %v", err)
 	}
@@ -157,7 +306,15 @@ func (enc *streamEncoder) saveTable(table *Table) error {
 		return fmt.Errorf("fitsio: wrote %d bytes. expected %d", ndata, len(table.data))
 	}
 
-	nheap, err := enc.w.Write(table.heap)
+	if table.gap > 0 {
+		n, err := writeFull(ctx, enc.w, make([]byte, table.gap), int64(ndata), total, enc.cfg.progress)
+		if err != nil {
+			return fmt.Errorf("fitsio: error writing table-heap gap: %v", err)
+		}
+		ndata += n
+	}
+
+	nheap, err := writeFull(ctx, enc.w, table.heap, int64(ndata), total, enc.cfg.progress)
 	if err != nil {
 		return fmt.Errorf("fitsio: error writing table-heap: %v", err)
 	}
@@ -166,7 +323,7 @@ func (enc *streamEncoder) saveTable(table *Table) error {
 	}
 
 	// align to FITS block
-	padsz := padBlock(ndata + nheap)
+	padsz := PadBlock(ndata + nheap)
 	if padsz > 0 {
 		n := 0
 
@@ -0,0 +1,97 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+var (
+	// ErrShortHeader is returned when the input ends in the middle of
+	// a header, before its END card.
+	ErrShortHeader = errors.New("fitsio: short header")
+
+	// ErrShortData is returned when the input ends before the data of
+	// an HDU, as described by its header.
+	ErrShortData = errors.New("fitsio: short data")
+
+	// ErrHDUTooLarge is returned when the data of an HDU is larger than
+	// the configured limit, or than what can be addressed.
+	ErrHDUTooLarge = errors.New("fitsio: HDU too large")
+
+	// ErrDuplicateKey is returned when a header holds several cards with
+	// the same keyword, and the decoder was configured to reject them.
+	ErrDuplicateKey = errors.New("fitsio: duplicate header keyword")
+
+	// ErrNull is returned when an undefined field of an ASCII table is read
+	// into a value which can not represent it, and the table was configured
+	// to reject such reads with NullAsError.
+	ErrNull = errors.New("fitsio: undefined value")
+
+	// ErrNotAligned is returned by BlockReader and BlockWriter when
+	// a buffer or an offset is not a whole number of FITS blocks.
+	ErrNotAligned = errors.New("fitsio: not aligned on a FITS block")
+)
+
+// ErrBadTFORM is returned when the TFORMn value of a column can not be
+// interpreted.
+type ErrBadTFORM struct {
+	Form   string // value of the TFORMn card
+	Reason string // optional details
+}
+
+func (e *ErrBadTFORM) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("fitsio: invalid TFORM format (%s)", e.Form)
+	}
+	return fmt.Sprintf("fitsio: invalid TFORM format (%s) (%s)", e.Form, e.Reason)
+}
+
+// ErrTypeMismatch is returned when a Go value does not have the type
+// needed to hold the values of a column or the pixels of an image.
+type ErrTypeMismatch struct {
+	Want reflect.Type
+	Got  reflect.Type
+}
+
+func (e *ErrTypeMismatch) Error() string {
+	return fmt.Sprintf("fitsio: type mismatch (got=%v, want=%v)", e.Got, e.Want)
+}
+
+// ErrTruncatedHDU is returned when the input ends before the end of the
+// data unit of an HDU, padding included.
+// It wraps ErrShortData and io.ErrUnexpectedEOF.
+type ErrTruncatedHDU struct {
+	HDU    int   // index of the HDU in the input
+	Offset int64 // offset of the data unit in the input
+	Want   int64 // size of the data unit, padding included
+	Got    int64 // number of bytes of the data unit present in the input
+}
+
+func (e *ErrTruncatedHDU) Error() string {
+	return fmt.Sprintf(
+		"fitsio: HDU #%d truncated: data unit at offset %d needs %d bytes, input ends at offset %d (%d bytes missing)",
+		e.HDU, e.Offset, e.Want, e.Offset+e.Got, e.Want-e.Got,
+	)
+}
+
+func (e *ErrTruncatedHDU) Unwrap() []error {
+	return []error{ErrShortData, io.ErrUnexpectedEOF}
+}
+
+// shortRead annotates err, an error reading want bytes of which n were
+// read, with sentinel when the input ended prematurely.
+func shortRead(err error, sentinel error, n, want int) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w (got %d bytes, want %d): %w", sentinel, n, want, err)
+	}
+	return fmt.Errorf("fitsio: error reading %d bytes (got %d): %w", want, n, err)
+}
@@ -0,0 +1,88 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTypedErrors(t *testing.T) {
+	raw, err := os.ReadFile("testdata/file001.fits")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	// truncated header of the second HDU.
+	_, err = Open(bytes.NewReader(raw[:2*BlockSize+100]))
+	if !errors.Is(err, ErrShortHeader) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrShortHeader)
+	}
+	if !strings.Contains(err.Error(), "HDU #1") {
+		t.Fatalf("error does not report the HDU index: %v", err)
+	}
+
+	// truncated data.
+	_, err = Open(bytes.NewReader(raw[:len(raw)-BlockSize]))
+	if !errors.Is(err, ErrShortData) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrShortData)
+	}
+
+	// invalid TFORM.
+	i := bytes.Index(raw, []byte("TFORM2  = 'E14.7"))
+	if i < 0 {
+		t.Fatalf("could not find TFORM2 card")
+	}
+	bad := append([]byte(nil), raw...)
+	copy(bad[i:], "TFORM2  = 'Z14.7")
+	_, err = Open(bytes.NewReader(bad))
+	var tform *ErrBadTFORM
+	if !errors.As(err, &tform) {
+		t.Fatalf("invalid error: got=%v (%T), want=%T", err, err, tform)
+	}
+	if got, want := tform.Form, "Z14.7"; got != want {
+		t.Fatalf("invalid TFORM: got=%q, want=%q", got, want)
+	}
+	if !strings.Contains(err.Error(), "TFORM2") {
+		t.Fatalf("error does not report the card name: %v", err)
+	}
+
+	_, err = NewTable("bad", []Column{{Name: "x", Format: "2Z"}}, BINARY_TBL)
+	if !errors.As(err, &tform) {
+		t.Fatalf("invalid error: got=%v (%T), want=%T", err, err, tform)
+	}
+
+	// type mismatches.
+	tbl, err := NewTable("tbl", []Column{{Name: "x", Format: "J"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	var (
+		f64s     []float64
+		mismatch *ErrTypeMismatch
+	)
+	err = tbl.ReadColumn(0, &f64s)
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("invalid error: got=%v (%T), want=%T", err, err, mismatch)
+	}
+	if mismatch.Want != reflect.TypeOf(int32(0)) || mismatch.Got != reflect.TypeOf(float64(0)) {
+		t.Fatalf("invalid mismatch: %+v", mismatch)
+	}
+
+	img := NewImage(16, []int{2})
+	err = img.Write([]float32{1, 2})
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("invalid error: got=%v (%T), want=%T", err, err, mismatch)
+	}
+	if mismatch.Want != reflect.TypeOf([]int16(nil)) || mismatch.Got != reflect.TypeOf([]float32(nil)) {
+		t.Fatalf("invalid mismatch: %+v", mismatch)
+	}
+}
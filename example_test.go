@@ -288,3 +288,37 @@ func Example_createImage3x4F64() {
 
 	// Output:
 }
+
+func Example_openReaderAt() {
+	f, err := os.Open("testdata/swp06542llg.fits")
+	if err != nil {
+		log.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		log.Fatalf("could not stat file: %+v", err)
+	}
+
+	// only the headers are read.
+	fits, err := fitsio.OpenReaderAt(f, fi.Size())
+	if err != nil {
+		log.Fatalf("could not open FITS file: %+v", err)
+	}
+	defer fits.Close()
+
+	for i := range fits.NumHDUs() {
+		hdr := fits.Header(i)
+		fmt.Printf("HDU #%d: %v %v\n", i, hdr.Type(), hdr.Axes())
+	}
+
+	// the data of the table is read on demand.
+	tbl := fits.Get("IUE MELO").(*fitsio.Table)
+	fmt.Printf("rows: %d\n", tbl.NumRows())
+
+	// Output:
+	// HDU #0: IMAGE []
+	// HDU #1: BINTABLE [7532 1]
+	// rows: 1
+}
@@ -0,0 +1,450 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// expr is an arithmetic expression over the scalar numerical columns of
+// a table, compiled by compileExpr.
+type expr struct {
+	cols []int // indices of the table columns used by the expression
+
+	// eval evaluates the expression, vs[i] holding the value of
+	// the column cols[i] of the current row.
+	eval func(vs []float64) float64
+}
+
+// exprFuncs are the functions available to expressions.
+var exprFuncs = map[string]struct {
+	nargs int
+	fct   func(args []float64) float64
+}{
+	"abs":     {1, func(x []float64) float64 { return math.Abs(x[0]) }},
+	"sqrt":    {1, func(x []float64) float64 { return math.Sqrt(x[0]) }},
+	"exp":     {1, func(x []float64) float64 { return math.Exp(x[0]) }},
+	"log":     {1, func(x []float64) float64 { return math.Log(x[0]) }},
+	"log10":   {1, func(x []float64) float64 { return math.Log10(x[0]) }},
+	"sin":     {1, func(x []float64) float64 { return math.Sin(x[0]) }},
+	"cos":     {1, func(x []float64) float64 { return math.Cos(x[0]) }},
+	"tan":     {1, func(x []float64) float64 { return math.Tan(x[0]) }},
+	"arcsin":  {1, func(x []float64) float64 { return math.Asin(x[0]) }},
+	"arccos":  {1, func(x []float64) float64 { return math.Acos(x[0]) }},
+	"arctan":  {1, func(x []float64) float64 { return math.Atan(x[0]) }},
+	"asin":    {1, func(x []float64) float64 { return math.Asin(x[0]) }},
+	"acos":    {1, func(x []float64) float64 { return math.Acos(x[0]) }},
+	"atan":    {1, func(x []float64) float64 { return math.Atan(x[0]) }},
+	"floor":   {1, func(x []float64) float64 { return math.Floor(x[0]) }},
+	"ceil":    {1, func(x []float64) float64 { return math.Ceil(x[0]) }},
+	"round":   {1, func(x []float64) float64 { return math.Round(x[0]) }},
+	"arctan2": {2, func(x []float64) float64 { return math.Atan2(x[0], x[1]) }},
+	"atan2":   {2, func(x []float64) float64 { return math.Atan2(x[0], x[1]) }},
+	"min":     {2, func(x []float64) float64 { return math.Min(x[0], x[1]) }},
+	"max":     {2, func(x []float64) float64 { return math.Max(x[0], x[1]) }},
+	"pow":     {2, func(x []float64) float64 { return math.Pow(x[0], x[1]) }},
+}
+
+// exprConsts are the constants available to expressions.
+var exprConsts = map[string]float64{
+	"#pi": math.Pi,
+	"#e":  math.E,
+}
+
+// compileExpr compiles the arithmetic expression src, whose identifiers
+// refer to scalar numerical columns of t.
+//
+// Expressions are made of numbers, column names, the #pi and #e constants,
+// the +, -, *, /, % and ^ (or **) operators, parentheses and calls to
+// functions such as sqrt, log or sin.
+func compileExpr(t *Table, src string) (*expr, error) {
+	p := exprParser{t: t, src: src}
+	err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	eval, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "" {
+		return nil, p.errorf("unexpected %q", p.tok)
+	}
+	return &expr{cols: p.cols, eval: eval}, nil
+}
+
+// exprParser is a recursive descent parser for arithmetic expressions.
+type exprParser struct {
+	t    *Table
+	src  string
+	pos  int    // position of the next token in src
+	tok  string // current token ("" at the end of the input)
+	cols []int
+}
+
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("fitsio: invalid expression %q: %s", p.src, fmt.Sprintf(format, args...))
+}
+
+// next scans the next token.
+func (p *exprParser) next() error {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	if p.pos >= len(p.src) {
+		p.tok = ""
+		return nil
+	}
+
+	beg := p.pos
+	c := p.src[p.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.':
+		for p.pos < len(p.src) {
+			c := p.src[p.pos]
+			switch {
+			case c >= '0' && c <= '9' || c == '.':
+				p.pos++
+			case c == 'e' || c == 'E':
+				p.pos++
+				if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+					p.pos++
+				}
+			default:
+				p.tok = p.src[beg:p.pos]
+				return nil
+			}
+		}
+	case c == '#' || c == '_' || unicode.IsLetter(rune(c)):
+		p.pos++
+		for p.pos < len(p.src) {
+			c := p.src[p.pos]
+			if c != '_' && !unicode.IsLetter(rune(c)) && !unicode.IsDigit(rune(c)) {
+				break
+			}
+			p.pos++
+		}
+	case strings.HasPrefix(p.src[p.pos:], "**"):
+		p.pos += 2
+	case strings.ContainsRune("+-*/%^(),", rune(c)):
+		p.pos++
+	default:
+		return p.errorf("unexpected character %q", c)
+	}
+	p.tok = p.src[beg:p.pos]
+	return nil
+}
+
+// parseSum parses: product (('+'|'-') product)*
+func (p *exprParser) parseSum() (func([]float64) float64, error) {
+	lhs, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok == "+" || p.tok == "-" {
+		op := p.tok
+		err = p.next()
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		l := lhs
+		switch op {
+		case "+":
+			lhs = func(vs []float64) float64 { return l(vs) + rhs(vs) }
+		case "-":
+			lhs = func(vs []float64) float64 { return l(vs) - rhs(vs) }
+		}
+	}
+	return lhs, nil
+}
+
+// parseProduct parses: unary (('*'|'/'|'%') unary)*
+func (p *exprParser) parseProduct() (func([]float64) float64, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok == "*" || p.tok == "/" || p.tok == "%" {
+		op := p.tok
+		err = p.next()
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := lhs
+		switch op {
+		case "*":
+			lhs = func(vs []float64) float64 { return l(vs) * rhs(vs) }
+		case "/":
+			lhs = func(vs []float64) float64 { return l(vs) / rhs(vs) }
+		case "%":
+			lhs = func(vs []float64) float64 { return math.Mod(l(vs), rhs(vs)) }
+		}
+	}
+	return lhs, nil
+}
+
+// parseUnary parses: ('-'|'+') unary | power
+func (p *exprParser) parseUnary() (func([]float64) float64, error) {
+	switch p.tok {
+	case "-", "+":
+		op := p.tok
+		err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "-" {
+			return func(vs []float64) float64 { return -x(vs) }, nil
+		}
+		return x, nil
+	}
+	return p.parsePower()
+}
+
+// parsePower parses: primary (('^'|'**') unary)?
+func (p *exprParser) parsePower() (func([]float64) float64, error) {
+	lhs, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "^" && p.tok != "**" {
+		return lhs, nil
+	}
+	err = p.next()
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return func(vs []float64) float64 { return math.Pow(lhs(vs), rhs(vs)) }, nil
+}
+
+// parsePrimary parses: number | constant | column | function '(' args ')' | '(' sum ')'
+func (p *exprParser) parsePrimary() (func([]float64) float64, error) {
+	tok := p.tok
+	switch {
+	case tok == "":
+		return nil, p.errorf("unexpected end of expression")
+
+	case tok == "(":
+		err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		x, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok != ")" {
+			return nil, p.errorf("missing ')'")
+		}
+		return x, p.next()
+
+	case tok[0] >= '0' && tok[0] <= '9' || tok[0] == '.':
+		v, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", tok)
+		}
+		return func([]float64) float64 { return v }, p.next()
+
+	case tok[0] == '#':
+		v, ok := exprConsts[strings.ToLower(tok)]
+		if !ok {
+			return nil, p.errorf("unknown constant %q", tok)
+		}
+		return func([]float64) float64 { return v }, p.next()
+
+	case tok[0] == '_' || unicode.IsLetter(rune(tok[0])):
+		err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok == "(" {
+			return p.parseCall(tok)
+		}
+		return p.column(tok)
+	}
+	return nil, p.errorf("unexpected %q", tok)
+}
+
+// parseCall parses the arguments of a call to the function name.
+func (p *exprParser) parseCall(name string) (func([]float64) float64, error) {
+	fct, ok := exprFuncs[strings.ToLower(name)]
+	if !ok {
+		return nil, p.errorf("unknown function %q", name)
+	}
+
+	var args []func([]float64) float64
+	err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok != ")" {
+		if len(args) > 0 {
+			if p.tok != "," {
+				return nil, p.errorf("missing ',' or ')' in call to %q", name)
+			}
+			err = p.next()
+			if err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	if len(args) != fct.nargs {
+		return nil, p.errorf("invalid number of arguments to %q (got=%d, want=%d)", name, len(args), fct.nargs)
+	}
+
+	xs := make([]float64, len(args))
+	eval := func(vs []float64) float64 {
+		for i, arg := range args {
+			xs[i] = arg(vs)
+		}
+		return fct.fct(xs)
+	}
+	return eval, p.next()
+}
+
+// column returns the evaluation function of the named column.
+// Column names are matched case-insensitively when there is no exact match.
+func (p *exprParser) column(name string) (func([]float64) float64, error) {
+	icol := p.t.Index(name)
+	if icol < 0 {
+		for i := range p.t.cols {
+			if strings.EqualFold(p.t.cols[i].Name, name) {
+				icol = i
+				break
+			}
+		}
+	}
+	if icol < 0 {
+		return nil, p.errorf("unknown column %q", name)
+	}
+	kind := p.t.cols[icol].Type().Kind()
+	if !isIntKind(kind) && !isFloatKind(kind) {
+		return nil, p.errorf("column %q is not a scalar numerical column", name)
+	}
+
+	slot := -1
+	for i, j := range p.cols {
+		if j == icol {
+			slot = i
+			break
+		}
+	}
+	if slot < 0 {
+		slot = len(p.cols)
+		p.cols = append(p.cols, icol)
+	}
+	return func(vs []float64) float64 { return vs[slot] }, nil
+}
+
+// AddComputedColumn adds a column named name to the table, holding for each
+// row the value of the arithmetic expression expr evaluated over the scalar
+// numerical columns of that row.
+//
+// Expressions are made of numbers, column names, the #pi and #e constants,
+// the +, -, *, /, % and ^ (or **) operators, parentheses and calls to
+// the abs, sqrt, exp, log, log10, sin, cos, tan, arcsin, arccos, arctan,
+// arctan2, floor, ceil, round, min, max and pow functions.
+// For example: "0.9*Y", "sqrt(X^2 + Y^2)" or "log10(FLUX) - 2.5".
+//
+// The new column holds 64-bit floating point values.
+// If a scalar numerical column with that name already exists, its values are
+// replaced instead, converted to the type of the column.
+func (t *Table) AddComputedColumn(name, expr string) error {
+	if name == "" {
+		return fmt.Errorf("fitsio: empty column name")
+	}
+
+	x, err := compileExpr(t, expr)
+	if err != nil {
+		return err
+	}
+
+	values := make([][]float64, len(x.cols))
+	for i, icol := range x.cols {
+		values[i], err = readFloatColumn(t, icol)
+		if err != nil {
+			return err
+		}
+	}
+
+	htype := t.Type()
+	icol := t.Index(name)
+	cols := make([]Column, len(t.cols), len(t.cols)+1)
+	for i := range t.cols {
+		cols[i] = schemaColumn(t, i, htype)
+	}
+	switch {
+	case icol >= 0:
+		kind := t.cols[icol].Type().Kind()
+		if !isIntKind(kind) && !isFloatKind(kind) {
+			return fmt.Errorf("fitsio: column %q is not a scalar numerical column", name)
+		}
+	default:
+		icol = len(cols)
+		cols = append(cols, Column{
+			Name:   name,
+			Format: formFromGoType(reflect.TypeOf(float64(0)), htype),
+		})
+	}
+
+	out, err := NewTable(t.Name(), cols, htype)
+	if err != nil {
+		return err
+	}
+	err = copyUserCards(&out.hdr, &t.hdr)
+	if err != nil {
+		return err
+	}
+
+	w, err := newRowWriter(out)
+	if err != nil {
+		return err
+	}
+
+	var (
+		irow int
+		vs   = make([]float64, len(x.cols))
+	)
+	err = scanRows(t, func(row []reflect.Value) error {
+		for i := range vs {
+			vs[i] = values[i][irow]
+		}
+		w.set(0, row)
+		w.set(icol, []reflect.Value{reflect.ValueOf(x.eval(vs))})
+		irow++
+		return w.write()
+	})
+	if err != nil {
+		return fmt.Errorf("fitsio: could not compute column %q: %v", name, err)
+	}
+
+	*t = *out
+	return nil
+}
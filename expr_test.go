@@ -0,0 +1,123 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAddComputedColumn(t *testing.T) {
+	type Row struct {
+		X int16   `fits:"X"`
+		Y float32 `fits:"Y"`
+	}
+
+	for _, htype := range []HDUType{BINARY_TBL, ASCII_TBL} {
+		t.Run(htype.String(), func(t *testing.T) {
+			tbl, err := NewTableFrom("TBL", Row{}, htype)
+			if err != nil {
+				t.Fatalf("could not create table: %v", err)
+			}
+			defer tbl.Close()
+			for _, row := range []Row{{3, 4}, {-1, 0.5}, {0, 2}} {
+				err = tbl.Write(&row)
+				if err != nil {
+					t.Fatalf("could not write row: %v", err)
+				}
+			}
+
+			for _, test := range []struct {
+				name, expr string
+			}{
+				{"R", "sqrt(X^2 + Y**2)"},
+				{"Z", ".9*y - -2e-1 + max(x, 1) % 2"},
+				{"A", "arctan2(Y, X) * 180 / #pi"},
+				{"X", "X + 10"},
+			} {
+				err = tbl.AddComputedColumn(test.name, test.expr)
+				if err != nil {
+					t.Fatalf("could not add column %q: %v", test.name, err)
+				}
+			}
+
+			if got, want := tbl.NumCols(), 5; got != want {
+				t.Fatalf("invalid number of columns: got=%d, want=%d", got, want)
+			}
+			if got, want := tbl.Name(), "TBL"; got != want {
+				t.Fatalf("invalid table name: got=%q, want=%q", got, want)
+			}
+
+			type Out struct {
+				X int16   `fits:"X"`
+				Y float32 `fits:"Y"`
+				R float64 `fits:"R"`
+				Z float64 `fits:"Z"`
+				A float64 `fits:"A"`
+			}
+			want := []Out{
+				{13, 4, 5, 0.9*4 + 0.2 + 1, math.Atan2(4, 3) * 180 / math.Pi},
+				{9, 0.5, math.Hypot(1, 0.5), 0.9*0.5 + 0.2 + 1, math.Atan2(0.5, -1) * 180 / math.Pi},
+				{10, 2, 2, 0.9*2 + 0.2 + 1, 90},
+			}
+
+			rows, err := tbl.Read(0, tbl.NumRows())
+			if err != nil {
+				t.Fatalf("could not read rows: %v", err)
+			}
+			defer rows.Close()
+			i := 0
+			for rows.Next() {
+				var got Out
+				err = rows.Scan(&got)
+				if err != nil {
+					t.Fatalf("could not scan row: %v", err)
+				}
+				w := want[i]
+				if got.X != w.X || got.Y != w.Y ||
+					math.Abs(got.R-w.R) > 1e-6 ||
+					math.Abs(got.Z-w.Z) > 1e-6 ||
+					math.Abs(got.A-w.A) > 1e-6 {
+					t.Fatalf("row %d: got=%+v, want=%+v", i, got, w)
+				}
+				i++
+			}
+			if i != len(want) {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", i, len(want))
+			}
+		})
+	}
+}
+
+func TestCompileExprErrors(t *testing.T) {
+	type Row struct {
+		X    int16  `fits:"X"`
+		Name string `fits:"NAME"`
+	}
+	tbl, err := NewTableFrom("TBL", Row{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	for _, expr := range []string{
+		"",
+		"X +",
+		"(X + 1",
+		"X + 1)",
+		"Y",
+		"NAME * 2",
+		"foo(X)",
+		"sqrt(X, 2)",
+		"#tau",
+		"X $ 2",
+		"1.2.3",
+	} {
+		_, err := compileExpr(tbl, expr)
+		if err == nil {
+			t.Fatalf("%q: expected an error", expr)
+		}
+	}
+}
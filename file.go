@@ -5,9 +5,17 @@
 package fitsio
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
+	"iter"
 	"os"
+	"strings"
+	"sync"
+
+	"github.com/astrogo/fitsio/xname"
 )
 
 // Mode defines a FITS file access mode (r/w)
@@ -19,17 +27,74 @@ const (
 	ReadWrite      = Mode(os.O_RDWR)   // open the file read-write
 )
 
+// String returns the name of the mode, such as "ReadOnly".
+// Invalid values are formatted as "Mode(n)".
+func (mode Mode) String() string {
+	switch mode {
+	case ReadOnly:
+		return "ReadOnly"
+	case WriteOnly:
+		return "WriteOnly"
+	case ReadWrite:
+		return "ReadWrite"
+	}
+	return fmt.Sprintf("Mode(%d)", int(mode))
+}
+
 // File represents a FITS file.
+//
+// The methods of File giving access to its HDUs may be called concurrently
+// from multiple goroutines, including while another goroutine writes a new
+// HDU to the file.
+// Writing HDUs is serialized.
 type File struct {
 	dec  Decoder
 	enc  Encoder
 	name string
 	mode Mode
+
+	wmu  sync.Mutex   // serializes writes
+	sw   io.Closer    // TableWriter or ImageWriter in use, if any (protected by wmu)
+	mu   sync.RWMutex // protects hdus and offs
 	hdus []HDU
+	offs []HDUOffsets // location of the HDUs in the underlying stream
+
+	closer io.Closer // underlying file opened by OpenFile, if any
+	zw     io.Closer // gzip stream of files created WithGzip, if any
+
+	// files opened with OpenReaderAt load their HDUs on demand: hdus[i]
+	// is nil until loaded, stubs[i] holding its header meanwhile.
+	ra    io.ReaderAt
+	opts  []Option
+	stubs []HDU
+	lmu   sync.Mutex // serializes loads
 }
 
 // Open opens a FITS file in read-only mode.
-func Open(r io.Reader) (*File, error) {
+//
+// Files compressed with gzip (.fits.gz) or bzip2 (.fits.bz2) are
+// transparently decompressed: the HDU offsets reported by Offsets are
+// then offsets in the decompressed stream.
+// Tile-compressed files (.fits.fz) are plain FITS files, whose compressed
+// HDUs may be decompressed with DecompressImage and DecompressTable.
+func Open(r io.Reader, opts ...Option) (*File, error) {
+	return OpenContext(context.Background(), r, opts...)
+}
+
+// OpenBytes opens in read-only mode the FITS file held by b.
+// The data units of the HDUs of the returned File do not share memory
+// with b.
+func OpenBytes(b []byte, opts ...Option) (*File, error) {
+	return Open(bytes.NewReader(b), opts...)
+}
+
+// OpenContext is like Open, but aborts the decoding of the file
+// when ctx is done, returning the context error.
+//
+// Inputs implementing io.ReaderAt and io.Seeker may have their data units
+// read on demand (see WithLazyData and WithLazyOversized) or decoded
+// concurrently (see WithConcurrency.)
+func OpenContext(ctx context.Context, r io.Reader, opts ...Option) (*File, error) {
 	var err error
 
 	type namer interface {
@@ -40,8 +105,25 @@ func Open(r io.Reader) (*File, error) {
 		name = r.Name()
 	}
 
+	if cfg := newConfig(opts); cfg.lazy || cfg.workers > 1 || cfg.lazyLarge {
+		if r, ok := r.(readSeekerAt); ok {
+			f, err := openSeekable(ctx, r, opts, cfg)
+			if f != nil || err != nil {
+				if f != nil {
+					f.name = name
+				}
+				return f, err
+			}
+		}
+	}
+
+	r, err = uncompressedReader(r)
+	if err != nil {
+		return nil, err
+	}
+
 	f := &File{
-		dec:  NewDecoder(r),
+		dec:  NewDecoder(r, opts...),
 		name: name,
 		mode: ReadOnly,
 		hdus: make([]HDU, 0, 1),
@@ -49,22 +131,107 @@ func Open(r io.Reader) (*File, error) {
 
 	for {
 		var hdu HDU
-		hdu, err = f.dec.DecodeHDU()
+		hdu, err = decodeHDU(ctx, f.dec)
 		if err != nil {
 			if err != io.EOF {
-				return nil, err
+				return nil, fmt.Errorf("fitsio: could not decode HDU #%d: %w", len(f.hdus), err)
 			}
 			err = nil
 			break
 		}
 		f.hdus = append(f.hdus, hdu)
+		f.offs = appendOffsets(f.offs, f.dec)
 	}
 
 	return f, err
 }
 
+// offsetter is implemented by the decoders and encoders keeping track of
+// the location of the last HDU they processed.
+type offsetter interface {
+	offsets() HDUOffsets
+}
+
+// appendOffsets appends the location of the last HDU processed by v,
+// a Decoder or an Encoder, to offs.
+func appendOffsets(offs []HDUOffsets, v interface{}) []HDUOffsets {
+	o, ok := v.(offsetter)
+	if !ok {
+		return offs
+	}
+	return append(offs, o.offsets())
+}
+
+// OpenFile opens the named FITS file in read-only mode.
+//
+// The name may use the extended filename syntax described in package xname:
+// OpenFile returns the HDU selected by the name, or the first HDU of the
+// file if the name has no HDU selector.
+// Filters specified in the name are not applied.
+//
+// Unlike a File created with Open, closing the returned File closes
+// the underlying os.File.
+func OpenFile(name string) (*File, HDU, error) {
+	xn, err := xname.Parse(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := os.Open(xn.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := Open(r)
+	if err != nil {
+		r.Close()
+		return nil, nil, err
+	}
+	f.closer = r
+
+	hdu, err := f.selectHDU(xn.HDU)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return f, hdu, nil
+}
+
+// selectHDU returns the HDU described by sel, or the first HDU if sel is nil.
+func (f *File) selectHDU(sel *xname.HDU) (HDU, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	switch {
+	case len(f.hdus) == 0:
+		return nil, fmt.Errorf("fitsio: file has no HDU")
+	case sel == nil:
+		return f.hdus[0], nil
+	case sel.Index >= 0:
+		if sel.Index >= len(f.hdus) {
+			return nil, fmt.Errorf("fitsio: no HDU with index %d", sel.Index)
+		}
+		return f.hdus[sel.Index], nil
+	}
+
+	for _, hdu := range f.hdus {
+		if !strings.EqualFold(hdu.Name(), sel.Name) {
+			continue
+		}
+		if sel.Version > 0 && hdu.Version() != sel.Version {
+			continue
+		}
+		return hdu, nil
+	}
+	return nil, fmt.Errorf("fitsio: no HDU matching [%v]", sel)
+}
+
 // Create creates a new FITS file in write-only mode
-func Create(w io.Writer) (*File, error) {
+//
+// With the WithGzip option, the file is compressed with gzip and Close
+// must be called to flush it.
+func Create(w io.Writer, opts ...Option) (*File, error) {
 	var err error
 	type namer interface {
 		Name() string
@@ -74,24 +241,54 @@ func Create(w io.Writer) (*File, error) {
 		name = w.Name()
 	}
 
+	var zw *gzip.Writer
+	if cfg := newConfig(opts); cfg.gzip {
+		zw, err = gzip.NewWriterLevel(w, cfg.gzipLevel)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not create gzip stream: %w", err)
+		}
+		w = zw
+	}
+
 	f := &File{
-		enc:  NewEncoder(w),
+		enc:  NewEncoder(w, opts...),
 		name: name,
 		mode: WriteOnly,
 		hdus: make([]HDU, 0, 1),
 	}
+	if zw != nil {
+		f.zw = zw
+	}
 
 	return f, err
 }
 
 // Close releases resources held by a FITS file.
 //
-// It does not close the underlying io.Reader or io.Writer.
+// It does not close the underlying io.Reader or io.Writer, except for
+// files opened with OpenFile.
+// Files created WithGzip are flushed.
 func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var err error
+	if f.zw != nil {
+		err = f.zw.Close()
+		f.zw = nil
+	}
+	if f.closer != nil {
+		if e := f.closer.Close(); e != nil && err == nil {
+			err = e
+		}
+		f.closer = nil
+	}
 	f.enc = nil
 	f.dec = nil
 	f.hdus = nil
-	return nil
+	f.offs = nil
+	f.stubs = nil
+	return err
 }
 
 // Mode returns the access-mode of this FITS file
@@ -105,13 +302,77 @@ func (f *File) Name() string {
 }
 
 // HDUs returns the list of all Header-Data Unit blocks in the file
+//
+// For files opened with OpenReaderAt, HDUs reads the data units of all the
+// HDUs: HDUs which could not be read are nil.
 func (f *File) HDUs() []HDU {
-	return f.hdus
+	if f.ra != nil {
+		for i := range f.NumHDUs() {
+			_, _ = f.LoadHDU(i)
+		}
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.hdus[:len(f.hdus):len(f.hdus)]
+}
+
+// Images returns an iterator over the image HDUs of the file, including
+// the primary HDU.
+func (f *File) Images() iter.Seq[Image] {
+	return func(yield func(Image) bool) {
+		for _, hdu := range f.HDUs() {
+			img, ok := hdu.(Image)
+			if !ok {
+				continue
+			}
+			if !yield(img) {
+				return
+			}
+		}
+	}
+}
+
+// Offsets returns the location of each HDU in the stream underlying the
+// file, in file order: HDUs decoded by Open and HDUs written with Write.
+func (f *File) Offsets() []HDUOffsets {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return append([]HDUOffsets(nil), f.offs...)
+}
+
+// Bytes returns the serialization of all the HDUs of the file, as written
+// by a File created with Create and no option.
+// As with Write, the size-dependent cards of the headers of the HDUs are
+// updated.
+func (f *File) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := Create(&buf)
+	if err != nil {
+		return nil, err
+	}
+	for i := range f.NumHDUs() {
+		hdu, err := f.LoadHDU(i)
+		if err != nil {
+			return nil, err
+		}
+		err = w.Write(hdu)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not encode HDU #%d: %w", i, err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 // HDU returns the i-th HDU
+//
+// For files opened with OpenReaderAt, HDU returns nil if the HDU could not
+// be read. See LoadHDU.
 func (f *File) HDU(i int) HDU {
-	return f.hdus[i]
+	return f.hdu(i)
 }
 
 // Get returns the HDU with name `name` or nil
@@ -123,6 +384,38 @@ func (f *File) Get(name string) HDU {
 	return hdu
 }
 
+// GetVersioned returns the HDU with name `name` and version `ver` or nil.
+// HDUs without an EXTVER card have version 1.
+func (f *File) GetVersioned(name string, ver int) HDU {
+	i := f.find(func(hdu HDU) bool {
+		return hdu.Name() == name && hdu.Version() == ver
+	})
+	if i < 0 {
+		return nil
+	}
+	return f.hdu(i)
+}
+
+// GetAll returns all the HDUs with name `name`, in file order.
+func (f *File) GetAll(name string) []HDU {
+	f.mu.RLock()
+	var idx []int
+	for i := range f.hdus {
+		if f.peek(i).Name() == name {
+			idx = append(idx, i)
+		}
+	}
+	f.mu.RUnlock()
+
+	var hdus []HDU
+	for _, i := range idx {
+		if hdu := f.hdu(i); hdu != nil {
+			hdus = append(hdus, hdu)
+		}
+	}
+	return hdus
+}
+
 // Has returns whether the File has a HDU with name `name`.
 func (f *File) Has(name string) bool {
 	i, _ := f.gethdu(name)
@@ -134,22 +427,82 @@ func (f *File) Has(name string) bool {
 
 // get returns the index and HDU of HDU with name `name`.
 func (f *File) gethdu(name string) (int, HDU) {
-	for i, hdu := range f.hdus {
-		if hdu.Name() == name {
-			return i, hdu
+	i := f.find(func(hdu HDU) bool { return hdu.Name() == name })
+	if i < 0 {
+		return -1, nil
+	}
+	hdu := f.hdu(i)
+	if hdu == nil {
+		return -1, nil
+	}
+	return i, hdu
+}
+
+// find returns the index of the first HDU matching the predicate, or -1.
+// The HDUs of files opened with OpenReaderAt are matched against their
+// header, without reading their data.
+func (f *File) find(match func(hdu HDU) bool) int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for i := range f.hdus {
+		if match(f.peek(i)) {
+			return i
 		}
 	}
-	return -1, nil
+	return -1
 }
 
 // Write writes a HDU to file
 func (f *File) Write(hdu HDU) error {
+	return f.WriteContext(context.Background(), hdu)
+}
+
+// WriteContext is like Write, but aborts the encoding of the HDU
+// when ctx is done, returning the context error.
+// The HDU is not added to the file if the encoding was aborted, but
+// the underlying io.Writer may hold a partially written HDU.
+func (f *File) WriteContext(ctx context.Context, hdu HDU) error {
 	var err error
 	if f.mode != WriteOnly && f.mode != ReadWrite {
 		return fmt.Errorf("fitsio: file not open for write")
 	}
 
-	if len(f.hdus) == 0 {
+	f.wmu.Lock()
+	defer f.wmu.Unlock()
+
+	if f.sw != nil {
+		return fmt.Errorf("fitsio: an HDU is being written with a %T", f.sw)
+	}
+
+	err = f.prepare(hdu)
+	if err != nil {
+		return err
+	}
+
+	err = encodeHDU(ctx, f.enc, hdu)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	err = f.append(hdu)
+	if err == nil {
+		f.offs = appendOffsets(f.offs, f.enc)
+	}
+	f.mu.Unlock()
+	return err
+}
+
+// prepare sets the mandatory cards of hdu before it is written to the file,
+// as the primary HDU if the file holds no HDU yet, or as an extension.
+// f.wmu must be held.
+func (f *File) prepare(hdu HDU) error {
+	var err error
+	f.mu.RLock()
+	nhdus := len(f.hdus)
+	f.mu.RUnlock()
+
+	if nhdus == 0 {
 		if hdu.Type() != IMAGE_HDU {
 			return fmt.Errorf("fitsio: file has no primary header. create one first")
 		}
@@ -165,7 +518,30 @@ func (f *File) Write(hdu HDU) error {
 				return err
 			}
 		}
+
+		switch card := hdr.Get("EXTEND"); {
+		case card == nil:
+			err = hdr.setMandatory(Card{
+				Name:    "EXTEND",
+				Value:   true,
+				Comment: "FITS dataset may contain extensions",
+			})
+			if err != nil {
+				return err
+			}
+		default:
+			if _, ok := card.Value.(bool); !ok {
+				return fmt.Errorf("fitsio: invalid EXTEND value (%v)", card.Value)
+			}
+		}
 	} else {
+		f.mu.RLock()
+		phdr := f.hdus[0].Header()
+		f.mu.RUnlock()
+		if card := phdr.Get("EXTEND"); card != nil && card.Value == false {
+			return fmt.Errorf("fitsio: primary HDU does not allow extensions (EXTEND=F)")
+		}
+
 		switch hdu.Type() {
 		case IMAGE_HDU:
 			img := hdu.(Image)
@@ -182,21 +558,11 @@ func (f *File) Write(hdu HDU) error {
 			}
 		}
 	}
-
-	err = f.enc.EncodeHDU(hdu)
-	if err != nil {
-		return err
-	}
-
-	err = f.append(hdu)
-	if err != nil {
-		return err
-	}
-
-	return err
+	return nil
 }
 
 // append appends an HDU to the list of Header-Data Unit blocks.
+// f.mu must be held for writing.
 func (f *File) append(hdu HDU) error {
 	var err error
 	if f.mode != WriteOnly && f.mode != ReadWrite {
@@ -5,8 +5,16 @@
 package fitsio
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -236,3 +244,701 @@ func TestCreateFile(t *testing.T) {
 		t.Fatalf("#hdus. expected %v. got %v", 0, len(f.HDUs()))
 	}
 }
+
+func TestOpenFileName(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		htype HDUType
+		hname string
+		err   bool
+	}{
+		{name: "testdata/swp06542llg.fits", htype: IMAGE_HDU, hname: "PRIMARY"},
+		{name: "testdata/swp06542llg.fits[0]", htype: IMAGE_HDU, hname: "PRIMARY"},
+		{name: "testdata/swp06542llg.fits[1]", htype: BINARY_TBL, hname: "IUE MELO"},
+		{name: "testdata/swp06542llg.fits+1", htype: BINARY_TBL, hname: "IUE MELO"},
+		{name: "testdata/swp06542llg.fits[1][col NET]", htype: BINARY_TBL, hname: "IUE MELO"},
+		{name: "testdata/file001.fits[primary]", htype: IMAGE_HDU, hname: "PRIMARY"},
+		{name: "testdata/swp06542llg.fits[2]", err: true},
+		{name: "testdata/swp06542llg.fits[EVENTS]", err: true},
+		{name: "testdata/not-there.fits", err: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			f, hdu, err := OpenFile(test.name)
+			switch {
+			case err != nil && !test.err:
+				t.Fatalf("could not open file: %v", err)
+			case err == nil && test.err:
+				f.Close()
+				t.Fatalf("expected an error")
+			case err != nil:
+				return
+			}
+			defer f.Close()
+
+			if got, want := hdu.Type(), test.htype; got != want {
+				t.Fatalf("invalid HDU type. got=%v, want=%v", got, want)
+			}
+			if got, want := hdu.Name(), test.hname; got != want {
+				t.Fatalf("invalid HDU name. got=%q, want=%q", got, want)
+			}
+
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close file: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetVersioned(t *testing.T) {
+	newHDU := func(name string, ver int) HDU {
+		img := NewImage(8, []int{1})
+		if name != "" {
+			img.Header().Set("EXTNAME", name, "")
+		}
+		if ver > 0 {
+			img.Header().Set("EXTVER", ver, "")
+		}
+		return img
+	}
+
+	f := &File{
+		hdus: []HDU{
+			newHDU("", 0),
+			newHDU("SCI", 1),
+			newHDU("ERR", 1),
+			newHDU("SCI", 2),
+			newHDU("ERR", 2),
+			newHDU("DQ", 0),
+		},
+	}
+
+	for _, test := range []struct {
+		name string
+		ver  int
+		want HDU
+	}{
+		{name: "SCI", ver: 1, want: f.hdus[1]},
+		{name: "SCI", ver: 2, want: f.hdus[3]},
+		{name: "ERR", ver: 2, want: f.hdus[4]},
+		{name: "DQ", ver: 1, want: f.hdus[5]},
+		{name: "SCI", ver: 3, want: nil},
+		{name: "sci", ver: 1, want: nil},
+	} {
+		got := f.GetVersioned(test.name, test.ver)
+		if got != test.want {
+			t.Errorf("GetVersioned(%q, %d): got %v, want %v", test.name, test.ver, got, test.want)
+		}
+	}
+
+	if got, want := len(f.GetAll("SCI")), 2; got != want {
+		t.Fatalf("invalid number of SCI HDUs. got=%d, want=%d", got, want)
+	}
+	if got := f.GetAll("SCI"); got[0] != f.hdus[1] || got[1] != f.hdus[3] {
+		t.Fatalf("invalid SCI HDUs order")
+	}
+	if got := f.GetAll("NONE"); got != nil {
+		t.Fatalf("expected no HDU. got %v", got)
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	r, err := os.Open("testdata/swp06542llg.fits")
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer r.Close()
+
+	f, err := Open(r)
+	if err != nil {
+		t.Fatalf("could not open FITS file: %v", err)
+	}
+	defer f.Close()
+
+	tbl := f.HDU(1).(*Table)
+	readAll := func() ([]map[string]interface{}, error) {
+		rows, err := tbl.Read(0, tbl.NumRows())
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		var data []map[string]interface{}
+		for rows.Next() {
+			row := make(map[string]interface{})
+			err = rows.Scan(&row)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, row)
+		}
+		return data, rows.Err()
+	}
+
+	want, err := readAll()
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+
+	out, err := Create(ioutil.Discard)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	defer out.Close()
+
+	const n = 8
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, 2*n)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			got, err := readAll()
+			if err != nil {
+				errs <- err
+				return
+			}
+			if !reflect.DeepEqual(got, want) {
+				errs <- fmt.Errorf("invalid table content")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for _, hdu := range out.HDUs() {
+				_ = out.Get(hdu.Name())
+			}
+		}()
+	}
+	for _, hdu := range f.HDUs() {
+		err = out.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent read: %v", err)
+	}
+
+	if got, want := len(out.HDUs()), len(f.HDUs()); got != want {
+		t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+	}
+}
+
+func TestFileImages(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := Create(&buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	tbl, err := NewTable("tbl", []Column{{Name: "x", Format: "D"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	img := NewImage(8, []int{2, 2})
+	err = img.Header().Append(Card{Name: "EXTNAME", Value: "img"})
+	if err != nil {
+		t.Fatalf("could not append card: %v", err)
+	}
+	err = img.Write([]byte{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	for _, hdu := range []HDU{NewImage(8, nil), tbl, img} {
+		err = f.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+
+	var names []string
+	for img := range f.Images() {
+		names = append(names, img.Name())
+	}
+	if got, want := names, []string{"", "img"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid images.\ngot= %q\nwant=%q", got, want)
+	}
+
+	for img := range f.Images() {
+		if img.Type() != IMAGE_HDU {
+			t.Fatalf("invalid HDU type: %v", img.Type())
+		}
+		break
+	}
+}
+
+func TestFileOffsets(t *testing.T) {
+	raw, err := os.ReadFile("testdata/swp06542llg.fits")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	check := func(f *File, size int64) {
+		t.Helper()
+		offs := f.Offsets()
+		if got, want := len(offs), len(f.HDUs()); got != want {
+			t.Fatalf("invalid number of offsets: got=%d, want=%d", got, want)
+		}
+		beg := int64(0)
+		for i, off := range offs {
+			if off.Header != beg {
+				t.Fatalf("HDU #%d: invalid header offset: got=%d, want=%d", i, off.Header, beg)
+			}
+			if n := off.Data - off.Header; n <= 0 || n%BlockSize != 0 {
+				t.Fatalf("HDU #%d: invalid header size: %d", i, n)
+			}
+			if got, want := off.End-off.Data, int64(AlignBlock(int(f.HDU(i).DataSize()))); got != want {
+				t.Fatalf("HDU #%d: invalid data unit size: got=%d, want=%d", i, got, want)
+			}
+			beg = off.End
+		}
+		if beg != size {
+			t.Fatalf("invalid end of file: got=%d, want=%d", beg, size)
+		}
+	}
+
+	f, err := Open(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+	check(f, int64(len(raw)))
+
+	var buf bytes.Buffer
+	w, err := Create(&buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	defer w.Close()
+	for _, hdu := range f.HDUs() {
+		err = w.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	check(w, int64(buf.Len()))
+
+	// truncated data unit of the last HDU.
+	offs := f.Offsets()
+	last := offs[len(offs)-1]
+	_, err = Open(bytes.NewReader(raw[:last.End-100]))
+	var trunc *ErrTruncatedHDU
+	if !errors.As(err, &trunc) {
+		t.Fatalf("invalid error: got=%v (%T), want=%T", err, err, trunc)
+	}
+	want := ErrTruncatedHDU{
+		HDU:    len(offs) - 1,
+		Offset: last.Data,
+		Want:   last.End - last.Data,
+		Got:    last.End - last.Data - 100,
+	}
+	if *trunc != want {
+		t.Fatalf("invalid error:\ngot= %+v\nwant=%+v", *trunc, want)
+	}
+	if !errors.Is(err, ErrShortData) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("error does not wrap ErrShortData and io.ErrUnexpectedEOF: %v", err)
+	}
+}
+
+func TestOpenCompressed(t *testing.T) {
+	raw, err := os.ReadFile("testdata/file001.fits")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+	ref, err := Open(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer ref.Close()
+
+	var gz bytes.Buffer
+	w, err := Create(&gz, WithGzip(gzip.BestCompression))
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for _, hdu := range ref.HDUs() {
+		err = w.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+	if !bytes.HasPrefix(gz.Bytes(), gzipMagic) {
+		t.Fatalf("file is not gzip-compressed")
+	}
+
+	bz2, err := os.ReadFile("testdata/file001.fits.bz2")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{"gzip", gz.Bytes()},
+		{"bzip2", bz2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := Open(bytes.NewReader(tc.data))
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			defer f.Close()
+
+			if got, want := len(f.HDUs()), len(ref.HDUs()); got != want {
+				t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+			}
+			for i, hdu := range f.HDUs() {
+				if got, want := hdu.Header().Text(), ref.HDU(i).Header().Text(); got != want {
+					t.Fatalf("HDU #%d: invalid header:\ngot:\n%s\nwant:\n%s", i, got, want)
+				}
+			}
+		})
+	}
+
+	_, err = Open(bytes.NewReader(gz.Bytes()[:gz.Len()/2]))
+	if err == nil {
+		t.Fatalf("expected an error opening a truncated gzip file")
+	}
+}
+
+// countingReaderAt counts the bytes read from an io.ReaderAt.
+type countingReaderAt struct {
+	r io.ReaderAt
+	n int64
+}
+
+func (r *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := r.r.ReadAt(p, off)
+	r.n += int64(n)
+	return n, err
+}
+
+func TestOpenReaderAt(t *testing.T) {
+	for _, fname := range []string{
+		"testdata/file-img2-bitpix-64.fits",
+		"testdata/swp06542llg.fits",
+		"testdata/file001.fits",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			raw, err := os.ReadFile(fname)
+			if err != nil {
+				t.Fatalf("could not read file: %v", err)
+			}
+			ref, err := Open(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			defer ref.Close()
+
+			ra := &countingReaderAt{r: bytes.NewReader(raw)}
+			f, err := OpenReaderAt(ra, int64(len(raw)))
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			defer f.Close()
+
+			if got, want := f.NumHDUs(), len(ref.HDUs()); got != want {
+				t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+			}
+			if got, want := f.Offsets(), ref.Offsets(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid offsets:\ngot= %v\nwant=%v", got, want)
+			}
+
+			var hdrsz int64
+			for i, off := range f.Offsets() {
+				hdrsz += off.Data - off.Header
+				if got, want := f.Header(i).Text(), ref.HDU(i).Header().Text(); got != want {
+					t.Fatalf("HDU #%d: invalid header:\ngot:\n%s\nwant:\n%s", i, got, want)
+				}
+			}
+			if ra.n != hdrsz {
+				t.Fatalf("invalid number of bytes read: got=%d, want=%d", ra.n, hdrsz)
+			}
+
+			last := f.NumHDUs() - 1
+			name := ref.HDU(last).Name()
+			hdu := f.Get(name)
+			if hdu == nil {
+				t.Fatalf("could not get HDU %q", name)
+			}
+			if got, want := ra.n, hdrsz+f.Offsets()[last].End-f.Offsets()[last].Header; got != want {
+				t.Fatalf("invalid number of bytes read: got=%d, want=%d", got, want)
+			}
+			if f.HDU(last) != hdu || f.GetAll(name)[0] != hdu {
+				t.Fatalf("HDU was loaded twice")
+			}
+
+			for i, hdu := range f.HDUs() {
+				want := ref.HDU(i)
+				if got, want := hdu.Header().Text(), want.Header().Text(); got != want {
+					t.Fatalf("HDU #%d: invalid header", i)
+				}
+				if got, want := hdu.DataSize(), want.DataSize(); got != want {
+					t.Fatalf("HDU #%d: invalid data size: got=%d, want=%d", i, got, want)
+				}
+				if img, ok := hdu.(Image); ok && !bytes.Equal(img.Raw(), want.(Image).Raw()) {
+					t.Fatalf("HDU #%d: invalid pixels", i)
+				}
+			}
+			if got, want := ra.n, int64(len(raw))+hdrsz; got > want {
+				t.Fatalf("invalid number of bytes read: got=%d, want<=%d", got, want)
+			}
+
+			_, err = OpenReaderAt(bytes.NewReader(raw), int64(len(raw)-BlockSize))
+			var terr *ErrTruncatedHDU
+			if !errors.As(err, &terr) {
+				t.Fatalf("invalid error opening a truncated file: %v", err)
+			}
+		})
+	}
+}
+
+func TestOpenConcurrency(t *testing.T) {
+	for _, fname := range []string{
+		"testdata/file-img2-bitpix-64.fits",
+		"testdata/swp06542llg.fits",
+		"testdata/file001.fits",
+		"testdata/file001.fits.bz2",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			raw, err := os.ReadFile(fname)
+			if err != nil {
+				t.Fatalf("could not read file: %v", err)
+			}
+			ref, err := Open(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			defer ref.Close()
+
+			for _, opt := range []Option{WithConcurrency(4), WithLazyData()} {
+				f, err := Open(bytes.NewReader(raw), opt)
+				if err != nil {
+					t.Fatalf("could not open file: %v", err)
+				}
+				defer f.Close()
+
+				if got, want := f.NumHDUs(), len(ref.HDUs()); got != want {
+					t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+				}
+				if got, want := f.Offsets(), ref.Offsets(); !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid offsets:\ngot= %v\nwant=%v", got, want)
+				}
+				for i, hdu := range f.HDUs() {
+					want := ref.HDU(i)
+					if got, want := hdu.Header().Text(), want.Header().Text(); got != want {
+						t.Fatalf("HDU #%d: invalid header", i)
+					}
+					if got, want := hdu.DataSize(), want.DataSize(); got != want {
+						t.Fatalf("HDU #%d: invalid data size: got=%d, want=%d", i, got, want)
+					}
+					if img, ok := hdu.(Image); ok && !bytes.Equal(img.Raw(), want.(Image).Raw()) {
+						t.Fatalf("HDU #%d: invalid pixels", i)
+					}
+				}
+			}
+		})
+	}
+
+	// the data units are read on demand.
+	raw, err := os.ReadFile("testdata/file001.fits")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+	f, err := Open(bytes.NewReader(raw), WithLazyData())
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+	f.mu.RLock()
+	loaded := f.hdus[1] != nil
+	f.mu.RUnlock()
+	if loaded {
+		t.Fatalf("data unit of HDU #1 was read eagerly")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = OpenContext(ctx, bytes.NewReader(raw), WithConcurrency(4))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("invalid error opening with a canceled context: %v", err)
+	}
+}
+
+func TestOpenBytes(t *testing.T) {
+	for _, fname := range []string{
+		"testdata/file-img2-bitpix+16.fits",
+		"testdata/swp06542llg.fits",
+		"testdata/file001.fits",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			raw, err := os.ReadFile(fname)
+			if err != nil {
+				t.Fatalf("could not read file: %v", err)
+			}
+			f, err := OpenBytes(raw)
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			defer f.Close()
+
+			b1, err := f.Bytes()
+			if err != nil {
+				t.Fatalf("could not serialize file: %v", err)
+			}
+			if len(b1)%BlockSize != 0 {
+				t.Fatalf("invalid size %d", len(b1))
+			}
+
+			g, err := OpenBytes(b1)
+			if err != nil {
+				t.Fatalf("could not open serialized file: %v", err)
+			}
+			defer g.Close()
+
+			b2, err := g.Bytes()
+			if err != nil {
+				t.Fatalf("could not serialize file: %v", err)
+			}
+			if !bytes.Equal(b1, b2) {
+				t.Fatalf("round trip is not stable")
+			}
+			for i, hdu := range g.HDUs() {
+				want := f.HDU(i)
+				if got, want := hdu.DataSize(), want.DataSize(); got != want {
+					t.Fatalf("HDU #%d: invalid data size: got=%d, want=%d", i, got, want)
+				}
+				if img, ok := hdu.(Image); ok && !bytes.Equal(img.Raw(), want.(Image).Raw()) {
+					t.Fatalf("HDU #%d: invalid pixels", i)
+				}
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	w, err := Create(&buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	img := NewImage(16, []int{3, 2})
+	err = img.Write([]int16{1, 2, 3, 4, 5, 6})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	err = w.Write(img)
+	if err != nil {
+		t.Fatalf("could not write HDU: %v", err)
+	}
+	got, err := w.Bytes()
+	if err != nil {
+		t.Fatalf("could not serialize file: %v", err)
+	}
+	if !bytes.Equal(got, buf.Bytes()) {
+		t.Fatalf("serialized file differs from written file")
+	}
+}
+
+func TestMandatoryCards(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := Create(&buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	defer w.Close()
+
+	err = w.Write(NewImage(8, []int{2, 3}))
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %v", err)
+	}
+	err = w.Write(NewImage(16, []int{4}))
+	if err != nil {
+		t.Fatalf("could not write image extension: %v", err)
+	}
+	tbl, err := NewTable("tbl", []Column{{Name: "x", Format: "1J"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	err = w.Write(tbl)
+	if err != nil {
+		t.Fatalf("could not write table: %v", err)
+	}
+
+	for i, want := range [][]string{
+		{"SIMPLE", "BITPIX", "NAXIS", "NAXIS1", "NAXIS2", "EXTEND"},
+		{"XTENSION", "BITPIX", "NAXIS", "NAXIS1", "PCOUNT", "GCOUNT"},
+		{"XTENSION", "BITPIX", "NAXIS", "NAXIS1", "NAXIS2", "PCOUNT", "GCOUNT"},
+	} {
+		keys := w.HDU(i).Header().Keys()
+		if got := keys[:len(want)]; !reflect.DeepEqual(got, want) {
+			t.Fatalf("HDU #%d: invalid keys:\ngot= %q\nwant=%q", i, got, want)
+		}
+	}
+	if got := w.HDU(0).Header().Get("EXTEND").Value; got != true {
+		t.Fatalf("invalid EXTEND value: %v", got)
+	}
+
+	for _, tc := range []struct {
+		name string
+		hdus func() []HDU
+	}{
+		{
+			name: "extend-type",
+			hdus: func() []HDU {
+				img := NewImage(8, nil)
+				img.Header().Set("EXTEND", "yes", "")
+				return []HDU{img}
+			},
+		},
+		{
+			name: "extend-false",
+			hdus: func() []HDU {
+				img := NewImage(8, nil)
+				img.Header().Set("EXTEND", false, "")
+				return []HDU{img, NewImage(8, []int{1})}
+			},
+		},
+		{
+			name: "gcount",
+			hdus: func() []HDU {
+				img := NewImage(8, []int{1})
+				img.Header().Set("GCOUNT", 2, "")
+				return []HDU{NewImage(8, nil), img}
+			},
+		},
+		{
+			name: "pcount",
+			hdus: func() []HDU {
+				img := NewImage(8, []int{1})
+				img.Header().Set("PCOUNT", 10, "")
+				return []HDU{NewImage(8, nil), img}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w, err := Create(io.Discard)
+			if err != nil {
+				t.Fatalf("could not create file: %v", err)
+			}
+			defer w.Close()
+			hdus := tc.hdus()
+			for _, hdu := range hdus[:len(hdus)-1] {
+				err = w.Write(hdu)
+				if err != nil {
+					t.Fatalf("could not write HDU: %v", err)
+				}
+			}
+			err = w.Write(hdus[len(hdus)-1])
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// selectRows returns a new table with the schema of t, holding the rows of t
+// for which keep is true.
+// The non-structural keywords of the header of t are carried over.
+func selectRows(t *Table, keep []bool) (*Table, error) {
+	if int64(len(keep)) != t.NumRows() {
+		return nil, fmt.Errorf("fitsio: row selection size mismatch (got=%d, want=%d)", len(keep), t.NumRows())
+	}
+
+	htype := t.Type()
+	cols := make([]Column, len(t.cols))
+	for i := range t.cols {
+		cols[i] = schemaColumn(t, i, htype)
+	}
+	out, err := NewTable(t.Name(), cols, htype)
+	if err != nil {
+		return nil, err
+	}
+
+	err = copyUserCards(&out.hdr, &t.hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	copyRange := func(beg, end int64) error {
+		if !sameLayout(out, t) {
+			return copyRowsRange(out, t, beg, end)
+		}
+		return CopyTableRange(out, t, beg, end)
+	}
+
+	// copy contiguous ranges of selected rows.
+	beg := int64(-1)
+	for i, ok := range keep {
+		irow := int64(i)
+		switch {
+		case ok && beg < 0:
+			beg = irow
+		case !ok && beg >= 0:
+			err = copyRange(beg, irow)
+			if err != nil {
+				return nil, err
+			}
+			beg = -1
+		}
+	}
+	if beg >= 0 {
+		err = copyRange(beg, int64(len(keep)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// copyRowsRange copies the rows [beg, end) of src into dst, value by value.
+// dst and src must have the same columns.
+func copyRowsRange(dst, src *Table, beg, end int64) error {
+	rows, err := src.Read(beg, end)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	ptrs := make([]interface{}, len(src.cols))
+	for i := range src.cols {
+		ptrs[i] = reflect.New(src.cols[i].Type()).Interface()
+	}
+
+	for rows.Next() {
+		err = rows.Scan(ptrs...)
+		if err != nil {
+			return err
+		}
+		err = dst.Write(ptrs...)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// copyUserCards appends to dst the cards of src which neither describe
+// the structure of the HDU nor are already defined in dst.
+func copyUserCards(dst, src *Header) error {
+	cards := make([]Card, 0, len(src.cards))
+	for _, card := range src.cards {
+		if isStructuralKey(card.Name) {
+			continue
+		}
+		switch card.Name {
+		case "COMMENT", "HISTORY", "":
+			// commentary cards may be repeated.
+		default:
+			if dst.Get(card.Name) != nil {
+				continue
+			}
+		}
+		cards = append(cards, card)
+	}
+	return dst.Append(cards...)
+}
+
+// isStructuralKey returns whether the keyword describes the layout of
+// the data of an HDU.
+func isStructuralKey(name string) bool {
+	switch name {
+	case "SIMPLE", "XTENSION", "BITPIX", "EXTEND", "PCOUNT", "GCOUNT", "TFIELDS", "THEAP", "END":
+		return true
+	}
+	return strings.HasPrefix(name, "NAXIS") || strings.HasPrefix(name, "TBCOL")
+}
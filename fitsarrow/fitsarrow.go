@@ -0,0 +1,438 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fitsarrow converts FITS tables to and from Apache Arrow records.
+//
+// Scalar columns are converted to primitive arrays (L to booleans, B, I, J
+// and K to integers, E and D to floating-point numbers, A to strings),
+// fixed-size array columns to fixed-size lists, nested along the TDIM
+// dimensions of the column, and variable length array columns to lists.
+// Unsigned integers and signed bytes, stored with a TZERO offset, are
+// converted to the corresponding Arrow integers.
+//
+// The records can then be written to Parquet files with the pqarrow
+// package of the Arrow module.
+//
+// fitsarrow lives in its own Go module, so that the fitsio package does not
+// depend on Arrow.
+package fitsarrow // import "github.com/astrogo/fitsio/fitsarrow"
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	fits "github.com/astrogo/fitsio"
+)
+
+// Metadata keys of the Arrow schemas and fields created by ToArrow, and
+// read by FromArrow.
+const (
+	NameKey    = "EXTNAME" // name of the table, in the schema metadata
+	UnitKey    = "TUNIT"   // unit of a column, in the field metadata
+	DisplayKey = "TDISP"   // display format of a column, in the field metadata
+)
+
+// ToArrow converts the table tbl to an Arrow record, with one array per
+// column of the table.
+// Undefined values of the table (NaN floating-point numbers, or the TNULL
+// value of integer columns) are converted as is.
+// Complex columns can not be converted.
+//
+// The returned record must be released after use.
+func ToArrow(tbl *fits.Table) (arrow.Record, error) {
+	var (
+		mem    = memory.DefaultAllocator
+		fields = make([]arrow.Field, tbl.NumCols())
+		arrs   = make([]arrow.Array, 0, tbl.NumCols())
+	)
+	defer func() {
+		for _, arr := range arrs {
+			arr.Release()
+		}
+	}()
+
+	for i, col := range tbl.Cols() {
+		rows := reflect.New(reflect.SliceOf(col.Type()))
+		err := tbl.ReadColumn(i, rows.Interface())
+		if err != nil {
+			return nil, err
+		}
+		arr, err := newArray(mem, &col, rows.Elem())
+		if err != nil {
+			return nil, fmt.Errorf("fitsarrow: could not convert column %q: %w", col.Name, err)
+		}
+		arrs = append(arrs, arr)
+
+		var keys, vals []string
+		if col.Unit != "" {
+			keys = append(keys, UnitKey)
+			vals = append(vals, col.Unit)
+		}
+		if col.Display != "" {
+			keys = append(keys, DisplayKey)
+			vals = append(vals, col.Display)
+		}
+		fields[i] = arrow.Field{
+			Name:     col.Name,
+			Type:     arr.DataType(),
+			Metadata: arrow.NewMetadata(keys, vals),
+		}
+	}
+
+	meta := arrow.NewMetadata([]string{NameKey}, []string{tbl.Name()})
+	schema := arrow.NewSchema(fields, &meta)
+	return array.NewRecord(schema, arrs, tbl.NumRows()), nil
+}
+
+// newArray returns the Arrow array holding the values of the column col,
+// read into the slice rows.
+func newArray(mem memory.Allocator, col *fits.Column, rows reflect.Value) (arrow.Array, error) {
+	rt := col.Type()
+	switch rt.Kind() {
+	case reflect.Array:
+		n := rt.Len()
+		vs := reflect.MakeSlice(reflect.SliceOf(rt.Elem()), rows.Len()*n, rows.Len()*n)
+		for i := 0; i < rows.Len(); i++ {
+			reflect.Copy(vs.Slice(i*n, (i+1)*n), rows.Index(i))
+		}
+		leaf, err := newLeaf(mem, vs)
+		if err != nil {
+			return nil, err
+		}
+		defer leaf.Release()
+
+		dims := []int{n}
+		if len(col.Dim) > 1 {
+			dims = make([]int, len(col.Dim))
+			size := 1
+			for i, dim := range col.Dim {
+				dims[i] = int(dim)
+				size *= int(dim)
+			}
+			if size != n {
+				dims = []int{n}
+			}
+		}
+
+		data := leaf.Data()
+		data.Retain()
+		for _, dim := range dims {
+			list := array.NewData(
+				arrow.FixedSizeListOf(int32(dim), data.DataType()), data.Len()/dim,
+				[]*memory.Buffer{nil}, []arrow.ArrayData{data}, 0, 0,
+			)
+			data.Release()
+			data = list
+		}
+		defer data.Release()
+		return array.MakeFromData(data), nil
+
+	case reflect.Slice:
+		offsets := make([]int32, rows.Len()+1)
+		vs := reflect.MakeSlice(rt, 0, 0)
+		for i := 0; i < rows.Len(); i++ {
+			vs = reflect.AppendSlice(vs, rows.Index(i))
+			if vs.Len() > math.MaxInt32 {
+				return nil, fmt.Errorf("too many values for an Arrow list")
+			}
+			offsets[i+1] = int32(vs.Len())
+		}
+		leaf, err := newLeaf(mem, vs)
+		if err != nil {
+			return nil, err
+		}
+		defer leaf.Release()
+
+		data := array.NewData(
+			arrow.ListOf(leaf.DataType()), rows.Len(),
+			[]*memory.Buffer{nil, memory.NewBufferBytes(arrow.Int32Traits.CastToBytes(offsets))},
+			[]arrow.ArrayData{leaf.Data()}, 0, 0,
+		)
+		defer data.Release()
+		return array.MakeFromData(data), nil
+	}
+	return newLeaf(mem, rows)
+}
+
+// newLeaf returns the Arrow array holding the values of vs, a slice of
+// booleans, numbers or strings.
+func newLeaf(mem memory.Allocator, vs reflect.Value) (arrow.Array, error) {
+	switch vs := vs.Interface().(type) {
+	case []bool:
+		return build(array.NewBooleanBuilder(mem), vs), nil
+	case []int8:
+		return build(array.NewInt8Builder(mem), vs), nil
+	case []int16:
+		return build(array.NewInt16Builder(mem), vs), nil
+	case []int32:
+		return build(array.NewInt32Builder(mem), vs), nil
+	case []int64:
+		return build(array.NewInt64Builder(mem), vs), nil
+	case []uint8:
+		return build(array.NewUint8Builder(mem), vs), nil
+	case []uint16:
+		return build(array.NewUint16Builder(mem), vs), nil
+	case []uint32:
+		return build(array.NewUint32Builder(mem), vs), nil
+	case []uint64:
+		return build(array.NewUint64Builder(mem), vs), nil
+	case []float32:
+		return build(array.NewFloat32Builder(mem), vs), nil
+	case []float64:
+		return build(array.NewFloat64Builder(mem), vs), nil
+	case []string:
+		return build(array.NewStringBuilder(mem), vs), nil
+	}
+	return nil, fmt.Errorf("unsupported type %v", vs.Type().Elem())
+}
+
+// build appends the values vs to the builder b, and returns the built array.
+func build[T any, B interface {
+	array.Builder
+	AppendValues([]T, []bool)
+}](b B, vs []T) arrow.Array {
+	defer b.Release()
+	b.AppendValues(vs, nil)
+	return b.NewArray()
+}
+
+// FromArrow converts the Arrow record rec to a binary table.
+// The name of the table is the EXTNAME value of the schema metadata, and
+// the units and display formats of the columns are the TUNIT and TDISP
+// values of the field metadata.
+//
+// Fixed-size lists of numbers are converted to fixed-size array columns,
+// with a TDIM keyword if they are nested, and lists to variable length
+// array columns.
+// Strings are stored in columns wide enough to hold the longest of them.
+// Null values are converted to undefined values of boolean and
+// floating-point columns; they are rejected in the other columns.
+//
+// The returned table must be closed after use.
+func FromArrow(rec arrow.Record) (*fits.Table, error) {
+	schema := rec.Schema()
+	var (
+		cols   = make([]fits.Column, rec.NumCols())
+		values = make([]func(i int) (interface{}, error), rec.NumCols())
+	)
+	for j, field := range schema.Fields() {
+		var err error
+		cols[j], values[j], err = newColumn(field, rec.Column(j))
+		if err != nil {
+			return nil, fmt.Errorf("fitsarrow: could not convert field %q: %w", field.Name, err)
+		}
+	}
+
+	name, _ := schema.Metadata().GetValue(NameKey)
+	tbl, err := fits.NewTable(name, cols, fits.BINARY_TBL)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]interface{}, len(cols))
+	for i := 0; i < int(rec.NumRows()); i++ {
+		for j, value := range values {
+			args[j], err = value(i)
+			if err != nil {
+				tbl.Close()
+				return nil, fmt.Errorf("fitsarrow: could not convert field %q: %w", cols[j].Name, err)
+			}
+		}
+		err = tbl.Write(args...)
+		if err != nil {
+			tbl.Close()
+			return nil, err
+		}
+	}
+	return tbl, nil
+}
+
+// newColumn returns the table column converted from the Arrow field and
+// its array arr, and the function returning a pointer to the value of the
+// i-th row of the column.
+func newColumn(field arrow.Field, arr arrow.Array) (fits.Column, func(i int) (interface{}, error), error) {
+	col := fits.Column{Name: field.Name}
+	col.Unit, _ = field.Metadata.GetValue(UnitKey)
+	col.Display, _ = field.Metadata.GetValue(DisplayKey)
+
+	switch dt := field.Type.(type) {
+	case *arrow.FixedSizeListType:
+		var (
+			leaf = arr
+			dims []int64
+			n    = 1
+		)
+		for {
+			list, ok := leaf.(*array.FixedSizeList)
+			if !ok {
+				break
+			}
+			dim := list.DataType().(*arrow.FixedSizeListType).Len()
+			dims = append([]int64{int64(dim)}, dims...)
+			n *= int(dim)
+			leaf = list.ListValues()
+		}
+		code, zero, err := leafForm(leaf.DataType())
+		if err != nil || code == "A" {
+			return col, nil, fmt.Errorf("unsupported type %v", dt)
+		}
+		col.Format = fmt.Sprintf("%d%s", n, code)
+		col.Bzero = zero
+		if len(dims) > 1 {
+			col.Dim = dims
+		}
+
+		vs, err := leafValues(leaf)
+		if err != nil {
+			return col, nil, err
+		}
+		rt := reflect.ArrayOf(n, vs.Type().Elem())
+		return col, func(i int) (interface{}, error) {
+			if arr.IsNull(i) {
+				return nil, fmt.Errorf("null value at row %d", i)
+			}
+			// the values of nested fixed-size lists are contiguous.
+			beg := int64(i)
+			for cur := arr; cur != leaf; cur = cur.(*array.FixedSizeList).ListValues() {
+				beg, _ = cur.(*array.FixedSizeList).ValueOffsets(int(beg))
+			}
+			ptr := reflect.New(rt)
+			reflect.Copy(ptr.Elem(), vs.Slice(int(beg), int(beg)+n))
+			return ptr.Interface(), nil
+		}, nil
+
+	case *arrow.ListType:
+		list := arr.(*array.List)
+		code, zero, err := leafForm(dt.Elem())
+		if err != nil || code == "A" {
+			return col, nil, fmt.Errorf("unsupported type %v", dt)
+		}
+		col.Format = "Q" + code
+		col.Bzero = zero
+
+		vs, err := leafValues(list.ListValues())
+		if err != nil {
+			return col, nil, err
+		}
+		return col, func(i int) (interface{}, error) {
+			if arr.IsNull(i) {
+				return nil, fmt.Errorf("null value at row %d", i)
+			}
+			beg, end := list.ValueOffsets(i)
+			ptr := reflect.New(vs.Type())
+			ptr.Elem().Set(vs.Slice(int(beg), int(end)))
+			return ptr.Interface(), nil
+		}, nil
+	}
+
+	code, zero, err := leafForm(field.Type)
+	if err != nil {
+		return col, nil, err
+	}
+	col.Format = code
+	col.Bzero = zero
+	if code == "A" {
+		width := 1
+		for i := 0; i < arr.Len(); i++ {
+			width = max(width, len(arr.(*array.String).Value(i)))
+		}
+		col.Format = fmt.Sprintf("%dA", width)
+	}
+
+	vs, err := leafValues(arr)
+	if err != nil {
+		return col, nil, err
+	}
+	nullable := code == "L" || code == "E" || code == "D"
+	return col, func(i int) (interface{}, error) {
+		if arr.IsNull(i) {
+			if !nullable {
+				return nil, fmt.Errorf("null value at row %d", i)
+			}
+			return reflect.New(reflect.PointerTo(vs.Type().Elem())).Interface(), nil
+		}
+		return vs.Index(i).Addr().Interface(), nil
+	}, nil
+}
+
+// leafForm returns the TFORM type code and the TZERO offset of the column
+// storing values of the Arrow type dt.
+func leafForm(dt arrow.DataType) (string, float64, error) {
+	switch dt.ID() {
+	case arrow.BOOL:
+		return "L", 0, nil
+	case arrow.UINT8:
+		return "B", 0, nil
+	case arrow.INT8:
+		return "B", -128, nil
+	case arrow.INT16:
+		return "I", 0, nil
+	case arrow.UINT16:
+		return "I", 1 << 15, nil
+	case arrow.INT32:
+		return "J", 0, nil
+	case arrow.UINT32:
+		return "J", 1 << 31, nil
+	case arrow.INT64:
+		return "K", 0, nil
+	case arrow.UINT64:
+		return "K", 1 << 63, nil
+	case arrow.FLOAT32:
+		return "E", 0, nil
+	case arrow.FLOAT64:
+		return "D", 0, nil
+	case arrow.STRING:
+		return "A", 0, nil
+	}
+	return "", 0, fmt.Errorf("unsupported type %v", dt)
+}
+
+// leafValues returns the values of arr, an array of booleans, numbers or
+// strings, as a Go slice.
+// Null elements are only allowed in scalar columns, where they are handled
+// by the caller.
+func leafValues(arr arrow.Array) (reflect.Value, error) {
+	var vs interface{}
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		bs := make([]bool, arr.Len())
+		for i := range bs {
+			bs[i] = arr.Value(i)
+		}
+		vs = bs
+	case *array.Int8:
+		vs = arr.Int8Values()
+	case *array.Int16:
+		vs = arr.Int16Values()
+	case *array.Int32:
+		vs = arr.Int32Values()
+	case *array.Int64:
+		vs = arr.Int64Values()
+	case *array.Uint8:
+		vs = arr.Uint8Values()
+	case *array.Uint16:
+		vs = arr.Uint16Values()
+	case *array.Uint32:
+		vs = arr.Uint32Values()
+	case *array.Uint64:
+		vs = arr.Uint64Values()
+	case *array.Float32:
+		vs = arr.Float32Values()
+	case *array.Float64:
+		vs = arr.Float64Values()
+	case *array.String:
+		strs := make([]string, arr.Len())
+		for i := range strs {
+			strs[i] = arr.Value(i)
+		}
+		vs = strs
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %v", arr.DataType())
+	}
+	return reflect.ValueOf(vs), nil
+}
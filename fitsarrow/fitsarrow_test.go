@@ -0,0 +1,205 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsarrow
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	fits "github.com/astrogo/fitsio"
+)
+
+func TestRoundTrip(t *testing.T) {
+	tbl, err := fits.NewTable("events", []fits.Column{
+		{Name: "ID", Format: "J"},
+		{Name: "CHAN", Format: "I", Bzero: 1 << 15},
+		{Name: "FLUX", Format: "D", Unit: "Jy", Display: "F8.2"},
+		{Name: "NAME", Format: "8A"},
+		{Name: "OK", Format: "L"},
+		{Name: "IMG", Format: "6E", Dim: []int64{3, 2}},
+		{Name: "VLA", Format: "PJ"},
+	}, fits.BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	for i := 0; i < 3; i++ {
+		var (
+			id   = int32(i)
+			ch   = uint16(65535 - i)
+			flux = float64(i) + 0.5
+			name = []string{"a", "bb", "ccc"}[i]
+			ok   = i%2 == 0
+			img  [6]float32
+			vla  = make([]int32, i)
+		)
+		for j := range img {
+			img[j] = float32(10*i + j)
+		}
+		for j := range vla {
+			vla[j] = int32(i * j)
+		}
+		err = tbl.Write(&id, &ch, &flux, &name, &ok, &img, &vla)
+		if err != nil {
+			t.Fatalf("could not write row %d: %v", i, err)
+		}
+	}
+
+	rec, err := ToArrow(tbl)
+	if err != nil {
+		t.Fatalf("could not convert table: %v", err)
+	}
+	defer rec.Release()
+
+	if got, want := rec.NumRows(), int64(3); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	for i, want := range []arrow.DataType{
+		arrow.PrimitiveTypes.Int32,
+		arrow.PrimitiveTypes.Uint16,
+		arrow.PrimitiveTypes.Float64,
+		arrow.BinaryTypes.String,
+		arrow.FixedWidthTypes.Boolean,
+		arrow.FixedSizeListOf(2, arrow.FixedSizeListOf(3, arrow.PrimitiveTypes.Float32)),
+		arrow.ListOf(arrow.PrimitiveTypes.Int32),
+	} {
+		if got := rec.Schema().Field(i).Type; !arrow.TypeEqual(got, want) {
+			t.Errorf("invalid type of field %d: got=%v, want=%v", i, got, want)
+		}
+	}
+	if got, want := rec.Column(1).(*array.Uint16).Value(2), uint16(65533); got != want {
+		t.Fatalf("invalid CHAN value: got=%d, want=%d", got, want)
+	}
+	if got, want := rec.Column(5).String(), "[[[0 1 2] [3 4 5]] [[10 11 12] [13 14 15]] [[20 21 22] [23 24 25]]]"; got != want {
+		t.Fatalf("invalid IMG values:\ngot= %s\nwant=%s", got, want)
+	}
+	if got, ok := rec.Schema().Field(2).Metadata.GetValue(UnitKey); !ok || got != "Jy" {
+		t.Fatalf("invalid FLUX unit: %q", got)
+	}
+
+	out, err := FromArrow(rec)
+	if err != nil {
+		t.Fatalf("could not convert record: %v", err)
+	}
+	defer out.Close()
+
+	if got, want := out.Name(), tbl.Name(); got != want {
+		t.Fatalf("invalid table name: got=%q, want=%q", got, want)
+	}
+	if got, want := out.NumRows(), tbl.NumRows(); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	for i, col := range tbl.Cols() {
+		ocol := out.Col(i)
+		if ocol.Name != col.Name || ocol.Unit != col.Unit || ocol.Display != col.Display {
+			t.Errorf("invalid column %d: got=%+v, want=%+v", i, *ocol, col)
+		}
+		if ocol.Type() != col.Type() {
+			t.Errorf("invalid type of column %q: got=%v, want=%v", col.Name, ocol.Type(), col.Type())
+		}
+
+		var (
+			want = reflect.New(reflect.SliceOf(col.Type()))
+			got  = reflect.New(reflect.SliceOf(ocol.Type()))
+		)
+		err = tbl.ReadColumn(i, want.Interface())
+		if err != nil {
+			t.Fatalf("could not read column %q: %v", col.Name, err)
+		}
+		err = out.ReadColumn(i, got.Interface())
+		if err != nil {
+			t.Fatalf("could not read column %q: %v", col.Name, err)
+		}
+		if !reflect.DeepEqual(got.Elem().Interface(), want.Elem().Interface()) {
+			t.Errorf("invalid column %q:\ngot= %v\nwant=%v", col.Name, got.Elem(), want.Elem())
+		}
+	}
+	if got, want := out.Col(5).Dim, []int64{3, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid IMG dimensions: got=%v, want=%v", got, want)
+	}
+}
+
+func TestFromArrowNulls(t *testing.T) {
+	mem := memory.DefaultAllocator
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "X", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "OK", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: "N", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	}, nil)
+	bld := array.NewRecordBuilder(mem, schema)
+	defer bld.Release()
+
+	bld.Field(0).(*array.Float64Builder).AppendValues([]float64{1, 0}, []bool{true, false})
+	bld.Field(1).(*array.BooleanBuilder).AppendValues([]bool{true, false}, []bool{true, false})
+	bld.Field(2).(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+	rec := bld.NewRecord()
+	defer rec.Release()
+
+	tbl, err := FromArrow(rec)
+	if err != nil {
+		t.Fatalf("could not convert record: %v", err)
+	}
+	defer tbl.Close()
+
+	var xs []float64
+	err = tbl.ReadColumn(0, &xs)
+	if err != nil {
+		t.Fatalf("could not read column: %v", err)
+	}
+	if xs[0] != 1 || !math.IsNaN(xs[1]) {
+		t.Fatalf("invalid X values: %v", xs)
+	}
+
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read rows: %v", err)
+	}
+	defer rows.Close()
+	var oks []*bool
+	for rows.Next() {
+		var (
+			x  float64
+			ok *bool
+			n  int32
+		)
+		err = rows.Scan(&x, &ok, &n)
+		if err != nil {
+			t.Fatalf("could not scan row: %v", err)
+		}
+		oks = append(oks, ok)
+	}
+	if len(oks) != 2 || oks[0] == nil || !*oks[0] || oks[1] != nil {
+		t.Fatalf("invalid OK values: %v", oks)
+	}
+
+	bld.Field(0).(*array.Float64Builder).Append(1)
+	bld.Field(1).(*array.BooleanBuilder).Append(true)
+	bld.Field(2).(*array.Int32Builder).AppendNull()
+	rec = bld.NewRecord()
+	defer rec.Release()
+
+	_, err = FromArrow(rec)
+	if err == nil {
+		t.Fatalf("expected an error for a null integer")
+	}
+}
+
+func TestToArrowComplex(t *testing.T) {
+	tbl, err := fits.NewTable("cplx", []fits.Column{{Name: "Z", Format: "M"}}, fits.BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	_, err = ToArrow(tbl)
+	if err == nil {
+		t.Fatalf("expected an error for a complex column")
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitstest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/astrogo/fitsio"
+)
+
+// Update makes Golden write the golden files, instead of comparing them.
+// Tests may bind it to a command-line flag:
+//
+//	func init() {
+//		flag.BoolVar(&fitstest.Update, "update", false, "update golden files")
+//	}
+var Update = false
+
+// Diff returns the differences between the FITS files a and b, one
+// line per difference, or nil if they hold the same HDUs.
+//
+// Files are compared semantically: headers are compared card by card (see
+// fitsio.DiffHeaders), ignoring the cards whose keyword is listed in
+// ignore (such as "DATE" or "CHECKSUM"), and data units are compared by
+// value, ignoring their padding and the layout of the heap of binary
+// tables. NaN values are equal.
+func Diff(a, b []byte, ignore ...string) ([]string, error) {
+	fa, err := fitsio.Open(bytes.NewReader(a))
+	if err != nil {
+		return nil, fmt.Errorf("fitstest: could not open first file: %w", err)
+	}
+	defer fa.Close()
+	fb, err := fitsio.Open(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("fitstest: could not open second file: %w", err)
+	}
+	defer fb.Close()
+
+	var diffs []string
+	na, nb := len(fa.HDUs()), len(fb.HDUs())
+	if na != nb {
+		diffs = append(diffs, fmt.Sprintf("number of HDUs: %d -> %d", na, nb))
+	}
+	for i := range min(na, nb) {
+		ds, err := diffHDU(fa.HDU(i), fb.HDU(i), ignore)
+		if err != nil {
+			return nil, fmt.Errorf("fitstest: could not compare HDU #%d: %w", i, err)
+		}
+		for _, d := range ds {
+			diffs = append(diffs, fmt.Sprintf("HDU #%d: %s", i, d))
+		}
+	}
+	return diffs, nil
+}
+
+func diffHDU(a, b fitsio.HDU, ignore []string) ([]string, error) {
+	if a.Type() != b.Type() {
+		return []string{fmt.Sprintf("type: %v -> %v", a.Type(), b.Type())}, nil
+	}
+
+	var diffs []string
+	for _, d := range fitsio.DiffHeaders(a.Header(), b.Header()) {
+		if slices.Contains(ignore, d.Name) {
+			continue
+		}
+		diffs = append(diffs, d.String())
+	}
+
+	da, err := a.Data()
+	if err != nil {
+		return nil, err
+	}
+	db, err := b.Data()
+	if err != nil {
+		return nil, err
+	}
+	switch da := da.(type) {
+	case *fitsio.ImageData:
+		db := db.(*fitsio.ImageData)
+		if !slices.Equal(da.Axes, db.Axes) {
+			// already reported by the NAXISn cards.
+			break
+		}
+		if i := mismatch(reflect.ValueOf(da.Pixels), reflect.ValueOf(db.Pixels)); i >= 0 {
+			diffs = append(diffs, fmt.Sprintf("pixels differ from pixel #%d", i))
+		}
+	case *fitsio.TableData:
+		db := db.(*fitsio.TableData)
+		for i, name := range da.Names {
+			vb := db.Column(name)
+			if vb == nil {
+				// already reported by the TTYPEn cards.
+				continue
+			}
+			va := reflect.ValueOf(da.Columns[i])
+			if va.Type() != reflect.TypeOf(vb) {
+				// already reported by the TFORMn cards.
+				continue
+			}
+			if i := mismatch(va, reflect.ValueOf(vb)); i >= 0 {
+				diffs = append(diffs, fmt.Sprintf("column %q differs from row #%d", name, i))
+			}
+		}
+	default:
+		if !equal(reflect.ValueOf(da), reflect.ValueOf(db)) {
+			diffs = append(diffs, "data differ")
+		}
+	}
+	return diffs, nil
+}
+
+// mismatch returns the index of the first element differing between the
+// slices a and b, or -1 if they are equal.
+func mismatch(a, b reflect.Value) int {
+	for i := range min(a.Len(), b.Len()) {
+		if !equal(a.Index(i), b.Index(i)) {
+			return i
+		}
+	}
+	if a.Len() != b.Len() {
+		return min(a.Len(), b.Len())
+	}
+	return -1
+}
+
+// equal returns whether a and b are deeply equal, NaN values included.
+func equal(a, b reflect.Value) bool {
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		x, y := a.Float(), b.Float()
+		return x == y || math.IsNaN(x) && math.IsNaN(y)
+	case reflect.Complex64, reflect.Complex128:
+		x, y := a.Complex(), b.Complex()
+		return equal(reflect.ValueOf(real(x)), reflect.ValueOf(real(y))) &&
+			equal(reflect.ValueOf(imag(x)), reflect.ValueOf(imag(y)))
+	case reflect.Array, reflect.Slice:
+		return a.Len() == b.Len() && mismatch(a, b) < 0
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// Golden compares got, the content of a FITS file, with the golden file at
+// path, and reports the differences (see Diff) as errors of tb.
+// The golden file is written with got instead when Update is true.
+func Golden(tb testing.TB, path string, got []byte, ignore ...string) {
+	tb.Helper()
+
+	if Update {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, got, 0644)
+		}
+		if err != nil {
+			tb.Fatalf("fitstest: could not update golden file: %+v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			tb.Fatalf("fitstest: missing golden file %q (set fitstest.Update to create it)", path)
+		}
+		tb.Fatalf("fitstest: could not read golden file: %+v", err)
+	}
+	diffs, err := Diff(want, got, ignore...)
+	if err != nil {
+		tb.Fatalf("fitstest: could not compare with golden file %q: %+v", path, err)
+	}
+	if len(diffs) > 0 {
+		tb.Errorf("fitstest: FITS file differs from golden file %q:\n%s", path, strings.Join(diffs, "\n"))
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fitstest provides helpers for testing code reading or writing
+// FITS files: synthetic HDUs, semantic comparison of FITS files, and
+// golden files.
+//
+// A typical round-trip test builds HDUs, runs them through the code under
+// test, and compares the output with a golden file:
+//
+//	img := fitstest.NewImage(t, -32, 10, 20)
+//	tbl := fitstest.NewTable(t, fitsio.BINARY_TBL, 5)
+//	raw := fitstest.Encode(t, img, tbl)
+//	out := process(t, raw)
+//	fitstest.Golden(t, "testdata/process.fits", out)
+package fitstest
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/astrogo/fitsio"
+)
+
+// Bitpixes lists the BITPIX values of FITS images.
+var Bitpixes = []int{8, 16, 32, 64, -32, -64}
+
+// Forms lists the TFORMn values of the columns of the tables built by
+// NewTable, for each type of table: one column for each FITS data type,
+// fixed-size arrays, and variable length arrays of each data type.
+var Forms = map[fitsio.HDUType][]string{
+	fitsio.ASCII_TBL: {"A8", "I6", "F10.3", "E15.7", "D25.17"},
+	fitsio.BINARY_TBL: {
+		"L", "B", "I", "J", "K", "E", "D", "C", "M", "8A",
+		"3L", "3B", "3I", "3J", "3K", "3E", "3D", "3C", "3M",
+		"PL", "PB", "PI", "PJ", "PK", "PE", "PD", "PC", "PM",
+		"QJ", "QD",
+	},
+}
+
+// NewImage returns an image with bitpix bits per pixel and the given axes,
+// holding a deterministic pattern of pixels which covers the range of the
+// pixel type: negative values, large values and, for floating-point
+// images, fractional values.
+func NewImage(tb testing.TB, bitpix int, axes ...int) fitsio.Image {
+	tb.Helper()
+
+	n := 1
+	for _, dim := range axes {
+		n *= dim
+	}
+	if len(axes) == 0 {
+		n = 0
+	}
+
+	var pixels interface{}
+	switch bitpix {
+	case 8:
+		vs := make([]uint8, n)
+		for i := range vs {
+			vs[i] = uint8(i * 37)
+		}
+		pixels = vs
+	case 16:
+		vs := make([]int16, n)
+		for i := range vs {
+			vs[i] = int16(pattern(i) * math.MaxInt16)
+		}
+		pixels = vs
+	case 32:
+		vs := make([]int32, n)
+		for i := range vs {
+			vs[i] = int32(pattern(i) * math.MaxInt32)
+		}
+		pixels = vs
+	case 64:
+		vs := make([]int64, n)
+		for i := range vs {
+			vs[i] = int64(pattern(i) * (1 << 62))
+		}
+		pixels = vs
+	case -32:
+		vs := make([]float32, n)
+		for i := range vs {
+			vs[i] = float32(pattern(i) * 1e6)
+		}
+		pixels = vs
+	case -64:
+		vs := make([]float64, n)
+		for i := range vs {
+			vs[i] = pattern(i) * 1e12
+		}
+		pixels = vs
+	default:
+		tb.Fatalf("fitstest: invalid bitpix value (%d)", bitpix)
+	}
+
+	img := fitsio.NewImage(bitpix, axes)
+	err := img.Write(pixels)
+	if err != nil {
+		tb.Fatalf("fitstest: could not write image: %+v", err)
+	}
+	return img
+}
+
+// pattern returns the i-th value, in [-1, 1], of the pattern of the
+// synthetic images and tables.
+func pattern(i int) float64 {
+	return math.Sin(float64(i) * 0.7)
+}
+
+// NewTable returns a table of type htype with nrows rows, and one column
+// for each TFORMn value of Forms, named after its TFORMn value (such as
+// "PE" or "F10_3"). Rows hold deterministic values; variable length
+// arrays have 0 to 3 elements.
+func NewTable(tb testing.TB, htype fitsio.HDUType, nrows int) *fitsio.Table {
+	tb.Helper()
+
+	forms, ok := Forms[htype]
+	if !ok {
+		tb.Fatalf("fitstest: invalid table type (%v)", htype)
+	}
+	cols := make([]fitsio.Column, len(forms))
+	for i, form := range forms {
+		cols[i] = fitsio.Column{Name: columnName(form), Format: form}
+	}
+	tbl, err := fitsio.NewTable("fitstest", cols, htype)
+	if err != nil {
+		tb.Fatalf("fitstest: could not create table: %+v", err)
+	}
+
+	args := make([]interface{}, len(cols))
+	for irow := 0; irow < nrows; irow++ {
+		for i := range cols {
+			col := tbl.Col(i)
+			v := reflect.New(col.Type())
+			fill(v.Elem(), irow)
+			args[i] = v.Interface()
+		}
+		err = tbl.Write(args...)
+		if err != nil {
+			tb.Fatalf("fitstest: could not write row %d: %+v", irow, err)
+		}
+	}
+	return tbl
+}
+
+func columnName(form string) string {
+	return strings.ReplaceAll(form, ".", "_")
+}
+
+// fill sets v, a value of the Go type of a column, to the value of the
+// column at row irow.
+func fill(v reflect.Value, irow int) {
+	x := pattern(irow)
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(x > 0)
+	case reflect.Uint8:
+		v.SetUint(uint64(irow * 37 % 256))
+	case reflect.Int16:
+		v.SetInt(int64(x * math.MaxInt16))
+	case reflect.Int32:
+		v.SetInt(int64(x * math.MaxInt32))
+	case reflect.Int:
+		// integers of ASCII tables, printed with 6 characters.
+		v.SetInt(int64(x * 99999))
+	case reflect.Int64:
+		v.SetInt(int64(x * (1 << 62)))
+	case reflect.Float32:
+		v.SetFloat(float64(float32(x * 1e6)))
+	case reflect.Float64:
+		// values printed with the precision of E15.7 and F10.3 columns.
+		v.SetFloat(math.Round(x*1e5) / 1e3)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(x, -x))
+	case reflect.String:
+		v.SetString(fmt.Sprintf("row%d", irow))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fill(v.Index(i), irow+i)
+		}
+	case reflect.Slice:
+		n := irow % 4
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			fill(v.Index(i), irow+i)
+		}
+	default:
+		panic(fmt.Errorf("fitstest: unsupported column type %v", v.Type()))
+	}
+}
+
+// Encode returns the FITS file holding the given HDUs. An empty primary
+// image is added if the first HDU is not an image.
+func Encode(tb testing.TB, hdus ...fitsio.HDU) []byte {
+	tb.Helper()
+
+	buf := new(bytes.Buffer)
+	f, err := fitsio.Create(buf)
+	if err != nil {
+		tb.Fatalf("fitstest: could not create file: %+v", err)
+	}
+	if len(hdus) == 0 || hdus[0].Type() != fitsio.IMAGE_HDU {
+		err = f.Write(fitsio.NewImage(8, nil))
+		if err != nil {
+			tb.Fatalf("fitstest: could not write primary HDU: %+v", err)
+		}
+	}
+	for i, hdu := range hdus {
+		err = f.Write(hdu)
+		if err != nil {
+			tb.Fatalf("fitstest: could not write HDU #%d: %+v", i, err)
+		}
+	}
+	err = f.Close()
+	if err != nil {
+		tb.Fatalf("fitstest: could not close file: %+v", err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,104 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitstest
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/astrogo/fitsio"
+)
+
+func TestRoundTrip(t *testing.T) {
+	var hdus []fitsio.HDU
+	for _, bitpix := range Bitpixes {
+		hdus = append(hdus, NewImage(t, bitpix, 3, 4))
+	}
+	for _, htype := range []fitsio.HDUType{fitsio.ASCII_TBL, fitsio.BINARY_TBL} {
+		hdus = append(hdus, NewTable(t, htype, 10))
+	}
+	raw := Encode(t, hdus...)
+
+	f, err := fitsio.Open(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+	if got, want := len(f.HDUs()), len(hdus); got != want {
+		t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+	}
+	for i, hdu := range hdus {
+		want, err := hdu.Data()
+		if err != nil {
+			t.Fatalf("could not get data of HDU #%d: %+v", i, err)
+		}
+		got, err := f.HDU(i).Data()
+		if err != nil {
+			t.Fatalf("could not read data of HDU #%d: %+v", i, err)
+		}
+		if !equal(reflect.ValueOf(got), reflect.ValueOf(want)) {
+			t.Fatalf("HDU #%d: invalid data:\ngot= %+v\nwant=%+v", i, got, want)
+		}
+	}
+
+	diffs, err := Diff(raw, Encode(t, hdus...))
+	if err != nil {
+		t.Fatalf("could not compare files: %+v", err)
+	}
+	if diffs != nil {
+		t.Fatalf("unexpected differences:\n%q", diffs)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	img := NewImage(t, -32, 4)
+	tbl := NewTable(t, fitsio.BINARY_TBL, 5)
+	a := Encode(t, img, tbl)
+
+	img.Header().Set("OBJECT", "M31", "")
+	b := Encode(t, img, tbl)
+	diffs, err := Diff(a, b)
+	if err != nil {
+		t.Fatalf("could not compare files: %+v", err)
+	}
+	if want := []string{"HDU #0: + OBJECT = M31 / "}; !reflect.DeepEqual(diffs, want) {
+		t.Fatalf("invalid differences:\ngot= %q\nwant=%q", diffs, want)
+	}
+	diffs, err = Diff(a, b, "OBJECT")
+	if err != nil {
+		t.Fatalf("could not compare files: %+v", err)
+	}
+	if diffs != nil {
+		t.Fatalf("unexpected differences: %q", diffs)
+	}
+
+	pix := NewImage(t, -32, 4)
+	err = pix.Write([]float32{0, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("could not write image: %+v", err)
+	}
+	diffs, err = Diff(a, Encode(t, pix, tbl, NewImage(t, 8, 2)))
+	if err != nil {
+		t.Fatalf("could not compare files: %+v", err)
+	}
+	if want := []string{"number of HDUs: 2 -> 3", "HDU #0: pixels differ from pixel #1"}; !reflect.DeepEqual(diffs, want) {
+		t.Fatalf("invalid differences:\ngot= %q\nwant=%q", diffs, want)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	defer func(v bool) { Update = v }(Update)
+
+	fname := filepath.Join(t.TempDir(), "golden", "file.fits")
+	raw := Encode(t, NewImage(t, 16, 5, 5), NewTable(t, fitsio.BINARY_TBL, 3))
+
+	Update = true
+	Golden(t, fname, raw)
+
+	Update = false
+	Golden(t, fname, raw)
+}
@@ -100,6 +100,63 @@ func (p *Gray32) PixOffset(x, y int) int {
 	return (y-p.Rect.Min.Y)*p.Stride + (x-p.Rect.Min.X)*4
 }
 
+// At64 returns the floating-point value of the pixel at (x, y).
+// At64 returns 0 if (x, y) is outside of the image bounds.
+func (p *Gray32) At64(x, y int) float64 {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return 0
+	}
+	return float64(p.at(p.PixOffset(x, y)))
+}
+
+// SetFloat sets the floating-point value of the pixel at (x, y).
+func (p *Gray32) SetFloat(x, y int, v float64) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	p.setf(p.PixOffset(x, y), float32(v))
+}
+
+// Floats returns the decoded pixel values, in row-major order.
+// The returned slice is a copy: modifying it does not modify the image.
+func (p *Gray32) Floats() []float32 {
+	w, h := p.Rect.Dx(), p.Rect.Dy()
+	vs := make([]float32, 0, w*h)
+	for y := p.Rect.Min.Y; y < p.Rect.Max.Y; y++ {
+		for x := p.Rect.Min.X; x < p.Rect.Max.X; x++ {
+			vs = append(vs, p.at(p.PixOffset(x, y)))
+		}
+	}
+	return vs
+}
+
+// Rescale recomputes the display range of the image from the current
+// pixel values, ignoring NaNs.
+func (p *Gray32) Rescale() {
+	min := float32(+math.MaxFloat32)
+	max := float32(-math.MaxFloat32)
+	for _, v := range p.Floats() {
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+		if v > max {
+			max = v
+		}
+		if v < min {
+			min = v
+		}
+	}
+	p.Min = min
+	p.Max = max
+}
+
+// WindowLevel sets the display range of the image to the window of
+// the given width, centered on level.
+func (p *Gray32) WindowLevel(level, width float64) {
+	p.Min = float32(level - width/2)
+	p.Max = float32(level + width/2)
+}
+
 type f64Gray float64
 
 func (c f64Gray) RGBA() (r, g, b, a uint32) {
@@ -185,6 +242,63 @@ func (p *Gray64) PixOffset(x, y int) int {
 	return (y-p.Rect.Min.Y)*p.Stride + (x-p.Rect.Min.X)*8
 }
 
+// At64 returns the floating-point value of the pixel at (x, y).
+// At64 returns 0 if (x, y) is outside of the image bounds.
+func (p *Gray64) At64(x, y int) float64 {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return 0
+	}
+	return p.at(p.PixOffset(x, y))
+}
+
+// SetFloat sets the floating-point value of the pixel at (x, y).
+func (p *Gray64) SetFloat(x, y int, v float64) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	p.setf(p.PixOffset(x, y), v)
+}
+
+// Floats returns the decoded pixel values, in row-major order.
+// The returned slice is a copy: modifying it does not modify the image.
+func (p *Gray64) Floats() []float64 {
+	w, h := p.Rect.Dx(), p.Rect.Dy()
+	vs := make([]float64, 0, w*h)
+	for y := p.Rect.Min.Y; y < p.Rect.Max.Y; y++ {
+		for x := p.Rect.Min.X; x < p.Rect.Max.X; x++ {
+			vs = append(vs, p.at(p.PixOffset(x, y)))
+		}
+	}
+	return vs
+}
+
+// Rescale recomputes the display range of the image from the current
+// pixel values, ignoring NaNs.
+func (p *Gray64) Rescale() {
+	min := +math.MaxFloat64
+	max := -math.MaxFloat64
+	for _, v := range p.Floats() {
+		if math.IsNaN(v) {
+			continue
+		}
+		if v > max {
+			max = v
+		}
+		if v < min {
+			min = v
+		}
+	}
+	p.Min = min
+	p.Max = max
+}
+
+// WindowLevel sets the display range of the image to the window of
+// the given width, centered on level.
+func (p *Gray64) WindowLevel(level, width float64) {
+	p.Min = level - width/2
+	p.Max = level + width/2
+}
+
 // Models for the fltimg color types.
 var (
 	Gray32Model color.Model = color.ModelFunc(gray32Model)
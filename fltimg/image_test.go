@@ -9,7 +9,9 @@ import (
 	"encoding/binary"
 	"image"
 	"image/color"
+	"math"
 	"math/rand"
+	"reflect"
 	"testing"
 )
 
@@ -156,3 +158,63 @@ func TestGray64(t *testing.T) {
 		}
 	}
 }
+
+func TestGray32Floats(t *testing.T) {
+	rect := image.Rect(0, 0, 3, 2)
+	pix := []float32{1, 2, 3, 4, 5, 6}
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.BigEndian, pix)
+
+	img := NewGray32(rect, buf.Bytes())
+	if got, want := img.At64(2, 1), 6.0; got != want {
+		t.Fatalf("At64: got=%v want=%v", got, want)
+	}
+	if got, want := img.At64(3, 1), 0.0; got != want {
+		t.Fatalf("At64 (out of bounds): got=%v want=%v", got, want)
+	}
+
+	img.SetFloat(0, 1, 10)
+	img.SetFloat(5, 5, 42) // out of bounds: no-op
+	if got, want := img.Floats(), []float32{1, 2, 3, 10, 5, 6}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Floats: got=%v want=%v", got, want)
+	}
+
+	img.Rescale()
+	if img.Min != 1 || img.Max != 10 {
+		t.Fatalf("Rescale: got=[%v, %v] want=[1, 10]", img.Min, img.Max)
+	}
+
+	img.WindowLevel(4, 2)
+	if img.Min != 3 || img.Max != 5 {
+		t.Fatalf("WindowLevel: got=[%v, %v] want=[3, 5]", img.Min, img.Max)
+	}
+}
+
+func TestGray64Floats(t *testing.T) {
+	rect := image.Rect(0, 0, 3, 2)
+	pix := []float64{1, 2, 3, 4, 5, 6}
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.BigEndian, pix)
+
+	img := NewGray64(rect, buf.Bytes())
+	if got, want := img.At64(2, 1), 6.0; got != want {
+		t.Fatalf("At64: got=%v want=%v", got, want)
+	}
+
+	img.SetFloat(0, 1, math.NaN())
+	img.SetFloat(1, 1, -1)
+	got := img.Floats()
+	if !math.IsNaN(got[3]) || got[4] != -1 {
+		t.Fatalf("Floats: got=%v", got)
+	}
+
+	img.Rescale()
+	if img.Min != -1 || img.Max != 6 {
+		t.Fatalf("Rescale: got=[%v, %v] want=[-1, 6]", img.Min, img.Max)
+	}
+
+	img.WindowLevel(0, 10)
+	if img.Min != -5 || img.Max != 5 {
+		t.Fatalf("WindowLevel: got=[%v, %v] want=[-5, 5]", img.Min, img.Max)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func FuzzParseHeaderLine(f *testing.F) {
+	raw, err := os.ReadFile("testdata/swp06542llg.fits")
+	if err != nil {
+		f.Fatalf("could not read file: %v", err)
+	}
+	for i := 0; i < 2*BlockSize; i += 80 {
+		f.Add(raw[i : i+80])
+	}
+	f.Add([]byte("COMPLEX = (1.5, -2)"))
+	f.Add([]byte("LONGSTR = 'abc&'"))
+
+	f.Fuzz(func(t *testing.T, line []byte) {
+		card, err := parseHeaderLine(line)
+		if err != nil {
+			return
+		}
+		if len(line) != 80 {
+			t.Fatalf("parsed a %d-byte line", len(line))
+		}
+		_, _ = makeHeaderLine(card)
+	})
+}
+
+func FuzzDecodeHDU(f *testing.F) {
+	for _, fname := range []string{
+		"testdata/file001.fits",
+		"testdata/issue-38.fits",
+		"testdata/swp06542llg.fits",
+		"testdata/file-img2-bitpix+08.fits",
+	} {
+		raw, err := os.ReadFile(fname)
+		if err != nil {
+			f.Fatalf("could not read file: %v", err)
+		}
+		f.Add(raw)
+	}
+
+	var buf bytes.Buffer
+	w, err := Create(&buf)
+	if err != nil {
+		f.Fatalf("could not create file: %v", err)
+	}
+	tbl := newPlanTable(f, 4)
+	for _, hdu := range []HDU{NewImage(8, nil), tbl} {
+		err = w.Write(hdu)
+		if err != nil {
+			f.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	f.Add(buf.Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		dec := NewDecoder(bytes.NewReader(data), WithMaxHDUSize(1<<20))
+		for {
+			hdu, err := dec.DecodeHDU()
+			if err != nil {
+				return
+			}
+			switch hdu := hdu.(type) {
+			case Image:
+				_ = hdu.Image()
+			case *Table:
+				rows, err := hdu.Read(0, min(hdu.NumRows(), 64))
+				if err != nil {
+					t.Fatalf("could not read table: %v", err)
+				}
+				for rows.Next() {
+					row := make(map[string]interface{})
+					if rows.Scan(&row) != nil {
+						break
+					}
+				}
+			}
+		}
+	})
+}
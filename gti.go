@@ -0,0 +1,122 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// FilterByGTI returns a new table holding the rows of the events table whose
+// time, read from the timeCol column, falls within any of the [START, STOP)
+// Good Time Intervals described by the START and STOP columns of the gti table.
+//
+// Events with a NaN time are discarded.
+// The returned table has the same columns and user keywords as events.
+func FilterByGTI(events *Table, gti *Table, timeCol string) (*Table, error) {
+	switch {
+	case events == nil:
+		return nil, fmt.Errorf("fitsio: nil events table")
+	case gti == nil:
+		return nil, fmt.Errorf("fitsio: nil GTI table")
+	}
+
+	if timeCol == "" {
+		timeCol = "TIME"
+	}
+	itime, err := floatColumnIndex(events, timeCol)
+	if err != nil {
+		return nil, err
+	}
+
+	ivs, err := readGTI(gti)
+	if err != nil {
+		return nil, err
+	}
+
+	times, err := readFloatColumn(events, itime)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make([]bool, len(times))
+	for i, t := range times {
+		keep[i] = ivs.contains(t)
+	}
+
+	return selectRows(events, keep)
+}
+
+// floatColumnIndex returns the index of the scalar numerical column name of t.
+func floatColumnIndex(t *Table, name string) (int, error) {
+	icol := t.Index(name)
+	if icol < 0 {
+		return -1, fmt.Errorf("fitsio: no column %q in table %q", name, t.Name())
+	}
+	kind := t.cols[icol].Type().Kind()
+	if !isIntKind(kind) && !isFloatKind(kind) {
+		return -1, fmt.Errorf("fitsio: column %q is not a scalar numerical column", name)
+	}
+	return icol, nil
+}
+
+// interval is a [start, stop) time interval.
+type interval struct {
+	start, stop float64
+}
+
+// intervals is a sorted list of disjoint intervals.
+type intervals []interval
+
+// readGTI reads the START and STOP columns of a GTI table into a sorted
+// list of disjoint intervals.
+func readGTI(gti *Table) (intervals, error) {
+	istart, err := floatColumnIndex(gti, "START")
+	if err != nil {
+		return nil, err
+	}
+	istop, err := floatColumnIndex(gti, "STOP")
+	if err != nil {
+		return nil, err
+	}
+
+	starts, err := readFloatColumn(gti, istart)
+	if err != nil {
+		return nil, err
+	}
+	stops, err := readFloatColumn(gti, istop)
+	if err != nil {
+		return nil, err
+	}
+
+	ivs := make(intervals, 0, len(starts))
+	for i := range starts {
+		iv := interval{start: starts[i], stop: stops[i]}
+		if math.IsNaN(iv.start) || math.IsNaN(iv.stop) || !(iv.start < iv.stop) {
+			// empty or invalid interval.
+			continue
+		}
+		ivs = append(ivs, iv)
+	}
+	sort.Slice(ivs, func(i, j int) bool { return ivs[i].start < ivs[j].start })
+
+	// merge overlapping intervals.
+	out := ivs[:0]
+	for _, iv := range ivs {
+		if n := len(out); n > 0 && iv.start <= out[n-1].stop {
+			out[n-1].stop = math.Max(out[n-1].stop, iv.stop)
+			continue
+		}
+		out = append(out, iv)
+	}
+	return out, nil
+}
+
+// contains returns whether t falls within any of the intervals.
+func (ivs intervals) contains(t float64) bool {
+	i := sort.Search(len(ivs), func(i int) bool { return ivs[i].start > t })
+	return i > 0 && t < ivs[i-1].stop
+}
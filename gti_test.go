@@ -0,0 +1,99 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFilterByGTI(t *testing.T) {
+	type Event struct {
+		Time float64 `fits:"TIME"`
+		PI   int16   `fits:"PI"`
+	}
+	type GTI struct {
+		Start float64 `fits:"START"`
+		Stop  float64 `fits:"STOP"`
+	}
+
+	events, err := NewTableFrom("EVENTS", Event{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer events.Close()
+	err = events.Header().Append(Card{Name: "TSTART", Value: 0.0})
+	if err != nil {
+		t.Fatalf("could not append card: %v", err)
+	}
+	for i, tt := range []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, math.NaN()} {
+		err = events.Write(&Event{Time: tt, PI: int16(i)})
+		if err != nil {
+			t.Fatalf("could not write event: %v", err)
+		}
+	}
+
+	gti, err := NewTableFrom("GTI", GTI{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer gti.Close()
+	// unsorted, overlapping and empty intervals.
+	for _, iv := range []GTI{{7, 9}, {1, 2}, {2.5, 4}, {3, 5}, {6, 6}} {
+		err = gti.Write(&iv)
+		if err != nil {
+			t.Fatalf("could not write GTI: %v", err)
+		}
+	}
+
+	out, err := FilterByGTI(events, gti, "TIME")
+	if err != nil {
+		t.Fatalf("could not filter events: %v", err)
+	}
+	defer out.Close()
+
+	if got, want := out.Name(), "EVENTS"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if card := out.Header().Get("TSTART"); card == nil {
+		t.Fatalf("missing TSTART card")
+	}
+
+	var got []float64
+	rows, err := out.Read(0, out.NumRows())
+	if err != nil {
+		t.Fatalf("could not read rows: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var evt Event
+		err = rows.Scan(&evt)
+		if err != nil {
+			t.Fatalf("could not scan row: %v", err)
+		}
+		if int16(evt.Time) != evt.PI {
+			t.Fatalf("invalid event: %+v", evt)
+		}
+		got = append(got, evt.Time)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows error: %v", err)
+	}
+
+	want := []float64{1, 3, 4, 7, 8}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid filtered times:\ngot= %v\nwant=%v", got, want)
+	}
+
+	_, err = FilterByGTI(events, gti, "PHA")
+	if err == nil {
+		t.Fatalf("expected an error for a missing time column")
+	}
+	_, err = FilterByGTI(gti, events, "START")
+	if err == nil {
+		t.Fatalf("expected an error for a GTI table without START/STOP columns")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte("BZh")
+)
+
+// WithGzip makes Create compress the whole FITS file with gzip, at the
+// given compression level (e.g. gzip.DefaultCompression), as for
+// .fits.gz files.
+// The gzip stream is flushed when the File is closed.
+//
+// Open detects gzip and bzip2 compressed files by itself.
+func WithGzip(level int) Option {
+	return func(cfg *config) {
+		cfg.gzip = true
+		cfg.gzipLevel = level
+	}
+}
+
+// uncompressedReader returns a reader of the uncompressed content of r,
+// if r holds a gzip or bzip2 stream, or a reader of r otherwise.
+func uncompressedReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(3)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not open gzip stream: %w", err)
+		}
+		return zr, nil
+	case bytes.HasPrefix(magic, bzip2Magic):
+		return bzip2.NewReader(br), nil
+	}
+	return br, nil
+}
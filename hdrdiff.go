@@ -0,0 +1,236 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DiffKind describes how a card differs between two headers.
+type DiffKind int
+
+const (
+	CardAdded   DiffKind = iota // the card is only present in the second header
+	CardRemoved                 // the card is only present in the first header
+	CardChanged                 // the value or comment of the card differs
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case CardAdded:
+		return "added"
+	case CardRemoved:
+		return "removed"
+	case CardChanged:
+		return "changed"
+	}
+	return fmt.Sprintf("DiffKind(%d)", int(k))
+}
+
+// CardDiff describes a difference between two headers.
+type CardDiff struct {
+	Name string
+	Kind DiffKind
+	A    *Card // card of the first header (nil if added)
+	B    *Card // card of the second header (nil if removed)
+}
+
+func (d CardDiff) String() string {
+	switch d.Kind {
+	case CardAdded:
+		return fmt.Sprintf("+ %s = %v / %s", d.Name, d.B.Value, d.B.Comment)
+	case CardRemoved:
+		return fmt.Sprintf("- %s = %v / %s", d.Name, d.A.Value, d.A.Comment)
+	default:
+		return fmt.Sprintf("~ %s = %v / %s -> %v / %s", d.Name, d.A.Value, d.A.Comment, d.B.Value, d.B.Comment)
+	}
+}
+
+// DiffHeaders returns the differences between the cards of the headers a
+// and b: the removed and changed cards, in the order of a, followed by
+// the added cards, in the order of b.
+//
+// Cards are matched by name. Repeated keywords are matched in order of
+// appearance, and commentary cards (COMMENT, HISTORY and blank keywords)
+// by their text, so that they are reported as added or removed only.
+func DiffHeaders(a, b *Header) []CardDiff {
+	var (
+		diffs   []CardDiff
+		matched = make([]bool, len(b.cards))
+		nth     = make(map[string]int) // occurrences of the keywords of a
+	)
+
+	for i := range a.cards {
+		ca := &a.cards[i]
+		if ca.Name == "END" {
+			continue
+		}
+		n := nth[ca.Name]
+		nth[ca.Name]++
+
+		var j int
+		if isCommentaryKey(ca.Name) {
+			j = findCard(b.cards, matched, func(cb *Card) bool {
+				return cb.Name == ca.Name && cb.Comment == ca.Comment
+			})
+		} else {
+			j = findCard(b.cards, matched, func(cb *Card) bool {
+				if cb.Name != ca.Name {
+					return false
+				}
+				n--
+				return n < 0
+			})
+		}
+		if j < 0 {
+			diffs = append(diffs, CardDiff{Name: ca.Name, Kind: CardRemoved, A: ca})
+			continue
+		}
+		matched[j] = true
+		cb := &b.cards[j]
+		if !reflect.DeepEqual(ca.Value, cb.Value) || ca.Comment != cb.Comment {
+			diffs = append(diffs, CardDiff{Name: ca.Name, Kind: CardChanged, A: ca, B: cb})
+		}
+	}
+
+	for j := range b.cards {
+		cb := &b.cards[j]
+		if matched[j] || cb.Name == "END" {
+			continue
+		}
+		diffs = append(diffs, CardDiff{Name: cb.Name, Kind: CardAdded, B: cb})
+	}
+	return diffs
+}
+
+// findCard returns the index of the first card not yet matched for which
+// match returns true, or -1.
+// Keywords already matched are still passed to match, to count them.
+func findCard(cards []Card, matched []bool, match func(*Card) bool) int {
+	for i := range cards {
+		if match(&cards[i]) && !matched[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+// MergePolicy describes how MergeHeaders handles cards defined with
+// different values in both headers.
+type MergePolicy int
+
+const (
+	MergeKeep      MergePolicy = iota // keep the card of the destination header
+	MergeOverwrite                    // replace the card with the one of the source header
+	MergeError                        // return an error
+)
+
+func (p MergePolicy) String() string {
+	switch p {
+	case MergeKeep:
+		return "keep"
+	case MergeOverwrite:
+		return "overwrite"
+	case MergeError:
+		return "error"
+	}
+	return fmt.Sprintf("MergePolicy(%d)", int(p))
+}
+
+// MergeHeaders copies into dst the cards of src.
+//
+// Cards missing from dst are appended to it, and cards defined with a
+// different value in both headers are handled according to policy.
+// Commentary cards (COMMENT, HISTORY and blank keywords) are appended,
+// unless dst already holds the same commentary card.
+//
+// Cards describing the structure of the HDU (such as BITPIX, NAXISn or
+// the TFORMn of table columns) and the CHECKSUM and DATASUM cards are
+// never copied.
+// With MergeError, dst is left untouched when a conflict is found.
+func MergeHeaders(dst, src *Header, policy MergePolicy) error {
+	switch policy {
+	case MergeKeep, MergeOverwrite, MergeError:
+	default:
+		return fmt.Errorf("fitsio: invalid merge policy (%d)", int(policy))
+	}
+
+	if policy == MergeError {
+		for i := range src.cards {
+			card := &src.cards[i]
+			if !isMergeableKey(card.Name) || isCommentaryKey(card.Name) {
+				continue
+			}
+			if old := dst.Get(card.Name); old != nil && !reflect.DeepEqual(old.Value, card.Value) {
+				return fmt.Errorf(
+					"fitsio: conflicting values for card %s (dst=%v, src=%v)",
+					card.Name, old.Value, card.Value,
+				)
+			}
+		}
+	}
+
+	var cards []Card
+	for i := range src.cards {
+		card := &src.cards[i]
+		if !isMergeableKey(card.Name) {
+			continue
+		}
+		if isCommentaryKey(card.Name) {
+			dup := false
+			for c := range dst.Cards() {
+				if c.Name == card.Name && c.Comment == card.Comment {
+					dup = true
+					break
+				}
+			}
+			if !dup {
+				cards = append(cards, *card)
+			}
+			continue
+		}
+		old := dst.Get(card.Name)
+		if old == nil {
+			cards = append(cards, *card)
+			continue
+		}
+		if policy == MergeOverwrite {
+			old.Value = card.Value
+			old.Comment = card.Comment
+		}
+	}
+	return dst.Append(cards...)
+}
+
+// isMergeableKey returns whether MergeHeaders may copy the keyword.
+func isMergeableKey(name string) bool {
+	if isStructuralKey(name) {
+		return false
+	}
+	switch name {
+	case "CHECKSUM", "DATASUM":
+		return false
+	}
+	for _, prefix := range []string{
+		"TTYPE", "TFORM", "TUNIT", "TNULL", "TSCAL", "TZERO", "TDISP", "TDIM",
+	} {
+		if len(name) > len(prefix) && strings.HasPrefix(name, prefix) && strings.Trim(name[len(prefix):], "0123456789") == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isCommentaryKey returns whether the keyword is a commentary keyword,
+// which may be repeated in a header.
+func isCommentaryKey(name string) bool {
+	switch name {
+	case "COMMENT", "HISTORY", "":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffHeaders(t *testing.T) {
+	a := NewHeader([]Card{
+		{Name: "OBJECT", Value: "M31"},
+		{Name: "EXPTIME", Value: 10.0, Comment: "exposure time"},
+		{Name: "FILTER", Value: "R"},
+		{Name: "HISTORY", Comment: "step 1"},
+	}, IMAGE_HDU, 16, []int{10, 10})
+	b := NewHeader([]Card{
+		{Name: "OBJECT", Value: "M31"},
+		{Name: "EXPTIME", Value: 20.0, Comment: "exposure time"},
+		{Name: "HISTORY", Comment: "step 1"},
+		{Name: "HISTORY", Comment: "step 2"},
+		{Name: "GAIN", Value: 1.5},
+	}, IMAGE_HDU, 16, []int{10, 20})
+
+	type diff struct {
+		Name string
+		Kind DiffKind
+	}
+	var got []diff
+	for _, d := range DiffHeaders(a, b) {
+		got = append(got, diff{d.Name, d.Kind})
+	}
+	want := []diff{
+		{"NAXIS2", CardChanged},
+		{"EXPTIME", CardChanged},
+		{"FILTER", CardRemoved},
+		{"HISTORY", CardAdded},
+		{"GAIN", CardAdded},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid diff:\ngot= %v\nwant=%v", got, want)
+	}
+
+	if diffs := DiffHeaders(a, a); len(diffs) != 0 {
+		t.Fatalf("unexpected differences: %v", diffs)
+	}
+}
+
+func TestMergeHeaders(t *testing.T) {
+	newDst := func() *Header {
+		return NewHeader([]Card{
+			{Name: "OBJECT", Value: "M31"},
+			{Name: "EXPTIME", Value: 10.0},
+			{Name: "HISTORY", Comment: "step 1"},
+		}, IMAGE_HDU, 16, []int{10, 10})
+	}
+	src := NewHeader([]Card{
+		{Name: "EXPTIME", Value: 20.0, Comment: "new exposure"},
+		{Name: "GAIN", Value: 1.5},
+		{Name: "HISTORY", Comment: "step 1"},
+		{Name: "HISTORY", Comment: "step 2"},
+		{Name: "DATASUM", Value: "0"},
+	}, IMAGE_HDU, 32, []int{5})
+
+	for _, tc := range []struct {
+		policy  MergePolicy
+		exptime float64
+		err     bool
+	}{
+		{MergeKeep, 10, false},
+		{MergeOverwrite, 20, false},
+		{MergeError, 10, true},
+	} {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			dst := newDst()
+			err := MergeHeaders(dst, src, tc.policy)
+			switch {
+			case tc.err && err == nil:
+				t.Fatalf("expected an error")
+			case !tc.err && err != nil:
+				t.Fatalf("could not merge headers: %+v", err)
+			}
+			if tc.err {
+				if diffs := DiffHeaders(newDst(), dst); len(diffs) != 0 {
+					t.Fatalf("header modified on error: %v", diffs)
+				}
+				return
+			}
+
+			if got := dst.Get("EXPTIME").Value; got != tc.exptime {
+				t.Fatalf("invalid EXPTIME: got=%v, want=%v", got, tc.exptime)
+			}
+			if dst.Get("GAIN") == nil {
+				t.Fatalf("missing GAIN card")
+			}
+			if dst.Get("DATASUM") != nil {
+				t.Fatalf("DATASUM card should not be copied")
+			}
+			if got, want := dst.Get("NAXIS").Value, 2; got != want {
+				t.Fatalf("invalid NAXIS: got=%v, want=%v", got, want)
+			}
+			var history []string
+			for card := range dst.Cards() {
+				if card.Name == "HISTORY" {
+					history = append(history, card.Comment)
+				}
+			}
+			if got, want := history, []string{"step 1", "step 2"}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid history: got=%q, want=%q", got, want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package fitsio
 
 import (
 	"fmt"
+	"strings"
 )
 
 // HDUType is the type of a Header-Data Unit
@@ -16,8 +17,18 @@ const (
 	ASCII_TBL                 // ASCII table HDU
 	BINARY_TBL                // Binary table HDU
 	ANY_HDU                   // matches any HDU type
+
+	// UNKNOWN_HDU is the type of conforming extensions of a type unknown
+	// to fitsio (such as 'FOREIGN' or 'DUMP').
+	// Their data unit is not decoded: it is available, verbatim, from the
+	// Raw method of the HDU and copied as is when the HDU is written.
+	UNKNOWN_HDU
 )
 
+// String returns the name of the HDU type: the value of the XTENSION card
+// of extensions of this type ("IMAGE", "TABLE" or "BINTABLE"), or
+// "ANY_HDU" and "UNKNOWN_HDU".
+// Invalid values are formatted as "HDUType(n)".
 func (htype HDUType) String() string {
 	switch htype {
 	case IMAGE_HDU:
@@ -28,9 +39,44 @@ func (htype HDUType) String() string {
 		return "BINTABLE"
 	case ANY_HDU:
 		return "ANY_HDU"
+	case UNKNOWN_HDU:
+		return "UNKNOWN_HDU"
 	default:
-		panic(fmt.Errorf("invalid HDU Type value (%v)", int(htype)))
+		return fmt.Sprintf("HDUType(%d)", int(htype))
+	}
+}
+
+// ParseHDUType returns the HDU type named s, as formatted by
+// HDUType.String or as the Go constant (such as "BINARY_TBL".)
+// Names are case insensitive.
+func ParseHDUType(s string) (HDUType, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "IMAGE", "IMAGE_HDU":
+		return IMAGE_HDU, nil
+	case "TABLE", "ASCII_TBL":
+		return ASCII_TBL, nil
+	case "BINTABLE", "BINARY_TBL":
+		return BINARY_TBL, nil
+	case "ANY_HDU":
+		return ANY_HDU, nil
+	case "UNKNOWN_HDU":
+		return UNKNOWN_HDU, nil
+	}
+	return 0, fmt.Errorf("fitsio: invalid HDU Type name %q", s)
+}
+
+// hduVersion returns the value of the EXTVER card of hdr, or 1 if it is
+// missing or does not hold an integer.
+func hduVersion(hdr *Header) int {
+	card := hdr.Get("EXTVER")
+	if card == nil {
+		return 1
+	}
+	v, err := cardInt(card)
+	if err != nil {
+		return 1
 	}
+	return v
 }
 
 // HDU is a "Header-Data Unit" block
@@ -40,9 +86,27 @@ type HDU interface {
 	Name() string
 	Version() int
 	Header() *Header
+
+	// DataSize returns the size in bytes of the data unit of the HDU,
+	// including the heap of binary tables but not the padding to
+	// the next FITS block.
+	DataSize() int64
+
+	// Data returns the decoded data unit of the HDU: an *ImageData for
+	// images, a *TableData for tables, and the bytes of the data unit
+	// for extensions of unknown types.
+	Data() (Value, error)
+}
+
+// HDUOffsets describes the location of an HDU in a FITS stream.
+type HDUOffsets struct {
+	Header int64 // offset of the first byte of the header
+	Data   int64 // offset of the first byte of the data unit
+	End    int64 // offset of the first byte after the HDU, padding included
 }
 
 // CopyHDU copies the i-th HDU from the src FITS file into the dst one.
+// See CopyHDURaw to copy HDUs between FITS streams without decoding them.
 func CopyHDU(dst, src *File, i int) error {
 	// FIXME(sbinet)
 	// use a more efficient implementation. directly copying raw-bytes
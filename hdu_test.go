@@ -0,0 +1,209 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestParseHDUType(t *testing.T) {
+	for _, htype := range []HDUType{IMAGE_HDU, ASCII_TBL, BINARY_TBL, ANY_HDU, UNKNOWN_HDU} {
+		got, err := ParseHDUType(htype.String())
+		if err != nil {
+			t.Fatalf("could not parse %v: %v", htype, err)
+		}
+		if got != htype {
+			t.Fatalf("invalid round trip: got=%v, want=%v", got, htype)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		want HDUType
+	}{
+		{"image", IMAGE_HDU},
+		{"IMAGE_HDU", IMAGE_HDU},
+		{" bintable", BINARY_TBL},
+		{"binary_tbl", BINARY_TBL},
+		{"ASCII_TBL", ASCII_TBL},
+	} {
+		got, err := ParseHDUType(tc.name)
+		if err != nil {
+			t.Fatalf("could not parse %q: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("invalid type for %q: got=%v, want=%v", tc.name, got, tc.want)
+		}
+	}
+
+	_, err := ParseHDUType("A3DTABLE")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := HDUType(42).String(), "HDUType(42)"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+}
+
+func TestModeString(t *testing.T) {
+	for _, tc := range []struct {
+		mode Mode
+		want string
+	}{
+		{ReadOnly, "ReadOnly"},
+		{WriteOnly, "WriteOnly"},
+		{ReadWrite, "ReadWrite"},
+		{Mode(42), "Mode(42)"},
+	} {
+		if got := tc.mode.String(); got != tc.want {
+			t.Fatalf("invalid name: got=%q, want=%q", got, tc.want)
+		}
+	}
+}
+
+func TestHDUData(t *testing.T) {
+	img := NewImage(-32, []int{3, 2})
+	err := img.Write([]float32{1, 2, 3, 4, 5, 6})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	v, err := img.Data()
+	if err != nil {
+		t.Fatalf("could not get image data: %v", err)
+	}
+	want := &ImageData{Axes: []int{3, 2}, Pixels: []float32{1, 2, 3, 4, 5, 6}}
+	if !reflect.DeepEqual(v, want) {
+		t.Fatalf("invalid image data:\ngot= %+v\nwant=%+v", v, want)
+	}
+
+	v, err = NewImage(16, nil).Data()
+	if err != nil {
+		t.Fatalf("could not get data of an empty image: %v", err)
+	}
+	if got := v.(*ImageData).Pixels.([]int16); len(got) != 0 {
+		t.Fatalf("invalid empty image data: %v", got)
+	}
+
+	_, err = NewImage(8, []int{4}).Data()
+	if err == nil {
+		t.Fatalf("expected an error for an image without pixels")
+	}
+
+	bin, err := NewTable("bin", []Column{
+		{Name: "ID", Format: "J"},
+		{Name: "VLA", Format: "PD"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	txt, err := NewTable("txt", []Column{
+		{Name: "ID", Format: "I6"},
+		{Name: "X", Format: "D23.15"},
+	}, ASCII_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		var (
+			id  = int32(i)
+			vla = []float64{float64(i), 0.5}[:i%2+1]
+			n   = i
+			x   = float64(i) / 2
+		)
+		err = bin.Write(&id, &vla)
+		if err != nil {
+			t.Fatalf("could not write binary row: %v", err)
+		}
+		err = txt.Write(&n, &x)
+		if err != nil {
+			t.Fatalf("could not write ASCII row: %v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		tbl  *Table
+		want *TableData
+	}{
+		{bin, &TableData{
+			Names:   []string{"ID", "VLA"},
+			Columns: []interface{}{[]int32{0, 1, 2}, [][]float64{{0}, {1, 0.5}, {2}}},
+		}},
+		{txt, &TableData{
+			Names:   []string{"ID", "X"},
+			Columns: []interface{}{[]int{0, 1, 2}, []float64{0, 0.5, 1}},
+		}},
+	} {
+		v, err := tc.tbl.Data()
+		if err != nil {
+			t.Fatalf("could not get %s data: %v", tc.tbl.Name(), err)
+		}
+		if !reflect.DeepEqual(v, tc.want) {
+			t.Fatalf("invalid %s data:\ngot= %+v\nwant=%+v", tc.tbl.Name(), v, tc.want)
+		}
+		if got, want := v.(*TableData).Column("ID"), tc.want.Columns[0]; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid %s ID column: got=%v, want=%v", tc.tbl.Name(), got, want)
+		}
+		if got := v.(*TableData).Column("MISSING"); got != nil {
+			t.Fatalf("invalid missing column: %v", got)
+		}
+	}
+
+	raw := &rawHDU{data: []byte("payload")}
+	v, err = raw.Data()
+	if err != nil {
+		t.Fatalf("could not get raw data: %v", err)
+	}
+	if got, want := v.([]byte), []byte("payload"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid raw data: got=%q, want=%q", got, want)
+	}
+}
+
+func TestHDUVersion(t *testing.T) {
+	tbl, err := NewTable("tbl", []Column{{Name: "x", Format: "D"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	img := NewImage(8, nil)
+	img.Header().Set("EXTNAME", "img", "")
+
+	var buf bytes.Buffer
+	w, err := Create(&buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for i, hdu := range []HDU{NewImage(8, nil), img, tbl} {
+		ver := interface{}("abc")
+		if i == 2 {
+			ver = 2.5
+		}
+		hdu.Header().Set("EXTVER", ver, "")
+		err = w.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	f, err := Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	for i, hdu := range f.HDUs() {
+		if got, want := hdu.Version(), 1; got != want {
+			t.Errorf("hdu #%d: invalid version: got=%d, want=%d", i, got, want)
+		}
+	}
+	if got, want := f.GetVersioned("img", 1), f.HDU(1); got != want {
+		t.Fatalf("invalid versioned HDU: got=%v, want=%v", got, want)
+	}
+}
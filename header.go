@@ -6,9 +6,13 @@ package fitsio
 
 import (
 	"fmt"
+	"iter"
+	"math"
 	"math/big"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 // Header describes a Header-Data Unit of a FITS file
@@ -17,6 +21,10 @@ type Header struct {
 	bitpix int     // character information
 	axes   []int   // dimensions of image data array
 	cards  []Card  // content of the Header
+
+	raw      []byte // original header blocks (see WithRawHeaders)
+	rawCards []Card // cards as decoded from raw
+	rawAxes  []int  // axes as decoded from raw
 }
 
 // newHeader creates a new Header.
@@ -145,6 +153,20 @@ func (hdr *Header) Text() string {
 	return string(buf)
 }
 
+// Raw returns the original header blocks this Header was decoded from,
+// including the END card and the padding of the last block.
+// Raw returns nil if the header was not decoded with WithRawHeaders, or if
+// its cards or dimensions were modified since.
+func (hdr *Header) Raw() []byte {
+	switch {
+	case hdr.raw == nil,
+		!reflect.DeepEqual(hdr.cards, hdr.rawCards),
+		!slices.Equal(hdr.axes, hdr.rawAxes):
+		return nil
+	}
+	return hdr.raw
+}
+
 // Append appends a set of Cards to this Header
 func (hdr *Header) Append(cards ...Card) error {
 	var err error
@@ -168,43 +190,68 @@ func (hdr *Header) Append(cards ...Card) error {
 				return fmt.Errorf("fitsio: duplicate Card [%s] (value=%v)", card.Name, card.Value)
 			}
 		}
-		rv := reflect.ValueOf(card.Value)
-		if rv.IsValid() {
-			switch rv.Type().Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				card.Value = int(rv.Int())
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				card.Value = int(rv.Uint())
-			case reflect.Float32, reflect.Float64:
-				card.Value = rv.Float()
-			case reflect.Complex64, reflect.Complex128:
-				card.Value = rv.Complex()
-			case reflect.String:
-				card.Value = card.Value.(string)
-			case reflect.Bool:
-				card.Value = card.Value.(bool)
-			case reflect.Struct:
-				switch card.Value.(type) {
-				case big.Int:
-					// ok
-				default:
-					return fmt.Errorf(
-						"fitsio: invalid value type (%T) for card [%s] (kind=%v)",
-						card.Value, card.Name, rv.Type().Kind(),
-					)
-				}
-			default:
-				return fmt.Errorf(
-					"fitsio: invalid value type (%T) for card [%s] (kind=%v)",
-					card.Value, card.Name, rv.Type().Kind(),
-				)
-			}
+		card.Value, err = cardValue(card.Name, card.Value)
+		if err != nil {
+			return err
 		}
 		hdr.cards = append(hdr.cards, card)
 	}
 	return err
 }
 
+// cardValue returns the value v of the card named name, converted to one
+// of the types of card values: string, bool, int, int64, big.Int, float64
+// or complex128.
+//
+// Values of type int64 and uint64 are stored as int64 (or as a big.Int if
+// they overflow an int64), other integer values as int (or as int64 if
+// they overflow an int.)
+func cardValue(name string, v Value) (Value, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return v, nil
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return int(rv.Int()), nil
+	case reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		switch {
+		case rv.Kind() != reflect.Uint64 && u <= math.MaxInt:
+			return int(u), nil
+		case u <= math.MaxInt64:
+			return int64(u), nil
+		}
+		var x big.Int
+		x.SetUint64(u)
+		return x, nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Complex64, reflect.Complex128:
+		return rv.Complex(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return rv.Bool(), nil
+	}
+	switch v := v.(type) {
+	case big.Int:
+		return v, nil
+	case RawCard:
+		return v, nil
+	case *big.Int:
+		if v != nil {
+			return *v, nil
+		}
+	}
+	return nil, fmt.Errorf(
+		"fitsio: invalid value type (%T) for card [%s] (kind=%v)",
+		v, name, rv.Kind(),
+	)
+}
+
 // prepend prepends a (set of) cards to this Header
 func (hdr *Header) prepend(cards ...Card) error {
 	var err error
@@ -229,27 +276,9 @@ func (hdr *Header) prepend(cards ...Card) error {
 				return fmt.Errorf("fitsio: duplicate Card [%s] (value=%v)", card.Name, card.Value)
 			}
 		}
-		rv := reflect.ValueOf(card.Value)
-		if rv.IsValid() {
-			switch rv.Type().Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				card.Value = int(rv.Int())
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				card.Value = int(rv.Uint())
-			case reflect.Float32, reflect.Float64:
-				card.Value = rv.Float()
-			case reflect.Complex64, reflect.Complex128:
-				card.Value = rv.Complex()
-			case reflect.String:
-				card.Value = card.Value.(string)
-			case reflect.Bool:
-				card.Value = card.Value.(bool)
-			default:
-				return fmt.Errorf(
-					"fitsio: invalid value type (%T) for card [%s]",
-					card.Value, card.Name,
-				)
-			}
+		card.Value, err = cardValue(card.Name, card.Value)
+		if err != nil {
+			return err
 		}
 		hcards = append(hcards, card)
 	}
@@ -258,6 +287,45 @@ func (hdr *Header) prepend(cards ...Card) error {
 	return err
 }
 
+// setMandatory adds the mandatory card c to this Header, after the
+// SIMPLE/XTENSION, BITPIX, NAXIS and NAXISn cards, unless the Header
+// already holds it.
+// setMandatory fails if the Header holds c with a different value.
+// Missing cards are not added to unmodified headers kept with
+// WithRawHeaders, so that they are written back verbatim.
+func (hdr *Header) setMandatory(c Card) error {
+	if card := hdr.Get(c.Name); card != nil {
+		if !valueEqual(card.Value, c.Value) {
+			return fmt.Errorf(
+				"fitsio: invalid %s value (got=%v, want=%v)",
+				c.Name, card.Value, c.Value,
+			)
+		}
+		return nil
+	}
+	if hdr.Raw() != nil {
+		return nil
+	}
+
+	v, err := cardValue(c.Name, c.Value)
+	if err != nil {
+		return err
+	}
+	c.Value = v
+
+	pos := 0
+	for i, card := range hdr.cards {
+		switch name := card.Name; {
+		case name == "SIMPLE", name == "XTENSION", name == "BITPIX",
+			name == "NAXIS", name == "PCOUNT" && c.Name == "GCOUNT",
+			strings.HasPrefix(name, "NAXIS") && strings.Trim(name[5:], "0123456789") == "":
+			pos = i + 1
+		}
+	}
+	hdr.cards = slices.Insert(hdr.cards, pos, c)
+	return nil
+}
+
 // Clear resets the Header to the default state.
 func (hdr *Header) Clear() {
 	hdr.cards = make([]Card, 0)
@@ -283,12 +351,103 @@ func (hdr *Header) Get(n string) *Card {
 	return card
 }
 
+// Match returns the cards whose name matches the CFITSIO-style pattern:
+//   - '?' matches any single character,
+//   - '*' matches any (possibly empty) sequence of characters,
+//   - '#' matches any (non-empty) sequence of decimal digits.
+//
+// The matching is case insensitive. For example, "CRVAL#" matches the
+// CRVAL1 and CRVAL2 cards, and "*OBS*" the DATE-OBS and OBSERVER cards.
+// Cards without a name (blank cards and raw cards) are never matched.
+func (hdr *Header) Match(pattern string) []*Card {
+	pattern = strings.ToUpper(pattern)
+	var cards []*Card
+	for i := range hdr.cards {
+		card := &hdr.cards[i]
+		if card.Name != "" && matchKey(pattern, card.Name) {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+// matchKey reports whether the keyword name matches pattern, as described
+// by Header.Match.
+func matchKey(pattern, name string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(name); i >= 0; i-- {
+				if matchKey(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		case '#':
+			n := 0
+			for n < len(name) && '0' <= name[n] && name[n] <= '9' {
+				n++
+			}
+			for i := n; i > 0; i-- {
+				if matchKey(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(name) == 0 {
+				return false
+			}
+		default:
+			if len(name) == 0 {
+				return false
+			}
+			c := name[0]
+			if 'a' <= c && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			if c != pattern[0] {
+				return false
+			}
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// GetAll returns all the Cards with name n, in their order in the header.
+func (hdr *Header) GetAll(n string) []*Card {
+	var cards []*Card
+	for i := range hdr.cards {
+		if c := &hdr.cards[i]; c.Name == n {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// NumCards returns the number of cards of this Header.
+func (hdr *Header) NumCards() int {
+	return len(hdr.cards)
+}
+
 // Card returns the i-th card.
 // Card panics if the index is out of range.
 func (hdr *Header) Card(i int) *Card {
 	return &hdr.cards[i]
 }
 
+// Cards returns an iterator over the cards of this Header.
+func (hdr *Header) Cards() iter.Seq[*Card] {
+	return func(yield func(*Card) bool) {
+		for i := range hdr.cards {
+			if !yield(&hdr.cards[i]) {
+				return
+			}
+		}
+	}
+}
+
 // Comment returns the whole comment string for this Header.
 func (hdr *Header) Comment() string {
 	card := hdr.Get("COMMENT")
@@ -338,7 +497,40 @@ func (hdr *Header) Keys() []string {
 	return keys
 }
 
+// RawCards returns an iterator over the cards of the header which could
+// not be parsed, holding a RawCard value (see WithRawCards).
+// These cards may be fixed in place, by setting their name and value.
+func (hdr *Header) RawCards() iter.Seq[*Card] {
+	return func(yield func(*Card) bool) {
+		for i := range hdr.cards {
+			card := &hdr.cards[i]
+			if _, ok := card.Value.(RawCard); !ok {
+				continue
+			}
+			if !yield(card) {
+				return
+			}
+		}
+	}
+}
+
+// DropRawCards removes the cards holding a RawCard value from the header,
+// and returns the number of removed cards.
+func (hdr *Header) DropRawCards() int {
+	n := len(hdr.cards)
+	hdr.cards = slices.DeleteFunc(hdr.cards, func(card Card) bool {
+		_, ok := card.Value.(RawCard)
+		return ok
+	})
+	return n - len(hdr.cards)
+}
+
 // Set modifies the value and comment of a Card with name n.
+// See SetValue to keep the comment of the card.
+//
+// The new value of an existing card keeps the type of its current value
+// when the conversion is lossless: setting a floating point card to 3
+// stores 3.0, and setting an int64 card to an int stores an int64.
 func (hdr *Header) Set(n string, v interface{}, comment string) {
 	card := hdr.Get(n)
 	if card == nil {
@@ -348,7 +540,74 @@ func (hdr *Header) Set(n string, v interface{}, comment string) {
 			Comment: comment,
 		})
 	} else {
+		if vv, err := cardValue(n, v); err == nil {
+			v = updatedValue(card.Value, vv)
+		}
 		card.Value = v
 		card.Comment = comment
 	}
 }
+
+// SetValue modifies the value of the Card with name n, keeping its comment,
+// or appends a new card without comment if the header has no card named n.
+// As with Set, the new value keeps the type of the current one when the
+// conversion is lossless.
+func (hdr *Header) SetValue(n string, v interface{}) error {
+	card := hdr.Get(n)
+	if card == nil {
+		return hdr.Append(Card{Name: n, Value: v})
+	}
+	vv, err := cardValue(n, v)
+	if err != nil {
+		return err
+	}
+	card.Value = updatedValue(card.Value, vv)
+	return nil
+}
+
+// SetComment modifies the comment of the Card with name n.
+func (hdr *Header) SetComment(n string, comment string) error {
+	card := hdr.Get(n)
+	if card == nil {
+		return fmt.Errorf("fitsio: missing %q card", n)
+	}
+	card.Comment = comment
+	return nil
+}
+
+// updatedValue returns the value v, as converted by cardValue, converted
+// to the type of the current value cur of a card when this conversion is
+// lossless, or v.
+func updatedValue(cur, v Value) Value {
+	switch cur.(type) {
+	case float64:
+		switch v := v.(type) {
+		case int:
+			if f := float64(v); int(f) == v {
+				return f
+			}
+		case int64:
+			if f := float64(v); int64(f) == v {
+				return f
+			}
+		}
+	case int64:
+		if v, ok := v.(int); ok {
+			return int64(v)
+		}
+	case int:
+		if v, ok := v.(int64); ok && v >= math.MinInt && v <= math.MaxInt {
+			return int(v)
+		}
+	case complex128:
+		switch v := v.(type) {
+		case float64:
+			return complex(v, 0)
+		case int:
+			if f := float64(v); int(f) == v {
+				return complex(f, 0)
+			}
+		}
+	}
+	return v
+}
@@ -5,12 +5,16 @@
 package fitsio
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math"
 	"math/big"
 	"os"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -209,10 +213,14 @@ func TestHeaderRW(t *testing.T) {
 				rv := reflect.ValueOf(ref.Value)
 				var val interface{}
 				switch rv.Type().Kind() {
-				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
 					val = int(rv.Int())
-				case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				case reflect.Int64:
+					val = rv.Int()
+				case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
 					val = int(rv.Uint())
+				case reflect.Uint64:
+					val = int64(rv.Uint())
 				case reflect.Float32, reflect.Float64:
 					val = rv.Float()
 				case reflect.Complex64, reflect.Complex128:
@@ -538,7 +546,7 @@ func TestMakeHeaderLine(t *testing.T) {
 			err:  nil,
 		},
 		{
-			line: []byte("STRING  = ' a /              / no-comment                                    1&'CONTINUE  '2|      '                                                            COMMENT my-comment                                                              "),
+			line: []byte("STRING  = ' a /              / no-comment                                    1&'CONTINUE  '2|'                                                                  COMMENT my-comment                                                              "),
 			card: &Card{
 				Name:    "STRING",
 				Value:   " a /              / no-comment                                    12|",
@@ -547,7 +555,7 @@ func TestMakeHeaderLine(t *testing.T) {
 			err: nil,
 		},
 		{
-			line: []byte("STRING  = ' a /              / no-comment                                    1&'CONTINUE  '2|      '                                                            "),
+			line: []byte("STRING  = ' a /              / no-comment                                    1&'CONTINUE  '2|'                                                                  "),
 			card: &Card{
 				Name:    "STRING",
 				Value:   " a /              / no-comment                                    12|",
@@ -619,7 +627,7 @@ func TestMakeHeaderLine(t *testing.T) {
 			err: nil,
 		},
 		{
-			line: []byte("HIERARCH SMALLESTNONZEROFLOAT=         5.00000E-324 / SmallestNonzeroFloat64    "),
+			line: []byte("HIERARCH SMALLESTNONZEROFLOAT=             5.0E-324 / SmallestNonzeroFloat64    "),
 			card: &Card{
 				Name:    "SMALLESTNONZEROFLOAT",
 				Value:   math.SmallestNonzeroFloat64,
@@ -659,3 +667,716 @@ func TestDuplicateCardKeys(t *testing.T) {
 		t.Fatalf("got %v for duplicate key. want %v (the first one)", c.Value, want)
 	}
 }
+
+func TestDuplicateKeysPolicy(t *testing.T) {
+	raw, err := os.ReadFile("testdata/issue-38.fits")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := Open(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := f.HDU(0).Header().GetAll("DUP")
+	f.Close()
+	if len(all) < 2 {
+		t.Fatalf("expected duplicate cards, got %d", len(all))
+	}
+	first, last := all[0].Value, all[len(all)-1].Value
+
+	for _, test := range []struct {
+		policy DuplicateKeys
+		want   []interface{}
+	}{
+		{KeepAllKeys, nil},
+		{KeepFirstKey, []interface{}{first}},
+		{KeepLastKey, []interface{}{last}},
+	} {
+		t.Run(test.policy.String(), func(t *testing.T) {
+			f, err := Open(bytes.NewReader(raw), WithDuplicateKeys(test.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			var got []interface{}
+			for _, card := range f.HDU(0).Header().GetAll("DUP") {
+				got = append(got, card.Value)
+			}
+			want := test.want
+			if want == nil {
+				for _, card := range all {
+					want = append(want, card.Value)
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid DUP cards: got=%v, want=%v", got, want)
+			}
+		})
+	}
+
+	_, err = Open(bytes.NewReader(raw), WithDuplicateKeys(RejectDuplicateKeys))
+	if !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrDuplicateKey)
+	}
+}
+
+func TestHeaderCards(t *testing.T) {
+	hdr := NewHeader([]Card{
+		{Name: "KEY1", Value: 1},
+		{Name: "KEY2", Value: "two"},
+		{Name: "KEY3", Value: 3.0},
+	}, IMAGE_HDU, 8, nil)
+
+	var keys []string
+	for card := range hdr.Cards() {
+		keys = append(keys, card.Name)
+		if card.Name == "KEY2" {
+			card.Value = "deux"
+		}
+	}
+	if got, want := keys, []string{"BITPIX", "NAXIS", "KEY1", "KEY2", "KEY3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid keys.\ngot= %q\nwant=%q", got, want)
+	}
+	if got, want := hdr.Get("KEY2").Value, "deux"; got != want {
+		t.Fatalf("card was not modified in place: got=%v, want=%v", got, want)
+	}
+
+	n := 0
+	for range hdr.Cards() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Fatalf("iteration did not stop (n=%d)", n)
+	}
+}
+
+func TestHeaderMatch(t *testing.T) {
+	hdr := NewHeader([]Card{
+		{Name: "CRVAL1", Value: 1.0},
+		{Name: "CRVAL2", Value: 2.0},
+		{Name: "CRVALA", Value: 3.0},
+		{Name: "TTYPE12", Value: "X"},
+		{Name: "TTYPE", Value: "Y"},
+		{Name: "DATE-OBS", Value: "2026-01-01"},
+		{Name: "OBSERVER", Value: "me"},
+		{Name: "", Comment: "blank"},
+	}, IMAGE_HDU, 8, nil)
+
+	for _, test := range []struct {
+		pattern string
+		want    []string
+	}{
+		{"CRVAL*", []string{"CRVAL1", "CRVAL2", "CRVALA"}},
+		{"CRVAL#", []string{"CRVAL1", "CRVAL2"}},
+		{"crval?", []string{"CRVAL1", "CRVAL2", "CRVALA"}},
+		{"TTYPE#", []string{"TTYPE12"}},
+		{"*OBS*", []string{"DATE-OBS", "OBSERVER"}},
+		{"NAXIS", []string{"NAXIS"}},
+		{"#", nil},
+		{"*", []string{"BITPIX", "NAXIS", "CRVAL1", "CRVAL2", "CRVALA", "TTYPE12", "TTYPE", "DATE-OBS", "OBSERVER"}},
+	} {
+		var got []string
+		for _, card := range hdr.Match(test.pattern) {
+			got = append(got, card.Name)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Fatalf("match %q: got=%q, want=%q", test.pattern, got, test.want)
+		}
+	}
+
+	hdr.Match("CRVAL2")[0].Value = 4.0
+	if got, want := hdr.Get("CRVAL2").Value, 4.0; got != want {
+		t.Fatalf("card was not modified in place: got=%v, want=%v", got, want)
+	}
+}
+
+func TestHeaderInt64Cards(t *testing.T) {
+	var huge big.Int
+	huge.SetUint64(math.MaxUint64)
+
+	hdr := NewHeader([]Card{
+		{Name: "I64", Value: int64(math.MaxInt64)},
+		{Name: "I64NEG", Value: int64(math.MinInt64)},
+		{Name: "U64", Value: uint64(42)},
+		{Name: "U64BIG", Value: uint64(math.MaxUint64)},
+		{Name: "INT", Value: int32(42)},
+	}, IMAGE_HDU, 8, nil)
+	hdr.Set("I64SET", int64(-42), "")
+
+	for _, tc := range []struct {
+		name string
+		want Value
+	}{
+		{"I64", int64(math.MaxInt64)},
+		{"I64NEG", int64(math.MinInt64)},
+		{"U64", int64(42)},
+		{"U64BIG", huge},
+		{"INT", 42},
+		{"I64SET", int64(-42)},
+	} {
+		if got := hdr.Get(tc.name).Value; !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("invalid %s value: got=%v (%T), want=%v (%T)", tc.name, got, got, tc.want, tc.want)
+		}
+	}
+
+	for card := range hdr.Cards() {
+		line, err := makeHeaderLine(card)
+		if err != nil {
+			t.Fatalf("could not encode card %s: %+v", card.Name, err)
+		}
+		got, err := parseHeaderLine(line)
+		if err != nil {
+			t.Fatalf("could not decode card %s: %+v", card.Name, err)
+		}
+		// integers are decoded as int when they fit in one.
+		want, err := cardValue(card.Name, card.Value)
+		if err != nil {
+			t.Fatalf("invalid card %s: %+v", card.Name, err)
+		}
+		if v, ok := want.(int64); ok && v >= math.MinInt && v <= math.MaxInt {
+			want = int(v)
+		}
+		if !reflect.DeepEqual(got.Value, want) {
+			t.Fatalf("invalid round-trip for %s: got=%v (%T), want=%v (%T)", card.Name, got.Value, got.Value, want, want)
+		}
+	}
+
+	err := hdr.Append(Card{Name: "BAD", Value: []int{1}})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	for _, tc := range []struct {
+		v    float64
+		exp  byte
+		want string
+	}{
+		{0, 'E', "0.0"},
+		{1, 'E', "1.0"},
+		{-2.5, 'E', "-2.5"},
+		{1950, 'E', "1950.0"},
+		{0.1, 'E', "0.1"},
+		{1e-12, 'E', "1.0E-12"},
+		{1e-12, 'D', "1.0D-12"},
+		{-1.5e-12, 'D', "-1.5D-12"},
+		{1.234567e20, 'E', "1.234567E+20"},
+		{2.0 / 3.0, 'E', "0.6666666666666666"},
+		{math.MaxFloat64, 'E', "1.7976931348623157E+308"},
+		{-1.2345678901234567e-300, 'E', "-1.2345678901234568E-300"},
+		{-1.2345678901234567e-300, 'D', "-1.2345678901234568D-300"},
+	} {
+		got, err := formatFloat(tc.v, tc.exp)
+		if err != nil {
+			t.Fatalf("could not format %v: %+v", tc.v, err)
+		}
+		if got != tc.want {
+			t.Fatalf("invalid format for %v: got=%q, want=%q", tc.v, got, tc.want)
+		}
+		back, err := strconv.ParseFloat(strings.Replace(got, "D", "E", 1), 64)
+		if err != nil || back != tc.v {
+			t.Fatalf("invalid round-trip for %v: got=%v (err=%v)", tc.v, back, err)
+		}
+	}
+
+	for _, v := range []float64{math.NaN(), math.Inf(+1), math.Inf(-1)} {
+		_, err := formatFloat(v, 'E')
+		if err == nil {
+			t.Fatalf("expected an error formatting %v", v)
+		}
+	}
+}
+
+func TestFixedFloatCards(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		v    float64
+		want string // value, in columns 11 to 30 or more
+	}{
+		{"BZERO", 32768, "             32768.0"},
+		{"BZERO", 1 << 63, "9223372036854775808."},
+		{"BSCALE", 1.2345678901234567, "  1.2345678901234567"},
+		{"BSCALE", -1.2345678901234567e-300, "-1.234567890123E-300"},
+		{"BZERO", -0.00012345678901234567, "-0.00012345678901235"},
+		{"TSCAL12", 1.2345678901234567e-300, "1.2345678901235E-300"},
+		{"TZERO1", 98765432109876543210, "9.87654321098765E+19"},
+		{"TZERO", -1.2345678901234567e-300, "-1.2345678901234568E-300"},
+		{"CRVAL1", -1.2345678901234567e-300, "-1.2345678901234568E-300"},
+	} {
+		line, err := makeHeaderLine(&Card{Name: tc.name, Value: tc.v})
+		if err != nil {
+			t.Fatalf("could not encode card %s=%v: %+v", tc.name, tc.v, err)
+		}
+		if got := string(line[10 : 10+len(tc.want)]); got != tc.want {
+			t.Fatalf("invalid value for %s=%v: got=%q, want=%q", tc.name, tc.v, got, tc.want)
+		}
+		card, err := parseHeaderLine(line)
+		if err != nil {
+			t.Fatalf("could not decode card %s: %+v", tc.name, err)
+		}
+		if got := card.Value.(float64); math.Abs(got-tc.v) > 1e-12*math.Abs(tc.v) {
+			t.Fatalf("invalid round-trip for %s: got=%v, want=%v", tc.name, got, tc.v)
+		}
+	}
+}
+
+func TestFloatCardsRoundTrip(t *testing.T) {
+	// files produced by CFITSIO.
+	for _, fname := range []string{
+		"testdata/file001.fits",
+		"testdata/swp06542llg.fits",
+		"testdata/file-img2-bitpix-32.fits",
+		"testdata/file-img2-bitpix-64.fits",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			r, err := os.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer r.Close()
+
+			f, err := Open(r)
+			if err != nil {
+				t.Fatalf("could not open FITS file: %+v", err)
+			}
+			defer f.Close()
+
+			for _, hdu := range f.HDUs() {
+				for card := range hdu.Header().Cards() {
+					if _, ok := card.Value.(float64); !ok {
+						continue
+					}
+					for _, exp := range []byte{'E', 'D'} {
+						line, err := formatHeaderLine(card, exp)
+						if err != nil {
+							t.Fatalf("could not encode card %s: %+v", card.Name, err)
+						}
+						got, err := parseHeaderLine(line[:80])
+						if err != nil {
+							t.Fatalf("could not decode card %s: %+v", card.Name, err)
+						}
+						if got.Value != card.Value {
+							t.Fatalf("invalid round-trip for card %s: got=%v, want=%v", card.Name, got.Value, card.Value)
+						}
+					}
+				}
+			}
+		})
+	}
+
+	hdr := NewHeader([]Card{
+		{Name: "SMALL", Value: 1.25e-12},
+		{Name: "LARGE", Value: -6.02214076e23},
+		{Name: "CPLX", Value: complex(1.5e-9, -2)},
+	}, IMAGE_HDU, 8, nil)
+	for card := range hdr.Cards() {
+		line, err := makeHeaderLine(card)
+		if err != nil {
+			t.Fatalf("could not encode card %s: %+v", card.Name, err)
+		}
+		got, err := parseHeaderLine(line)
+		if err != nil {
+			t.Fatalf("could not decode card %s: %+v", card.Name, err)
+		}
+		if got.Value != card.Value {
+			t.Fatalf("invalid round-trip for card %s: got=%v, want=%v", card.Name, got.Value, card.Value)
+		}
+	}
+}
+
+func TestStringCardsRoundTrip(t *testing.T) {
+	values := []string{
+		"", "a", "abc", "abcdefgh", "abcdefghi", "  leading", "O'Brien",
+		"'", "''", "'''", "'quoted'", "a / b", "a & b", "&", "end&",
+		"tab\there",
+	}
+	for n := 60; n < 150; n++ {
+		x := strings.Repeat("x", n)
+		values = append(values,
+			x, x+"'", "'"+x, x[:n/2]+"''"+x[n/2:], x+"&",
+			strings.Repeat("'", n/2),
+		)
+	}
+
+	cards := make([]Card, len(values))
+	for i, v := range values {
+		cards[i] = Card{Name: fmt.Sprintf("STR%d", i), Value: v, Comment: "a comment"}
+	}
+
+	buf := new(bytes.Buffer)
+	f, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	phdu, err := NewPrimaryHDU(NewHeader(cards, IMAGE_HDU, 8, nil))
+	if err != nil {
+		t.Fatalf("could not create primary HDU: %+v", err)
+	}
+	err = f.Write(phdu)
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	for i := 0; i < buf.Len(); i += 80 {
+		line := buf.Bytes()[i : i+80]
+		if bytes.HasPrefix(line, []byte("STR")) && bytes.Contains(line, []byte("'abc     '")) {
+			t.Fatalf("string value padded: %q", line)
+		}
+	}
+
+	r, err := Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	hdr := r.HDU(0).Header()
+	for i, v := range values {
+		card := hdr.Get(fmt.Sprintf("STR%d", i))
+		if card == nil {
+			t.Fatalf("missing card for %q", v)
+		}
+		// trailing spaces are not significant.
+		if got, want := card.Value, strings.TrimRight(v, " "); got != want {
+			t.Fatalf("invalid round-trip (len=%d):\ngot= %q\nwant=%q", len(v), got, want)
+		}
+	}
+}
+
+func TestXTENSIONPadding(t *testing.T) {
+	line, err := makeHeaderLine(&Card{Name: "XTENSION", Value: "TABLE"})
+	if err != nil {
+		t.Fatalf("could not make header line: %+v", err)
+	}
+	if got, want := string(line[:20]), "XTENSION= 'TABLE   '"; got != want {
+		t.Fatalf("invalid XTENSION line: got=%q, want=%q", got, want)
+	}
+}
+
+func TestHeaderRaw(t *testing.T) {
+	for _, fname := range []string{
+		"testdata/swp06542llg.fits",
+		"testdata/file001.fits",
+		"testdata/file-img2-bitpix-64.fits",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			raw := mustReadFile(t, fname)
+			f, err := Open(bytes.NewReader(raw), WithRawHeaders())
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			for i, hdu := range f.HDUs() {
+				offs := f.Offsets()[i]
+				if got, want := hdu.Header().Raw(), raw[offs.Header:offs.Data]; !bytes.Equal(got, want) {
+					t.Fatalf("HDU #%d: invalid raw header", i)
+				}
+			}
+
+			// unmodified HDUs are copied byte for byte.
+			buf := new(bytes.Buffer)
+			w, err := Create(buf, WithRawHeaders())
+			if err != nil {
+				t.Fatalf("could not create file: %+v", err)
+			}
+			for i, hdu := range f.HDUs() {
+				err = w.Write(hdu)
+				if err != nil {
+					t.Fatalf("could not write HDU #%d: %+v", i, err)
+				}
+			}
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close file: %+v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), raw) {
+				t.Fatalf("copy is not byte-identical")
+			}
+		})
+	}
+
+	t.Run("modified", func(t *testing.T) {
+		f, err := Open(bytes.NewReader(mustReadFile(t, "testdata/swp06542llg.fits")), WithRawHeaders())
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+
+		hdr := f.HDU(0).Header()
+		if hdr.Raw() == nil {
+			t.Fatalf("missing raw header")
+		}
+		hdr.Get("TELESCOP").Comment = "modified"
+		if hdr.Raw() != nil {
+			t.Fatalf("raw header of a modified header")
+		}
+
+		buf := new(bytes.Buffer)
+		w, err := Create(buf, WithRawHeaders())
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		err = w.Write(f.HDU(0))
+		if err != nil {
+			t.Fatalf("could not write HDU: %+v", err)
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+		if !bytes.Contains(buf.Bytes(), []byte("/ modified")) {
+			t.Fatalf("modified card was not written")
+		}
+	})
+
+	t.Run("modified-mandatory", func(t *testing.T) {
+		// the extension of this file lacks the PCOUNT and GCOUNT cards, and
+		// its primary HDU the EXTEND card.
+		f, err := Open(bytes.NewReader(mustReadFile(t, "testdata/file-img2-bitpix-64.fits")), WithRawHeaders())
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+
+		buf := new(bytes.Buffer)
+		w, err := Create(buf, WithRawHeaders())
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		for i, hdu := range f.HDUs() {
+			hdu.Header().Set("OBJECT", "M31", "modified")
+			err = w.Write(hdu)
+			if err != nil {
+				t.Fatalf("could not write HDU #%d: %+v", i, err)
+			}
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+
+		r, err := Open(buf)
+		if err != nil {
+			t.Fatalf("could not reopen file: %+v", err)
+		}
+		defer r.Close()
+		for i, keys := range [][]string{{"EXTEND"}, {"PCOUNT", "GCOUNT"}} {
+			hdr := r.HDU(i).Header()
+			for _, key := range keys {
+				if hdr.Get(key) == nil {
+					t.Fatalf("HDU #%d: missing %s card:\n%s", i, key, hdr.Text())
+				}
+			}
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		f, err := Open(bytes.NewReader(mustReadFile(t, "testdata/swp06542llg.fits")))
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+		if f.HDU(0).Header().Raw() != nil {
+			t.Fatalf("raw header kept without WithRawHeaders")
+		}
+	})
+}
+
+func mustReadFile(t *testing.T, fname string) []byte {
+	t.Helper()
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+	return raw
+}
+
+func TestRawCards(t *testing.T) {
+	img := NewImage(8, []int{2})
+	err := img.Header().Append(
+		Card{Name: "VENDOR", Value: "abc", Comment: "vendor keyword"},
+		Card{Name: "EXPTIME", Value: 1.5},
+	)
+	if err != nil {
+		t.Fatalf("could not append cards: %v", err)
+	}
+	err = img.Write([]uint8{1, 2})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	buf := new(bytes.Buffer)
+	w, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	err = w.Write(img)
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	// corrupt the VENDOR card: its string value is not terminated.
+	quirk := fmt.Sprintf("%-80s", "VENDOR  = 'abc / \xe9t\xe9")
+	raw := buf.Bytes()
+	i := bytes.Index(raw, []byte("VENDOR  ="))
+	copy(raw[i:i+80], quirk)
+
+	_, err = Open(bytes.NewReader(raw))
+	if err == nil {
+		t.Fatalf("expected an error decoding an invalid card")
+	}
+
+	f, err := Open(bytes.NewReader(raw), WithRawCards())
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+	hdr := f.HDU(0).Header()
+	if hdr.Get("VENDOR") != nil {
+		t.Fatalf("invalid card was parsed")
+	}
+	if got, want := hdr.Get("EXPTIME").Value, 1.5; got != want {
+		t.Fatalf("invalid EXPTIME: got=%v, want=%v", got, want)
+	}
+	var cards []*Card
+	for card := range hdr.RawCards() {
+		cards = append(cards, card)
+	}
+	if len(cards) != 1 {
+		t.Fatalf("invalid number of raw cards: got=%d, want=1", len(cards))
+	}
+	rc := cards[0].Value.(RawCard)
+	if got, want := rc.Name(), "VENDOR"; got != want {
+		t.Fatalf("invalid raw card name: got=%q, want=%q", got, want)
+	}
+	if got := rc.String(); got != quirk {
+		t.Fatalf("invalid raw card:\ngot= %q\nwant=%q", got, quirk)
+	}
+	if rc.Err() == nil {
+		t.Fatalf("expected a parse error for the raw card")
+	}
+
+	encode := func(hdu HDU) []byte {
+		buf := new(bytes.Buffer)
+		w, err := Create(buf)
+		if err != nil {
+			t.Fatalf("could not create file: %v", err)
+		}
+		err = w.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	// raw cards are written back verbatim.
+	if got := encode(f.HDU(0)); !bytes.Equal(got, raw) {
+		t.Fatalf("invalid round-trip of raw cards")
+	}
+
+	out := new(bytes.Buffer)
+	err = CopyHDURaw(out, bytes.NewReader(raw), false)
+	if err != nil {
+		t.Fatalf("could not copy HDU: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), raw) {
+		t.Fatalf("invalid raw copy of raw cards")
+	}
+
+	// fix the card.
+	cards[0].Name = "VENDOR"
+	cards[0].Value = "abc"
+	fixed, err := Open(bytes.NewReader(encode(f.HDU(0))))
+	if err != nil {
+		t.Fatalf("could not open fixed file: %v", err)
+	}
+	defer fixed.Close()
+	if got, want := fixed.HDU(0).Header().Get("VENDOR").Value, "abc"; got != want {
+		t.Fatalf("invalid fixed card: got=%v, want=%v", got, want)
+	}
+
+	// drop the card.
+	hdr.Get("VENDOR").Value = RawCard([]byte(quirk))
+	if got, want := hdr.DropRawCards(), 1; got != want {
+		t.Fatalf("invalid number of dropped cards: got=%d, want=%d", got, want)
+	}
+	if bytes.Contains(encode(f.HDU(0)), []byte("VENDOR")) {
+		t.Fatalf("raw card was not dropped")
+	}
+}
+
+func TestHeaderSetValue(t *testing.T) {
+	hdr := NewHeader([]Card{
+		{Name: "EXPTIME", Value: 30.0, Comment: "exposure time [s]"},
+		{Name: "NCOMBINE", Value: int64(3), Comment: "number of frames"},
+		{Name: "GAIN", Value: 1, Comment: "gain [e-/ADU]"},
+		{Name: "OBJECT", Value: "M31", Comment: "target"},
+	}, IMAGE_HDU, 8, nil)
+
+	for _, tc := range []struct {
+		name    string
+		value   interface{}
+		want    Value
+		comment string
+	}{
+		{"EXPTIME", 60, 60.0, "exposure time [s]"},
+		{"EXPTIME", 1.5, 1.5, "exposure time [s]"},
+		{"NCOMBINE", 5, int64(5), "number of frames"},
+		{"GAIN", 2.5, 2.5, "gain [e-/ADU]"},
+		{"OBJECT", "M33", "M33", "target"},
+		{"OBJECT", true, true, "target"},
+		{"AIRMASS", float32(1.25), 1.25, ""},
+	} {
+		err := hdr.SetValue(tc.name, tc.value)
+		if err != nil {
+			t.Fatalf("could not set %s: %v", tc.name, err)
+		}
+		card := hdr.Get(tc.name)
+		if !reflect.DeepEqual(card.Value, tc.want) {
+			t.Fatalf("invalid %s value: got=%v (%T), want=%v (%T)", tc.name, card.Value, card.Value, tc.want, tc.want)
+		}
+		if card.Comment != tc.comment {
+			t.Fatalf("invalid %s comment: got=%q, want=%q", tc.name, card.Comment, tc.comment)
+		}
+	}
+
+	err := hdr.SetValue("OBJECT", struct{}{})
+	if err == nil {
+		t.Fatalf("expected an error setting an invalid value")
+	}
+
+	hdr.Set("EXPTIME", 90, "")
+	if got, want := hdr.Get("EXPTIME").Value, 90.0; got != want {
+		t.Fatalf("invalid EXPTIME value: got=%v (%T), want=%v", got, got, want)
+	}
+
+	err = hdr.SetComment("EXPTIME", "exposure [s]")
+	if err != nil {
+		t.Fatalf("could not set comment: %v", err)
+	}
+	if got, want := hdr.Get("EXPTIME").Comment, "exposure [s]"; got != want {
+		t.Fatalf("invalid comment: got=%q, want=%q", got, want)
+	}
+	if got, want := hdr.Get("EXPTIME").Value, 90.0; got != want {
+		t.Fatalf("invalid EXPTIME value: got=%v, want=%v", got, want)
+	}
+	err = hdr.SetComment("MISSING", "comment")
+	if err == nil {
+		t.Fatalf("expected an error setting the comment of a missing card")
+	}
+}
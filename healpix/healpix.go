@@ -0,0 +1,495 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package healpix reads and writes HEALPix maps stored in FITS binary
+// tables, following the conventions of the HEALPix library: the table
+// header holds the PIXTYPE='HEALPIX', ORDERING and NSIDE keywords, and
+// each column of the table holds the pixel values of one map.
+//
+//	m, err := healpix.ReadHealpix(f)
+//	if err != nil {
+//		return err
+//	}
+//	// pixel values of the first map, in NESTED ordering.
+//	pix, err := m.Pixels(0, healpix.Nested)
+package healpix
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strings"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+// Unseen is the value of the pixels missing from a map.
+const Unseen = -1.6375e30
+
+// Ordering is the pixel ordering scheme of a HEALPix map.
+type Ordering int
+
+const (
+	Ring   Ordering = iota // RING ordering
+	Nested                 // NESTED ordering
+)
+
+func (o Ordering) String() string {
+	switch o {
+	case Ring:
+		return "RING"
+	case Nested:
+		return "NESTED"
+	}
+	return fmt.Sprintf("Ordering(%d)", int(o))
+}
+
+// HealpixMap is a set of HEALPix maps sharing the same resolution and
+// pixel ordering.
+type HealpixMap struct {
+	Nside    int         // resolution parameter of the maps
+	Ordering Ordering    // ordering of the pixels of Maps
+	Coordsys string      // coordinate system (COORDSYS), such as G, E or C
+	Names    []string    // names of the maps
+	Units    []string    // units of the maps (optional)
+	Maps     [][]float64 // pixel values of the maps, 12*Nside*Nside per map
+}
+
+// NPix returns the number of pixels of the maps.
+func (m *HealpixMap) NPix() int {
+	return 12 * m.Nside * m.Nside
+}
+
+// Pixels returns the pixel values of the i-th map, in the ordering o.
+// The returned slice is a copy of the values of m.Maps[i].
+func (m *HealpixMap) Pixels(i int, o Ordering) ([]float64, error) {
+	if i < 0 || i >= len(m.Maps) {
+		return nil, fmt.Errorf("healpix: map index out of range (%d)", i)
+	}
+	src := m.Maps[i]
+	if len(src) != m.NPix() {
+		return nil, fmt.Errorf("healpix: invalid number of pixels (got=%d, want=%d)", len(src), m.NPix())
+	}
+	dst := make([]float64, len(src))
+	switch {
+	case o == m.Ordering:
+		copy(dst, src)
+		return dst, nil
+	case o != Ring && o != Nested:
+		return nil, fmt.Errorf("healpix: invalid ordering %v", o)
+	case !isPow2(m.Nside):
+		return nil, fmt.Errorf("healpix: NESTED ordering requires a power of 2 NSIDE (got %d)", m.Nside)
+	}
+
+	conv := Nest2Ring
+	if o == Nested {
+		conv = Ring2Nest
+	}
+	for pix, v := range src {
+		dst[conv(m.Nside, pix)] = v
+	}
+	return dst, nil
+}
+
+// ReadHealpix reads the HEALPix maps held by the first binary table of f
+// with a PIXTYPE='HEALPIX' keyword.
+//
+// Both the implicit indexing scheme (one pixel value per element, with
+// vector columns flattened) and the explicit one (a PIXEL column holding
+// the indices of the pixels, the other pixels being set to Unseen) are
+// supported.
+func ReadHealpix(f *fits.File) (*HealpixMap, error) {
+	var tbl *fits.Table
+	for _, hdu := range f.HDUs() {
+		t, ok := hdu.(*fits.Table)
+		if !ok || t.Type() != fits.BINARY_TBL {
+			continue
+		}
+		if strings.TrimSpace(headerString(t.Header(), "PIXTYPE")) == "HEALPIX" {
+			tbl = t
+			break
+		}
+	}
+	if tbl == nil {
+		return nil, fmt.Errorf("healpix: no HEALPix table in file")
+	}
+	hdr := tbl.Header()
+
+	m := &HealpixMap{
+		Coordsys: strings.TrimSpace(headerString(hdr, "COORDSYS")),
+	}
+
+	card := hdr.Get("NSIDE")
+	if card == nil {
+		return nil, fmt.Errorf("healpix: missing NSIDE keyword")
+	}
+	nside, ok := intValue(card.Value)
+	if !ok || nside <= 0 || nside > 1<<29 {
+		return nil, fmt.Errorf("healpix: invalid NSIDE value (%v)", card.Value)
+	}
+	m.Nside = int(nside)
+
+	switch ordering := strings.TrimSpace(headerString(hdr, "ORDERING")); ordering {
+	case "RING":
+		m.Ordering = Ring
+	case "NESTED":
+		m.Ordering = Nested
+		if !isPow2(m.Nside) {
+			return nil, fmt.Errorf("healpix: NESTED ordering requires a power of 2 NSIDE (got %d)", m.Nside)
+		}
+	default:
+		return nil, fmt.Errorf("healpix: invalid ORDERING value %q", ordering)
+	}
+
+	explicit := strings.TrimSpace(headerString(hdr, "INDXSCHM")) == "EXPLICIT"
+	var index []float64
+	if explicit {
+		i := tbl.Index("PIXEL")
+		if i < 0 {
+			return nil, fmt.Errorf("healpix: missing PIXEL column of explicit indexing scheme")
+		}
+		var err error
+		index, err = readColumn(tbl, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	npix := m.NPix()
+	for i, col := range tbl.Cols() {
+		if explicit && col.Name == "PIXEL" {
+			continue
+		}
+		values, err := readColumn(tbl, i)
+		if err != nil {
+			return nil, err
+		}
+
+		pixels := values
+		switch {
+		case explicit:
+			if len(values) != len(index) {
+				return nil, fmt.Errorf("healpix: column %q is not a scalar column", col.Name)
+			}
+			pixels = make([]float64, npix)
+			for j := range pixels {
+				pixels[j] = Unseen
+			}
+			for j, pix := range index {
+				if pix < 0 || pix >= float64(npix) {
+					return nil, fmt.Errorf("healpix: invalid pixel index %v", pix)
+				}
+				pixels[int(pix)] = values[j]
+			}
+		case len(values) < npix:
+			return nil, fmt.Errorf(
+				"healpix: not enough pixels in column %q (got=%d, want=%d)",
+				col.Name, len(values), npix,
+			)
+		default:
+			pixels = values[:npix]
+		}
+		m.Names = append(m.Names, col.Name)
+		m.Units = append(m.Units, col.Unit)
+		m.Maps = append(m.Maps, pixels)
+	}
+
+	return m, nil
+}
+
+// WriteHealpix writes the maps of m to f, as a new binary table using the
+// implicit indexing scheme.
+// An empty primary HDU is written first if f has no HDU yet.
+//
+// Pixel values are stored as double precision floating point values, in
+// vector columns of 1024 elements when the number of pixels allows it.
+func WriteHealpix(f *fits.File, m *HealpixMap) error {
+	npix := m.NPix()
+	switch {
+	case m.Nside <= 0:
+		return fmt.Errorf("healpix: invalid NSIDE value (%d)", m.Nside)
+	case m.Ordering != Ring && m.Ordering != Nested:
+		return fmt.Errorf("healpix: invalid ordering %v", m.Ordering)
+	case m.Ordering == Nested && !isPow2(m.Nside):
+		return fmt.Errorf("healpix: NESTED ordering requires a power of 2 NSIDE (got %d)", m.Nside)
+	case len(m.Maps) == 0:
+		return fmt.Errorf("healpix: no map to write")
+	case len(m.Names) != len(m.Maps):
+		return fmt.Errorf("healpix: invalid number of map names (got=%d, want=%d)", len(m.Names), len(m.Maps))
+	case m.Units != nil && len(m.Units) != len(m.Maps):
+		return fmt.Errorf("healpix: invalid number of map units (got=%d, want=%d)", len(m.Units), len(m.Maps))
+	}
+	for i, pixels := range m.Maps {
+		if len(pixels) != npix {
+			return fmt.Errorf(
+				"healpix: invalid number of pixels for map %q (got=%d, want=%d)",
+				m.Names[i], len(pixels), npix,
+			)
+		}
+	}
+
+	repeat, format := 1, "D"
+	if npix%1024 == 0 {
+		repeat, format = 1024, "1024D"
+	}
+	cols := make([]fits.Column, len(m.Maps))
+	for i := range cols {
+		cols[i] = fits.Column{Name: m.Names[i], Format: format}
+		if m.Units != nil {
+			cols[i].Unit = m.Units[i]
+		}
+	}
+
+	tbl, err := fits.NewTable("HEALPIX", cols, fits.BINARY_TBL)
+	if err != nil {
+		return fmt.Errorf("healpix: could not create table: %w", err)
+	}
+
+	cards := []fits.Card{
+		{Name: "PIXTYPE", Value: "HEALPIX", Comment: "HEALPix pixelisation"},
+		{Name: "ORDERING", Value: m.Ordering.String(), Comment: "pixel ordering scheme"},
+		{Name: "NSIDE", Value: m.Nside, Comment: "resolution parameter of the map"},
+		{Name: "FIRSTPIX", Value: 0, Comment: "first pixel index"},
+		{Name: "LASTPIX", Value: npix - 1, Comment: "last pixel index"},
+		{Name: "INDXSCHM", Value: "IMPLICIT", Comment: "indexing scheme"},
+		{Name: "OBJECT", Value: "FULLSKY", Comment: "sky coverage"},
+	}
+	if m.Coordsys != "" {
+		cards = append(cards, fits.Card{Name: "COORDSYS", Value: m.Coordsys, Comment: "coordinate system"})
+	}
+	err = tbl.Header().Append(cards...)
+	if err != nil {
+		return err
+	}
+
+	rt := reflect.TypeOf(float64(0))
+	if repeat > 1 {
+		rt = reflect.ArrayOf(repeat, rt)
+	}
+	row := make([]interface{}, len(cols))
+	for i := range row {
+		row[i] = reflect.New(rt).Interface()
+	}
+	for irow := 0; irow < npix/repeat; irow++ {
+		for i, pixels := range m.Maps {
+			rv := reflect.ValueOf(row[i]).Elem()
+			if repeat == 1 {
+				rv.SetFloat(pixels[irow])
+				continue
+			}
+			reflect.Copy(rv.Slice(0, repeat), reflect.ValueOf(pixels[irow*repeat:(irow+1)*repeat]))
+		}
+		err = tbl.Write(row...)
+		if err != nil {
+			return fmt.Errorf("healpix: could not write pixels: %w", err)
+		}
+	}
+
+	if len(f.HDUs()) == 0 {
+		err = f.Write(fits.NewImage(8, nil))
+		if err != nil {
+			return fmt.Errorf("healpix: could not write primary HDU: %w", err)
+		}
+	}
+	return f.Write(tbl)
+}
+
+// readColumn returns the values of the i-th column of t, as float64,
+// with the elements of vector columns flattened.
+func readColumn(t *fits.Table, i int) ([]float64, error) {
+	col := t.Col(i)
+	ptr := reflect.New(reflect.SliceOf(col.Type()))
+	err := t.ReadColumn(i, ptr.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("healpix: could not read column %q: %w", col.Name, err)
+	}
+
+	rows := ptr.Elem()
+	var values []float64
+	for irow := 0; irow < rows.Len(); irow++ {
+		values, err = appendValues(values, rows.Index(irow))
+		if err != nil {
+			return nil, fmt.Errorf("healpix: invalid column %q: %w", col.Name, err)
+		}
+	}
+	return values, nil
+}
+
+func appendValues(dst []float64, rv reflect.Value) ([]float64, error) {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		var err error
+		for i := 0; i < rv.Len(); i++ {
+			dst, err = appendValues(dst, rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+		}
+		return dst, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return append(dst, float64(rv.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return append(dst, float64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return append(dst, rv.Float()), nil
+	case reflect.Bool:
+		v := 0.0
+		if rv.Bool() {
+			v = 1
+		}
+		return append(dst, v), nil
+	}
+	return nil, fmt.Errorf("non numerical values (%v)", rv.Type())
+}
+
+func headerString(hdr *fits.Header, key string) string {
+	card := hdr.Get(key)
+	if card == nil {
+		return ""
+	}
+	str, _ := card.Value.(string)
+	return str
+}
+
+func intValue(v fits.Value) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+func isPow2(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// isqrt returns the integer square root of v.
+func isqrt(v int) int {
+	r := int(math.Sqrt(float64(v)))
+	for r*r > v {
+		r--
+	}
+	for (r+1)*(r+1) <= v {
+		r++
+	}
+	return r
+}
+
+// coordinates of the south corner of the base pixels, in units of nside.
+var (
+	jrll = [12]int{2, 2, 2, 2, 3, 3, 3, 3, 4, 4, 4, 4}
+	jpll = [12]int{1, 3, 5, 7, 0, 2, 4, 6, 1, 3, 5, 7}
+)
+
+// Nest2Ring converts the index of a pixel from the NESTED ordering to the
+// RING ordering, for a map of resolution nside (a power of 2).
+func Nest2Ring(nside, pix int) int {
+	npface := nside * nside
+	face := pix / npface
+	ipf := pix % npface
+	ix, iy := compressBits(ipf), compressBits(ipf>>1)
+
+	var (
+		npix = 12 * npface
+		ncap = 2 * nside * (nside - 1)
+		jr   = jrll[face]*nside - ix - iy - 1
+
+		nr, nbefore, kshift int
+	)
+	switch {
+	case jr < nside:
+		nr = jr
+		nbefore = 2 * nr * (nr - 1)
+	case jr > 3*nside:
+		nr = 4*nside - jr
+		nbefore = npix - 2*(nr+1)*nr
+	default:
+		nr = nside
+		nbefore = ncap + (jr-nside)*4*nside
+		kshift = (jr - nside) & 1
+	}
+
+	jp := (jpll[face]*nr + ix - iy + 1 + kshift) / 2
+	switch {
+	case jp > 4*nside:
+		jp -= 4 * nside
+	case jp < 1:
+		jp += 4 * nside
+	}
+	return nbefore + jp - 1
+}
+
+// Ring2Nest converts the index of a pixel from the RING ordering to the
+// NESTED ordering, for a map of resolution nside (a power of 2).
+func Ring2Nest(nside, pix int) int {
+	var (
+		npix = 12 * nside * nside
+		ncap = 2 * nside * (nside - 1)
+
+		iring, iphi, kshift, nr, face int
+	)
+	switch {
+	case pix < ncap: // north polar cap
+		iring = (1 + isqrt(1+2*pix)) >> 1
+		iphi = pix + 1 - 2*iring*(iring-1)
+		nr = iring
+		face = (iphi - 1) / nr
+	case pix < npix-ncap: // equatorial region
+		ip := pix - ncap
+		tmp := ip / (4 * nside)
+		iring = tmp + nside
+		iphi = ip - tmp*4*nside + 1
+		kshift = (iring + nside) & 1
+		nr = nside
+		ire := tmp + 1
+		irm := 2*nside + 1 - tmp
+		ifm := (iphi - ire>>1 + nside - 1) / nside
+		ifp := (iphi - irm>>1 + nside - 1) / nside
+		switch {
+		case ifp == ifm:
+			face = ifp | 4
+		case ifp < ifm:
+			face = ifp
+		default:
+			face = ifm + 8
+		}
+	default: // south polar cap
+		ip := npix - pix
+		iring = (1 + isqrt(2*ip-1)) >> 1
+		iphi = 4*iring + 1 - (ip - 2*iring*(iring-1))
+		nr = iring
+		iring = 4*nside - iring
+		face = 8 + (iphi-1)/nr
+	}
+
+	irt := iring - jrll[face]*nside + 1
+	ipt := 2*iphi - jpll[face]*nr - kshift - 1
+	if ipt >= 2*nside {
+		ipt -= 8 * nside
+	}
+	ix := (ipt - irt) >> 1
+	iy := (-ipt - irt) >> 1
+	return face*nside*nside + spreadBits(ix) + spreadBits(iy)<<1
+}
+
+// spreadBits interleaves the bits of v with zeros.
+func spreadBits(v int) int {
+	var r int
+	for i := 0; i < bits.UintSize/2; i++ {
+		r |= (v >> i & 1) << (2 * i)
+	}
+	return r
+}
+
+// compressBits is the inverse of spreadBits, on the even bits of v.
+func compressBits(v int) int {
+	var r int
+	for i := 0; i < bits.UintSize/2; i++ {
+		r |= (v >> (2 * i) & 1) << i
+	}
+	return r
+}
@@ -0,0 +1,210 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healpix
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+func TestRingNest(t *testing.T) {
+	for _, tc := range []struct {
+		nside, nest, ring int
+	}{
+		{1, 0, 0},
+		{1, 4, 4},
+		{1, 11, 11},
+		{2, 0, 13},
+		{2, 3, 0},
+		{2, 47, 35},
+		{16, 1130, 1504},
+	} {
+		if got := Nest2Ring(tc.nside, tc.nest); got != tc.ring {
+			t.Errorf("Nest2Ring(%d, %d): got=%d, want=%d", tc.nside, tc.nest, got, tc.ring)
+		}
+		if got := Ring2Nest(tc.nside, tc.ring); got != tc.nest {
+			t.Errorf("Ring2Nest(%d, %d): got=%d, want=%d", tc.nside, tc.ring, got, tc.nest)
+		}
+	}
+
+	for _, nside := range []int{1, 2, 4, 8, 16, 64} {
+		npix := 12 * nside * nside
+		seen := make([]bool, npix)
+		for pix := 0; pix < npix; pix++ {
+			ring := Nest2Ring(nside, pix)
+			if ring < 0 || ring >= npix || seen[ring] {
+				t.Fatalf("nside=%d: invalid ring index %d for nested pixel %d", nside, ring, pix)
+			}
+			seen[ring] = true
+			if got := Ring2Nest(nside, ring); got != pix {
+				t.Fatalf("nside=%d: invalid round-trip for nested pixel %d: got=%d", nside, pix, got)
+			}
+		}
+	}
+}
+
+func TestReadWriteHealpix(t *testing.T) {
+	for _, nside := range []int{4, 16} {
+		npix := 12 * nside * nside
+		m := &HealpixMap{
+			Nside:    nside,
+			Ordering: Ring,
+			Coordsys: "G",
+			Names:    []string{"TEMPERATURE", "N_OBS"},
+			Units:    []string{"K", ""},
+			Maps:     [][]float64{make([]float64, npix), make([]float64, npix)},
+		}
+		for i := 0; i < npix; i++ {
+			m.Maps[0][i] = float64(i) / 10
+			m.Maps[1][i] = float64(i % 7)
+		}
+
+		buf := new(bytes.Buffer)
+		f, err := fits.Create(buf)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		err = WriteHealpix(f, m)
+		if err != nil {
+			t.Fatalf("could not write map: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+
+		r, err := fits.Open(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer r.Close()
+
+		got, err := ReadHealpix(r)
+		if err != nil {
+			t.Fatalf("could not read map: %+v", err)
+		}
+		if !reflect.DeepEqual(got, m) {
+			t.Fatalf("nside=%d: invalid round-trip:\ngot= %+v\nwant=%+v", nside, got, m)
+		}
+
+		nest, err := got.Pixels(0, Nested)
+		if err != nil {
+			t.Fatalf("could not reorder pixels: %+v", err)
+		}
+		for pix := 0; pix < npix; pix++ {
+			if got, want := nest[pix], m.Maps[0][Nest2Ring(nside, pix)]; got != want {
+				t.Fatalf("nside=%d: invalid nested pixel %d: got=%v, want=%v", nside, pix, got, want)
+			}
+		}
+		ring, err := (&HealpixMap{Nside: nside, Ordering: Nested, Maps: [][]float64{nest}}).Pixels(0, Ring)
+		if err != nil {
+			t.Fatalf("could not reorder pixels: %+v", err)
+		}
+		if !reflect.DeepEqual(ring, m.Maps[0]) {
+			t.Fatalf("nside=%d: invalid ring pixels", nside)
+		}
+	}
+}
+
+func TestReadHealpixExplicit(t *testing.T) {
+	tbl, err := fits.NewTable("PARTIAL", []fits.Column{
+		{Name: "PIXEL", Format: "J"},
+		{Name: "SIGNAL", Format: "E", Unit: "mK"},
+	}, fits.BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %+v", err)
+	}
+	err = tbl.Header().Append(
+		fits.Card{Name: "PIXTYPE", Value: "HEALPIX"},
+		fits.Card{Name: "ORDERING", Value: "NESTED"},
+		fits.Card{Name: "NSIDE", Value: 2},
+		fits.Card{Name: "INDXSCHM", Value: "EXPLICIT"},
+	)
+	if err != nil {
+		t.Fatalf("could not append cards: %+v", err)
+	}
+	for _, row := range []struct {
+		pix int32
+		v   float32
+	}{{3, 1.5}, {40, -2}} {
+		err = tbl.Write(&row.pix, &row.v)
+		if err != nil {
+			t.Fatalf("could not write row: %+v", err)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	f, err := fits.Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	for _, hdu := range []fits.HDU{fits.NewImage(8, nil), tbl} {
+		err = f.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %+v", err)
+		}
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := fits.Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	m, err := ReadHealpix(r)
+	if err != nil {
+		t.Fatalf("could not read map: %+v", err)
+	}
+	if m.Nside != 2 || m.Ordering != Nested {
+		t.Fatalf("invalid map: nside=%d, ordering=%v", m.Nside, m.Ordering)
+	}
+	if got, want := m.Names, []string{"SIGNAL"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid names: got=%q, want=%q", got, want)
+	}
+	for pix, v := range m.Maps[0] {
+		want := Unseen
+		switch pix {
+		case 3:
+			want = 1.5
+		case 40:
+			want = -2
+		}
+		if v != want {
+			t.Fatalf("invalid pixel %d: got=%v, want=%v", pix, v, want)
+		}
+	}
+}
+
+func TestWriteHealpixErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		m    HealpixMap
+	}{
+		{"nside", HealpixMap{Nside: 0, Names: []string{"A"}, Maps: [][]float64{{}}}},
+		{"nested", HealpixMap{Nside: 3, Ordering: Nested, Names: []string{"A"}, Maps: [][]float64{make([]float64, 108)}}},
+		{"no-map", HealpixMap{Nside: 1}},
+		{"names", HealpixMap{Nside: 1, Maps: [][]float64{make([]float64, 12)}}},
+		{"npix", HealpixMap{Nside: 1, Names: []string{"A"}, Maps: [][]float64{make([]float64, 10)}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := fits.Create(new(bytes.Buffer))
+			if err != nil {
+				t.Fatalf("could not create file: %+v", err)
+			}
+			defer f.Close()
+			err = WriteHealpix(f, &tc.m)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
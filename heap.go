@@ -0,0 +1,225 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"slices"
+	"strings"
+)
+
+// maxPDescriptor is the largest element count or heap offset a 'P'
+// (32-bit) array descriptor can hold.
+var maxPDescriptor int64 = math.MaxInt32
+
+// WithHeapGap reserves a gap of n bytes between the data and the heap
+// of a binary table created with NewTable, as described by its THEAP
+// keyword.
+func WithHeapGap(n int) Option {
+	return func(cfg *config) {
+		cfg.heapGap = n
+	}
+}
+
+// WithHeapDedup enables the deduplication of variable length arrays
+// written to a binary table created with NewTable: identical arrays
+// share their storage in the heap.
+func WithHeapDedup() Option {
+	return func(cfg *config) {
+		cfg.heapDedup = true
+	}
+}
+
+// WithHeapAlign aligns the variable length arrays written to a binary
+// table created with NewTable on a multiple of n bytes in the heap.
+func WithHeapAlign(n int) Option {
+	return func(cfg *config) {
+		cfg.heapAlign = n
+	}
+}
+
+// heapIndex indexes the arrays stored in a heap by content.
+type heapIndex struct {
+	seed maphash.Seed
+	arrs map[uint64][]heapArray
+}
+
+// heapArray is the location of an array in a heap.
+type heapArray struct {
+	off int
+	n   int
+}
+
+func newHeapIndex() *heapIndex {
+	return &heapIndex{
+		seed: maphash.MakeSeed(),
+		arrs: make(map[uint64][]heapArray),
+	}
+}
+
+// heapReserve reserves n bytes at the end of the heap, aligned as configured,
+// and returns their offset.
+func (t *Table) heapReserve(n int) int {
+	off := len(t.heap)
+	if n == 0 {
+		return off
+	}
+	if a := t.heapAlign; a > 1 && off%a != 0 {
+		off += a - off%a
+	}
+
+	end := off + n
+	if end > cap(t.heap) {
+		size := 2 * cap(t.heap)
+		if size < end {
+			size = end
+		}
+		heap := make([]byte, len(t.heap), size)
+		copy(heap, t.heap)
+		t.heap = heap
+	}
+	beg := len(t.heap)
+	t.heap = t.heap[:end]
+
+	// clear the alignment padding.
+	pad := t.heap[beg:off]
+	for i := range pad {
+		pad[i] = 0
+	}
+	return off
+}
+
+// heapDedup returns the offset of an array identical to the one stored
+// at the end of the heap, from off, if any.
+// The storage of the latter is then released, down to mark.
+func (t *Table) heapDedup(mark, off int) int {
+	if t.heapIdx == nil || off == len(t.heap) {
+		return off
+	}
+
+	arr := t.heap[off:]
+	key := maphash.Bytes(t.heapIdx.seed, arr)
+	for _, prev := range t.heapIdx.arrs[key] {
+		if prev.n == len(arr) && bytes.Equal(t.heap[prev.off:prev.off+prev.n], arr) {
+			t.heap = t.heap[:mark]
+			return prev.off
+		}
+	}
+	t.heapIdx.arrs[key] = append(t.heapIdx.arrs[key], heapArray{off: off, n: len(arr)})
+	return off
+}
+
+// heapTruncate truncates the heap to n bytes, forgetting the arrays stored
+// past n.
+func (t *Table) heapTruncate(n int) {
+	if n >= len(t.heap) {
+		return
+	}
+	t.heap = t.heap[:n]
+	if t.heapIdx == nil {
+		return
+	}
+	for key, arrs := range t.heapIdx.arrs {
+		arrs = slices.DeleteFunc(arrs, func(arr heapArray) bool {
+			return arr.off+arr.n > n
+		})
+		if len(arrs) == 0 {
+			delete(t.heapIdx.arrs, key)
+			continue
+		}
+		t.heapIdx.arrs[key] = arrs
+	}
+}
+
+// writeDescriptor writes the array descriptor of column icol at row irow.
+// 'P' columns are promoted to 'Q' columns when the descriptor does not
+// fit in 32 bits.
+func (t *Table) writeDescriptor(icol int, irow int64, n, off int) error {
+	col := &t.cols[icol]
+	if col.dtype.dsize == 8 && (int64(n) > maxPDescriptor || int64(off) > maxPDescriptor) {
+		err := t.promoteVLA(icol)
+		if err != nil {
+			return err
+		}
+	}
+
+	beg := t.rowsz*int(irow) + col.offset
+	w := newWriter(t.data[beg : beg+col.dtype.dsize])
+	switch col.dtype.dsize {
+	case 8:
+		w.writeI32(int32(n))
+		w.writeI32(int32(off))
+	case 16:
+		w.writeI64(int64(n))
+		w.writeI64(int64(off))
+	default:
+		return fmt.Errorf("fitsio: invalid array descriptor size (%d)", col.dtype.dsize)
+	}
+	return nil
+}
+
+// promoteVLA converts the 'P' column icol into a 'Q' column, widening the
+// array descriptors of all the rows of the table.
+func (t *Table) promoteVLA(icol int) error {
+	col := &t.cols[icol]
+	i := strings.IndexByte(col.Format, 'P')
+	if i < 0 {
+		return fmt.Errorf("fitsio: column %q is not a 'P' column (%s)", col.Name, col.Format)
+	}
+	form := col.Format[:i] + "Q" + col.Format[i+1:]
+	dtype, err := typeFromForm(form, BINARY_TBL)
+	if err != nil {
+		return err
+	}
+	dtype = zeroType(dtype, col.Bscale, col.Bzero)
+
+	var (
+		delta  = dtype.dsize - col.dtype.dsize
+		rowsz  = t.rowsz + delta
+		nrows  = len(t.data) / t.rowsz
+		data   = make([]byte, nrows*rowsz)
+		beg    = col.offset
+		end    = col.offset + col.dtype.dsize
+		newend = col.offset + dtype.dsize
+	)
+	for irow := 0; irow < nrows; irow++ {
+		src := t.data[irow*t.rowsz : (irow+1)*t.rowsz]
+		dst := data[irow*rowsz : (irow+1)*rowsz]
+		copy(dst[:beg], src[:beg])
+		copy(dst[newend:], src[end:])
+
+		var n, off int32
+		r := newReader(src[beg:end])
+		r.readI32(&n)
+		r.readI32(&off)
+		w := newWriter(dst[beg:newend])
+		w.writeI64(int64(n))
+		w.writeI64(int64(off))
+	}
+
+	t.data = data
+	t.rowsz = rowsz
+	t.hdr.axes[0] = rowsz
+	if card := t.hdr.Get("NAXIS1"); card != nil {
+		card.Value = rowsz
+	}
+
+	col.Format = form
+	col.dtype = dtype
+	col.scanner = nil
+	if card := t.hdr.Get(fmt.Sprintf("TFORM%d", icol+1)); card != nil {
+		card.Value = form
+	}
+	for i := icol + 1; i < len(t.cols); i++ {
+		t.cols[i].offset += delta
+		if card := t.hdr.Get(fmt.Sprintf("TBCOL%d", i+1)); card != nil && t.cols[i].Start == 0 {
+			card.Value = t.cols[i].offset + 1
+		}
+	}
+	return nil
+}
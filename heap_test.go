@@ -0,0 +1,256 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type heapData struct {
+	I32 int32     `fits:"i32"`
+	Vla []float32 `fits:"vla"`
+	F64 float64   `fits:"f64"`
+}
+
+func newHeapTable(t *testing.T, n int, opts ...Option) (*Table, []heapData) {
+	t.Helper()
+	tbl, err := NewTable("heap", []Column{
+		{Name: "i32", Format: "J"},
+		{Name: "vla", Format: "PE"},
+		{Name: "f64", Format: "D"},
+	}, BINARY_TBL, opts...)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+
+	var rows []heapData
+	for i := 0; i < n; i++ {
+		row := heapData{
+			I32: int32(i),
+			Vla: make([]float32, i%3+1),
+			F64: float64(-i),
+		}
+		for j := range row.Vla {
+			row.Vla[j] = float32(i%2 + j)
+		}
+		err = tbl.Write(&row)
+		if err != nil {
+			t.Fatalf("could not write row %d: %v", i, err)
+		}
+		rows = append(rows, row)
+	}
+	return tbl, rows
+}
+
+func checkHeapTable(t *testing.T, tbl *Table, want []heapData) {
+	t.Helper()
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	var got []heapData
+	err = rows.ScanAll(&got)
+	if err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows.\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+// heapOffsets returns the heap offsets of the arrays of column icol.
+func heapOffsets(tbl *Table, icol int) []int64 {
+	col := tbl.Col(icol)
+	var offs []int64
+	for irow := 0; irow < int(tbl.NumRows()); irow++ {
+		r := newReader(tbl.data[irow*tbl.rowsz+col.offset:])
+		switch col.dtype.dsize {
+		case 8:
+			var n, off int32
+			r.readI32(&n)
+			r.readI32(&off)
+			offs = append(offs, int64(off))
+		case 16:
+			var n, off int64
+			r.readI64(&n)
+			r.readI64(&off)
+			offs = append(offs, off)
+		}
+	}
+	return offs
+}
+
+func TestHeapDedup(t *testing.T) {
+	ref, want := newHeapTable(t, 12)
+	defer ref.Close()
+
+	tbl, _ := newHeapTable(t, 12, WithHeapDedup())
+	defer tbl.Close()
+
+	checkHeapTable(t, tbl, want)
+
+	// only 6 distinct arrays (3 lengths x 2 first values).
+	if got, want := len(tbl.heap), 2*(1+2+3)*4; got != want {
+		t.Fatalf("invalid heap size: got=%d, want=%d (no dedup: %d)", got, want, len(ref.heap))
+	}
+}
+
+func TestHeapAlign(t *testing.T) {
+	tbl, want := newHeapTable(t, 12, WithHeapAlign(16))
+	defer tbl.Close()
+
+	checkHeapTable(t, tbl, want)
+	for i, off := range heapOffsets(tbl, 1) {
+		if off%16 != 0 {
+			t.Fatalf("row %d: unaligned heap offset %d", i, off)
+		}
+	}
+}
+
+func TestHeapPromoteQ(t *testing.T) {
+	defer func(max int64) {
+		maxPDescriptor = max
+	}(maxPDescriptor)
+	maxPDescriptor = 40
+
+	tbl, want := newHeapTable(t, 12)
+	defer tbl.Close()
+
+	col := tbl.Col(1)
+	if got, want := col.Format, "QE"; got != want {
+		t.Fatalf("invalid format: got=%q, want=%q", got, want)
+	}
+	if got, want := tbl.Header().Get("TFORM2").Value, "QE"; got != want {
+		t.Fatalf("invalid TFORM2: got=%q, want=%q", got, want)
+	}
+	if got, want := tbl.Header().Axes()[0], 4+16+8; got != want {
+		t.Fatalf("invalid NAXIS1: got=%d, want=%d", got, want)
+	}
+	checkHeapTable(t, tbl, want)
+
+	var buf bytes.Buffer
+	f, err := Create(&buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	err = f.Write(NewImage(8, nil))
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %v", err)
+	}
+	err = f.Write(tbl)
+	if err != nil {
+		t.Fatalf("could not write table: %v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	r, err := Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer r.Close()
+	checkHeapTable(t, r.HDU(1).(*Table), want)
+}
+
+func TestHeapGap(t *testing.T) {
+	tbl, want := newHeapTable(t, 12, WithHeapGap(100))
+	defer tbl.Close()
+
+	encode := func(tbl *Table) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		f, err := Create(&buf)
+		if err != nil {
+			t.Fatalf("could not create file: %v", err)
+		}
+		err = f.Write(NewImage(8, nil))
+		if err != nil {
+			t.Fatalf("could not write primary HDU: %v", err)
+		}
+		err = f.Write(tbl)
+		if err != nil {
+			t.Fatalf("could not write table: %v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	raw := encode(tbl)
+	datasz := int(tbl.NumRows()) * tbl.rowsz
+	if got, want := tbl.Header().Get("THEAP").Value, datasz+100; got != want {
+		t.Fatalf("invalid THEAP: got=%v, want=%v", got, want)
+	}
+	if got, want := tbl.Header().Get("PCOUNT").Value, 100+len(tbl.heap); got != want {
+		t.Fatalf("invalid PCOUNT: got=%v, want=%v", got, want)
+	}
+
+	f, err := Open(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+	got := f.HDU(1).(*Table)
+	checkHeapTable(t, got, want)
+	if got.gap != 100 {
+		t.Fatalf("invalid gap: got=%d, want=%d", got.gap, 100)
+	}
+	if got, want := got.Header().Get("THEAP").Value, datasz+100; got != want {
+		t.Fatalf("invalid decoded THEAP: got=%v, want=%v", got, want)
+	}
+
+	// rows added to a decoded table update the size-dependent cards.
+	err = got.Write(&heapData{I32: 42, Vla: []float32{1, 2}})
+	if err != nil {
+		t.Fatalf("could not write row: %v", err)
+	}
+	want = append(want, heapData{I32: 42, Vla: []float32{1, 2}})
+	f2, err := Open(bytes.NewReader(encode(got)))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f2.Close()
+	checkHeapTable(t, f2.HDU(1).(*Table), want)
+	if got := f2.HDU(1).(*Table).gap; got != 100 {
+		t.Fatalf("invalid gap: got=%d, want=%d", got, 100)
+	}
+
+	// legacy files with THEAP=0, and invalid THEAP values.
+	setTHEAP := func(v int) []byte {
+		out := append([]byte(nil), raw...)
+		i := bytes.Index(out, []byte("THEAP   ="))
+		if i < 0 || i%80 != 0 {
+			t.Fatalf("could not find THEAP card")
+		}
+		line := []byte(fmt.Sprintf("THEAP   = %20d", v))
+		copy(out[i:i+80], append(line, bytes.Repeat([]byte(" "), 80-len(line))...))
+		return out
+	}
+
+	for _, tc := range []struct {
+		theap int
+		ok    bool
+	}{
+		{theap: 0, ok: true},
+		{theap: datasz, ok: true},
+		{theap: datasz - 1, ok: false},
+		{theap: datasz + 100 + len(tbl.heap) + 1, ok: false},
+	} {
+		_, err := Open(bytes.NewReader(setTHEAP(tc.theap)))
+		switch {
+		case tc.ok && err != nil:
+			t.Fatalf("THEAP=%d: could not open file: %v", tc.theap, err)
+		case !tc.ok && err == nil:
+			t.Fatalf("THEAP=%d: expected an error", tc.theap)
+		}
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"encoding/binary"
 	"fmt"
 	"image"
+	"iter"
 	"math"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/astrogo/fitsio/fltimg"
 )
@@ -19,9 +22,49 @@ type Image interface {
 	HDU
 	Read(ptr interface{}) error
 	Write(ptr interface{}) error
+
+	// WriteInto is like Write, but stores the raw pixels in buf when it is
+	// large enough, instead of allocating a new buffer.
+	// Passing the result of Raw allows to reuse the current pixel buffer.
+	WriteInto(buf []byte, ptr interface{}) error
+
+	// WriteConverted writes values of any numerical type, converting them
+	// to the pixel type of the image.
+	WriteConverted(ptr interface{}) error
+
 	Raw() []byte
 	Image() image.Image
 
+	// Scaled returns the physical values of the pixels, with the BSCALE
+	// and BZERO keywords applied and BLANK pixels set to NaN.
+	Scaled() ([]float64, error)
+
+	// Plane returns the i-th 2-dimensional plane of the image.
+	Plane(i int) (Image, error)
+
+	// ReadPlane reads the data of the i-th 2-dimensional plane of the image into ptr.
+	ReadPlane(ptr interface{}, i int) error
+
+	// ImageWithScaling returns a grayscale rendering of the image.
+	ImageWithScaling(s Scaling) (image.Image, error)
+
+	// ImageWithColormap returns a color rendering of the image.
+	ImageWithColormap(s Scaling, cmap Colormap) (image.Image, error)
+
+	// Stats computes summary statistics of the image pixels.
+	Stats(percentiles ...float64) (ImageStats, error)
+
+	// SubtractDark returns the image minus the provided dark frame.
+	SubtractDark(dark Image) (Image, error)
+
+	// DivideFlat returns the image divided by the provided flat field,
+	// normalized to its mean value.
+	DivideFlat(flat Image) (Image, error)
+
+	// Tiles returns an iterator over the tiles of the image, of shape
+	// tileShape.
+	Tiles(tileShape []int) iter.Seq[Tile]
+
 	freeze() error
 }
 
@@ -29,6 +72,8 @@ type Image interface {
 type imageHDU struct {
 	hdr Header
 	raw []byte
+
+	scaling *Scaling // display scaling applied by Image, or nil
 }
 
 // NewImage creates a new Image with bitpix size for the pixels and axes as its axes
@@ -40,6 +85,163 @@ func NewImage(bitpix int, axes []int) *imageHDU {
 	}
 }
 
+// NewImageFrom creates a new Image holding the pixels of the provided
+// image.Image, encoded with bitpix bits per pixel.
+//
+// Grayscale images (image.Gray, image.Gray16, fltimg.Gray32 and
+// fltimg.Gray64) are converted to a 2-dimensional image.
+// Other images are converted to a 3-dimensional cube with 3 planes,
+// holding the red, green and blue channels (NAXIS3=3.)
+//
+// Pixel values are rounded and clamped to the range of the bitpix type.
+// 16-bit unsigned values (from image.Gray16 or 16-bit color images) stored
+// with bitpix=16 use the BZERO=32768 convention.
+func NewImageFrom(img image.Image, bitpix int) (Image, error) {
+	if img == nil {
+		return nil, fmt.Errorf("fitsio: nil image")
+	}
+
+	var (
+		rect   = img.Bounds()
+		w, h   = rect.Dx(), rect.Dy()
+		planes [][]float64
+		u16    bool // whether the source pixels are 16-bit unsigned values
+	)
+
+	switch src := img.(type) {
+	case *image.Gray:
+		plane := make([]float64, 0, w*h)
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			for x := rect.Min.X; x < rect.Max.X; x++ {
+				plane = append(plane, float64(src.GrayAt(x, y).Y))
+			}
+		}
+		planes = [][]float64{plane}
+
+	case *image.Gray16:
+		plane := make([]float64, 0, w*h)
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			for x := rect.Min.X; x < rect.Max.X; x++ {
+				plane = append(plane, float64(src.Gray16At(x, y).Y))
+			}
+		}
+		planes = [][]float64{plane}
+		u16 = true
+
+	case *fltimg.Gray32:
+		plane := make([]float64, 0, w*h)
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			for x := rect.Min.X; x < rect.Max.X; x++ {
+				plane = append(plane, src.At64(x, y))
+			}
+		}
+		planes = [][]float64{plane}
+
+	case *fltimg.Gray64:
+		plane := make([]float64, 0, w*h)
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			for x := rect.Min.X; x < rect.Max.X; x++ {
+				plane = append(plane, src.At64(x, y))
+			}
+		}
+		planes = [][]float64{plane}
+
+	default:
+		switch img.(type) {
+		case *image.RGBA64, *image.NRGBA64:
+			u16 = true
+		}
+		planes = [][]float64{
+			make([]float64, 0, w*h),
+			make([]float64, 0, w*h),
+			make([]float64, 0, w*h),
+		}
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			for x := rect.Min.X; x < rect.Max.X; x++ {
+				r, g, b, _ := img.At(x, y).RGBA()
+				if !u16 {
+					r >>= 8
+					g >>= 8
+					b >>= 8
+				}
+				planes[0] = append(planes[0], float64(r))
+				planes[1] = append(planes[1], float64(g))
+				planes[2] = append(planes[2], float64(b))
+			}
+		}
+	}
+
+	axes := []int{w, h}
+	if len(planes) > 1 {
+		axes = append(axes, len(planes))
+	}
+
+	var (
+		bzero  = 0.0
+		minval = 0.0
+		maxval = 0.0
+	)
+	switch bitpix {
+	case 8:
+		minval, maxval = 0, math.MaxUint8
+	case 16:
+		minval, maxval = math.MinInt16, math.MaxInt16
+		if u16 {
+			bzero = 1 << 15
+		}
+	case 32:
+		minval, maxval = math.MinInt32, math.MaxInt32
+	case 64:
+		minval, maxval = math.MinInt64, math.MaxInt64
+	case -32, -64:
+	default:
+		return nil, fmt.Errorf("fitsio: invalid bitpix value (%d)", bitpix)
+	}
+
+	hdu := NewImage(bitpix, axes)
+	if bzero != 0 {
+		err := hdu.Header().Append(Card{
+			Name:    "BZERO",
+			Value:   bzero,
+			Comment: "offset data range to that of unsigned short",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pixsz := bitpix / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
+	hdu.raw = make([]byte, pixsz*w*h*len(planes))
+	wbuf := newWriter(hdu.raw)
+	for _, plane := range planes {
+		for _, v := range plane {
+			v -= bzero
+			if bitpix > 0 {
+				v = math.Max(minval, math.Min(maxval, math.Round(v)))
+			}
+			switch bitpix {
+			case 8:
+				wbuf.writeU8(uint8(v))
+			case 16:
+				wbuf.writeI16(int16(v))
+			case 32:
+				wbuf.writeI32(int32(v))
+			case 64:
+				wbuf.writeI64(int64(v))
+			case -32:
+				wbuf.writeF32(float32(v))
+			case -64:
+				wbuf.writeF64(v)
+			}
+		}
+	}
+
+	return hdu, nil
+}
+
 // Close closes this HDU, cleaning up cycles (if any) for garbage collection
 func (img *imageHDU) Close() error {
 	return nil
@@ -64,13 +266,67 @@ func (img *imageHDU) Name() string {
 	return card.Value.(string)
 }
 
-// Version returns the value of the 'EXTVER' Card (or 1 if none)
+// Version returns the value of the 'EXTVER' Card (or 1 if none or invalid)
 func (img *imageHDU) Version() int {
-	card := img.hdr.Get("EXTVER")
-	if card == nil {
-		return 1
+	return hduVersion(&img.hdr)
+}
+
+// DataSize returns the size in bytes of the data unit of the image, as
+// described by its header.
+func (img *imageHDU) DataSize() int64 {
+	axes := img.hdr.Axes()
+	if len(axes) == 0 {
+		return 0
+	}
+	bitpix := img.hdr.Bitpix()
+	if bitpix < 0 {
+		bitpix = -bitpix
+	}
+	size := int64(bitpix / 8)
+	for _, dim := range axes {
+		size *= int64(dim)
 	}
-	return card.Value.(int)
+	return size
+}
+
+// ImageData is the data unit of an image, as returned by Image.Data.
+type ImageData struct {
+	Axes []int // dimensions of the image, from the ``NAXISn`` keywords
+
+	// Pixels holds the raw pixels of the image, as a slice of the Go type
+	// of the pixels (uint8, int16, int32, int64, float32 or float64), in
+	// the order of the data unit: the first axis varies fastest.
+	// BSCALE and BZERO are not applied.
+	Pixels interface{}
+}
+
+// Data returns the pixels of the image, as an *ImageData.
+func (img *imageHDU) Data() (Value, error) {
+	ptype := pixelType(img.hdr.Bitpix())
+	if ptype == nil {
+		return nil, fmt.Errorf("fitsio: invalid bitpix value (%d)", img.hdr.Bitpix())
+	}
+	size := img.DataSize()
+	if int64(len(img.raw)) < size {
+		return nil, fmt.Errorf("fitsio: image with no raw data")
+	}
+
+	n := 0
+	if size > 0 {
+		n = int(size) / int(ptype.Size())
+	}
+	pixels := reflect.New(reflect.SliceOf(ptype))
+	pixels.Elem().Set(reflect.MakeSlice(pixels.Elem().Type(), n, n))
+	if n > 0 {
+		err := img.Read(pixels.Interface())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &ImageData{
+		Axes:   slices.Clone(img.hdr.Axes()),
+		Pixels: pixels.Elem().Interface(),
+	}, nil
 }
 
 // Raw returns the raw bytes which make the image
@@ -78,7 +334,12 @@ func (img *imageHDU) Raw() []byte {
 	return img.raw
 }
 
-// Read reads the image data into ptr
+// Read reads the image data into ptr.
+//
+// ptr is a pointer to a slice or an array holding the pixels in storage
+// order, or to a multi-dimensional slice or array with one dimension per
+// axis: the innermost dimension spans NAXIS1 and the outermost one NAXISn,
+// so that the pixel (x, y) of a 2-dimensional image is read into data[y][x].
 func (img *imageHDU) Read(ptr interface{}) error {
 	var err error
 	if img.raw == nil {
@@ -104,6 +365,10 @@ func (img *imageHDU) Read(ptr interface{}) error {
 		return fmt.Errorf("fitsio: invalid type (%v). expected array or slice", rt.Kind())
 	}
 
+	if k := rt.Elem().Kind(); k == reflect.Slice || k == reflect.Array {
+		return img.readNested(rv)
+	}
+
 	pixsz := hdr.Bitpix() / 8
 	if pixsz < 0 {
 		pixsz = -pixsz
@@ -111,8 +376,8 @@ func (img *imageHDU) Read(ptr interface{}) error {
 	otype := rt.Elem()
 	if int(otype.Size()) != pixsz {
 		return fmt.Errorf(
-			"fitsio: element-size do not match. bitpix=%d. elmt-size=%d (conversion not yet supported)",
-			hdr.Bitpix(), otype.Size())
+			"fitsio: element-size do not match. bitpix=%d. elmt-size=%d (conversion not yet supported): %w",
+			hdr.Bitpix(), otype.Size(), &ErrTypeMismatch{Want: pixelType(hdr.Bitpix()), Got: otype})
 	}
 
 	if rt.Kind() == reflect.Slice {
@@ -124,20 +389,16 @@ func (img *imageHDU) Read(ptr interface{}) error {
 	case 8:
 		switch slice := rv.Interface().(type) {
 		case []int8:
-			for i := 0; i < nelmts; i++ {
-				r.readI8(&slice[i])
-			}
+			r.readI8s(slice[:nelmts])
 			return nil
 		case []byte:
-			for i := 0; i < nelmts; i++ {
-				r.readByte(&slice[i])
-			}
+			r.readU8s(slice[:nelmts])
 			return nil
 		}
 
 		itype := reflect.TypeOf((*byte)(nil)).Elem()
 		if !rt.Elem().ConvertibleTo(itype) {
-			return fmt.Errorf("fitsio: can not convert []byte to %s", rt.Name())
+			return fmt.Errorf("fitsio: can not convert pixels: %w", &ErrTypeMismatch{Want: itype, Got: otype})
 		}
 		cnv := func(v reflect.Value) reflect.Value {
 			return v.Convert(otype)
@@ -152,15 +413,13 @@ func (img *imageHDU) Read(ptr interface{}) error {
 	case 16:
 		if otype.Kind() == reflect.Int16 {
 			slice := rv.Interface().([]int16)
-			for i := 0; i < nelmts; i++ {
-				r.readI16(&slice[i])
-			}
+			r.readI16s(slice[:nelmts])
 			return nil
 		}
 
 		itype := reflect.TypeOf((*int16)(nil)).Elem()
 		if !rt.Elem().ConvertibleTo(itype) {
-			return fmt.Errorf("fitsio: can not convert []int16 to %s", rt.Name())
+			return fmt.Errorf("fitsio: can not convert pixels: %w", &ErrTypeMismatch{Want: itype, Got: otype})
 		}
 		cnv := func(v reflect.Value) reflect.Value {
 			return v.Convert(otype)
@@ -174,15 +433,13 @@ func (img *imageHDU) Read(ptr interface{}) error {
 	case 32:
 		if otype.Kind() == reflect.Int32 {
 			slice := rv.Interface().([]int32)
-			for i := 0; i < nelmts; i++ {
-				r.readI32(&slice[i])
-			}
+			r.readI32s(slice[:nelmts])
 			return nil
 		}
 
 		itype := reflect.TypeOf((*int32)(nil)).Elem()
 		if !rt.Elem().ConvertibleTo(itype) {
-			return fmt.Errorf("fitsio: can not convert []int32 to %s", rt.Name())
+			return fmt.Errorf("fitsio: can not convert pixels: %w", &ErrTypeMismatch{Want: itype, Got: otype})
 		}
 		cnv := func(v reflect.Value) reflect.Value {
 			return v.Convert(otype)
@@ -196,15 +453,13 @@ func (img *imageHDU) Read(ptr interface{}) error {
 	case 64:
 		if otype.Kind() == reflect.Int64 {
 			slice := rv.Interface().([]int64)
-			for i := 0; i < nelmts; i++ {
-				r.readI64(&slice[i])
-			}
+			r.readI64s(slice[:nelmts])
 			return nil
 		}
 
 		itype := reflect.TypeOf((*int64)(nil)).Elem()
 		if !rt.Elem().ConvertibleTo(itype) {
-			return fmt.Errorf("fitsio: can not convert []int64 to %s", rt.Name())
+			return fmt.Errorf("fitsio: can not convert pixels: %w", &ErrTypeMismatch{Want: itype, Got: otype})
 		}
 		cnv := func(v reflect.Value) reflect.Value {
 			return v.Convert(otype)
@@ -218,15 +473,13 @@ func (img *imageHDU) Read(ptr interface{}) error {
 	case -32:
 		if otype.Kind() == reflect.Float32 {
 			slice := rv.Interface().([]float32)
-			for i := 0; i < nelmts; i++ {
-				r.readF32(&slice[i])
-			}
+			r.readF32s(slice[:nelmts])
 			return nil
 		}
 
 		itype := reflect.TypeOf((*float32)(nil)).Elem()
 		if !rt.Elem().ConvertibleTo(itype) {
-			return fmt.Errorf("fitsio: can not convert []float32 to %s", rt.Name())
+			return fmt.Errorf("fitsio: can not convert pixels: %w", &ErrTypeMismatch{Want: itype, Got: otype})
 		}
 		cnv := func(v reflect.Value) reflect.Value {
 			return v.Convert(otype)
@@ -240,15 +493,13 @@ func (img *imageHDU) Read(ptr interface{}) error {
 	case -64:
 		if otype.Kind() == reflect.Float64 {
 			slice := rv.Interface().([]float64)
-			for i := 0; i < nelmts; i++ {
-				r.readF64(&slice[i])
-			}
+			r.readF64s(slice[:nelmts])
 			return nil
 		}
 
 		itype := reflect.TypeOf((*float64)(nil)).Elem()
 		if !rt.Elem().ConvertibleTo(itype) {
-			return fmt.Errorf("fitsio: can not convert []float64 to %s", rt.Name())
+			return fmt.Errorf("fitsio: can not convert pixels: %w", &ErrTypeMismatch{Want: itype, Got: otype})
 		}
 		cnv := func(v reflect.Value) reflect.Value {
 			return v.Convert(otype)
@@ -266,10 +517,134 @@ func (img *imageHDU) Read(ptr interface{}) error {
 	return err
 }
 
+// readNested reads the image data into rv, a multi-dimensional slice or
+// array whose innermost dimension spans NAXIS1, and outermost one NAXISn.
+func (img *imageHDU) readNested(rv reflect.Value) error {
+	axes := img.Header().Axes()
+	rt := rv.Type()
+	dims := 0
+	for rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array {
+		if dims >= len(axes) {
+			return fmt.Errorf("fitsio: invalid type (%v) for an image with %d axes", rv.Type(), len(axes))
+		}
+		if n := axes[len(axes)-1-dims]; rt.Kind() == reflect.Array && rt.Len() != n {
+			return fmt.Errorf(
+				"fitsio: invalid array length for axis %d of %v (got=%d, want=%d)",
+				len(axes)-dims, rv.Type(), rt.Len(), n,
+			)
+		}
+		rt = rt.Elem()
+		dims++
+	}
+	if dims != len(axes) {
+		return fmt.Errorf("fitsio: invalid type (%v) for an image with %d axes", rv.Type(), len(axes))
+	}
+
+	nelmts := 1
+	for _, dim := range axes {
+		nelmts *= dim
+	}
+	flat := reflect.New(reflect.SliceOf(rt))
+	flat.Elem().Set(reflect.MakeSlice(flat.Elem().Type(), nelmts, nelmts))
+	err := img.Read(flat.Interface())
+	if err != nil {
+		return err
+	}
+
+	var fill func(v reflect.Value, axis int, data reflect.Value)
+	fill = func(v reflect.Value, axis int, data reflect.Value) {
+		n := axes[axis]
+		if v.Kind() == reflect.Slice {
+			if v.Cap() < n {
+				v.Set(reflect.MakeSlice(v.Type(), n, n))
+			}
+			v.SetLen(n)
+		}
+		if axis == 0 {
+			reflect.Copy(v, data)
+			return
+		}
+		sz := data.Len() / n
+		for i := 0; i < n; i++ {
+			fill(v.Index(i), axis-1, data.Slice(i*sz, (i+1)*sz))
+		}
+	}
+	fill(rv, len(axes)-1, flat.Elem())
+	return nil
+}
+
+// Plane returns the i-th 2-dimensional plane of a N-dimensional image.
+// Planes are indexed in storage order, ie: i spans all the axes beyond
+// the second one.
+//
+// The returned Image shares its pixel data with img: no copy is performed.
+// Its Image method can be used to render the plane.
+func (img *imageHDU) Plane(i int) (Image, error) {
+	axes := img.hdr.Axes()
+	if len(axes) < 2 {
+		return nil, fmt.Errorf("fitsio: image has less than 2 axes (%d)", len(axes))
+	}
+
+	nplanes := 1
+	for _, dim := range axes[2:] {
+		nplanes *= dim
+	}
+	if i < 0 || i >= nplanes {
+		return nil, fmt.Errorf("fitsio: plane index out of range (%d/%d)", i, nplanes)
+	}
+
+	pixsz := img.hdr.Bitpix() / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
+	sz := pixsz * axes[0] * axes[1]
+	beg := i * sz
+	end := beg + sz
+	if end > len(img.raw) {
+		return nil, fmt.Errorf("fitsio: image with no raw data for plane %d", i)
+	}
+
+	cards := make([]Card, 0, len(img.hdr.cards))
+	for _, card := range img.hdr.cards {
+		switch card.Name {
+		case "SIMPLE", "XTENSION", "EXTEND", "BITPIX", "NAXIS":
+			continue
+		}
+		if strings.HasPrefix(card.Name, "NAXIS") {
+			continue
+		}
+		cards = append(cards, card)
+	}
+
+	hdr := NewHeader(cards, IMAGE_HDU, img.hdr.Bitpix(), axes[:2])
+	return &imageHDU{
+		hdr:     *hdr,
+		raw:     img.raw[beg:end:end],
+		scaling: img.scaling,
+	}, nil
+}
+
+// ReadPlane reads the data of the i-th 2-dimensional plane of the image into ptr.
+func (img *imageHDU) ReadPlane(ptr interface{}, i int) error {
+	plane, err := img.Plane(i)
+	if err != nil {
+		return err
+	}
+	return plane.Read(ptr)
+}
+
 // Write writes the given image data to the HDU
 func (img *imageHDU) Write(data interface{}) error {
+	return img.WriteInto(nil, data)
+}
+
+// WriteInto writes the given image data to the HDU, using buf as storage
+// for the raw pixels if it is large enough.
+func (img *imageHDU) WriteInto(buf []byte, data interface{}) error {
 	var err error
-	rv := reflect.Indirect(reflect.ValueOf(data))
+	if rv := reflect.ValueOf(data); rv.Kind() == reflect.Ptr {
+		data = rv.Elem().Interface()
+	}
 
 	hdr := img.Header()
 	naxes := len(hdr.Axes())
@@ -286,94 +661,145 @@ func (img *imageHDU) Write(data interface{}) error {
 		pixsz = -pixsz
 	}
 
-	img.raw = make([]byte, pixsz*nelmts)
-	w := newWriter(img.raw)
-	switch data := rv.Interface().(type) {
+	raw := buf
+	if size := pixsz * nelmts; cap(raw) >= size {
+		raw = raw[:size]
+	} else {
+		raw = make([]byte, size)
+	}
+	w := newWriter(raw)
+	err = img.encodePixels(w, data)
+	if err != nil {
+		return err
+	}
+
+	// clear the pixels not provided by data, if any.
+	tail := raw[w.c:]
+	for i := range tail {
+		tail[i] = 0
+	}
+
+	img.raw = raw
+	return err
+}
+
+// encodePixels writes the pixels held by the data slice to w, checking
+// their type against the BITPIX of the image.
+func (img *imageHDU) encodePixels(w *wbuf, data interface{}) error {
+	bitpix := img.hdr.Bitpix()
+	switch data := data.(type) {
 	case []byte:
-		if hdr.Bitpix() != 8 {
-			return fmt.Errorf("fitsio: got a %T but bitpix!=%d", data, hdr.Bitpix())
+		if bitpix != 8 {
+			return img.typeMismatch(data)
 		}
-		copy(img.raw, data)
+		w.writeU8s(data)
 
 	case []int8:
-		if hdr.Bitpix() != 8 {
-			return fmt.Errorf("fitsio: got a %T but bitpix!=%d", data, hdr.Bitpix())
-		}
-		for _, v := range data {
-			w.writeI8(v)
+		if bitpix != 8 {
+			return img.typeMismatch(data)
 		}
+		w.writeI8s(data)
 
 	case []int16:
-		if hdr.Bitpix() != 16 {
-			return fmt.Errorf("fitsio: got a %T but bitpix!=%d", data, hdr.Bitpix())
-		}
-		for _, v := range data {
-			w.writeI16(v)
+		if bitpix != 16 {
+			return img.typeMismatch(data)
 		}
+		w.writeI16s(data)
 	case []uint16:
-		if hdr.Bitpix() != 16 {
-			return fmt.Errorf("fitsio: got a %T but bitpix!=%d", data, hdr.Bitpix())
-		}
-		for _, v := range data {
-			w.writeU16(v)
+		if bitpix != 16 {
+			return img.typeMismatch(data)
 		}
+		w.writeU16s(data)
 
 	case []int32:
-		if hdr.Bitpix() != 32 {
-			return fmt.Errorf("fitsio: got a %T but bitpix!=%d", data, hdr.Bitpix())
-		}
-		for _, v := range data {
-			w.writeI32(v)
+		if bitpix != 32 {
+			return img.typeMismatch(data)
 		}
+		w.writeI32s(data)
 	case []uint32:
-		if hdr.Bitpix() != 32 {
-			return fmt.Errorf("fitsio: got a %T but bitpix!=%d", data, hdr.Bitpix())
-		}
-		for _, v := range data {
-			w.writeU32(v)
+		if bitpix != 32 {
+			return img.typeMismatch(data)
 		}
+		w.writeU32s(data)
 
 	case []int64:
-		if hdr.Bitpix() != 64 {
-			return fmt.Errorf("fitsio: got a %T but bitpix!=%d", data, hdr.Bitpix())
-		}
-		for _, v := range data {
-			w.writeI64(v)
+		if bitpix != 64 {
+			return img.typeMismatch(data)
 		}
+		w.writeI64s(data)
 	case []uint64:
-		if hdr.Bitpix() != 64 {
-			return fmt.Errorf("fitsio: got a %T but bitpix!=%d", data, hdr.Bitpix())
-		}
-		for _, v := range data {
-			w.writeU64(v)
+		if bitpix != 64 {
+			return img.typeMismatch(data)
 		}
+		w.writeU64s(data)
 
 	case []float32:
-		if hdr.Bitpix() != -32 {
-			return fmt.Errorf("fitsio: got a %T but bitpix!=%d", data, hdr.Bitpix())
-		}
-		for _, v := range data {
-			w.writeF32(v)
+		if bitpix != -32 {
+			return img.typeMismatch(data)
 		}
+		w.writeF32s(data)
 
 	case []float64:
-		if hdr.Bitpix() != -64 {
-			return fmt.Errorf("fitsio: got a %T but bitpix!=%d", data, hdr.Bitpix())
-		}
-		for _, v := range data {
-			w.writeF64(v)
+		if bitpix != -64 {
+			return img.typeMismatch(data)
 		}
+		w.writeF64s(data)
 
 	default:
-		return fmt.Errorf("fitsio: invalid image type (%T)", rv.Interface())
+		return fmt.Errorf("fitsio: invalid image type (%T)", data)
 	}
+	return nil
+}
 
-	//img.raw = buf.Bytes()
-	return err
+// typeMismatch returns the error reporting that data can not hold the
+// pixels of the image.
+func (img *imageHDU) typeMismatch(data interface{}) error {
+	bitpix := img.hdr.Bitpix()
+	return fmt.Errorf("fitsio: invalid image data for bitpix=%d: %w", bitpix, &ErrTypeMismatch{
+		Want: reflect.SliceOf(pixelType(bitpix)),
+		Got:  reflect.TypeOf(data),
+	})
+}
+
+// pixelType returns the Go type of the pixels of an image with the
+// provided BITPIX value, or nil if BITPIX is invalid.
+func pixelType(bitpix int) reflect.Type {
+	switch bitpix {
+	case 8:
+		return reflect.TypeOf(uint8(0))
+	case 16:
+		return reflect.TypeOf(int16(0))
+	case 32:
+		return reflect.TypeOf(int32(0))
+	case 64:
+		return reflect.TypeOf(int64(0))
+	case -32:
+		return reflect.TypeOf(float32(0))
+	case -64:
+		return reflect.TypeOf(float64(0))
+	}
+	return nil
 }
 
 // Image returns an image.Image value.
+// Pixels are scaled by the BSCALE and BZERO keywords and re-quantized
+// into the pixel type of the returned image, when needed: physical values
+// outside of the range of that pixel type, such as negative values, are
+// mapped linearly from the range of the physical values of the image.
+//
+// Images decoded with the WithScaling option are rendered with the
+// configured display scaling, as by ImageWithScaling.
+//
+// Image returns nil if the pixels can not be decoded; ImageWithScaling
+// reports the corresponding error.
 func (img *imageHDU) Image() image.Image {
+	if img.scaling != nil {
+		m, err := img.ImageWithScaling(*img.scaling)
+		if err != nil {
+			return nil
+		}
+		return m
+	}
 
 	// Getting the HDU bitpix and axes.
 	header := img.Header()
@@ -399,73 +825,41 @@ func (img *imageHDU) Image() image.Image {
 
 	// handle BSCALE/BZERO rescaling header keywords.
 	// see: https://heasarc.gsfc.nasa.gov/docs/fcg/standard_dict.html
-	if bscale, bzero := header.Get("BSCALE"), header.Get("BZERO"); bscale != nil || bzero != nil {
-		scale := 1.0
-		zero := 0.0
-		if bzero != nil {
-			switch v := bzero.Value.(type) {
-			case float64:
-				zero = v
-			case int:
-				zero = float64(v)
-			default:
-				panic("fitsio: handle non-float types for BSCALE and BZERO")
-			}
-		}
-		if bscale != nil {
-			switch v := bscale.Value.(type) {
-			case float64:
-				scale = v
-			case int:
-				scale = float64(v)
-			default:
-				panic("fitsio: handle non-float types for BSCALE and BZERO")
-			}
+	//
+	// physical values are computed in floating point from the signed
+	// stored values, and then re-quantized into the pixel type of the
+	// returned image.
+	if header.Get("BSCALE") != nil || header.Get("BZERO") != nil {
+		pix, err := newPixels(header, raw)
+		if err != nil {
+			return nil
 		}
+		var q requantizer
 		switch bitpix {
 		case 8:
-			zero := uint8(zero)
-			scale := uint8(scale)
-			for i, v := range raw {
-				raw[i] = zero + scale*v
-			}
-
+			q = newRequantizer(pix, math.MaxUint8)
 		case 16:
-			zero := uint16(zero)
-			scale := uint16(scale)
-			for i := 0; i < len(raw); i += 2 {
-				v := zero + scale*binary.BigEndian.Uint16(raw[i:i+2])
-				binary.BigEndian.PutUint16(raw[i:i+2], v)
-			}
-
+			q = newRequantizer(pix, math.MaxUint16)
 		case 32:
-			zero := uint32(zero)
-			scale := uint32(scale)
-			for i := 0; i < len(raw); i += 4 {
-				v := zero + scale*binary.BigEndian.Uint32(raw[i:i+4])
-				binary.BigEndian.PutUint32(raw[i:i+4], v)
-			}
-
+			q = newRequantizer(pix, math.MaxUint32)
 		case 64:
-			zero := uint64(zero)
-			scale := uint64(scale)
-			for i := 0; i < len(raw); i += 8 {
-				v := zero + scale*binary.BigEndian.Uint64(raw[i:i+8])
-				binary.BigEndian.PutUint64(raw[i:i+8], v)
-			}
-
-		case -32:
-			zero := float32(zero)
-			scale := float32(scale)
-			for i := 0; i < len(raw); i += 4 {
-				v := zero + scale*math.Float32frombits(binary.BigEndian.Uint32(raw[i:i+4]))
-				binary.BigEndian.PutUint32(raw[i:i+4], math.Float32bits(v))
-			}
-
-		case -64:
-			for i := 0; i < len(raw); i += 8 {
-				v := zero + scale*math.Float64frombits(binary.BigEndian.Uint64(raw[i:i+8]))
-				binary.BigEndian.PutUint64(raw[i:i+8], math.Float64bits(v))
+			q = newRequantizer(pix, math.MaxUint64)
+		}
+		for i := 0; i < pix.n; i++ {
+			v, _ := pix.at(i)
+			switch bitpix {
+			case 8:
+				raw[i] = uint8(q.quantize(v))
+			case 16:
+				binary.BigEndian.PutUint16(raw[2*i:], uint16(q.quantize(v)))
+			case 32:
+				binary.BigEndian.PutUint32(raw[4*i:], uint32(q.quantize(v)))
+			case 64:
+				binary.BigEndian.PutUint64(raw[8*i:], q.quantize(v))
+			case -32:
+				binary.BigEndian.PutUint32(raw[4*i:], math.Float32bits(float32(v)))
+			case -64:
+				binary.BigEndian.PutUint64(raw[8*i:], math.Float64bits(v))
 			}
 		}
 	}
@@ -519,21 +913,90 @@ func (img *imageHDU) Image() image.Image {
 
 }
 
+// requantizer maps physical pixel values to the [0, max] range of the
+// pixels of an image.Image.
+type requantizer struct {
+	max    uint64
+	lo, hi float64 // range of physical values mapped to [0, max]
+}
+
+// newQuantizer returns the quantizer of the physical values of pix.
+// Values are kept as is when they all lie in the [0, max] range, and
+// mapped linearly from their own range otherwise.
+func newRequantizer(pix pixels, max uint64) requantizer {
+	q := requantizer{max: max, lo: 0, hi: float64(max)}
+	lo, hi := math.Inf(+1), math.Inf(-1)
+	for i := 0; i < pix.n; i++ {
+		v, ok := pix.at(i)
+		if !ok || math.IsNaN(v) {
+			continue
+		}
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	if lo < 0 || hi > float64(max) {
+		q.lo, q.hi = lo, hi
+	}
+	return q
+}
+
+// quantize rounds the physical value v, mapped to the [0, max] range, to
+// the nearest integer. NaN values are mapped to 0.
+func (q requantizer) quantize(v float64) uint64 {
+	if q.lo != 0 || q.hi != float64(q.max) {
+		x := 0.0
+		if q.hi > q.lo {
+			x = (v - q.lo) / (q.hi - q.lo) * float64(q.max)
+		}
+		v = x
+	}
+	switch {
+	case !(v > 0):
+		return 0
+	case v >= float64(q.max):
+		return q.max
+	}
+	return uint64(math.Round(v))
+}
+
+// Scaled returns the physical values of the image pixels, in storage order.
+// The BSCALE and BZERO keywords are applied to the stored values, and
+// BLANK pixels are returned as NaN.
+func (img *imageHDU) Scaled() ([]float64, error) {
+	pix, err := newPixels(&img.hdr, img.raw)
+	if err != nil {
+		return nil, err
+	}
+	vs := make([]float64, pix.n)
+	for i := range vs {
+		vs[i], _ = pix.at(i)
+	}
+	return vs, nil
+}
+
 // freeze freezes an Image before writing, finalizing header values.
 func (img *imageHDU) freeze() error {
 	var err error
-	card := img.Header().Get("XTENSION")
-	if card != nil {
-		return err
+	hdr := img.Header()
+	if card := hdr.Get("XTENSION"); card == nil {
+		err = hdr.prepend(Card{
+			Name:    "XTENSION",
+			Value:   "IMAGE   ",
+			Comment: "IMAGE extension",
+		})
+		if err != nil {
+			return err
+		}
 	}
 
-	err = img.Header().prepend(Card{
-		Name:    "XTENSION",
-		Value:   "IMAGE   ",
-		Comment: "IMAGE extension",
-	})
-	if err != nil {
-		return err
+	for _, card := range []Card{
+		{Name: "PCOUNT", Value: 0, Comment: "number of parameters"},
+		{Name: "GCOUNT", Value: 1, Comment: "number of groups"},
+	} {
+		err = hdr.setMandatory(card)
+		if err != nil {
+			return err
+		}
 	}
 
 	return err
@@ -6,6 +6,7 @@ package fitsio
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"image"
 	"image/color"
@@ -1008,3 +1009,703 @@ func TestPanicWhenNAXISTooLarge(t *testing.T) {
 	ax := make([]int, 1000)
 	_ = NewImage(32, ax)
 }
+
+func TestImageStats(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		bitpix int
+		data   interface{}
+		cards  []Card
+		ps     []float64
+		want   ImageStats
+	}{
+		{
+			name:   "i16",
+			bitpix: 16,
+			data:   []int16{4, 1, 3, 2, 5, 6},
+			ps:     []float64{0, 25, 100},
+			want: ImageStats{
+				N: 6, Min: 1, Max: 6, Mean: 3.5, StdDev: math.Sqrt(3.5), Median: 3.5,
+				Percentiles: []float64{1, 2.25, 6},
+			},
+		},
+		{
+			name:   "i16-bscale-bzero-blank",
+			bitpix: 16,
+			data:   []int16{4, 1, -1, 3, 2, -1},
+			cards: []Card{
+				{Name: "BSCALE", Value: 2.0},
+				{Name: "BZERO", Value: 10},
+				{Name: "BLANK", Value: -1},
+			},
+			want: ImageStats{
+				N: 4, NBlank: 2, Min: 12, Max: 18, Mean: 15, StdDev: math.Sqrt(20.0 / 3), Median: 15,
+				Percentiles: []float64{},
+			},
+		},
+		{
+			name:   "f64-nan",
+			bitpix: -64,
+			data:   []float64{1, math.NaN(), 2, 3, math.NaN(), 4},
+			ps:     []float64{50},
+			want: ImageStats{
+				N: 4, NBlank: 2, Min: 1, Max: 4, Mean: 2.5, StdDev: math.Sqrt(5.0 / 3), Median: 2.5,
+				Percentiles: []float64{2.5},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			img := NewImage(test.bitpix, []int{3, 2})
+			err := img.Header().Append(test.cards...)
+			if err != nil {
+				t.Fatalf("could not append cards: %v", err)
+			}
+			err = img.Write(test.data)
+			if err != nil {
+				t.Fatalf("could not write image: %v", err)
+			}
+
+			got, err := img.Stats(test.ps...)
+			if err != nil {
+				t.Fatalf("could not compute stats: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("invalid stats.\ngot= %+v\nwant=%+v", got, test.want)
+			}
+		})
+	}
+
+	img := NewImage(8, []int{2, 2})
+	err := img.Write([]byte{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	_, err = img.Stats(101)
+	if err == nil {
+		t.Fatalf("expected an error for an invalid percentile")
+	}
+}
+
+func TestImageScaled(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		bitpix int
+		data   interface{}
+		cards  []Card
+		scaled []float64
+		want   image.Image
+	}{
+		{
+			name:   "i16-bzero",
+			bitpix: 16,
+			data:   []int16{-32768, -1, 0, 1, 32767, -32767},
+			cards:  []Card{{Name: "BZERO", Value: 32768}},
+			scaled: []float64{0, 32767, 32768, 32769, 65535, 1},
+			want: &image.Gray16{
+				Pix:    []byte{0x00, 0x00, 0x7f, 0xff, 0x80, 0x00, 0x80, 0x01, 0xff, 0xff, 0x00, 0x01},
+				Stride: 6,
+				Rect:   image.Rect(0, 0, 3, 2),
+			},
+		},
+		{
+			name:   "i16-negative",
+			bitpix: 16,
+			data:   []int16{-2, -1, 0, 1, 2, 3},
+			cards:  []Card{{Name: "BZERO", Value: -1}},
+			scaled: []float64{-3, -2, -1, 0, 1, 2},
+			want: &image.Gray16{
+				Pix:    []byte{0x00, 0x00, 0x33, 0x33, 0x66, 0x66, 0x99, 0x99, 0xcc, 0xcc, 0xff, 0xff},
+				Stride: 6,
+				Rect:   image.Rect(0, 0, 3, 2),
+			},
+		},
+		{
+			name:   "u8-bzero-negative",
+			bitpix: 8,
+			data:   []uint8{0, 64, 128, 192, 255, 1},
+			cards:  []Card{{Name: "BZERO", Value: -128}},
+			scaled: []float64{-128, -64, 0, 64, 127, -127},
+			want: &image.Gray{
+				Pix:    []byte{0, 64, 128, 192, 255, 1},
+				Stride: 3,
+				Rect:   image.Rect(0, 0, 3, 2),
+			},
+		},
+		{
+			name:   "u8-bscale-blank",
+			bitpix: 8,
+			data:   []uint8{0, 1, 2, 3, 255, 100},
+			cards: []Card{
+				{Name: "BSCALE", Value: 0.5},
+				{Name: "BZERO", Value: 10.0},
+				{Name: "BLANK", Value: 255},
+			},
+			scaled: []float64{10, 10.5, 11, 11.5, math.NaN(), 60},
+			want: &image.Gray{
+				Pix:    []byte{10, 11, 11, 12, 0, 60},
+				Stride: 3,
+				Rect:   image.Rect(0, 0, 3, 2),
+			},
+		},
+		{
+			name:   "i32-range",
+			bitpix: 32,
+			data:   []int32{-1, 0, 1, 2, math.MaxInt32, math.MinInt32},
+			cards:  []Card{{Name: "BSCALE", Value: 4}},
+			scaled: []float64{-4, 0, 4, 8, 4 * math.MaxInt32, 4 * math.MinInt32},
+			want: &image.RGBA{
+				Pix: []byte{
+					0x7f, 0xff, 0xff, 0xff, 0x80, 0x00, 0x00, 0x00, 0x80, 0x00, 0x00, 0x01,
+					0x80, 0x00, 0x00, 0x02, 0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00,
+				},
+				Stride: 12,
+				Rect:   image.Rect(0, 0, 3, 2),
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			img := NewImage(test.bitpix, []int{3, 2})
+			err := img.Header().Append(test.cards...)
+			if err != nil {
+				t.Fatalf("could not append cards: %v", err)
+			}
+			err = img.Write(test.data)
+			if err != nil {
+				t.Fatalf("could not write image: %v", err)
+			}
+
+			scaled, err := img.Scaled()
+			if err != nil {
+				t.Fatalf("could not scale pixels: %v", err)
+			}
+			if len(scaled) != len(test.scaled) {
+				t.Fatalf("invalid number of pixels: got=%d, want=%d", len(scaled), len(test.scaled))
+			}
+			for i, v := range scaled {
+				want := test.scaled[i]
+				if v != want && !(math.IsNaN(v) && math.IsNaN(want)) {
+					t.Fatalf("invalid scaled pixel %d: got=%v, want=%v", i, v, want)
+				}
+			}
+
+			if got := img.Image(); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("invalid image:\ngot= %v\nwant=%v", got, test.want)
+			}
+		})
+	}
+
+	// pixels which can not be decoded.
+	img := NewImage(16, []int{3, 2})
+	img.Header().Set("BZERO", 32768, "")
+	img.raw = []byte{0, 1}
+	if got := img.Image(); got != nil {
+		t.Fatalf("expected a nil image. got=%v", got)
+	}
+	_, err := img.ImageWithScaling(LinearScale)
+	if err == nil {
+		t.Fatalf("expected an error for truncated pixels")
+	}
+}
+
+func TestImageWithScaling(t *testing.T) {
+	img := NewImage(-64, []int{4, 2})
+	err := img.Write([]float64{0, 1, 2, 3, 4, 5, 6, math.NaN()})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+
+	for _, test := range []struct {
+		name string
+		s    Scaling
+		want []uint8
+	}{
+		{
+			name: "linear",
+			s:    LinearScale,
+			want: []uint8{0, 43, 85, 128, 170, 213, 255, 0},
+		},
+		{
+			name: "percentile",
+			s:    PercentileScale(50, 100),
+			want: []uint8{0, 0, 0, 0, 85, 170, 255, 0},
+		},
+		{
+			name: "sqrt",
+			s:    Scaling{Stretch: SqrtStretch},
+			want: []uint8{0, 104, 147, 180, 208, 233, 255, 0},
+		},
+		{
+			name: "histeq",
+			s:    HistEqScale,
+			want: []uint8{36, 73, 109, 146, 182, 219, 255, 0},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := img.ImageWithScaling(test.s)
+			if err != nil {
+				t.Fatalf("could not render image: %v", err)
+			}
+			gray, ok := m.(*image.Gray)
+			if !ok {
+				t.Fatalf("invalid image type %T", m)
+			}
+			if got, want := gray.Bounds(), image.Rect(0, 0, 4, 2); got != want {
+				t.Fatalf("invalid bounds. got=%v, want=%v", got, want)
+			}
+			if got, want := gray.Pix, test.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid pixels.\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+
+	_, err = img.ImageWithScaling(PercentileScale(90, 10))
+	if err == nil {
+		t.Fatalf("expected an error for an invalid percentile interval")
+	}
+
+	// Image applies the scaling configured with WithScaling.
+	var buf bytes.Buffer
+	w, err := Create(&buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	err = w.Write(img)
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	for _, test := range []struct {
+		name string
+		opts []Option
+		want image.Image
+	}{
+		{
+			name: "raw",
+			want: img.Image(),
+		},
+		{
+			name: "percentile",
+			opts: []Option{WithScaling(PercentileScale(50, 100))},
+			want: &image.Gray{
+				Pix:    []uint8{0, 0, 0, 0, 85, 170, 255, 0},
+				Stride: 4,
+				Rect:   image.Rect(0, 0, 4, 2),
+			},
+		},
+	} {
+		f, err := Open(bytes.NewReader(buf.Bytes()), test.opts...)
+		if err != nil {
+			t.Fatalf("could not open file: %v", err)
+		}
+		defer f.Close()
+
+		hdu := f.HDU(0).(Image)
+		if got := hdu.Image(); !reflect.DeepEqual(got, test.want) {
+			t.Fatalf("%s: invalid image.\ngot= %v\nwant=%v", test.name, got, test.want)
+		}
+		plane, err := hdu.Plane(0)
+		if err != nil {
+			t.Fatalf("could not get plane: %v", err)
+		}
+		if got := plane.Image(); !reflect.DeepEqual(got, test.want) {
+			t.Fatalf("%s: invalid plane image.\ngot= %v\nwant=%v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestZScale(t *testing.T) {
+	// a flat background with a few bright outliers.
+	vs := make([]float64, 2000)
+	for i := range vs {
+		vs[i] = 100 + float64(i%10)
+	}
+	vs[10] = 1e6
+	vs[500] = 1e5
+	vs[1500] = -1e5
+
+	lo, hi := zscale(vs, zscaleSamples, zscaleContrast)
+	// outliers must not drive the display range.
+	if lo < 0 || hi > 200 || lo >= hi {
+		t.Fatalf("invalid zscale range [%v, %v]", lo, hi)
+	}
+}
+
+func TestImageWithColormap(t *testing.T) {
+	img := NewImage(16, []int{3, 1})
+	err := img.Header().Append(Card{Name: "BLANK", Value: -1})
+	if err != nil {
+		t.Fatalf("could not append BLANK: %v", err)
+	}
+	err = img.Write([]int16{0, 10, -1})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+
+	for _, test := range []struct {
+		cmap Colormap
+		want []uint8
+	}{
+		{
+			cmap: GrayColormap,
+			want: []uint8{0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0},
+		},
+		{
+			cmap: GrayColormap.Invert(),
+			want: []uint8{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0xff, 0, 0, 0, 0},
+		},
+		{
+			cmap: HeatColormap,
+			want: []uint8{0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0},
+		},
+		{
+			cmap: ViridisColormap,
+			want: []uint8{0x44, 0x01, 0x54, 0xff, 0xfd, 0xe7, 0x25, 0xff, 0, 0, 0, 0},
+		},
+	} {
+		t.Run(test.cmap.Name, func(t *testing.T) {
+			m, err := img.ImageWithColormap(LinearScale, test.cmap)
+			if err != nil {
+				t.Fatalf("could not render image: %v", err)
+			}
+			rgba, ok := m.(*image.RGBA)
+			if !ok {
+				t.Fatalf("invalid image type %T", m)
+			}
+			if got, want := rgba.Pix, test.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid pixels.\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+
+	if got, want := HeatColormap.At(0.5), (color.RGBA{0xff, 0x80, 0x00, 0xff}); got != want {
+		t.Fatalf("invalid interpolated color. got=%v, want=%v", got, want)
+	}
+}
+
+func TestNewImageFrom(t *testing.T) {
+	rect := image.Rect(0, 0, 3, 2)
+
+	gray := image.NewGray(rect)
+	gray16 := image.NewGray16(rect)
+	rgba := image.NewRGBA(rect)
+	for i := 0; i < 6; i++ {
+		x, y := i%3, i/3
+		gray.SetGray(x, y, color.Gray{Y: uint8(40 * i)})
+		gray16.SetGray16(x, y, color.Gray16{Y: uint16(12000 * i)})
+		rgba.SetRGBA(x, y, color.RGBA{R: uint8(i), G: uint8(10 + i), B: uint8(20 + i), A: 0xff})
+	}
+
+	for _, test := range []struct {
+		name   string
+		img    image.Image
+		bitpix int
+	}{
+		{"gray-8", gray, 8},
+		{"gray16-16", gray16, 16},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			hdu, err := NewImageFrom(test.img, test.bitpix)
+			if err != nil {
+				t.Fatalf("could not create image: %v", err)
+			}
+			if got, want := hdu.Header().Axes(), []int{3, 2}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid axes. got=%v, want=%v", got, want)
+			}
+			got := hdu.Image()
+			for i := 0; i < 6; i++ {
+				x, y := i%3, i/3
+				c1 := color.Gray16Model.Convert(got.At(x, y))
+				c2 := color.Gray16Model.Convert(test.img.At(x, y))
+				if c1 != c2 {
+					t.Fatalf("invalid pixel (%d,%d). got=%v, want=%v", x, y, c1, c2)
+				}
+			}
+		})
+	}
+
+	t.Run("rgba-cube", func(t *testing.T) {
+		hdu, err := NewImageFrom(rgba, 16)
+		if err != nil {
+			t.Fatalf("could not create image: %v", err)
+		}
+		if got, want := hdu.Header().Axes(), []int{3, 2, 3}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid axes. got=%v, want=%v", got, want)
+		}
+		got := make([]int16, 18)
+		err = hdu.Read(&got)
+		if err != nil {
+			t.Fatalf("could not read image: %v", err)
+		}
+		want := []int16{
+			0, 1, 2, 3, 4, 5,
+			10, 11, 12, 13, 14, 15,
+			20, 21, 22, 23, 24, 25,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid pixels.\ngot= %v\nwant=%v", got, want)
+		}
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		raw := new(bytes.Buffer)
+		binary.Write(raw, binary.BigEndian, []float64{-1.5, 0, 1.5, 2, 1e10, 3})
+		src := fltimg.NewGray64(rect, raw.Bytes())
+
+		hdu, err := NewImageFrom(src, -64)
+		if err != nil {
+			t.Fatalf("could not create image: %v", err)
+		}
+		got := make([]float64, 6)
+		err = hdu.Read(&got)
+		if err != nil {
+			t.Fatalf("could not read image: %v", err)
+		}
+		if want := []float64{-1.5, 0, 1.5, 2, 1e10, 3}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid pixels.\ngot= %v\nwant=%v", got, want)
+		}
+
+		hdu, err = NewImageFrom(src, 8)
+		if err != nil {
+			t.Fatalf("could not create image: %v", err)
+		}
+		u8 := make([]byte, 6)
+		err = hdu.Read(&u8)
+		if err != nil {
+			t.Fatalf("could not read image: %v", err)
+		}
+		if want := []byte{0, 0, 2, 2, 255, 3}; !reflect.DeepEqual(u8, want) {
+			t.Fatalf("invalid clamped pixels.\ngot= %v\nwant=%v", u8, want)
+		}
+	})
+
+	_, err := NewImageFrom(gray, 12)
+	if err == nil {
+		t.Fatalf("expected an error for an invalid bitpix")
+	}
+}
+
+func TestImagePlane(t *testing.T) {
+	data := make([]int16, 2*3*2*2)
+	for i := range data {
+		data[i] = int16(i)
+	}
+
+	im := NewImage(16, []int{2, 3, 2, 2})
+	err := im.Header().Append(Card{Name: "BZERO", Value: 10})
+	if err != nil {
+		t.Fatalf("could not append card: %v", err)
+	}
+	err = im.Write(data)
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		plane, err := im.Plane(i)
+		if err != nil {
+			t.Fatalf("could not extract plane %d: %v", i, err)
+		}
+		if got, want := plane.Header().Axes(), []int{2, 3}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("plane %d: invalid axes. got=%v, want=%v", i, got, want)
+		}
+		if got := plane.Header().Get("NAXIS3"); got != nil {
+			t.Fatalf("plane %d: unexpected NAXIS3 card", i)
+		}
+		if got := plane.Header().Get("BZERO"); got == nil || got.Value != 10 {
+			t.Fatalf("plane %d: invalid BZERO card: %v", i, got)
+		}
+
+		got := make([]int16, 6)
+		err = im.ReadPlane(&got, i)
+		if err != nil {
+			t.Fatalf("could not read plane %d: %v", i, err)
+		}
+		if want := data[6*i : 6*i+6]; !reflect.DeepEqual(got, want) {
+			t.Fatalf("plane %d: invalid data.\ngot= %v\nwant=%v", i, got, want)
+		}
+	}
+
+	for _, i := range []int{-1, 4} {
+		_, err := im.Plane(i)
+		if err == nil {
+			t.Fatalf("expected an error for plane %d", i)
+		}
+	}
+}
+
+func TestImageWriteInto(t *testing.T) {
+	im := NewImage(-32, []int{4, 2})
+	data := []float32{1, 2, 3, 4, 5, 6, 7, 8}
+	err := im.Write(data)
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+
+	raw := im.Raw()
+	data[0] = -1
+	err = im.WriteInto(raw, &data)
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	if &im.Raw()[0] != &raw[0] {
+		t.Fatalf("pixel buffer was not reused")
+	}
+	got := make([]float32, 8)
+	err = im.Read(&got)
+	if err != nil {
+		t.Fatalf("could not read image: %v", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Fatalf("invalid image data.\ngot= %v\nwant=%v", got, data)
+	}
+
+	// missing pixels are cleared.
+	err = im.WriteInto(im.Raw(), data[:6])
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	err = im.Read(&got)
+	if err != nil {
+		t.Fatalf("could not read image: %v", err)
+	}
+	if want := []float32{-1, 2, 3, 4, 5, 6, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid image data.\ngot= %v\nwant=%v", got, want)
+	}
+
+	// buffers too small are not used.
+	small := make([]byte, 4)
+	err = im.WriteInto(small, data)
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	if len(im.Raw()) != 32 || small[0] != 0 {
+		t.Fatalf("invalid use of a small buffer")
+	}
+
+	// failed writes leave the image untouched.
+	raw = im.Raw()
+	err = im.WriteInto(raw, []int16{1, 2})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if &im.Raw()[0] != &raw[0] {
+		t.Fatalf("failed write modified the image")
+	}
+
+	var v interface{} = data
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = im.WriteInto(im.Raw(), v)
+	})
+	if allocs != 0 {
+		t.Fatalf("WriteInto allocates (%v allocs)", allocs)
+	}
+}
+
+func TestImageReadNested(t *testing.T) {
+	im := NewImage(16, []int{3, 4, 2})
+	data := make([]int16, 3*4*2)
+	for i := range data {
+		data[i] = int16(i)
+	}
+	err := im.Write(data)
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+
+	var cube [][][]int16
+	err = im.Read(&cube)
+	if err != nil {
+		t.Fatalf("could not read image: %v", err)
+	}
+	if len(cube) != 2 || len(cube[0]) != 4 || len(cube[0][0]) != 3 {
+		t.Fatalf("invalid dimensions: %v", cube)
+	}
+	for z := range cube {
+		for y := range cube[z] {
+			for x := range cube[z][y] {
+				if got, want := cube[z][y][x], data[(z*4+y)*3+x]; got != want {
+					t.Fatalf("invalid pixel (%d,%d,%d): got=%d, want=%d", x, y, z, got, want)
+				}
+			}
+		}
+	}
+
+	var arr [2][4][3]int16
+	err = im.Read(&arr)
+	if err != nil {
+		t.Fatalf("could not read image: %v", err)
+	}
+	for z := range arr {
+		if !reflect.DeepEqual(arr[z][:], [][3]int16{
+			{cube[z][0][0], cube[z][0][1], cube[z][0][2]},
+			{cube[z][1][0], cube[z][1][1], cube[z][1][2]},
+			{cube[z][2][0], cube[z][2][1], cube[z][2][2]},
+			{cube[z][3][0], cube[z][3][1], cube[z][3][2]},
+		}) {
+			t.Fatalf("invalid plane %d: %v", z, arr[z])
+		}
+	}
+
+	// mixed slices and arrays, reusing the provided storage.
+	mixed := make([][4][3]int16, 2, 8)
+	err = im.Read(&mixed)
+	if err != nil {
+		t.Fatalf("could not read image: %v", err)
+	}
+	if !reflect.DeepEqual(mixed, arr[:]) {
+		t.Fatalf("invalid image data.\ngot= %v\nwant=%v", mixed, arr)
+	}
+
+	var plane [][]int16
+	err = im.ReadPlane(&plane, 1)
+	if err != nil {
+		t.Fatalf("could not read plane: %v", err)
+	}
+	if !reflect.DeepEqual(plane, cube[1]) {
+		t.Fatalf("invalid plane.\ngot= %v\nwant=%v", plane, cube[1])
+	}
+
+	for _, ptr := range []interface{}{
+		&[2][3][4]int16{},
+		&[][]int16{},
+		&[][][][]int16{},
+		&[][][]float64{},
+	} {
+		err = im.Read(ptr)
+		if err == nil {
+			t.Fatalf("expected an error reading into %T", ptr)
+		}
+	}
+}
+
+func BenchmarkImageWriteInto(b *testing.B) {
+	im := NewImage(-32, []int{1024, 1024})
+	data := make([]float32, 1024*1024)
+	for i := range data {
+		data[i] = float32(i)
+	}
+
+	b.Run("alloc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			err := im.Write(data)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("reuse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			err := im.WriteInto(im.Raw(), data)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
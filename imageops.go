@@ -0,0 +1,281 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ImageOp describes an element-wise arithmetic operation on the physical
+// pixel values of images.
+type ImageOp int
+
+const (
+	AddOp ImageOp = iota // addition
+	SubOp                // subtraction
+	MulOp                // multiplication
+	DivOp                // division
+)
+
+func (op ImageOp) String() string {
+	switch op {
+	case AddOp:
+		return "add"
+	case SubOp:
+		return "sub"
+	case MulOp:
+		return "mul"
+	case DivOp:
+		return "div"
+	}
+	return fmt.Sprintf("ImageOp(%d)", int(op))
+}
+
+func (op ImageOp) eval(x, y float64) float64 {
+	switch op {
+	case AddOp:
+		return x + y
+	case SubOp:
+		return x - y
+	case MulOp:
+		return x * y
+	default:
+		return x / y
+	}
+}
+
+// Apply returns a new image holding the result of the element-wise
+// operation between the physical pixel values of a and b, which must
+// have the same axes.
+//
+// The BITPIX of the result follows these promotion rules:
+//   - images with a BSCALE or BZERO keyword are considered as floating
+//     point images (BITPIX -32 for 8 and 16 bits pixels, -64 otherwise),
+//   - additions, subtractions and multiplications of integer images
+//     yield integer images with the widest BITPIX of a and b, whose values
+//     are rounded and clamped to the range of the pixel type,
+//   - other operations yield floating point images, with BITPIX -32 when
+//     both operands fit into a float32 (8 and 16 bits integers, and -32),
+//     and -64 otherwise.
+//
+// Blank pixels yield blank pixels.
+// The header of the result holds the non-structural keywords of a.
+func (op ImageOp) Apply(a, b Image) (Image, error) {
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("fitsio: nil image")
+	}
+	if op < AddOp || op > DivOp {
+		return nil, fmt.Errorf("fitsio: invalid image operation %v", op)
+	}
+	if !slices.Equal(a.Header().Axes(), b.Header().Axes()) {
+		return nil, fmt.Errorf(
+			"fitsio: image axes mismatch (%v != %v)",
+			a.Header().Axes(), b.Header().Axes(),
+		)
+	}
+
+	pa, err := newPixels(a.Header(), a.Raw())
+	if err != nil {
+		return nil, err
+	}
+	pb, err := newPixels(b.Header(), b.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	bitpix := promoteBitpix(op, pa.kind(), pb.kind())
+	vs := make([]float64, pa.n)
+	for i := range vs {
+		x, _ := pa.at(i)
+		y, _ := pb.at(i)
+		vs[i] = op.eval(x, y)
+	}
+	return newImageOpResult(a.Header(), bitpix, a.Header().Axes(), vs)
+}
+
+// ApplyScalar returns a new image holding the result of the element-wise
+// operation between the physical pixel values of img and v.
+//
+// Integer images stay integer images when v is an integer, except for
+// divisions. Otherwise, the promotion rules of Apply are used, v being
+// considered as a float32 value.
+func (op ImageOp) ApplyScalar(img Image, v float64) (Image, error) {
+	if img == nil {
+		return nil, fmt.Errorf("fitsio: nil image")
+	}
+	if op < AddOp || op > DivOp {
+		return nil, fmt.Errorf("fitsio: invalid image operation %v", op)
+	}
+
+	pix, err := newPixels(img.Header(), img.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	kind := pix.kind()
+	bitpix := kind
+	if kind < 0 || op == DivOp || v != math.Trunc(v) {
+		bitpix = promoteBitpix(op, kind, -32)
+	}
+	vs := make([]float64, pix.n)
+	for i := range vs {
+		x, _ := pix.at(i)
+		vs[i] = op.eval(x, v)
+	}
+	return newImageOpResult(img.Header(), bitpix, img.Header().Axes(), vs)
+}
+
+// SubtractDark returns a new image holding the pixels of img minus
+// the pixels of the dark (or bias) frame.
+func (img *imageHDU) SubtractDark(dark Image) (Image, error) {
+	return SubOp.Apply(img, dark)
+}
+
+// DivideFlat returns a new image holding the pixels of img divided by
+// the pixels of the flat field, normalized to its mean value.
+func (img *imageHDU) DivideFlat(flat Image) (Image, error) {
+	if flat == nil {
+		return nil, fmt.Errorf("fitsio: nil image")
+	}
+	pix, err := newPixels(flat.Header(), flat.Raw())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		sum float64
+		n   int
+	)
+	for i := 0; i < pix.n; i++ {
+		v, ok := pix.at(i)
+		if !ok || math.IsInf(v, 0) {
+			continue
+		}
+		sum += v
+		n++
+	}
+	if n == 0 || sum == 0 {
+		return nil, fmt.Errorf("fitsio: flat field with a null mean value")
+	}
+
+	norm, err := DivOp.ApplyScalar(flat, sum/float64(n))
+	if err != nil {
+		return nil, err
+	}
+	return DivOp.Apply(img, norm)
+}
+
+// kind returns the BITPIX of the physical values of the pixels:
+// scaled integer pixels are considered as floating point values.
+func (pix *pixels) kind() int {
+	if pix.bitpix < 0 || (pix.bscale == 1 && pix.bzero == 0) {
+		return pix.bitpix
+	}
+	if pix.bitpix <= 16 {
+		return -32
+	}
+	return -64
+}
+
+// promoteBitpix returns the BITPIX of the result of op between pixels
+// of the provided BITPIX kinds.
+func promoteBitpix(op ImageOp, a, b int) int {
+	if a > 0 && b > 0 && op != DivOp {
+		return max(a, b)
+	}
+	small := func(bitpix int) bool {
+		return bitpix == -32 || (bitpix > 0 && bitpix <= 16)
+	}
+	if small(a) && small(b) {
+		return -32
+	}
+	return -64
+}
+
+// newImageOpResult creates an image with the non-structural keywords of
+// hdr and the provided axes, holding the vs physical values encoded with
+// bitpix.
+func newImageOpResult(hdr *Header, bitpix int, axes []int, vs []float64) (Image, error) {
+	cards := make([]Card, 0, len(hdr.cards))
+	for _, card := range hdr.cards {
+		if isStructuralKey(card.Name) {
+			continue
+		}
+		switch card.Name {
+		case "BSCALE", "BZERO", "BLANK", "DATAMIN", "DATAMAX", "CHECKSUM", "DATASUM":
+			// these do not describe the new pixels.
+			continue
+		}
+		cards = append(cards, card)
+	}
+	pixsz := bitpix / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
+	img := &imageHDU{
+		hdr: *NewHeader(cards, IMAGE_HDU, bitpix, axes),
+		raw: make([]byte, len(vs)*pixsz),
+	}
+
+	var (
+		lo, hi float64
+		blank  int64
+		blanks bool
+	)
+	switch bitpix {
+	case 8:
+		lo, hi, blank = 0, math.MaxUint8, math.MaxUint8
+	case 16:
+		lo, hi, blank = math.MinInt16, math.MaxInt16, math.MinInt16
+	case 32:
+		lo, hi, blank = math.MinInt32, math.MaxInt32, math.MinInt32
+	case 64:
+		lo, hi, blank = math.MinInt64, math.MaxInt64, math.MinInt64
+	}
+	raw := img.raw
+	for i, v := range vs {
+		var iv int64
+		if bitpix > 0 {
+			switch {
+			case math.IsNaN(v):
+				iv = blank
+				blanks = true
+			case v <= lo:
+				iv = int64(lo)
+			case v >= hi && bitpix == 64:
+				// float64(math.MaxInt64) is not representable as an int64.
+				iv = math.MaxInt64
+			case v >= hi:
+				iv = int64(hi)
+			default:
+				iv = int64(math.Round(v))
+			}
+		}
+		switch bitpix {
+		case 8:
+			raw[i] = uint8(iv)
+		case 16:
+			binary.BigEndian.PutUint16(raw[2*i:], uint16(iv))
+		case 32:
+			binary.BigEndian.PutUint32(raw[4*i:], uint32(iv))
+		case 64:
+			binary.BigEndian.PutUint64(raw[8*i:], uint64(iv))
+		case -32:
+			binary.BigEndian.PutUint32(raw[4*i:], math.Float32bits(float32(v)))
+		case -64:
+			binary.BigEndian.PutUint64(raw[8*i:], math.Float64bits(v))
+		}
+	}
+
+	if blanks {
+		err := img.hdr.Append(Card{Name: "BLANK", Value: int(blank), Comment: "value of undefined pixels"})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return img, nil
+}
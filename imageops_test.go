@@ -0,0 +1,193 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestImageOp(t *testing.T) {
+	newImg := func(bitpix int, data interface{}, cards ...Card) Image {
+		t.Helper()
+		img := NewImage(bitpix, []int{2, 2})
+		err := img.Header().Append(cards...)
+		if err != nil {
+			t.Fatalf("could not append cards: %v", err)
+		}
+		err = img.Write(data)
+		if err != nil {
+			t.Fatalf("could not write image: %v", err)
+		}
+		return img
+	}
+
+	for _, test := range []struct {
+		name   string
+		op     ImageOp
+		a, b   Image
+		bitpix int
+		want   []float64
+	}{
+		{
+			name:   "add-i16-i32",
+			op:     AddOp,
+			a:      newImg(16, []int16{1, 2, 3, 4}),
+			b:      newImg(32, []int32{10, 20, 30, 40}),
+			bitpix: 32,
+			want:   []float64{11, 22, 33, 44},
+		},
+		{
+			name:   "sub-u8-clamp",
+			op:     SubOp,
+			a:      newImg(8, []uint8{10, 20, 30, 40}),
+			b:      newImg(8, []uint8{5, 25, 30, 0}),
+			bitpix: 8,
+			want:   []float64{5, 0, 0, 40},
+		},
+		{
+			name:   "mul-i16-blank",
+			op:     MulOp,
+			a:      newImg(16, []int16{1, -1, 3, 4}, Card{Name: "BLANK", Value: -1}),
+			b:      newImg(16, []int16{2, 2, 2, 2}),
+			bitpix: 16,
+			want:   []float64{2, math.NaN(), 6, 8},
+		},
+		{
+			name:   "div-i16",
+			op:     DivOp,
+			a:      newImg(16, []int16{1, 2, 3, 4}),
+			b:      newImg(8, []uint8{2, 2, 2, 0}),
+			bitpix: -32,
+			want:   []float64{0.5, 1, 1.5, math.Inf(+1)},
+		},
+		{
+			name:   "add-i32-f32",
+			op:     AddOp,
+			a:      newImg(32, []int32{1, 2, 3, 4}),
+			b:      newImg(-32, []float32{0.5, 0.5, 0.5, 0.5}),
+			bitpix: -64,
+			want:   []float64{1.5, 2.5, 3.5, 4.5},
+		},
+		{
+			name:   "sub-scaled",
+			op:     SubOp,
+			a:      newImg(16, []int16{-32768, 0, 1, 32767}, Card{Name: "BZERO", Value: 32768}),
+			b:      newImg(16, []int16{1, 1, 1, 1}),
+			bitpix: -32,
+			want:   []float64{-1, 32767, 32768, 65534},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			img, err := test.op.Apply(test.a, test.b)
+			if err != nil {
+				t.Fatalf("could not apply %v: %v", test.op, err)
+			}
+			if got, want := img.Header().Bitpix(), test.bitpix; got != want {
+				t.Fatalf("invalid BITPIX: got=%d, want=%d", got, want)
+			}
+			if got := img.Header().Get("BZERO"); got != nil {
+				t.Fatalf("unexpected BZERO card: %v", got)
+			}
+			got, err := img.Scaled()
+			if err != nil {
+				t.Fatalf("could not scale pixels: %v", err)
+			}
+			if !floatsEqual(got, test.want) {
+				t.Fatalf("invalid pixels: got=%v, want=%v", got, test.want)
+			}
+		})
+	}
+
+	t.Run("scalar", func(t *testing.T) {
+		a := newImg(16, []int16{1, 2, 3, 4}, Card{Name: "OBJECT", Value: "M31"})
+		for _, test := range []struct {
+			op     ImageOp
+			v      float64
+			bitpix int
+			want   []float64
+		}{
+			{AddOp, 10, 16, []float64{11, 12, 13, 14}},
+			{MulOp, 0.5, -32, []float64{0.5, 1, 1.5, 2}},
+			{DivOp, 2, -32, []float64{0.5, 1, 1.5, 2}},
+			{SubOp, 1e5, 16, []float64{-32768, -32768, -32768, -32768}},
+		} {
+			img, err := test.op.ApplyScalar(a, test.v)
+			if err != nil {
+				t.Fatalf("could not apply %v: %v", test.op, err)
+			}
+			if got, want := img.Header().Bitpix(), test.bitpix; got != want {
+				t.Fatalf("%v: invalid BITPIX: got=%d, want=%d", test.op, got, want)
+			}
+			if got := img.Header().Get("OBJECT"); got == nil || got.Value != "M31" {
+				t.Fatalf("%v: invalid OBJECT card: %v", test.op, got)
+			}
+			got, err := img.Scaled()
+			if err != nil {
+				t.Fatalf("could not scale pixels: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("%v: invalid pixels: got=%v, want=%v", test.op, got, test.want)
+			}
+		}
+	})
+
+	t.Run("calibration", func(t *testing.T) {
+		var (
+			raw  = newImg(16, []int16{110, 210, 310, 410})
+			dark = newImg(16, []int16{10, 10, 10, 10})
+			flat = newImg(-32, []float32{0.5, 1, 1.5, 1})
+		)
+		img, err := raw.SubtractDark(dark)
+		if err != nil {
+			t.Fatalf("could not subtract dark: %v", err)
+		}
+		img, err = img.DivideFlat(flat)
+		if err != nil {
+			t.Fatalf("could not divide flat: %v", err)
+		}
+		got, err := img.Scaled()
+		if err != nil {
+			t.Fatalf("could not scale pixels: %v", err)
+		}
+		if want := []float64{200, 200, 200, 400}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid pixels: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		a := newImg(16, []int16{1, 2, 3, 4})
+		b := NewImage(16, []int{4})
+		err := b.Write([]int16{1, 2, 3, 4})
+		if err != nil {
+			t.Fatalf("could not write image: %v", err)
+		}
+		_, err = AddOp.Apply(a, b)
+		if err == nil {
+			t.Fatalf("expected an error for mismatched axes")
+		}
+		_, err = ImageOp(42).Apply(a, a)
+		if err == nil {
+			t.Fatalf("expected an error for an invalid operation")
+		}
+		_, err = a.DivideFlat(newImg(16, []int16{0, 0, 0, 0}))
+		if err == nil {
+			t.Fatalf("expected an error for a null flat field")
+		}
+	})
+}
+
+func floatsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] && !(math.IsNaN(a[i]) && math.IsNaN(b[i])) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,199 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// imageChunk is the size of the pixels buffered by an ImageWriter before
+// they are written out.
+const imageChunk = 64 << 10
+
+// ImageWriter writes the pixels of an image HDU of a File incrementally,
+// so that the whole image does not have to be held in memory.
+//
+// The header of the image is written by NewImageWriter. The size of the
+// image being known in advance, the underlying io.Writer does not need to
+// be seekable.
+type ImageWriter struct {
+	f   *File
+	enc *streamEncoder
+	img *imageHDU
+
+	pixsz int    // size of a pixel, in bytes
+	npix  int64  // number of pixels of the image
+	ndone int64  // number of pixels written by Write
+	buf   []byte // encoded pixels not yet written out
+	err   error  // first error encountered, if any
+}
+
+// NewImageWriter writes the header of a new image HDU, with bitpix bits
+// per pixel and the given axes, to the file f, and returns an ImageWriter
+// to write its pixels.
+//
+// The image is the primary HDU of the file if the file holds no HDU yet,
+// and an image extension otherwise.
+// No other HDU may be written to the file until the ImageWriter is closed.
+func NewImageWriter(f *File, bitpix int, axes []int) (*ImageWriter, error) {
+	if f.mode != WriteOnly && f.mode != ReadWrite {
+		return nil, fmt.Errorf("fitsio: file not open for write")
+	}
+	var enc *streamEncoder
+	switch e := f.enc.(type) {
+	case *streamEncoder:
+		enc = e
+	case *seekEncoder:
+		enc = e.streamEncoder
+	default:
+		return nil, fmt.Errorf("fitsio: streaming images requires a file created with Create")
+	}
+	if pixelType(bitpix) == nil {
+		return nil, fmt.Errorf("fitsio: invalid bitpix value (%d)", bitpix)
+	}
+	npix := int64(1)
+	for i, dim := range axes {
+		if dim < 0 {
+			return nil, fmt.Errorf("fitsio: invalid NAXIS%d value (%d)", i+1, dim)
+		}
+		npix *= int64(dim)
+	}
+	if len(axes) == 0 {
+		npix = 0
+	}
+
+	f.wmu.Lock()
+	defer f.wmu.Unlock()
+
+	if f.sw != nil {
+		return nil, fmt.Errorf("fitsio: an HDU is already being written with a %T", f.sw)
+	}
+
+	img := NewImage(bitpix, axes)
+	err := f.prepare(img)
+	if err != nil {
+		return nil, err
+	}
+	_, err = enc.beginHDU(context.Background(), &img.hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	pixsz := bitpix / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
+	w := &ImageWriter{
+		f:     f,
+		enc:   enc,
+		img:   img,
+		pixsz: pixsz,
+		npix:  npix,
+	}
+	f.sw = w
+	return w, nil
+}
+
+// NumPixels returns the number of pixels written so far.
+func (w *ImageWriter) NumPixels() int64 {
+	return w.ndone
+}
+
+// Write writes the pixels held by data, a slice (or a pointer to a slice)
+// of the types accepted by Image.Write, after the pixels already written.
+// Pixels are written in the order of the image data unit: the first axis
+// varies fastest.
+func (w *ImageWriter) Write(data interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.f == nil {
+		return fmt.Errorf("fitsio: write to closed ImageWriter")
+	}
+	rv := reflect.ValueOf(data)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+		data = rv.Interface()
+	}
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("fitsio: invalid image type (%T)", data)
+	}
+	n := int64(rv.Len())
+	if w.ndone+n > w.npix {
+		return fmt.Errorf("fitsio: too many pixels: %d pixels left to write, got %d", w.npix-w.ndone, n)
+	}
+
+	beg := len(w.buf)
+	w.buf = append(w.buf, make([]byte, int(n)*w.pixsz)...)
+	err := w.img.encodePixels(newWriter(w.buf[beg:]), data)
+	if err != nil {
+		w.buf = w.buf[:beg]
+		return err
+	}
+	w.ndone += n
+	if len(w.buf) >= imageChunk {
+		w.err = w.flush()
+	}
+	return w.err
+}
+
+// flush writes the buffered pixels out.
+func (w *ImageWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.enc.w.Write(w.buf)
+	if err != nil {
+		return fmt.Errorf("fitsio: error writing image-data: %v", err)
+	}
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// Close writes the remaining pixels out, completes the image with zero
+// pixels if fewer pixels than the size of the image were written, and pads
+// the data unit to a whole number of FITS blocks.
+// The image is then added to the HDUs of the file: its header is available
+// from File.HDU, but not its pixels.
+func (w *ImageWriter) Close() error {
+	if w.f == nil {
+		return w.err
+	}
+	f := w.f
+	f.wmu.Lock()
+	defer f.wmu.Unlock()
+	f.sw = nil
+	w.f = nil
+
+	if w.err == nil {
+		w.err = w.flush()
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	size := w.npix * int64(w.pixsz)
+	zeros := size - w.ndone*int64(w.pixsz) + int64(PadBlock(int(size%BlockSize)))
+	for zeros > 0 {
+		n := min(zeros, imageChunk)
+		_, w.err = w.enc.w.Write(make([]byte, n))
+		if w.err != nil {
+			w.err = fmt.Errorf("fitsio: error while padding data-image block: %v", w.err)
+			return w.err
+		}
+		zeros -= n
+	}
+	w.enc.cur.End = w.enc.w.n
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.err = f.append(w.img)
+	if w.err == nil {
+		f.offs = appendOffsets(f.offs, f.enc)
+	}
+	return w.err
+}
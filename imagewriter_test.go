@@ -0,0 +1,117 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestImageWriter(t *testing.T) {
+	const (
+		nx = 300
+		ny = 200
+	)
+	buf := new(bytes.Buffer)
+	f, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+
+	// a primary HDU, streamed row by row.
+	w, err := NewImageWriter(f, 16, []int{nx, ny})
+	if err != nil {
+		t.Fatalf("could not create image writer: %v", err)
+	}
+	err = f.Write(NewImage(8, nil))
+	if err == nil {
+		t.Fatalf("expected an error writing an HDU while streaming an image")
+	}
+	err = w.Write([]float32{1})
+	if err == nil {
+		t.Fatalf("expected an error writing pixels of the wrong type")
+	}
+	want := make([]int16, 0, nx*ny)
+	row := make([]int16, nx)
+	for y := 0; y < ny; y++ {
+		for x := range row {
+			row[x] = int16(x*y - 1000)
+		}
+		err = w.Write(row)
+		if err != nil {
+			t.Fatalf("could not write row %d: %v", y, err)
+		}
+		want = append(want, row...)
+	}
+	if got, want := w.NumPixels(), int64(nx*ny); got != want {
+		t.Fatalf("invalid number of pixels: got=%d, want=%d", got, want)
+	}
+	err = w.Write(row[:1])
+	if err == nil {
+		t.Fatalf("expected an error writing too many pixels")
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close image writer: %v", err)
+	}
+
+	// an extension, partially written.
+	w, err = NewImageWriter(f, -64, []int{3, 2})
+	if err != nil {
+		t.Fatalf("could not create image writer: %v", err)
+	}
+	err = w.Write(&[]float64{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("could not write pixels: %v", err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close image writer: %v", err)
+	}
+	err = w.Write([]float64{5})
+	if err == nil {
+		t.Fatalf("expected an error writing to a closed image writer")
+	}
+
+	offs := f.Offsets()
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+	if got := buf.Len() % BlockSize; got != 0 {
+		t.Fatalf("file is not a whole number of blocks (%d bytes)", buf.Len())
+	}
+
+	r, err := Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer r.Close()
+	if got, want := r.NumHDUs(), 2; got != want {
+		t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+	}
+	if got, want := r.Offsets(), offs; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid offsets:\ngot= %+v\nwant=%+v", got, want)
+	}
+
+	pix := make([]int16, nx*ny)
+	err = r.HDU(0).(Image).Read(&pix)
+	if err != nil {
+		t.Fatalf("could not read primary image: %v", err)
+	}
+	if !reflect.DeepEqual(pix, want) {
+		t.Fatalf("invalid primary image")
+	}
+
+	ext := make([]float64, 6)
+	err = r.HDU(1).(Image).Read(&ext)
+	if err != nil {
+		t.Fatalf("could not read image extension: %v", err)
+	}
+	if want := []float64{1, 2, 3, 4, 0, 0}; !reflect.DeepEqual(ext, want) {
+		t.Fatalf("invalid image extension: got=%v, want=%v", ext, want)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Index is an in-memory index over the values of a scalar column of a table,
+// associating each value with the indices of the rows holding it.
+//
+// An Index is a snapshot of the column at the time it was built: rows
+// written or updated afterwards are not indexed.
+type Index struct {
+	col  string
+	kind reflect.Kind // kind of the keys: Int64, Float64, String or Bool
+
+	rows map[interface{}][]int64 // row indices, by key
+	keys []interface{}           // keys, sorted
+	ids  []int64                 // row indices, sorted by key
+}
+
+// BuildIndex builds an index over the values of the named column, which must
+// be a scalar integer, floating point, string or logical column.
+// NaN values are not indexed.
+func (t *Table) BuildIndex(col string) (*Index, error) {
+	icol := t.Index(col)
+	if icol < 0 {
+		return nil, fmt.Errorf("fitsio: no column %q in table %q", col, t.Name())
+	}
+	rt := t.cols[icol].Type()
+
+	idx := &Index{
+		col:  col,
+		rows: make(map[interface{}][]int64),
+	}
+	switch k := rt.Kind(); {
+	case isIntKind(k):
+		idx.kind = reflect.Int64
+	case isFloatKind(k):
+		idx.kind = reflect.Float64
+	case k == reflect.String:
+		idx.kind = reflect.String
+	case k == reflect.Bool:
+		idx.kind = reflect.Bool
+	default:
+		return nil, fmt.Errorf("fitsio: can not index column %q of type %v", col, rt)
+	}
+
+	vs := reflect.New(reflect.SliceOf(rt))
+	err := t.ReadColumn(icol, vs.Interface())
+	if err != nil {
+		return nil, err
+	}
+	vs = vs.Elem()
+
+	for i := 0; i < vs.Len(); i++ {
+		key, ok := idx.key(vs.Index(i))
+		if !ok {
+			continue
+		}
+		idx.rows[key] = append(idx.rows[key], int64(i))
+		idx.keys = append(idx.keys, key)
+		idx.ids = append(idx.ids, int64(i))
+	}
+	sort.Stable(byKey{idx})
+
+	return idx, nil
+}
+
+// Column returns the name of the indexed column.
+func (idx *Index) Column() string {
+	return idx.col
+}
+
+// Len returns the number of indexed rows.
+func (idx *Index) Len() int {
+	return len(idx.ids)
+}
+
+// Lookup returns the indices of the rows whose value is v, in increasing
+// order. Numerical values are compared independently of their Go type.
+func (idx *Index) Lookup(v interface{}) []int64 {
+	key, ok := idx.key(reflect.ValueOf(v))
+	if !ok {
+		return nil
+	}
+	return slices.Clone(idx.rows[key])
+}
+
+// Range returns the indices of the rows whose value is within [lo, hi],
+// sorted by value.
+func (idx *Index) Range(lo, hi interface{}) []int64 {
+	klo, ok := idx.key(reflect.ValueOf(lo))
+	if !ok {
+		return nil
+	}
+	khi, ok := idx.key(reflect.ValueOf(hi))
+	if !ok {
+		return nil
+	}
+	beg := sort.Search(len(idx.keys), func(i int) bool {
+		return compareKeys(idx.keys[i], klo) >= 0
+	})
+	end := sort.Search(len(idx.keys), func(i int) bool {
+		return compareKeys(idx.keys[i], khi) > 0
+	})
+	if beg >= end {
+		return nil
+	}
+	return slices.Clone(idx.ids[beg:end])
+}
+
+// key returns the index key corresponding to the value rv.
+// Integer keys are stored as int64 values, or as uint64 values when they
+// do not fit.
+func (idx *Index) key(rv reflect.Value) (interface{}, bool) {
+	if !rv.IsValid() {
+		return nil, false
+	}
+	switch idx.kind {
+	case reflect.Int64:
+		switch k := rv.Kind(); {
+		case k >= reflect.Int && k <= reflect.Int64:
+			return rv.Int(), true
+		case k >= reflect.Uint && k <= reflect.Uintptr:
+			u := rv.Uint()
+			if u > math.MaxInt64 {
+				return u, true
+			}
+			return int64(u), true
+		case isFloatKind(k):
+			f := rv.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return nil, false
+			}
+			return int64(f), true
+		}
+	case reflect.Float64:
+		var f float64
+		switch k := rv.Kind(); {
+		case k >= reflect.Int && k <= reflect.Int64:
+			f = float64(rv.Int())
+		case k >= reflect.Uint && k <= reflect.Uintptr:
+			f = float64(rv.Uint())
+		case isFloatKind(k):
+			f = rv.Float()
+		default:
+			return nil, false
+		}
+		if math.IsNaN(f) {
+			return nil, false
+		}
+		return f, true
+	case reflect.String:
+		if rv.Kind() == reflect.String {
+			return rv.String(), true
+		}
+	case reflect.Bool:
+		if rv.Kind() == reflect.Bool {
+			return rv.Bool(), true
+		}
+	}
+	return nil, false
+}
+
+// compareKeys compares two keys of the same index.
+func compareKeys(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return cmp.Compare(a, b)
+		case uint64:
+			return -1
+		}
+	case uint64:
+		switch b := b.(type) {
+		case int64:
+			return +1
+		case uint64:
+			return cmp.Compare(a, b)
+		}
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	case string:
+		return strings.Compare(a, b.(string))
+	case bool:
+		switch {
+		case a == b.(bool):
+			return 0
+		case a:
+			return +1
+		}
+		return -1
+	}
+	panic(fmt.Errorf("fitsio: invalid index keys (%T, %T)", a, b))
+}
+
+// byKey sorts the keys of an index, and their row indices.
+type byKey struct {
+	idx *Index
+}
+
+func (p byKey) Len() int { return len(p.idx.keys) }
+func (p byKey) Less(i, j int) bool {
+	return compareKeys(p.idx.keys[i], p.idx.keys[j]) < 0
+}
+func (p byKey) Swap(i, j int) {
+	p.idx.keys[i], p.idx.keys[j] = p.idx.keys[j], p.idx.keys[i]
+	p.idx.ids[i], p.idx.ids[j] = p.idx.ids[j], p.idx.ids[i]
+}
@@ -0,0 +1,115 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestIndex(t *testing.T) {
+	type Source struct {
+		ID   int64   `fits:"ID"`
+		Name string  `fits:"NAME"`
+		Flux float32 `fits:"FLUX"`
+		Det  bool    `fits:"DET"`
+		Pos  [2]float64
+	}
+	tbl, err := NewTable("sources", []Column{
+		{Name: "ID", Format: "K"},
+		{Name: "NAME", Format: "8A"},
+		{Name: "FLUX", Format: "E"},
+		{Name: "DET", Format: "L"},
+		{Name: "Pos", Format: "2D"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	for _, src := range []Source{
+		{ID: 42, Name: "a", Flux: 1.5, Det: true},
+		{ID: 7, Name: "b", Flux: float32(math.NaN())},
+		{ID: 42, Name: "c", Flux: 0.5, Det: true},
+		{ID: -3, Name: "a", Flux: 2.5},
+		{ID: 100, Name: "d", Flux: 1.5},
+	} {
+		err = tbl.Write(&src)
+		if err != nil {
+			t.Fatalf("could not write row: %v", err)
+		}
+	}
+
+	ids, err := tbl.BuildIndex("ID")
+	if err != nil {
+		t.Fatalf("could not build index: %v", err)
+	}
+	if got, want := ids.Len(), 5; got != want {
+		t.Fatalf("invalid index length: got=%d, want=%d", got, want)
+	}
+	for _, test := range []struct {
+		key  interface{}
+		want []int64
+	}{
+		{int64(42), []int64{0, 2}},
+		{42, []int64{0, 2}},
+		{uint8(7), []int64{1}},
+		{-3.0, []int64{3}},
+		{42.5, nil},
+		{1, nil},
+		{"42", nil},
+	} {
+		if got := ids.Lookup(test.key); !reflect.DeepEqual(got, test.want) {
+			t.Fatalf("lookup(%v): got=%v, want=%v", test.key, got, test.want)
+		}
+	}
+	if got, want := ids.Range(0, 50), []int64{1, 0, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid range: got=%v, want=%v", got, want)
+	}
+	if got := ids.Range(50, 0); got != nil {
+		t.Fatalf("invalid empty range: got=%v", got)
+	}
+
+	names, err := tbl.BuildIndex("NAME")
+	if err != nil {
+		t.Fatalf("could not build index: %v", err)
+	}
+	if got, want := names.Lookup("a"), []int64{0, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid lookup: got=%v, want=%v", got, want)
+	}
+	if got, want := names.Range("b", "c"), []int64{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid range: got=%v, want=%v", got, want)
+	}
+
+	fluxes, err := tbl.BuildIndex("FLUX")
+	if err != nil {
+		t.Fatalf("could not build index: %v", err)
+	}
+	if got, want := fluxes.Len(), 4; got != want {
+		t.Fatalf("invalid index length: got=%d, want=%d", got, want)
+	}
+	if got, want := fluxes.Lookup(1.5), []int64{0, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid lookup: got=%v, want=%v", got, want)
+	}
+	if got := fluxes.Lookup(math.NaN()); got != nil {
+		t.Fatalf("invalid NaN lookup: got=%v", got)
+	}
+
+	dets, err := tbl.BuildIndex("DET")
+	if err != nil {
+		t.Fatalf("could not build index: %v", err)
+	}
+	if got, want := dets.Lookup(false), []int64{1, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid lookup: got=%v, want=%v", got, want)
+	}
+
+	for _, col := range []string{"Pos", "NOPE"} {
+		_, err := tbl.BuildIndex(col)
+		if err == nil {
+			t.Fatalf("expected an error indexing column %q", col)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+// parseBinSpec parses the binning specification of an extended filename.
+// Supported forms are:
+//   - "X,Y" or "(X,Y)=4" (same bin size for both axes)
+//   - "X=1:1024:4,Y=1:1024:4" (range and bin size for each axis)
+func parseBinSpec(str string) (xcol, ycol string, spec fits.BinSpec, err error) {
+	str = strings.TrimSpace(str)
+
+	if strings.HasPrefix(str, "(") {
+		end := strings.Index(str, ")")
+		if end < 0 {
+			return "", "", spec, fmt.Errorf("invalid bin specification %q", str)
+		}
+		bin := 0.0
+		if rest := strings.TrimSpace(str[end+1:]); rest != "" {
+			if !strings.HasPrefix(rest, "=") {
+				return "", "", spec, fmt.Errorf("invalid bin specification %q", str)
+			}
+			bin, err = strconv.ParseFloat(strings.TrimSpace(rest[1:]), 64)
+			if err != nil {
+				return "", "", spec, fmt.Errorf("invalid bin size in %q: %v", str, err)
+			}
+		}
+		names := strings.Split(str[1:end], ",")
+		if len(names) != 2 {
+			return "", "", spec, fmt.Errorf("invalid bin specification %q", str)
+		}
+		spec.XBin = bin
+		spec.YBin = bin
+		return strings.TrimSpace(names[0]), strings.TrimSpace(names[1]), spec, nil
+	}
+
+	axes := strings.Split(str, ",")
+	if len(axes) != 2 {
+		return "", "", spec, fmt.Errorf("invalid bin specification %q (need 2 columns)", str)
+	}
+
+	var names [2]string
+	var ranges [2][3]float64
+	for i, axis := range axes {
+		name, rng, ok := strings.Cut(axis, "=")
+		names[i] = strings.TrimSpace(name)
+		if !ok {
+			continue
+		}
+		toks := strings.Split(rng, ":")
+		if len(toks) != 3 {
+			return "", "", spec, fmt.Errorf("invalid bin range %q (want min:max:binsize)", rng)
+		}
+		for j, tok := range toks {
+			ranges[i][j], err = strconv.ParseFloat(strings.TrimSpace(tok), 64)
+			if err != nil {
+				return "", "", spec, fmt.Errorf("invalid bin range %q: %v", rng, err)
+			}
+		}
+	}
+
+	spec.XMin, spec.XMax, spec.XBin = ranges[0][0], ranges[0][1], ranges[0][2]
+	spec.YMin, spec.YMax, spec.YBin = ranges[1][0], ranges[1][1], ranges[1][2]
+	return names[0], names[1], spec, nil
+}
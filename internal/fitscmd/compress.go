@@ -0,0 +1,264 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+// Compress compresses the images of a FITS file into tile-compressed images.
+func Compress(prog string, args []string) int {
+	fset := newFlagSet(prog, fmt.Sprintf(`Usage: %[1]s [options] input output
+
+Compress the images of a FITS file, according to the FITS tiled image
+compression convention (as fpack does.)
+Tables are copied as-is, unless -table is given.
+Already compressed HDUs are copied as-is.
+A compressed primary image is stored in the first extension, after an
+empty primary HDU.
+
+Examples:
+  %[1]s in.fits out.fits.fz                 - RICE_1, row by row tiles
+  %[1]s -c gzip2 -t 100,100 in.fits out.fz  - GZIP_2, 100x100 tiles
+  %[1]s -q 16 -seed 42 in.fits out.fz       - finer, reproducible quantization
+  %[1]s -c gzip1 -q 0 in.fits out.fz        - lossless float compression
+  %[1]s -table in.fits out.fz               - compress binary tables too
+`, prog))
+
+	var (
+		algo  = fset.String("c", "rice", "compression algorithm (rice, gzip1, gzip2, none)")
+		tile  = fset.String("t", "", "comma-separated tile dimensions (default: row by row)")
+		level = fset.Float64("q", 4, "quantization level of floating point images (0: lossless, <0: absolute step)")
+		meth  = fset.String("qz", "dither1", "quantization method (dither1, dither2, none)")
+		seed  = fset.Int64("seed", -1, "seed of the dithering offset (ZDITHER0) (default: 1)")
+		table = fset.Bool("table", false, "compress binary tables (the tile size is a number of rows)")
+	)
+
+	err := fset.Parse(args)
+	if err != nil {
+		return 1
+	}
+	if fset.NArg() != 2 {
+		fset.Usage()
+		return 1
+	}
+
+	opts, err := compressOptions(*algo, *tile, *meth, *level, *seed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+
+	err = compressFile(fset.Arg(1), fset.Arg(0), *table, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// Decompress decompresses the tile-compressed images of a FITS file.
+func Decompress(prog string, args []string) int {
+	fset := newFlagSet(prog, fmt.Sprintf(`Usage: %[1]s input output
+
+Decompress the tile-compressed images and tables of a FITS file (as
+funpack does.)
+Other HDUs are copied as-is.
+A compressed primary image, stored after an empty primary HDU, is
+restored as the primary HDU.
+
+Example:
+  %[1]s in.fits.fz out.fits
+`, prog))
+
+	err := fset.Parse(args)
+	if err != nil {
+		return 1
+	}
+	if fset.NArg() != 2 {
+		fset.Usage()
+		return 1
+	}
+
+	err = decompressFile(fset.Arg(1), fset.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func compressOptions(algo, tile, meth string, level float64, seed int64) ([]fits.Option, error) {
+	var opts []fits.Option
+
+	switch strings.ToLower(algo) {
+	case "rice", "rice1", "rice_1":
+		opts = append(opts, fits.WithCompression(fits.Rice1))
+	case "gzip", "gzip1", "gzip_1":
+		opts = append(opts, fits.WithCompression(fits.GZIP1))
+	case "gzip2", "gzip_2":
+		opts = append(opts, fits.WithCompression(fits.GZIP2))
+	case "none", "nocompress":
+		opts = append(opts, fits.WithCompression(fits.NoCompress))
+	default:
+		return nil, fmt.Errorf("invalid compression algorithm %q", algo)
+	}
+
+	if tile != "" {
+		var dims []int
+		for _, v := range strings.Split(tile, ",") {
+			dim, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil || dim < 0 {
+				return nil, fmt.Errorf("invalid tile dimensions %q", tile)
+			}
+			dims = append(dims, dim)
+		}
+		opts = append(opts, fits.WithTileSize(dims...))
+	}
+
+	var method fits.Quantization
+	switch strings.ToLower(meth) {
+	case "dither1", "subtractive_dither_1":
+		method = fits.SubtractiveDither1
+	case "dither2", "subtractive_dither_2":
+		method = fits.SubtractiveDither2
+	case "none", "no_dither":
+		method = fits.NoDither
+	default:
+		return nil, fmt.Errorf("invalid quantization method %q", meth)
+	}
+	opts = append(opts, fits.WithQuantization(method, level))
+
+	if seed >= 0 {
+		opts = append(opts, fits.WithDither(seed))
+	}
+	return opts, nil
+}
+
+func compressFile(ofname, ifname string, tables bool, opts []fits.Option) error {
+	in, r, _, err := openFITS(ifname)
+	if err != nil {
+		return fmt.Errorf("could not open input file: %w", err)
+	}
+	defer r.Close()
+	defer in.Close()
+
+	out, w, err := createFITS(ofname)
+	if err != nil {
+		return fmt.Errorf("could not create output file: %w", err)
+	}
+	defer w.Close()
+	defer out.Close()
+
+	for i, hdu := range in.HDUs() {
+		if tbl, ok := hdu.(*fits.Table); ok && tables && tbl.Type() == fits.BINARY_TBL &&
+			!fits.IsCompressedImage(tbl) && !fits.IsCompressedTable(tbl) {
+			ztbl, err := fits.CompressTable(tbl, opts...)
+			if err != nil {
+				return fmt.Errorf("could not compress HDU #%d: %w", i, err)
+			}
+			hdu = ztbl
+		}
+
+		img, ok := hdu.(fits.Image)
+		if !ok || len(img.Header().Axes()) == 0 {
+			err = out.Write(hdu)
+			if err != nil {
+				return fmt.Errorf("could not copy HDU #%d: %w", i, err)
+			}
+			continue
+		}
+
+		tbl, err := fits.CompressImage(img, opts...)
+		if err != nil {
+			return fmt.Errorf("could not compress HDU #%d: %w", i, err)
+		}
+		if i == 0 {
+			err = out.Write(fits.NewImage(8, nil))
+			if err != nil {
+				return fmt.Errorf("could not write primary HDU: %w", err)
+			}
+		}
+		err = out.Write(tbl)
+		if err != nil {
+			return fmt.Errorf("could not write compressed HDU #%d: %w", i, err)
+		}
+	}
+
+	err = out.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output FITS file: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output file: %w", err)
+	}
+	return nil
+}
+
+func decompressFile(ofname, ifname string) error {
+	in, r, _, err := openFITS(ifname)
+	if err != nil {
+		return fmt.Errorf("could not open input file: %w", err)
+	}
+	defer r.Close()
+	defer in.Close()
+
+	out, w, err := createFITS(ofname)
+	if err != nil {
+		return fmt.Errorf("could not create output file: %w", err)
+	}
+	defer w.Close()
+	defer out.Close()
+
+	hdus := in.HDUs()
+	for i, hdu := range hdus {
+		if i == 0 && len(hdus) > 1 && isEmptyPrimary(hdu) && hdus[1].Header().Get("ZSIMPLE") != nil {
+			// the next HDU is the compressed primary image.
+			continue
+		}
+
+		if tbl, ok := hdu.(*fits.Table); ok {
+			switch {
+			case fits.IsCompressedImage(tbl):
+				hdu, err = fits.DecompressImage(tbl)
+			case fits.IsCompressedTable(tbl):
+				hdu, err = fits.DecompressTable(tbl)
+			}
+			if err != nil {
+				return fmt.Errorf("could not decompress HDU #%d: %w", i, err)
+			}
+		}
+
+		err = out.Write(hdu)
+		if err != nil {
+			return fmt.Errorf("could not write HDU #%d: %w", i, err)
+		}
+	}
+
+	err = out.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output FITS file: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output file: %w", err)
+	}
+	return nil
+}
+
+func isEmptyPrimary(hdu fits.HDU) bool {
+	img, ok := hdu.(fits.Image)
+	return ok && len(img.Header().Axes()) == 0
+}
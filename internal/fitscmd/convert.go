@@ -0,0 +1,177 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+// Convert converts tables between FITS and CSV/TSV files.
+func Convert(prog string, args []string) int {
+	fset := newFlagSet(prog, fmt.Sprintf(`Usage: %[1]s [options] input output
+
+Convert tables between FITS and CSV/TSV formats.
+The direction of the conversion is inferred from the file extensions
+(.csv and .tsv for text files, anything else for FITS files.)
+
+Examples:
+  %[1]s tab.fits tab.csv        - export the first table to CSV
+  %[1]s -hdu 2 tab.fits tab.tsv - export the 2nd extension to TSV
+  %[1]s tab.csv tab.fits        - import a CSV file into a FITS table
+`, prog))
+
+	var (
+		ihdu = fset.Int("hdu", -1, "index of the table HDU to export (default: first table)")
+		name = fset.String("name", "", "EXTNAME of the imported table")
+	)
+
+	err := fset.Parse(args)
+	if err != nil {
+		return 1
+	}
+	if fset.NArg() != 2 {
+		fset.Usage()
+		return 1
+	}
+
+	var (
+		ifname = fset.Arg(0)
+		ofname = fset.Arg(1)
+	)
+
+	switch {
+	case isText(ifname) && !isText(ofname):
+		err = csv2fits(ofname, ifname, *name)
+	case !isText(ifname) && isText(ofname):
+		err = fits2csv(ofname, ifname, *ihdu)
+	default:
+		err = fmt.Errorf("can not convert [%s] into [%s]", ifname, ofname)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func isText(fname string) bool {
+	switch strings.ToLower(filepath.Ext(fname)) {
+	case ".csv", ".tsv":
+		return true
+	}
+	return false
+}
+
+func textOptions(fname string) fits.CSVOptions {
+	opts := fits.CSVOptions{Header: true}
+	if strings.ToLower(filepath.Ext(fname)) == ".tsv" {
+		opts.Comma = '\t'
+	}
+	return opts
+}
+
+func fits2csv(oname, iname string, ihdu int) error {
+	f, r, xn, err := openFITS(iname)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	defer f.Close()
+
+	if ihdu < 0 && xn.HDU != nil {
+		ihdus, err := selectHDUs(f, xn.HDU)
+		if err != nil {
+			return err
+		}
+		ihdu = ihdus[0]
+	}
+
+	var table *fits.Table
+	switch {
+	case ihdu < 0:
+		for _, hdu := range f.HDUs() {
+			if tbl, ok := hdu.(*fits.Table); ok {
+				table = tbl
+				break
+			}
+		}
+		if table == nil {
+			return fmt.Errorf("no table HDU in [%s]", iname)
+		}
+	case ihdu >= len(f.HDUs()):
+		return fmt.Errorf("no HDU #%d in [%s]", ihdu, iname)
+	default:
+		tbl, ok := f.HDU(ihdu).(*fits.Table)
+		if !ok {
+			return fmt.Errorf("HDU #%d of [%s] is not a table", ihdu, iname)
+		}
+		table = tbl
+	}
+
+	w, err := os.Create(oname)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	err = fits.WriteCSV(w, table, textOptions(oname))
+	if err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+func csv2fits(oname, iname, name string) error {
+	r, err := os.Open(iname)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	table, err := fits.ReadCSVWith(r, nil, textOptions(iname))
+	if err != nil {
+		return err
+	}
+	defer table.Close()
+	if name != "" {
+		table.Header().Set("EXTNAME", name, "name of this table extension")
+	}
+
+	out, w, err := createFITS(oname)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	defer out.Close()
+
+	phdu, err := fits.NewPrimaryHDU(nil)
+	if err != nil {
+		return err
+	}
+
+	err = out.Write(phdu)
+	if err != nil {
+		return err
+	}
+
+	err = out.Write(table)
+	if err != nil {
+		return err
+	}
+
+	err = out.Close()
+	if err != nil {
+		return err
+	}
+
+	return w.Close()
+}
@@ -0,0 +1,210 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	fits "github.com/astrogo/fitsio"
+	"github.com/astrogo/fitsio/xname"
+)
+
+// Copy copies an input FITS file to an output FITS file.
+func Copy(prog string, args []string) int {
+	fset := newFlagSet(prog, fmt.Sprintf(`Usage:  %[1]s inputfile outputfile
+
+Copy an input file to an output file, optionally filtering
+the file in the process.  This seemingly simple program can
+apply powerful filters which transform the input file as
+it is being copied.  Filters may be used to extract a
+subimage from a larger image, select rows from a table,
+filter a table with a GTI time extension or a SAO region file,
+create or delete columns in a table, create an image by
+binning (histogramming) 2 table columns, and convert IRAF
+format *.imh or raw binary data files into FITS images.
+See the CFITSIO User's Guide for a complete description of
+the Extended File Name filtering syntax.
+
+Examples:
+
+%[1]s in.fit out.fit                   (simple file copy)
+%[1]s - -                              (stdin to stdout)
+%[1]s in.fit[11:50,21:60] out.fit      (copy a subimage)
+%[1]s iniraf.imh out.fit               (IRAF image to FITS)
+%[1]s in.dat[i512,512] out.fit         (raw array to FITS)
+%[1]s in.fit[events][pi>35] out.fit    (copy rows with pi>35)
+%[1]s in.fit[events][bin X,Y] out.fit  (bin an image) 
+%[1]s in.fit[events][col x=.9*y] out.fit        (new x column)
+%[1]s in.fit[events][gtifilter()] out.fit       (time filter)
+%[1]s in.fit[2][regfilter("pow.reg")] out.fit (spatial filter)
+
+Note that it may be necessary to enclose the input file name
+in single quote characters on the Unix command line.
+`, prog))
+
+	err := fset.Parse(args)
+	if err != nil {
+		return 1
+	}
+	if fset.NArg() != 2 {
+		fset.Usage()
+		return 1
+	}
+
+	ifname := fset.Arg(0)
+	ofname := fset.Arg(1)
+
+	xn, err := xname.Parse(ifname)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** invalid input file name: %v\n", err)
+		return 1
+	}
+	if xn.HDU == nil && len(xn.Cols) == 0 && xn.Rows == "" && xn.Bin == "" && xn.Section == "" &&
+		!isCompressed(xn.Path) && !isCompressed(ofname) {
+		// plain copy: stream the HDUs without decoding them.
+		err = rawCopy(ofname, xn.Path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** could not copy file: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	in, r, xn, err := openFITS(ifname)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** could not open input file: %v\n", err)
+		return 1
+	}
+	defer r.Close()
+	defer in.Close()
+
+	out, w, err := createFITS(ofname)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** could not create output file: %v\n", err)
+		return 1
+	}
+	defer w.Close()
+	defer out.Close()
+
+	filters, err := tableFilters(in, xn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** invalid filter: %v\n", err)
+		return 1
+	}
+
+	switch {
+	case len(filters) > 0:
+		err = filterTable(out, in, xn, filters)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** could not filter table: %v\n", err)
+			return 1
+		}
+
+	case xn.Bin != "":
+		err = binTable(out, in, xn)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** could not bin table: %v\n", err)
+			return 1
+		}
+
+	default:
+		// copy every HDU until we get an error
+		for ihdu := range in.HDUs() {
+			err = fits.CopyHDU(out, in, ihdu)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "**error** could not copy HDU #%d: %v\n", ihdu, err)
+				return 1
+			}
+		}
+	}
+
+	err = out.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** could not close output FITS file: %v\n", err)
+		return 1
+	}
+
+	err = w.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** could not close output file: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// binTable histograms the table selected by xn into the primary image of out.
+func binTable(out, in *fits.File, xn xname.Name) error {
+	ihdus, err := selectHDUs(in, xn.HDU)
+	if err != nil {
+		return err
+	}
+
+	var table *fits.Table
+	for _, i := range ihdus {
+		if tbl, ok := in.HDU(i).(*fits.Table); ok {
+			table = tbl
+			break
+		}
+	}
+	if table == nil {
+		return fmt.Errorf("no table HDU to bin")
+	}
+
+	xcol, ycol, spec, err := parseBinSpec(xn.Bin)
+	if err != nil {
+		return err
+	}
+
+	img, err := fits.BinTable(table, xcol, ycol, spec)
+	if err != nil {
+		return err
+	}
+	defer img.Close()
+
+	return out.Write(img)
+}
+
+// rawCopy copies all the HDUs of the src FITS file into dst, block by block.
+// "-" designates the standard input or output.
+func rawCopy(dst, src string) error {
+	var (
+		r io.Reader = os.Stdin
+		w io.Writer = os.Stdout
+	)
+	if src != "-" {
+		f, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	if dst != "-" {
+		f, err := os.Create(dst)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	for {
+		err := fits.CopyHDURaw(w, r, false)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if f, ok := w.(*os.File); ok && dst != "-" {
+		return f.Close()
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// Describe prints the layout of a FITS file: its HDUs and the schema of
+// its tables.
+func Describe(prog string, args []string) int {
+	fset := newFlagSet(prog, fmt.Sprintf(`Usage: %[1]s filename
+
+Print the list of the HDUs of a FITS file, with the dimensions of the
+images and the columns of the tables.
+
+Examples:
+
+   %[1]s file.fits
+`, prog))
+
+	err := fset.Parse(args)
+	if err != nil {
+		return 1
+	}
+	if fset.NArg() != 1 {
+		fset.Usage()
+		return 1
+	}
+
+	f, r, _, err := openFITS(fset.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+	defer r.Close()
+	defer f.Close()
+
+	err = f.Describe(os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+	return 0
+}
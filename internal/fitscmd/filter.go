@@ -0,0 +1,137 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"fmt"
+	"strings"
+
+	fits "github.com/astrogo/fitsio"
+	"github.com/astrogo/fitsio/xname"
+)
+
+// parseFilterCall parses a row filter of the form fct(arg1, arg2, ...),
+// returning its (unquoted) arguments.
+// ok reports whether expr is a call to fct.
+func parseFilterCall(expr, fct string) (args []string, ok bool, err error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(strings.ToLower(expr), fct+"(") {
+		return nil, false, nil
+	}
+	if !strings.HasSuffix(expr, ")") {
+		return nil, true, fmt.Errorf("invalid %s filter %q", fct, expr)
+	}
+
+	body := strings.TrimSpace(expr[len(fct)+1 : len(expr)-1])
+	if body == "" {
+		return nil, true, nil
+	}
+
+	// split arguments on commas, outside of quoted strings.
+	var (
+		quote rune
+		beg   int
+	)
+	for i, r := range body + "," {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			args = append(args, strings.Trim(strings.TrimSpace(body[beg:i]), `"'`))
+			beg = i + 1
+		}
+	}
+	if quote != 0 {
+		return nil, true, fmt.Errorf("invalid %s filter %q (unterminated string)", fct, expr)
+	}
+	return args, true, nil
+}
+
+// tableFilter transforms a table into a new, filtered, table.
+type tableFilter func(t *fits.Table) (*fits.Table, error)
+
+// tableFilters returns the filters described by the column and row filters
+// of xn.
+// Computed columns are applied first, so row filters may use them.
+func tableFilters(in *fits.File, xn xname.Name) ([]tableFilter, error) {
+	var filters []tableFilter
+	for _, col := range xn.Cols {
+		name, expr, ok := strings.Cut(col, "=")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		filters = append(filters, func(t *fits.Table) (*fits.Table, error) {
+			err := t.AddComputedColumn(name, expr)
+			return t, err
+		})
+	}
+
+	if _, _, ok, err := parseGTIFilter(xn.Rows); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		filter, err := gtiFilter(in, xn.Rows)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	if _, _, _, ok, err := parseRegFilter(xn.Rows); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		filter, err := regFilter(xn.Rows)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// filterTable copies all the HDUs of in into out, replacing the table
+// selected by xn with its filtered version.
+func filterTable(out, in *fits.File, xn xname.Name, filters []tableFilter) error {
+	ihdus, err := selectHDUs(in, xn.HDU)
+	if err != nil {
+		return err
+	}
+	itbl := -1
+	for _, i := range ihdus {
+		if _, ok := in.HDU(i).(*fits.Table); ok {
+			itbl = i
+			break
+		}
+	}
+	if itbl < 0 {
+		return fmt.Errorf("no table HDU to filter")
+	}
+
+	filtered := in.HDU(itbl).(*fits.Table)
+	for _, filter := range filters {
+		filtered, err = filter(filtered)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, hdu := range in.HDUs() {
+		if i == itbl {
+			hdu = filtered
+		}
+		err = out.Write(hdu)
+		if err != nil {
+			return fmt.Errorf("could not write HDU #%d: %v", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fitscmd holds the implementation of the fitsio command-line tools.
+//
+// Each command takes the name under which it is invoked (used in usage
+// messages) and its command-line arguments, and returns an exit code.
+package fitscmd
+
+import (
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	fits "github.com/astrogo/fitsio"
+	"github.com/astrogo/fitsio/xname"
+)
+
+// newFlagSet creates a new flag set with the provided usage message.
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fset := flag.NewFlagSet(name, flag.ContinueOnError)
+	fset.Usage = func() {
+		fmt.Fprintf(os.Stderr, "%v\n", usage)
+		fset.PrintDefaults()
+	}
+	return fset
+}
+
+// openFITS opens the FITS file designated by the extended filename name.
+// "-" designates the standard input.
+func openFITS(name string) (*fits.File, io.Closer, xname.Name, error) {
+	var (
+		r io.Reader
+		c io.Closer
+	)
+
+	xn, err := xname.Parse(name)
+	if err != nil {
+		return nil, nil, xn, err
+	}
+
+	switch xn.Path {
+	case "-":
+		r = os.Stdin
+		c = io.NopCloser(nil)
+	default:
+		f, err := os.Open(xn.Path)
+		if err != nil {
+			return nil, nil, xn, err
+		}
+		r = f
+		c = f
+	}
+
+	f, err := fits.Open(r)
+	if err != nil {
+		c.Close()
+		return nil, nil, xn, err
+	}
+	return f, c, xn, nil
+}
+
+// selectHDUs returns the indices of the HDUs matching the selector.
+// All HDUs are selected when sel is nil.
+func selectHDUs(f *fits.File, sel *xname.HDU) ([]int, error) {
+	var idx []int
+	for i, hdu := range f.HDUs() {
+		switch {
+		case sel == nil:
+		case sel.Index >= 0:
+			if i != sel.Index {
+				continue
+			}
+		default:
+			if !strings.EqualFold(hdu.Name(), sel.Name) {
+				continue
+			}
+			if sel.Version > 0 && hdu.Version() != sel.Version {
+				continue
+			}
+		}
+		idx = append(idx, i)
+		if sel != nil {
+			break
+		}
+	}
+	if len(idx) == 0 && sel != nil {
+		return nil, fmt.Errorf("no HDU matching [%v]", sel)
+	}
+	return idx, nil
+}
+
+// setHDU sets the HDU selector of the extended filename xn from sel, the
+// value of a -hdu flag, with the syntax of the filename's [ext] selector
+// (an index, NAME or NAME,VERSION.)
+// It is an error to select the HDU with both the filename and sel.
+func setHDU(xn *xname.Name, sel string) error {
+	if sel == "" {
+		return nil
+	}
+	if xn.HDU != nil {
+		return fmt.Errorf("HDU selected by both the filename and -hdu")
+	}
+	v, err := xname.Parse("-[" + sel + "]")
+	if err != nil || v.HDU == nil {
+		return fmt.Errorf("invalid HDU selector %q", sel)
+	}
+	xn.HDU = v.HDU
+	return nil
+}
+
+// isCompressed returns whether the named file is compressed with gzip or
+// bzip2, according to its extension.
+func isCompressed(fname string) bool {
+	return strings.HasSuffix(fname, ".gz") || strings.HasSuffix(fname, ".bz2")
+}
+
+// createFITS creates the named FITS file.
+// "-" designates the standard output.
+// Files with a .gz extension are compressed with gzip.
+func createFITS(fname string) (*fits.File, io.WriteCloser, error) {
+	var w io.WriteCloser
+	switch fname {
+	case "-":
+		w = nopWriteCloser{os.Stdout}
+	default:
+		f, err := os.Create(fname)
+		if err != nil {
+			return nil, nil, err
+		}
+		w = f
+	}
+
+	var opts []fits.Option
+	if strings.HasSuffix(fname, ".gz") {
+		opts = append(opts, fits.WithGzip(gzip.DefaultCompression))
+	}
+
+	f, err := fits.Create(w, opts...)
+	if err != nil {
+		w.Close()
+		return nil, nil, err
+	}
+	return f, w, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,102 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"fmt"
+	"strings"
+
+	fits "github.com/astrogo/fitsio"
+	"github.com/astrogo/fitsio/xname"
+)
+
+// parseGTIFilter parses a row filter of the form:
+//
+//	gtifilter()
+//	gtifilter("gti.fits[GTI]")
+//	gtifilter("gti.fits[GTI]", TIME)
+//
+// An empty GTI file name designates the input file itself.
+// The time column defaults to TIME.
+func parseGTIFilter(expr string) (gtiname, timecol string, ok bool, err error) {
+	args, ok, err := parseFilterCall(expr, "gtifilter")
+	if !ok || err != nil {
+		return "", "", ok, err
+	}
+
+	timecol = "TIME"
+	switch len(args) {
+	case 0:
+	case 1:
+		gtiname = args[0]
+	case 2:
+		gtiname, timecol = args[0], args[1]
+	default:
+		return "", "", true, fmt.Errorf("unsupported GTI filter %q (too many arguments)", expr)
+	}
+	if timecol == "" {
+		return "", "", true, fmt.Errorf("invalid time column in GTI filter %q", expr)
+	}
+	return gtiname, timecol, true, nil
+}
+
+// gtiFilter returns the table filter described by the gtifilter(...)
+// row filter expr, looking for the GTI extension in the input file in.
+func gtiFilter(in *fits.File, expr string) (tableFilter, error) {
+	gtiname, timecol, _, err := parseGTIFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	gfile := in
+	var gsel *xname.HDU
+	if gtiname != "" {
+		f, r, gxn, err := openFITS(gtiname)
+		if err != nil {
+			return nil, fmt.Errorf("could not open GTI file: %v", err)
+		}
+		defer r.Close()
+		defer f.Close()
+		gfile = f
+		gsel = gxn.HDU
+	}
+
+	gti, err := findGTI(gfile, gsel)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(events *fits.Table) (*fits.Table, error) {
+		return fits.FilterByGTI(events, gti, timecol)
+	}, nil
+}
+
+// findGTI returns the GTI table of f selected by sel.
+// When sel is nil, the first table named GTI or STDGTI is used.
+func findGTI(f *fits.File, sel *xname.HDU) (*fits.Table, error) {
+	if sel != nil {
+		ihdus, err := selectHDUs(f, sel)
+		if err != nil {
+			return nil, err
+		}
+		gti, ok := f.HDU(ihdus[0]).(*fits.Table)
+		if !ok {
+			return nil, fmt.Errorf("HDU [%v] is not a table", sel)
+		}
+		return gti, nil
+	}
+
+	for _, hdu := range f.HDUs() {
+		gti, ok := hdu.(*fits.Table)
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(gti.Name()) {
+		case "GTI", "STDGTI":
+			return gti, nil
+		}
+	}
+	return nil, fmt.Errorf("no GTI extension in %q", f.Name())
+}
@@ -0,0 +1,224 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+// hduHeader is the JSON listing of the header of an HDU.
+type hduHeader struct {
+	HDU   int        `json:"hdu"`
+	Name  string     `json:"name"`
+	Type  string     `json:"type"`
+	Cards []jsonCard `json:"cards"`
+}
+
+// jsonCard is the JSON form of a header card.
+// Integers too large for an int64 are written as JSON numbers, and complex
+// values as [real, imag] pairs.
+type jsonCard struct {
+	Name    string      `json:"name"`
+	Value   interface{} `json:"value"`
+	Comment string      `json:"comment,omitempty"`
+}
+
+// patterns is a flag.Value collecting keyword patterns, given as repeated
+// or comma-separated flag values.
+type patterns []string
+
+func (ps *patterns) String() string { return strings.Join(*ps, ",") }
+
+func (ps *patterns) Set(v string) error {
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			*ps = append(*ps, p)
+		}
+	}
+	return nil
+}
+
+// Header lists the header keywords of a FITS file.
+func Header(prog string, args []string) int {
+	fset := newFlagSet(prog, fmt.Sprintf(`Usage: %[1]s [options] filename[ext]
+
+
+List the FITS header keywords in a single extension, or, if
+ext is not given, list the keywords in all the extensions.
+
+Examples:
+
+   %[1]s file.fits      - list every header in the file
+   %[1]s file.fits[0]   - list primary array header
+   %[1]s file.fits[2]   - list header of 2nd extension
+   %[1]s file.fits+2    - same as above
+   %[1]s -hdu 2 file.fits - same as above
+   %[1]s file.fits[GTI] - list header of GTI extension
+   %[1]s -key 'NAXIS*,DATE-OBS' file.fits
+                        - list the NAXISn and DATE-OBS keywords
+   %[1]s -json file.fits[GTI] - list the cards of the GTI header as JSON
+
+Keyword patterns are case insensitive, and may use the '?' (any
+character), '*' (any sequence of characters) and '#' (any sequence of
+digits) wildcards.
+
+Note that it may be necessary to enclose the input file
+name in single quote characters on the Unix command line.
+
+Options:
+`, prog))
+
+	var keys patterns
+	fset.Var(&keys, "key", "list only the keywords matching the pattern (may be repeated, or comma-separated)")
+	var (
+		hduSel  = fset.String("hdu", "", "select the HDU by index, NAME or NAME,VERSION (as filename[ext])")
+		jsonOut = fset.Bool("json", false, "print the header cards as JSON")
+	)
+
+	err := fset.Parse(args)
+	if err != nil {
+		return 1
+	}
+	if fset.NArg() != 1 {
+		fset.Usage()
+		return 1
+	}
+
+	f, r, xn, err := openFITS(fset.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+	defer r.Close()
+	defer f.Close()
+
+	err = setHDU(&xn, *hduSel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+
+	// list only a single header if a specific extension was given
+	ihdus, err := selectHDUs(f, xn.HDU)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+
+	var (
+		w    = bufio.NewWriter(os.Stdout)
+		hdrs = make([]hduHeader, 0, len(ihdus))
+	)
+	for _, i := range ihdus {
+		hdu := f.HDU(i)
+		cards := matchCards(hdu.Header(), keys)
+
+		if *jsonOut {
+			hdr := hduHeader{
+				HDU:   i,
+				Name:  hdu.Name(),
+				Type:  hdu.Type().String(),
+				Cards: make([]jsonCard, len(cards)),
+			}
+			for j, card := range cards {
+				hdr.Cards[j] = jsonCard{
+					Name:    card.Name,
+					Value:   jsonCardValue(card.Value),
+					Comment: card.Comment,
+				}
+			}
+			hdrs = append(hdrs, hdr)
+			continue
+		}
+
+		fmt.Fprintf(w, "Header listing for HDU #%d:\n", i)
+		for _, card := range cards {
+			switch v := card.Value.(type) {
+			case fits.RawCard:
+				fmt.Fprintf(w, "%s\n", strings.TrimRight(v.String(), " "))
+			case nil:
+				fmt.Fprintf(w, "%-8s= %-29s / %s\n", card.Name, "", card.Comment)
+			case string:
+				if card.Name == "" || card.Name == "COMMENT" || card.Name == "HISTORY" {
+					fmt.Fprintf(w, "%-8s%s\n", card.Name, v)
+					continue
+				}
+				fmt.Fprintf(w, "%-8s= %-29s / %s\n", card.Name, v, card.Comment)
+			default:
+				fmt.Fprintf(
+					w, "%-8s= %-29s / %s\n",
+					card.Name,
+					fmt.Sprintf("%v", card.Value),
+					card.Comment,
+				)
+			}
+		}
+		fmt.Fprintf(w, "END\n\n")
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(hdrs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+			return 1
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// matchCards returns the cards of hdr matching any of the keyword patterns,
+// in their order in the header, or all the cards if there is no pattern.
+// The END card is never returned.
+func matchCards(hdr *fits.Header, keys []string) []*fits.Card {
+	var matched map[*fits.Card]bool
+	if len(keys) > 0 {
+		matched = make(map[*fits.Card]bool)
+		for _, key := range keys {
+			for _, card := range hdr.Match(key) {
+				matched[card] = true
+			}
+		}
+	}
+
+	var cards []*fits.Card
+	for card := range hdr.Cards() {
+		if card.Name == "END" {
+			continue
+		}
+		if matched == nil || matched[card] {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+// jsonCardValue returns the value of a header card, in a form encoding/json
+// can encode.
+func jsonCardValue(v fits.Value) interface{} {
+	switch v := v.(type) {
+	case big.Int:
+		return json.Number(v.String())
+	case complex128:
+		return []float64{real(v), imag(v)}
+	case fits.RawCard:
+		return v.String()
+	}
+	return v
+}
@@ -0,0 +1,149 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+// Merge merges the tables of multiple FITS files into a single file.
+func Merge(prog string, args []string) int {
+	fset := newFlagSet(prog, fmt.Sprintf(`Usage: %[1]s -o outfname file1 file2 [file3 ...]
+
+Merge FITS tables into a single file.
+
+Tables are merged by EXTNAME. Columns are matched by name: the schema of
+each merged table is the union of the input schemas, columns missing from
+an input table are filled with null values.
+`, prog))
+
+	outfname := fset.String("o", "out.fits", "path to merged FITS file")
+
+	err := fset.Parse(args)
+	if err != nil {
+		return 1
+	}
+	if fset.NArg() < 2 {
+		fset.Usage()
+		return 1
+	}
+
+	start := time.Now()
+	defer func() {
+		delta := time.Since(start)
+		fmt.Printf("::: timing: %v\n", delta)
+	}()
+
+	fmt.Printf("::: creating merged file [%s]...\n", *outfname)
+	out, w, err := createFITS(*outfname)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** could not create output file: %v\n", err)
+		return 1
+	}
+	defer w.Close()
+	defer out.Close()
+
+	infiles := fset.Args()
+
+	// tables are merged by EXTNAME, in order of first appearance.
+	var (
+		names  []string
+		groups = make(map[string][]*fits.Table)
+	)
+	fmt.Printf("::: merging [%d] FITS files...\n", len(infiles))
+	for i, fname := range infiles {
+		f, r, _, err := openFITS(fname)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** could not open input file: %v\n", err)
+			return 1
+		}
+		defer r.Close()
+		defer f.Close()
+
+		if i == 0 {
+			// get header from first input file
+			err = fits.CopyHDU(out, f, 0)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "**error** could not copy primary HDU: %v\n", err)
+				return 1
+			}
+		}
+
+		for _, hdu := range f.HDUs() {
+			table, ok := hdu.(*fits.Table)
+			if !ok {
+				continue
+			}
+			name := table.Name()
+			fmt.Printf("::: reading [%s][%s] -> nrows=%d\n", fname, name, table.NumRows())
+			if _, dup := groups[name]; !dup {
+				names = append(names, name)
+			}
+			groups[name] = append(groups[name], table)
+		}
+	}
+
+	if len(names) == 0 {
+		fmt.Fprintf(os.Stderr, "**error** no table to merge\n")
+		return 1
+	}
+
+	for _, name := range names {
+		srcs := groups[name]
+
+		// the schema of the merged table is the union of all the input schemas.
+		var cols []fits.Column
+		seen := make(map[string]bool)
+		for _, src := range srcs {
+			for _, col := range src.Cols() {
+				if seen[col.Name] {
+					continue
+				}
+				seen[col.Name] = true
+				cols = append(cols, col)
+			}
+		}
+
+		table, err := fits.NewTable(name, cols, srcs[0].Type())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** could not create output table [%s]: %v\n", name, err)
+			return 1
+		}
+		defer table.Close()
+
+		err = fits.MergeTables(table, srcs, fits.MergeOptions{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** could not merge tables [%s]: %v\n", name, err)
+			return 1
+		}
+
+		err = out.Write(table)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** could not write merged table [%s]: %v\n", name, err)
+			return 1
+		}
+		fmt.Printf("::: merged table [%s]: nrows=%d\n", name, table.NumRows())
+	}
+
+	fmt.Printf("::: merging [%d] FITS files... [done]\n", len(infiles))
+
+	err = out.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** could not close output FITS file: %v\n", err)
+		return 1
+	}
+
+	err = w.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** could not close output file: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,63 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"fmt"
+	"os"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+// parseRegFilter parses a row filter of the form:
+//
+//	regfilter("pow.reg")
+//	regfilter("pow.reg", X, Y)
+//
+// The position columns default to X and Y.
+func parseRegFilter(expr string) (regname, xcol, ycol string, ok bool, err error) {
+	args, ok, err := parseFilterCall(expr, "regfilter")
+	if !ok || err != nil {
+		return "", "", "", ok, err
+	}
+
+	xcol, ycol = "X", "Y"
+	switch len(args) {
+	case 1:
+		regname = args[0]
+	case 3:
+		regname, xcol, ycol = args[0], args[1], args[2]
+	default:
+		return "", "", "", true, fmt.Errorf("invalid region filter %q (want a region file and optional X,Y columns)", expr)
+	}
+	if regname == "" || xcol == "" || ycol == "" {
+		return "", "", "", true, fmt.Errorf("invalid region filter %q", expr)
+	}
+	return regname, xcol, ycol, true, nil
+}
+
+// regFilter returns the table filter described by the regfilter(...)
+// row filter expr.
+func regFilter(expr string) (tableFilter, error) {
+	regname, xcol, ycol, _, err := parseRegFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(regname)
+	if err != nil {
+		return nil, fmt.Errorf("could not open region file: %v", err)
+	}
+	defer f.Close()
+
+	reg, err := fits.ParseRegion(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(t *fits.Table) (*fits.Table, error) {
+		return fits.FilterByRegion(t, xcol, ycol, reg)
+	}, nil
+}
@@ -0,0 +1,284 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+// hduStats holds the statistics of an HDU.
+type hduStats struct {
+	HDU  int    `json:"hdu"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// images
+	Bitpix int         `json:"bitpix,omitempty"`
+	Axes   []int       `json:"axes,omitempty"`
+	Pixels *pixelStats `json:"pixels,omitempty"`
+
+	// tables
+	Rows    *int64        `json:"rows,omitempty"`
+	Columns []columnStats `json:"columns,omitempty"`
+}
+
+// pixelStats holds the statistics of the pixels of an image.
+// Statistics are nil when the image has no valid pixel.
+type pixelStats struct {
+	N      int      `json:"n"`
+	Blank  int      `json:"blank"`
+	Min    *float64 `json:"min"`
+	Max    *float64 `json:"max"`
+	Mean   *float64 `json:"mean"`
+	Median *float64 `json:"median"`
+	StdDev *float64 `json:"stddev"`
+}
+
+// columnStats holds the statistics of a column of a table.
+// Min and Max are only computed for scalar numeric columns, and Nulls for
+// scalar columns.
+type columnStats struct {
+	Name  string   `json:"name"`
+	Form  string   `json:"form"`
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+	Nulls *int64   `json:"nulls,omitempty"`
+}
+
+// Stat prints statistics about the images and tables of a FITS file.
+func Stat(prog string, args []string) int {
+	fset := newFlagSet(prog, fmt.Sprintf(`Usage: %[1]s [options] filename[ext]
+
+Print statistics about the HDUs of a FITS file, or, if ext is given,
+about a single HDU:
+ - the number of valid and blank pixels of the images, and the minimum,
+   maximum, mean, median and standard deviation of their physical values,
+ - the number of rows of the tables, and the minimum, maximum and number
+   of null values of their scalar columns.
+
+Examples:
+
+   %[1]s file.fits
+   %[1]s -json file.fits[EVENTS]
+
+Options:
+`, prog))
+
+	jsonOut := fset.Bool("json", false, "print the statistics as JSON")
+
+	err := fset.Parse(args)
+	if err != nil {
+		return 1
+	}
+	if fset.NArg() != 1 {
+		fset.Usage()
+		return 1
+	}
+
+	f, r, xn, err := openFITS(fset.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+	defer r.Close()
+	defer f.Close()
+
+	ihdus, err := selectHDUs(f, xn.HDU)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+
+	stats := make([]hduStats, 0, len(ihdus))
+	for _, i := range ihdus {
+		st, err := statHDU(i, f.HDU(i))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** HDU #%d: %v\n", i, err)
+			return 1
+		}
+		stats = append(stats, st)
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(stats)
+	} else {
+		err = printStats(stats)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error** %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func statHDU(i int, hdu fits.HDU) (hduStats, error) {
+	st := hduStats{
+		HDU:  i,
+		Name: hdu.Name(),
+		Type: hdu.Type().String(),
+	}
+	if st.Name == "" && i == 0 {
+		st.Name = "PRIMARY"
+	}
+
+	switch hdu := hdu.(type) {
+	case fits.Image:
+		st.Bitpix = hdu.Header().Bitpix()
+		st.Axes = hdu.Header().Axes()
+		if len(st.Axes) == 0 {
+			break
+		}
+		s, err := hdu.Stats()
+		if err != nil {
+			return st, err
+		}
+		st.Pixels = &pixelStats{
+			N:      s.N,
+			Blank:  s.NBlank,
+			Min:    finite(s.Min),
+			Max:    finite(s.Max),
+			Mean:   finite(s.Mean),
+			Median: finite(s.Median),
+			StdDev: finite(s.StdDev),
+		}
+
+	case *fits.Table:
+		n := hdu.NumRows()
+		st.Rows = &n
+		cols, err := statColumns(hdu)
+		if err != nil {
+			return st, err
+		}
+		st.Columns = cols
+	}
+	return st, nil
+}
+
+// statColumns computes the statistics of the columns of the table, in
+// a single pass over its rows.
+func statColumns(tbl *fits.Table) ([]columnStats, error) {
+	var (
+		ncols = tbl.NumCols()
+		stats = make([]columnStats, ncols)
+		args  = make([]interface{}, ncols)
+		nums  = make([]**float64, ncols) // scalar numeric columns
+	)
+	for i := range ncols {
+		col := tbl.Col(i)
+		stats[i] = columnStats{Name: col.Name, Form: col.Format}
+		switch col.Type().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			nums[i] = new(*float64)
+			args[i] = nums[i]
+			stats[i].Nulls = new(int64)
+		case reflect.Bool, reflect.String:
+			args[i] = reflect.New(reflect.PointerTo(col.Type())).Interface()
+			stats[i].Nulls = new(int64)
+		default:
+			args[i] = reflect.New(col.Type()).Interface()
+		}
+	}
+
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = rows.Scan(args...)
+		if err != nil {
+			return nil, err
+		}
+		for i, arg := range args {
+			st := &stats[i]
+			if st.Nulls == nil {
+				continue
+			}
+			if reflect.ValueOf(arg).Elem().IsNil() {
+				*st.Nulls++
+				continue
+			}
+			if nums[i] == nil {
+				continue
+			}
+			v := **nums[i]
+			if st.Min == nil {
+				st.Min, st.Max = new(float64), new(float64)
+				*st.Min, *st.Max = v, v
+			}
+			*st.Min = math.Min(*st.Min, v)
+			*st.Max = math.Max(*st.Max, v)
+		}
+	}
+	return stats, rows.Err()
+}
+
+// finite returns a pointer to v, or nil if v is not a finite number.
+func finite(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
+
+func printStats(stats []hduStats) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	for _, st := range stats {
+		fmt.Fprintf(w, "HDU #%d: %q %s", st.HDU, st.Name, st.Type)
+		switch {
+		case st.Rows != nil:
+			fmt.Fprintf(w, ", %d rows\n", *st.Rows)
+			if len(st.Columns) > 0 {
+				fmt.Fprintf(w, "  COLUMN\tFORM\tMIN\tMAX\tNULLS\n")
+			}
+			for _, col := range st.Columns {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", col.Name, col.Form, fmtFloat(col.Min), fmtFloat(col.Max), fmtCount(col.Nulls))
+			}
+		case st.Pixels != nil:
+			px := st.Pixels
+			axes := make([]string, len(st.Axes))
+			for i, dim := range st.Axes {
+				axes[i] = fmt.Sprint(dim)
+			}
+			fmt.Fprintf(w, ", BITPIX=%d, %s\n", st.Bitpix, strings.Join(axes, "x"))
+			fmt.Fprintf(w, "  N\tBLANK\tMIN\tMAX\tMEAN\tMEDIAN\tSTDDEV\n")
+			fmt.Fprintf(
+				w, "  %d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+				px.N, px.Blank, fmtFloat(px.Min), fmtFloat(px.Max),
+				fmtFloat(px.Mean), fmtFloat(px.Median), fmtFloat(px.StdDev),
+			)
+		default:
+			fmt.Fprintf(w, "\n")
+		}
+	}
+	return w.Flush()
+}
+
+func fmtFloat(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%g", *v)
+}
+
+func fmtCount(v *int64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprint(*v)
+}
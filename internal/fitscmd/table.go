@@ -0,0 +1,391 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	fits "github.com/astrogo/fitsio"
+	"github.com/astrogo/fitsio/xname"
+)
+
+// Table lists the content of the tables of a FITS file.
+func Table(prog string, args []string) int {
+	fset := newFlagSet(prog, fmt.Sprintf(`Usage: %[1]s [options] filename[ext][col filter][row filter]
+
+List the contents of a FITS table
+
+Examples:
+  %[1]s tab.fits[GTI]           - list the GTI extension
+  %[1]s tab.fits[1][#row < 101] - list first 100 rows
+  %[1]s tab.fits[1][col X;Y]    - list X and Y cols only
+  %[1]s tab.fits[1][col -PI]    - list all but the PI col
+  %[1]s tab.fits[1][col -PI][#row < 101]  - combined case
+  %[1]s -hdu GTI -cols START,STOP -rows 1:100 tab.fits
+                                - same selections, with flags
+  %[1]s -format list tab.fits   - list the tables one row at a time
+  %[1]s -format csv tab.fits    - export tables in CSV format
+  %[1]s -format json tab.fits   - export tables as JSON arrays of rows
+
+Display formats can be modified with the TDISPn keywords.
+Long cells of the table format are truncated.
+
+Options:
+`, prog))
+
+	var (
+		hduSel = fset.String("hdu", "", "select the table by name (NAME or NAME,VERSION) or index")
+		colSel = fset.String("cols", "", "comma-separated list of the columns to list (-NAME excludes a column)")
+		rowSel = fset.String("rows", "", "range of rows to list, as first:last (1-based, inclusive, either bound may be omitted)")
+		format = fset.String("format", "table", "output format: table, list, csv, tsv or json")
+		page   = fset.Int("page", 0, "number of rows per page of the table format (0: a single page)")
+		tocsv  = fset.Bool("csv", false, "export tables in CSV format (same as -format csv)")
+		totsv  = fset.Bool("tsv", false, "export tables in TSV format (same as -format tsv)")
+	)
+
+	err := fset.Parse(args)
+	if err != nil {
+		return 1
+	}
+	if fset.NArg() != 1 {
+		fset.Usage()
+		return 1
+	}
+	switch {
+	case *tocsv:
+		*format = "csv"
+	case *totsv:
+		*format = "tsv"
+	}
+	switch *format {
+	case "table", "list", "csv", "tsv", "json":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: invalid output format %q\n", *format)
+		return 1
+	}
+
+	f, r, xn, err := openFITS(fset.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer r.Close()
+	defer f.Close()
+
+	err = applySelection(&xn, *hduSel, *colSel, *rowSel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	ihdus, err := selectHDUs(f, xn.HDU)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	for _, ihdu := range ihdus {
+		hdu := f.HDU(ihdu)
+		table, ok := hdu.(*fits.Table)
+		if !ok {
+			if xn.HDU != nil {
+				fmt.Fprintf(os.Stderr, "Error: this program only displays tables, not images\n")
+				return 1
+			}
+			continue
+		}
+
+		nrows := table.NumRows()
+		beg, end, ok := xn.RowRange(nrows)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: unsupported row filter %q\n", xn.Rows)
+			return 1
+		}
+
+		names := make([]string, table.NumCols())
+		for i, col := range table.Cols() {
+			names[i] = col.Name
+		}
+		selected, err := xn.Columns(names)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		icols := make([]int, len(selected))
+		for i, name := range selected {
+			icols[i] = table.Index(name)
+		}
+
+		switch *format {
+		case "csv", "tsv":
+			err = writeTableCSV(w, table, icols, beg, end, *format == "tsv")
+		case "json":
+			err = writeTableJSON(w, table, icols, beg, end)
+		case "list":
+			err = writeTableText(w, table, icols, beg, end)
+		default:
+			if end <= beg {
+				continue
+			}
+			err = fits.Fprint(w, table, fits.PrintOptions{
+				Columns:  selected,
+				Start:    beg,
+				NumRows:  end - beg,
+				PageSize: *page,
+				Units:    true,
+				RowIndex: true,
+			})
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// applySelection adds the HDU, columns and rows selections given with the
+// -hdu, -cols and -rows flags to the extended filename xn.
+func applySelection(xn *xname.Name, hdu, cols, rows string) error {
+	err := setHDU(xn, hdu)
+	if err != nil {
+		return err
+	}
+
+	if cols != "" {
+		for _, col := range strings.Split(cols, ",") {
+			if col = strings.TrimSpace(col); col != "" {
+				xn.Cols = append(xn.Cols, col)
+			}
+		}
+	}
+
+	if rows != "" {
+		first, last, ok := strings.Cut(rows, ":")
+		if !ok {
+			last = first
+		}
+		var conds []string
+		for _, bound := range []struct {
+			v  string
+			op string
+		}{{first, ">="}, {last, "<="}} {
+			v := strings.TrimSpace(bound.v)
+			if v == "" {
+				continue
+			}
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid row range %q", rows)
+			}
+			conds = append(conds, "#row "+bound.op+" "+v)
+		}
+		if xn.Rows != "" {
+			conds = append(conds, xn.Rows)
+		}
+		xn.Rows = strings.Join(conds, " && ")
+	}
+	return nil
+}
+
+// scanArgs returns the values rows of table are scanned into.
+func scanArgs(table *fits.Table) []interface{} {
+	data := make([]interface{}, table.NumCols())
+	for i, col := range table.Cols() {
+		data[i] = reflect.New(col.Type()).Interface()
+	}
+	return data
+}
+
+// writeTableText lists the rows [beg, end) of the columns icols of table.
+func writeTableText(w io.Writer, table *fits.Table, icols []int, beg, end int64) error {
+	rows, err := table.Read(beg, end)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		nrows   = table.NumRows()
+		hdrline = strings.Repeat("=", 80-15)
+		data    = scanArgs(table)
+		maxname = 10
+	)
+	for _, i := range icols {
+		maxname = max(maxname, len(table.Col(i).Name))
+	}
+
+	rowfmt := fmt.Sprintf("%%-%ds | %%v\n", maxname)
+	for irow := beg; rows.Next(); irow++ {
+		err = rows.Scan(data...)
+		if err != nil {
+			fmt.Fprintf(w, "Error: (row=%v) %v\n", irow, err)
+		}
+		fmt.Fprintf(w, "== %05d/%05d %s\n", irow, nrows, hdrline)
+		for _, i := range icols {
+			rv := reflect.Indirect(reflect.ValueOf(data[i]))
+			fmt.Fprintf(w, rowfmt, table.Col(i).Name, rv.Interface())
+		}
+	}
+	return rows.Err()
+}
+
+// writeTableCSV writes the rows [beg, end) of the columns icols of table
+// in CSV (or TSV) format.
+func writeTableCSV(w io.Writer, table *fits.Table, icols []int, beg, end int64, tsv bool) error {
+	opts := fits.CSVOptions{Header: true}
+	if tsv {
+		opts.Comma = '\t'
+	}
+	if len(icols) == table.NumCols() && beg == 0 && end == table.NumRows() {
+		return fits.WriteCSV(w, table, opts)
+	}
+
+	cols := make([]fits.Column, len(icols))
+	for i, icol := range icols {
+		cols[i] = *table.Col(icol)
+		cols[i].Start = 0
+	}
+	sub, err := fits.NewTable(table.Name(), cols, table.Type())
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	rows, err := table.Read(beg, end)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		data = scanArgs(table)
+		row  = make([]interface{}, len(icols))
+	)
+	for i, icol := range icols {
+		row[i] = data[icol]
+	}
+	for rows.Next() {
+		err = rows.Scan(data...)
+		if err != nil {
+			return err
+		}
+		err = sub.Write(row...)
+		if err != nil {
+			return err
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+	return fits.WriteCSV(w, sub, opts)
+}
+
+// writeTableJSON writes the rows [beg, end) of the columns icols of table
+// as a JSON array of objects, one per row, with the columns in the order
+// of icols.
+// Non-finite floating-point values are written as null, and complex
+// numbers as [real, imag] pairs.
+func writeTableJSON(w io.Writer, table *fits.Table, icols []int, beg, end int64) error {
+	rows, err := table.Read(beg, end)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	names := make([][]byte, len(icols))
+	for i, icol := range icols {
+		names[i], err = json.Marshal(table.Col(icol).Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	var (
+		data = scanArgs(table)
+		buf  []byte
+	)
+	io.WriteString(w, "[")
+	for irow := beg; rows.Next(); irow++ {
+		err = rows.Scan(data...)
+		if err != nil {
+			return err
+		}
+		buf = buf[:0]
+		if irow > beg {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, "\n  {"...)
+		for i, icol := range icols {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			v, err := json.Marshal(jsonValue(reflect.ValueOf(data[icol]).Elem()))
+			if err != nil {
+				return fmt.Errorf("could not encode column %q: %w", table.Col(icol).Name, err)
+			}
+			buf = append(buf, names[i]...)
+			buf = append(buf, ':')
+			buf = append(buf, v...)
+		}
+		buf = append(buf, '}')
+		_, err = w.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n]\n")
+	return err
+}
+
+// jsonValue returns the value of rv, in a form encoding/json can encode.
+func jsonValue(rv reflect.Value) interface{} {
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		v := rv.Float()
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil
+		}
+		return v
+	case reflect.Complex64, reflect.Complex128:
+		v := rv.Complex()
+		return []interface{}{
+			jsonValue(reflect.ValueOf(real(v))),
+			jsonValue(reflect.ValueOf(imag(v))),
+		}
+	case reflect.Array, reflect.Slice:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return []interface{}{}
+		}
+		vs := make([]interface{}, rv.Len())
+		for i := range vs {
+			vs[i] = jsonValue(rv.Index(i))
+		}
+		return vs
+	}
+	return rv.Interface()
+}
@@ -0,0 +1,144 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitscmd
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+// Exit codes of the verify command.
+const (
+	verifyOK       = 0 // no finding
+	verifyFailure  = 1 // invalid arguments, or files which could not be read
+	verifyWarnings = 2 // warnings, but no error
+	verifyErrors   = 3 // errors
+)
+
+// verifyReport is the JSON report of the verification of a file.
+type verifyReport struct {
+	File     string         `json:"file"`
+	Findings []fits.Finding `json:"findings"`
+	Error    string         `json:"error,omitempty"` // error reading the file, if any
+}
+
+// Verify checks that FITS files follow the FITS standard.
+func Verify(prog string, args []string) int {
+	fset := newFlagSet(prog, fmt.Sprintf(`Usage: %[1]s [options] file1.fits [file2.fits ...]
+
+Check that FITS files follow the FITS standard: structure of the headers,
+mandatory keywords, size and padding of the data units, CHECKSUM and
+DATASUM keywords (when present), and decoding of each HDU.
+
+Files with a .gz or .bz2 extension are decompressed. "-" designates the
+standard input.
+
+The exit code is:
+   0 - no deviation from the standard was found
+   1 - invalid arguments, or a file could not be read
+   2 - warnings were found, but no error
+   3 - errors were found
+When several of these apply, the highest code is returned.
+
+Examples:
+
+   %[1]s file.fits
+   %[1]s -json *.fits > report.json
+
+Options:
+`, prog))
+
+	jsonOut := fset.Bool("json", false, "print the findings as JSON")
+
+	err := fset.Parse(args)
+	if err != nil {
+		return verifyFailure
+	}
+	if fset.NArg() < 1 {
+		fset.Usage()
+		return verifyFailure
+	}
+
+	var (
+		code    = verifyOK
+		reports = make([]verifyReport, 0, fset.NArg())
+	)
+	for _, fname := range fset.Args() {
+		report := verifyReport{File: fname, Findings: []fits.Finding{}}
+		findings, err := verifyFile(fname)
+		report.Findings = append(report.Findings, findings...)
+		if err != nil {
+			report.Error = err.Error()
+			code = max(code, verifyFailure)
+		}
+		for _, f := range findings {
+			switch f.Severity {
+			case fits.SeverityError:
+				code = max(code, verifyErrors)
+			case fits.SeverityWarning:
+				code = max(code, verifyWarnings)
+			}
+		}
+		reports = append(reports, report)
+
+		if *jsonOut {
+			continue
+		}
+		for _, f := range findings {
+			fmt.Printf("%s: %v\n", fname, f)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** %s: %v\n", fname, err)
+		}
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(reports)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "**error** could not encode report: %v\n", err)
+			return verifyFailure
+		}
+	}
+	return code
+}
+
+// verifyFile verifies the named FITS file.
+func verifyFile(fname string) ([]fits.Finding, error) {
+	var r io.Reader
+	switch fname {
+	case "-":
+		r = os.Stdin
+	default:
+		f, err := os.Open(fname)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	switch {
+	case strings.HasSuffix(fname, ".gz"):
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	case strings.HasSuffix(fname, ".bz2"):
+		r = bzip2.NewReader(r)
+	}
+
+	return fits.Verify(r)
+}
@@ -0,0 +1,499 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// JoinKind describes which rows are kept when joining two tables.
+type JoinKind int
+
+const (
+	InnerJoin JoinKind = iota // keep rows with a matching key in both tables
+	LeftJoin                  // keep all rows of the left table
+	RightJoin                 // keep all rows of the right table
+	OuterJoin                 // keep all rows of both tables
+)
+
+func (k JoinKind) String() string {
+	switch k {
+	case InnerJoin:
+		return "inner"
+	case LeftJoin:
+		return "left"
+	case RightJoin:
+		return "right"
+	case OuterJoin:
+		return "outer"
+	}
+	return fmt.Sprintf("JoinKind(%d)", int(k))
+}
+
+// JoinTables performs an equality join of the tables a and b on the key
+// column named on, and returns the result as a new table.
+//
+// The resulting table holds all the columns of a, followed by the columns
+// of b except the key column.
+// Columns of b whose name is already used by a column of a are suffixed
+// with "_2".
+// Values of unmatched rows are filled with null values (see MergeTables.)
+//
+// The whole content of b is loaded in memory.
+func JoinTables(a, b *Table, on string, how JoinKind) (*Table, error) {
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("fitsio: nil table")
+	}
+	switch how {
+	case InnerJoin, LeftJoin, RightJoin, OuterJoin:
+	default:
+		return nil, fmt.Errorf("fitsio: invalid join kind (%v)", how)
+	}
+
+	akey := a.Index(on)
+	if akey < 0 {
+		return nil, fmt.Errorf("fitsio: no column %q in table %q", on, a.Name())
+	}
+	bkey := b.Index(on)
+	if bkey < 0 {
+		return nil, fmt.Errorf("fitsio: no column %q in table %q", on, b.Name())
+	}
+
+	at := a.cols[akey].Type()
+	bt := b.cols[bkey].Type()
+	switch {
+	case !isKeyType(at) || !isKeyType(bt):
+		return nil, fmt.Errorf("fitsio: unsupported key column type (%v, %v)", at, bt)
+	case !convertibleType(at, bt) && !convertibleType(bt, at):
+		return nil, fmt.Errorf("fitsio: incompatible key column types (%v, %v)", at, bt)
+	}
+
+	bcols := make([]int, 0, len(b.cols))
+	for i := range b.cols {
+		if i != bkey {
+			bcols = append(bcols, i)
+		}
+	}
+
+	out, err := newJoinTable(a, b, bcols, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	brows, err := readAllRows(b)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[interface{}][]int, len(brows))
+	for i, row := range brows {
+		k := keyOf(row[bkey])
+		index[k] = append(index[k], i)
+	}
+
+	w, err := newRowWriter(out)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]bool, len(brows))
+	err = scanRows(a, func(arow []reflect.Value) error {
+		ibs := index[keyOf(arow[akey])]
+		if len(ibs) == 0 {
+			if how == LeftJoin || how == OuterJoin {
+				w.set(0, arow)
+				return w.write()
+			}
+			return nil
+		}
+		for _, ib := range ibs {
+			matched[ib] = true
+			w.set(0, arow)
+			w.setCols(len(a.cols), brows[ib], bcols)
+			err := w.write()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if how == RightJoin || how == OuterJoin {
+		for ib, brow := range brows {
+			if matched[ib] {
+				continue
+			}
+			w.setCols(len(a.cols), brow, bcols)
+			if key := brow[bkey]; key.Type().ConvertibleTo(at) {
+				w.vals[akey] = key.Convert(at)
+			}
+			err = w.write()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// CrossMatch matches the sources of the tables a and b by sky position.
+// raCol and decCol are the names of the columns holding the right
+// ascension and declination of the sources, in degrees, in both tables.
+// radius is the maximum angular separation of matched sources, in degrees.
+//
+// For each row of a, the closest row of b within radius is selected.
+// Rows of a without any counterpart are dropped.
+//
+// The resulting table holds all the columns of a, followed by all the
+// columns of b and a "SEPARATION" column with the angular separation of
+// the matched sources, in degrees.
+// Columns of b whose name is already used are suffixed with "_2".
+func CrossMatch(a, b *Table, raCol, decCol string, radius float64) (*Table, error) {
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("fitsio: nil table")
+	}
+	if !(radius > 0) || radius > 180 {
+		return nil, fmt.Errorf("fitsio: invalid cross-match radius (%v)", radius)
+	}
+
+	var icols [2][2]int
+	for i, t := range []*Table{a, b} {
+		for j, name := range []string{raCol, decCol} {
+			icol := t.Index(name)
+			if icol < 0 {
+				return nil, fmt.Errorf("fitsio: no column %q in table %q", name, t.Name())
+			}
+			if !isFloatKind(t.cols[icol].Type().Kind()) && !isIntKind(t.cols[icol].Type().Kind()) {
+				return nil, fmt.Errorf("fitsio: column %q of table %q is not numerical", name, t.Name())
+			}
+			icols[i][j] = icol
+		}
+	}
+
+	bcols := make([]int, len(b.cols))
+	for i := range bcols {
+		bcols[i] = i
+	}
+	sep := Column{Name: "SEPARATION", Format: "D", Unit: "deg"}
+	out, err := newJoinTable(a, b, bcols, []Column{sep})
+	if err != nil {
+		return nil, err
+	}
+
+	brows, err := readAllRows(b)
+	if err != nil {
+		return nil, err
+	}
+	idx := newSkyIndex(radius)
+	for i, row := range brows {
+		ra := toFloat(row[icols[1][0]])
+		dec := toFloat(row[icols[1][1]])
+		idx.add(i, ra, dec)
+	}
+
+	w, err := newRowWriter(out)
+	if err != nil {
+		return nil, err
+	}
+	isep := len(out.cols) - 1
+
+	err = scanRows(a, func(arow []reflect.Value) error {
+		ra := toFloat(arow[icols[0][0]])
+		dec := toFloat(arow[icols[0][1]])
+		ib, dist := idx.nearest(ra, dec)
+		if ib < 0 {
+			return nil
+		}
+		w.set(0, arow)
+		w.setCols(len(a.cols), brows[ib], bcols)
+		w.vals[isep] = reflect.ValueOf(dist)
+		return w.write()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// newJoinTable creates the table holding the result of the join of a and b.
+func newJoinTable(a, b *Table, bcols []int, extra []Column) (*Table, error) {
+	htype := BINARY_TBL
+	if !a.binary && !b.binary && len(extra) == 0 {
+		htype = ASCII_TBL
+	}
+
+	cols := make([]Column, 0, len(a.cols)+len(bcols)+len(extra))
+	names := make(map[string]bool, cap(cols))
+	for i := range a.cols {
+		col := schemaColumn(a, i, htype)
+		names[col.Name] = true
+		cols = append(cols, col)
+	}
+	for _, i := range bcols {
+		col := schemaColumn(b, i, htype)
+		if names[col.Name] {
+			col.Name += "_2"
+		}
+		names[col.Name] = true
+		cols = append(cols, col)
+	}
+	for _, col := range extra {
+		if names[col.Name] {
+			col.Name += "_2"
+		}
+		names[col.Name] = true
+		cols = append(cols, col)
+	}
+
+	return NewTable(a.Name(), cols, htype)
+}
+
+// schemaColumn returns a copy of the metadata of the i-th column of t,
+// suitable for creating a new table of type htype.
+func schemaColumn(t *Table, i int, htype HDUType) Column {
+	src := &t.cols[i]
+	col := Column{
+		Name:    src.Name,
+		Format:  src.Format,
+		Unit:    src.Unit,
+		Null:    src.Null,
+		Bscale:  src.Bscale,
+		Bzero:   src.Bzero,
+		Display: src.Display,
+		Dim:     src.Dim,
+	}
+	if t.binary != (htype == BINARY_TBL) {
+		col.Format = formFromGoType(src.Type(), htype)
+		if _, ok := g_tzero[src.dtype.tc]; ok {
+			// unsigned integers are not offset in ASCII tables.
+			col.Bscale = 1
+			col.Bzero = 0
+		}
+		col.Null = ""
+		col.Display = ""
+	}
+	return col
+}
+
+// isKeyType returns whether values of type rt can be used as join keys.
+func isKeyType(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Slice, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return isKeyType(rt.Elem())
+	}
+	return true
+}
+
+// keyOf returns a comparable value for the join key rv,
+// so keys of different numerical types can be matched.
+func keyOf(rv reflect.Value) interface{} {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v := rv.Uint(); v <= math.MaxInt64 {
+			return int64(v)
+		}
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		v := rv.Float()
+		if v == math.Trunc(v) && math.Abs(v) < 1<<63 {
+			return int64(v)
+		}
+		return v
+	}
+	return rv.Interface()
+}
+
+// toFloat returns the numerical value rv as a float64.
+func toFloat(rv reflect.Value) float64 {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	}
+	return rv.Float()
+}
+
+// scanRows calls fn with the values of each row of t.
+// The values are only valid during the call to fn.
+func scanRows(t *Table, fn func(row []reflect.Value) error) error {
+	rows, err := t.Read(0, t.NumRows())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	ptrs := make([]interface{}, len(t.cols))
+	vals := make([]reflect.Value, len(t.cols))
+	for i := range t.cols {
+		rv := reflect.New(t.cols[i].Type())
+		ptrs[i] = rv.Interface()
+		vals[i] = rv.Elem()
+	}
+
+	for rows.Next() {
+		err = rows.Scan(ptrs...)
+		if err != nil {
+			return err
+		}
+		err = fn(vals)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// readAllRows reads all the rows of t in memory.
+func readAllRows(t *Table) ([][]reflect.Value, error) {
+	out := make([][]reflect.Value, 0, t.NumRows())
+	err := scanRows(t, func(row []reflect.Value) error {
+		vals := make([]reflect.Value, len(row))
+		for i, v := range row {
+			vals[i] = reflect.New(v.Type()).Elem()
+			vals[i].Set(v)
+		}
+		out = append(out, vals)
+		return nil
+	})
+	return out, err
+}
+
+// rowWriter writes rows of values into a table, filling unset values
+// with nulls.
+type rowWriter struct {
+	t     *Table
+	nulls []reflect.Value
+	vals  []reflect.Value
+	ptrs  []interface{}
+}
+
+func newRowWriter(t *Table) (*rowWriter, error) {
+	w := &rowWriter{
+		t:     t,
+		nulls: make([]reflect.Value, len(t.cols)),
+		vals:  make([]reflect.Value, len(t.cols)),
+		ptrs:  make([]interface{}, len(t.cols)),
+	}
+	for i := range t.cols {
+		null, err := nullValue(&t.cols[i])
+		if err != nil {
+			return nil, err
+		}
+		w.nulls[i] = null
+		w.ptrs[i] = reflect.New(t.cols[i].Type()).Interface()
+	}
+	return w, nil
+}
+
+// set sets the values of the columns starting at index beg.
+func (w *rowWriter) set(beg int, vals []reflect.Value) {
+	copy(w.vals[beg:], vals)
+}
+
+// setCols sets the values of the columns starting at index beg,
+// from the values vals[icols[0]], vals[icols[1]], ...
+func (w *rowWriter) setCols(beg int, vals []reflect.Value, icols []int) {
+	for i, icol := range icols {
+		w.vals[beg+i] = vals[icol]
+	}
+}
+
+// write writes the current values into a new row and resets the values.
+func (w *rowWriter) write() error {
+	for i, v := range w.vals {
+		dst := reflect.ValueOf(w.ptrs[i]).Elem()
+		switch {
+		case !v.IsValid():
+			dst.Set(w.nulls[i])
+		case v.Type() == dst.Type():
+			dst.Set(v)
+		default:
+			convertValue(dst, v)
+		}
+		w.vals[i] = reflect.Value{}
+	}
+	return w.t.Write(w.ptrs...)
+}
+
+// skyIndex is a simple spatial index of sky positions, bucketing positions
+// in declination zones.
+type skyIndex struct {
+	radius float64
+	zones  map[int][]skyPos
+}
+
+type skyPos struct {
+	i       int
+	ra, dec float64 // in radians
+}
+
+func newSkyIndex(radius float64) *skyIndex {
+	return &skyIndex{
+		radius: radius,
+		zones:  make(map[int][]skyPos),
+	}
+}
+
+func (idx *skyIndex) zone(dec float64) int {
+	return int(math.Floor((dec + 90) / idx.radius))
+}
+
+func (idx *skyIndex) add(i int, ra, dec float64) {
+	if math.IsNaN(ra) || math.IsNaN(dec) {
+		return
+	}
+	z := idx.zone(dec)
+	idx.zones[z] = append(idx.zones[z], skyPos{
+		i:   i,
+		ra:  ra * math.Pi / 180,
+		dec: dec * math.Pi / 180,
+	})
+}
+
+// nearest returns the index of the closest position within the index radius,
+// and its angular separation in degrees.
+// nearest returns -1 if there is no such position.
+func (idx *skyIndex) nearest(ra, dec float64) (int, float64) {
+	if math.IsNaN(ra) || math.IsNaN(dec) {
+		return -1, 0
+	}
+	var (
+		best = -1
+		dist = math.Inf(+1)
+		z    = idx.zone(dec)
+		rra  = ra * math.Pi / 180
+		rdec = dec * math.Pi / 180
+	)
+	for iz := z - 1; iz <= z+1; iz++ {
+		for _, pos := range idx.zones[iz] {
+			d := angularSeparation(rra, rdec, pos.ra, pos.dec) * 180 / math.Pi
+			if d <= idx.radius && d < dist {
+				best = pos.i
+				dist = d
+			}
+		}
+	}
+	return best, dist
+}
+
+// angularSeparation returns the angular separation (in radians) of two
+// positions given in radians, using the haversine formula.
+func angularSeparation(ra1, dec1, ra2, dec2 float64) float64 {
+	sdec := math.Sin((dec2 - dec1) / 2)
+	sra := math.Sin((ra2 - ra1) / 2)
+	h := sdec*sdec + math.Cos(dec1)*math.Cos(dec2)*sra*sra
+	return 2 * math.Asin(math.Min(1, math.Sqrt(h)))
+}
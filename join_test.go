@@ -0,0 +1,240 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestJoinTables(t *testing.T) {
+	type A struct {
+		ID   int32   `fits:"ID"`
+		Name string  `fits:"NAME"`
+		X    float64 `fits:"X"`
+	}
+	type B struct {
+		ID int64   `fits:"ID"`
+		X  float32 `fits:"X"`
+		Y  int16   `fits:"Y"`
+	}
+
+	a, err := NewTableFrom("A", A{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer a.Close()
+	for _, row := range []A{{1, "one", 1.5}, {2, "two", 2.5}, {3, "three", 3.5}} {
+		err = a.Write(&row)
+		if err != nil {
+			t.Fatalf("could not write row: %v", err)
+		}
+	}
+
+	b, err := NewTableFrom("B", B{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer b.Close()
+	for _, row := range []B{{2, -2, 20}, {4, -4, 40}, {2, -22, 22}} {
+		err = b.Write(&row)
+		if err != nil {
+			t.Fatalf("could not write row: %v", err)
+		}
+	}
+
+	type Out struct {
+		ID   int32   `fits:"ID"`
+		Name string  `fits:"NAME"`
+		X    float64 `fits:"X"`
+		X2   float32 `fits:"X_2"`
+		Y    int16   `fits:"Y"`
+	}
+
+	nan := float32(math.NaN())
+	for _, test := range []struct {
+		how  JoinKind
+		want []Out
+	}{
+		{
+			how: InnerJoin,
+			want: []Out{
+				{2, "two", 2.5, -2, 20},
+				{2, "two", 2.5, -22, 22},
+			},
+		},
+		{
+			how: LeftJoin,
+			want: []Out{
+				{1, "one", 1.5, nan, 0},
+				{2, "two", 2.5, -2, 20},
+				{2, "two", 2.5, -22, 22},
+				{3, "three", 3.5, nan, 0},
+			},
+		},
+		{
+			how: RightJoin,
+			want: []Out{
+				{2, "two", 2.5, -2, 20},
+				{2, "two", 2.5, -22, 22},
+				{4, "", math.NaN(), -4, 40},
+			},
+		},
+		{
+			how: OuterJoin,
+			want: []Out{
+				{1, "one", 1.5, nan, 0},
+				{2, "two", 2.5, -2, 20},
+				{2, "two", 2.5, -22, 22},
+				{3, "three", 3.5, nan, 0},
+				{4, "", math.NaN(), -4, 40},
+			},
+		},
+	} {
+		t.Run(test.how.String(), func(t *testing.T) {
+			out, err := JoinTables(a, b, "ID", test.how)
+			if err != nil {
+				t.Fatalf("could not join tables: %v", err)
+			}
+			defer out.Close()
+
+			var names []string
+			for _, col := range out.Cols() {
+				names = append(names, col.Name)
+			}
+			if want := []string{"ID", "NAME", "X", "X_2", "Y"}; !reflect.DeepEqual(names, want) {
+				t.Fatalf("invalid columns.\ngot= %q\nwant=%q", names, want)
+			}
+
+			var got []Out
+			rows, err := out.Read(0, out.NumRows())
+			if err != nil {
+				t.Fatalf("could not read rows: %v", err)
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var row Out
+				err = rows.Scan(&row)
+				if err != nil {
+					t.Fatalf("could not scan row: %v", err)
+				}
+				got = append(got, row)
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("invalid number of rows. got=%d, want=%d", len(got), len(test.want))
+			}
+			for i := range got {
+				g, w := got[i], test.want[i]
+				if !sameFloat(g.X, w.X) || !sameFloat(float64(g.X2), float64(w.X2)) {
+					t.Fatalf("row %d: invalid values.\ngot= %+v\nwant=%+v", i, g, w)
+				}
+				g.X, g.X2, w.X, w.X2 = 0, 0, 0, 0
+				if g != w {
+					t.Fatalf("row %d: invalid values.\ngot= %+v\nwant=%+v", i, got[i], test.want[i])
+				}
+			}
+		})
+	}
+
+	_, err = JoinTables(a, b, "NONE", InnerJoin)
+	if err == nil {
+		t.Fatalf("expected an error for a missing key column")
+	}
+	_, err = JoinTables(a, b, "ID", JoinKind(42))
+	if err == nil {
+		t.Fatalf("expected an error for an invalid join kind")
+	}
+}
+
+func TestCrossMatch(t *testing.T) {
+	type Src struct {
+		ID  int32   `fits:"ID"`
+		RA  float64 `fits:"RA"`
+		DEC float64 `fits:"DEC"`
+	}
+
+	newTable := func(name string, srcs []Src) *Table {
+		tbl, err := NewTableFrom(name, Src{}, BINARY_TBL)
+		if err != nil {
+			t.Fatalf("could not create table: %v", err)
+		}
+		for _, src := range srcs {
+			err = tbl.Write(&src)
+			if err != nil {
+				t.Fatalf("could not write row: %v", err)
+			}
+		}
+		return tbl
+	}
+
+	const arcsec = 1.0 / 3600
+	a := newTable("A", []Src{
+		{1, 10, 20},
+		{2, 359.9999, 0},
+		{3, 150, -45},
+		{4, 45, 89.9999},
+	})
+	defer a.Close()
+	b := newTable("B", []Src{
+		{10, 10 + 0.5*arcsec, 20},
+		{11, 10 + 2*arcsec, 20},
+		{12, 0.0001, 0},
+		{13, 150, -44},
+		{14, 225, 89.9999},
+	})
+	defer b.Close()
+
+	out, err := CrossMatch(a, b, "RA", "DEC", 1*arcsec)
+	if err != nil {
+		t.Fatalf("could not cross-match: %v", err)
+	}
+	defer out.Close()
+
+	type Match struct {
+		ID  int32   `fits:"ID"`
+		ID2 int32   `fits:"ID_2"`
+		Sep float64 `fits:"SEPARATION"`
+	}
+	var got []Match
+	rows, err := out.Read(0, out.NumRows())
+	if err != nil {
+		t.Fatalf("could not read rows: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m Match
+		err = rows.Scan(&m)
+		if err != nil {
+			t.Fatalf("could not scan row: %v", err)
+		}
+		got = append(got, m)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("invalid number of matches: got=%d, want=3\n%+v", len(got), got)
+	}
+	for i, want := range [][2]int32{{1, 10}, {2, 12}, {4, 14}} {
+		if got[i].ID != want[0] || got[i].ID2 != want[1] {
+			t.Fatalf("match %d: got=(%d,%d), want=(%d,%d)", i, got[i].ID, got[i].ID2, want[0], want[1])
+		}
+		if got[i].Sep > arcsec {
+			t.Fatalf("match %d: separation too large (%v)", i, got[i].Sep)
+		}
+	}
+
+	_, err = CrossMatch(a, b, "RA", "DEC", -1)
+	if err == nil {
+		t.Fatalf("expected an error for an invalid radius")
+	}
+}
+
+func sameFloat(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return a == b
+}
@@ -0,0 +1,250 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (htype HDUType) MarshalText() ([]byte, error) {
+	switch htype {
+	case IMAGE_HDU, ASCII_TBL, BINARY_TBL, ANY_HDU, UNKNOWN_HDU:
+		return []byte(htype.String()), nil
+	}
+	return nil, fmt.Errorf("fitsio: invalid HDU Type value (%v)", int(htype))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It accepts the names accepted by ParseHDUType.
+func (htype *HDUType) UnmarshalText(p []byte) error {
+	v, err := ParseHDUType(string(p))
+	if err != nil {
+		return err
+	}
+	*htype = v
+	return nil
+}
+
+type jsonCard struct {
+	Name    string          `json:"name"`
+	Value   json.RawMessage `json:"value,omitempty"`
+	Comment string          `json:"comment,omitempty"`
+}
+
+type jsonComplex struct {
+	Real float64 `json:"real"`
+	Imag float64 `json:"imag"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// Floating point values are always written with a decimal point or an
+// exponent so they can be told apart from integer values when decoded.
+func (card Card) MarshalJSON() ([]byte, error) {
+	var (
+		err error
+		raw []byte
+	)
+	switch v := card.Value.(type) {
+	case nil:
+		// no value
+	case int:
+		raw = []byte(strconv.Itoa(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("fitsio: could not marshal card [%s]: unsupported value %v", card.Name, v)
+		}
+		str := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(str, ".eE") {
+			str += ".0"
+		}
+		raw = []byte(str)
+	case big.Int:
+		raw = []byte(v.String())
+	case complex128:
+		raw, err = json.Marshal(jsonComplex{real(v), imag(v)})
+	default:
+		raw, err = json.Marshal(v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fitsio: could not marshal card [%s]: %v", card.Name, err)
+	}
+	return json.Marshal(jsonCard{
+		Name:    card.Name,
+		Value:   raw,
+		Comment: card.Comment,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (card *Card) UnmarshalJSON(p []byte) error {
+	var jc jsonCard
+	err := json.Unmarshal(p, &jc)
+	if err != nil {
+		return err
+	}
+	card.Name = jc.Name
+	card.Comment = jc.Comment
+	card.Value = nil
+
+	raw := bytes.TrimSpace(jc.Value)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	switch raw[0] {
+	case '"':
+		var v string
+		err = json.Unmarshal(raw, &v)
+		card.Value = v
+	case 't', 'f':
+		var v bool
+		err = json.Unmarshal(raw, &v)
+		card.Value = v
+	case '{':
+		var v jsonComplex
+		err = json.Unmarshal(raw, &v)
+		card.Value = complex(v.Real, v.Imag)
+	default:
+		str := string(raw)
+		if strings.ContainsAny(str, ".eE") {
+			var v float64
+			v, err = strconv.ParseFloat(str, 64)
+			card.Value = v
+			break
+		}
+		var v int64
+		v, err = strconv.ParseInt(str, 10, 64)
+		if err == nil {
+			if v >= math.MinInt && v <= math.MaxInt {
+				card.Value = int(v)
+			} else {
+				card.Value = v
+			}
+			break
+		}
+		var x big.Int
+		if _, ok := x.SetString(str, 10); !ok {
+			break
+		}
+		err = nil
+		card.Value = x
+	}
+	if err != nil {
+		return fmt.Errorf("fitsio: could not unmarshal card [%s]: %v", card.Name, err)
+	}
+	return nil
+}
+
+type jsonHeader struct {
+	Type   HDUType `json:"type"`
+	Bitpix int     `json:"bitpix"`
+	Axes   []int   `json:"axes"`
+	Cards  []Card  `json:"cards"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (hdr *Header) MarshalJSON() ([]byte, error) {
+	axes := hdr.axes
+	if axes == nil {
+		axes = []int{}
+	}
+	cards := hdr.cards
+	if cards == nil {
+		cards = []Card{}
+	}
+	return json.Marshal(jsonHeader{
+		Type:   hdr.htype,
+		Bitpix: hdr.bitpix,
+		Axes:   axes,
+		Cards:  cards,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (hdr *Header) UnmarshalJSON(p []byte) error {
+	var jh jsonHeader
+	err := json.Unmarshal(p, &jh)
+	if err != nil {
+		return err
+	}
+	hdr.htype = jh.Type
+	hdr.bitpix = jh.Bitpix
+	hdr.axes = jh.Axes
+	if hdr.axes == nil {
+		hdr.axes = []int{}
+	}
+	hdr.cards = make([]Card, 0, len(jh.Cards))
+	return hdr.Append(jh.Cards...)
+}
+
+// Summary describes the layout of a FITS file.
+type Summary struct {
+	Name string       `json:"name,omitempty"`
+	HDUs []HDUSummary `json:"hdus"`
+}
+
+// HDUSummary describes a Header-Data Unit.
+type HDUSummary struct {
+	Index   int             `json:"index"`
+	Type    HDUType         `json:"type"`
+	Name    string          `json:"name,omitempty"`
+	Version int             `json:"version"`
+	Bitpix  int             `json:"bitpix"`
+	Axes    []int           `json:"axes"`
+	NumRows int64           `json:"nrows,omitempty"`
+	Columns []ColumnSummary `json:"columns,omitempty"`
+}
+
+// ColumnSummary describes a table column.
+type ColumnSummary struct {
+	Name   string  `json:"name"`
+	Format string  `json:"format"`
+	Unit   string  `json:"unit,omitempty"`
+	Dim    []int64 `json:"dim,omitempty"`
+}
+
+// Summary returns a description of the HDUs held by this file.
+func (f *File) Summary() Summary {
+	hdus := f.HDUs()
+	sum := Summary{
+		Name: f.name,
+		HDUs: make([]HDUSummary, len(hdus)),
+	}
+	for i, hdu := range hdus {
+		hdr := hdu.Header()
+		axes := make([]int, len(hdr.Axes()))
+		copy(axes, hdr.Axes())
+		sum.HDUs[i] = HDUSummary{
+			Index:   i,
+			Type:    hdu.Type(),
+			Name:    hdu.Name(),
+			Version: hdu.Version(),
+			Bitpix:  hdr.Bitpix(),
+			Axes:    axes,
+		}
+		tbl, ok := hdu.(*Table)
+		if !ok {
+			continue
+		}
+		sum.HDUs[i].NumRows = tbl.NumRows()
+		sum.HDUs[i].Columns = make([]ColumnSummary, len(tbl.cols))
+		for j, col := range tbl.cols {
+			sum.HDUs[i].Columns[j] = ColumnSummary{
+				Name:   col.Name,
+				Format: col.Format,
+				Unit:   col.Unit,
+				Dim:    col.Dim,
+			}
+		}
+	}
+	return sum
+}
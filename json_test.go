@@ -0,0 +1,126 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestHeaderJSON(t *testing.T) {
+	for _, fname := range []string{
+		"testdata/file001.fits",
+		"testdata/swp06542llg.fits",
+		"testdata/issue-38.fits",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			r, err := os.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			defer r.Close()
+
+			f, err := Open(r)
+			if err != nil {
+				t.Fatalf("could not open FITS file: %v", err)
+			}
+			defer f.Close()
+
+			for i, hdu := range f.HDUs() {
+				raw, err := json.Marshal(hdu.Header())
+				if err != nil {
+					t.Fatalf("hdu[%d]: could not marshal header: %v", i, err)
+				}
+				var hdr Header
+				err = json.Unmarshal(raw, &hdr)
+				if err != nil {
+					t.Fatalf("hdu[%d]: could not unmarshal header: %v", i, err)
+				}
+				if !reflect.DeepEqual(&hdr, hdu.Header()) {
+					t.Fatalf("hdu[%d]: header round-trip failed.\ngot= %#v\nwant=%#v", i, hdr, *hdu.Header())
+				}
+			}
+		})
+	}
+}
+
+func TestCardJSON(t *testing.T) {
+	for _, card := range []Card{
+		{Name: "NONE", Comment: "no value"},
+		{Name: "BOOL", Value: true},
+		{Name: "INT", Value: -42},
+		{Name: "FLOAT", Value: 2.0},
+		{Name: "FLOAT2", Value: 1e-12},
+		{Name: "STRING", Value: "hello \"world\""},
+		{Name: "CPLX", Value: complex(1.5, -2)},
+		{Name: "BIG", Value: newBigInt(t)},
+		{Name: "COMMENT", Comment: "a comment"},
+	} {
+		t.Run(card.Name, func(t *testing.T) {
+			raw, err := json.Marshal(card)
+			if err != nil {
+				t.Fatalf("could not marshal card: %v", err)
+			}
+			var got Card
+			err = json.Unmarshal(raw, &got)
+			if err != nil {
+				t.Fatalf("could not unmarshal card: %v", err)
+			}
+			if !reflect.DeepEqual(got, card) {
+				t.Fatalf("round-trip failed.\ngot= %#v\nwant=%#v\njson=%s", got, card, raw)
+			}
+		})
+	}
+
+	_, err := json.Marshal(Card{Name: "NAN", Value: math.NaN()})
+	if err == nil {
+		t.Fatalf("expected an error marshaling NaN")
+	}
+}
+
+func TestFileSummary(t *testing.T) {
+	r, err := os.Open("testdata/file001.fits")
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer r.Close()
+
+	f, err := Open(r)
+	if err != nil {
+		t.Fatalf("could not open FITS file: %v", err)
+	}
+	defer f.Close()
+
+	raw, err := json.Marshal(f.Summary())
+	if err != nil {
+		t.Fatalf("could not marshal summary: %v", err)
+	}
+
+	var sum Summary
+	err = json.Unmarshal(raw, &sum)
+	if err != nil {
+		t.Fatalf("could not unmarshal summary: %v", err)
+	}
+
+	if got, want := len(sum.HDUs), 2; got != want {
+		t.Fatalf("invalid number of HDUs. got=%d, want=%d", got, want)
+	}
+	tbl := sum.HDUs[1]
+	if got, want := tbl.Type, ASCII_TBL; got != want {
+		t.Fatalf("invalid HDU type. got=%v, want=%v", got, want)
+	}
+	if got, want := tbl.NumRows, int64(10); got != want {
+		t.Fatalf("invalid number of rows. got=%d, want=%d", got, want)
+	}
+	if got, want := len(tbl.Columns), 7; got != want {
+		t.Fatalf("invalid number of columns. got=%d, want=%d", got, want)
+	}
+	if got, want := tbl.Columns[0].Name, "IDEN."; got != want {
+		t.Fatalf("invalid column name. got=%q, want=%q", got, want)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"math"
+)
+
+// lightCurveKeys are the keywords of an events table carried over to its
+// light curve.
+var lightCurveKeys = []string{
+	"TELESCOP", "INSTRUME", "DETNAM", "FILTER", "OBJECT", "OBS_ID",
+	"DATE-OBS", "DATE-END", "TIMESYS", "TIMEREF", "TIMEUNIT", "TASSIGN",
+	"MJDREF", "MJDREFI", "MJDREFF", "TIMEZERO", "CLOCKCOR",
+	"RADECSYS", "EQUINOX", "RA_OBJ", "DEC_OBJ",
+}
+
+// LightCurve bins the events of the table t into a light curve, with bins
+// of binWidth, in the units of the timeCol column (TIME if empty).
+//
+// The light curve covers [TSTART, TSTOP), as given by the keywords of t,
+// or the range of the event times if they are missing.
+// Events with a NaN time, or outside of that range, are ignored, except
+// for events at TSTOP which fall in the last bin.
+//
+// The returned RATE binary table follows the OGIP conventions, with the
+// TIME (center of the bin), COUNTS, RATE and ERROR (Poisson error of the
+// rate) columns, and the TSTART, TSTOP, TIMEDEL and TIMEPIXR keywords.
+// The timing and observation keywords of t (such as MJDREF, TIMESYS or
+// TELESCOP) are carried over.
+func LightCurve(t *Table, timeCol string, binWidth float64) (*Table, error) {
+	if t == nil {
+		return nil, fmt.Errorf("fitsio: nil table")
+	}
+	if !(binWidth > 0) || math.IsInf(binWidth, 0) {
+		return nil, fmt.Errorf("fitsio: invalid bin width (%v)", binWidth)
+	}
+
+	if timeCol == "" {
+		timeCol = "TIME"
+	}
+	itime, err := floatColumnIndex(t, timeCol)
+	if err != nil {
+		return nil, err
+	}
+	times, err := readFloatColumn(t, itime)
+	if err != nil {
+		return nil, err
+	}
+
+	start, stop := math.Inf(+1), math.Inf(-1)
+	for _, v := range times {
+		if math.IsNaN(v) {
+			continue
+		}
+		start = math.Min(start, v)
+		stop = math.Max(stop, v)
+	}
+	if card := t.hdr.Get("TSTART"); card != nil {
+		start, err = cardFloat(card)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: invalid TSTART: %w", err)
+		}
+	}
+	if card := t.hdr.Get("TSTOP"); card != nil {
+		stop, err = cardFloat(card)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: invalid TSTOP: %w", err)
+		}
+	}
+	switch {
+	case math.IsInf(start, 0) || math.IsInf(stop, 0):
+		return nil, fmt.Errorf("fitsio: no event to bin")
+	case stop < start:
+		return nil, fmt.Errorf("fitsio: invalid time range [%v, %v)", start, stop)
+	}
+
+	nbins := int(math.Ceil((stop - start) / binWidth))
+	if nbins < 1 {
+		nbins = 1
+	}
+	if nbins > math.MaxInt32 {
+		return nil, fmt.Errorf("fitsio: too many bins (%d)", nbins)
+	}
+	counts := make([]int32, nbins)
+	for _, v := range times {
+		if math.IsNaN(v) || v < start || v > stop {
+			continue
+		}
+		i := int((v - start) / binWidth)
+		if i >= nbins {
+			if v != stop {
+				continue
+			}
+			i = nbins - 1
+		}
+		counts[i]++
+	}
+
+	unit := t.cols[itime].Unit
+	if unit == "" {
+		unit = "s"
+	}
+	rate := "count/" + unit
+	lc, err := NewTable("RATE", []Column{
+		{Name: "TIME", Format: "D", Unit: unit},
+		{Name: "COUNTS", Format: "J", Unit: "count"},
+		{Name: "RATE", Format: "E", Unit: rate},
+		{Name: "ERROR", Format: "E", Unit: rate},
+	}, BINARY_TBL)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := []Card{
+		{Name: "HDUCLASS", Value: "OGIP", Comment: "format conforms to OGIP standard"},
+		{Name: "HDUCLAS1", Value: "LIGHTCURVE", Comment: "extension contains a light curve"},
+		{Name: "HDUCLAS2", Value: "TOTAL", Comment: "light curve of the total counts"},
+		{Name: "HDUCLAS3", Value: "RATE", Comment: "light curve in count rates"},
+		{Name: "TSTART", Value: start, Comment: "start time of the light curve"},
+		{Name: "TSTOP", Value: start + float64(nbins)*binWidth, Comment: "stop time of the light curve"},
+		{Name: "TIMEDEL", Value: binWidth, Comment: "width of the time bins"},
+		{Name: "TIMEPIXR", Value: 0.5, Comment: "TIME is the center of the bins"},
+	}
+	for _, key := range lightCurveKeys {
+		if card := t.hdr.Get(key); card != nil {
+			cards = append(cards, *card)
+		}
+	}
+	err = lc.hdr.Append(cards...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, n := range counts {
+		var (
+			tbin = start + (float64(i)+0.5)*binWidth
+			r    = float32(float64(n) / binWidth)
+			e    = float32(math.Sqrt(float64(n)) / binWidth)
+		)
+		err = lc.Write(&tbin, &n, &r, &e)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lc, nil
+}
@@ -0,0 +1,133 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLightCurve(t *testing.T) {
+	type Event struct {
+		Time float64 `fits:"TIME"`
+		PI   int16   `fits:"PI"`
+	}
+	type Bin struct {
+		Time   float64 `fits:"TIME"`
+		Counts int32   `fits:"COUNTS"`
+		Rate   float32 `fits:"RATE"`
+		Error  float32 `fits:"ERROR"`
+	}
+
+	events, err := NewTableFrom("EVENTS", Event{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer events.Close()
+	err = events.Header().Append(
+		Card{Name: "TSTART", Value: 10.0},
+		Card{Name: "TSTOP", Value: 18.0},
+		Card{Name: "MJDREF", Value: 51544.0},
+		Card{Name: "TELESCOP", Value: "XMM"},
+	)
+	if err != nil {
+		t.Fatalf("could not append cards: %v", err)
+	}
+	for _, tt := range []float64{9, 10, 10.5, 11, 12, 13.9, 14, 14, 14, 14, 18, 19, math.NaN()} {
+		err = events.Write(&Event{Time: tt})
+		if err != nil {
+			t.Fatalf("could not write event: %v", err)
+		}
+	}
+
+	lc, err := LightCurve(events, "", 2)
+	if err != nil {
+		t.Fatalf("could not create light curve: %v", err)
+	}
+	defer lc.Close()
+
+	if got, want := lc.Name(), "RATE"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	for _, tc := range []struct {
+		key  string
+		want Value
+	}{
+		{"TSTART", 10.0},
+		{"TSTOP", 18.0},
+		{"TIMEDEL", 2.0},
+		{"TIMEPIXR", 0.5},
+		{"HDUCLAS1", "LIGHTCURVE"},
+		{"MJDREF", 51544.0},
+		{"TELESCOP", "XMM"},
+	} {
+		card := lc.Header().Get(tc.key)
+		if card == nil {
+			t.Fatalf("missing card %q", tc.key)
+		}
+		if card.Value != tc.want {
+			t.Fatalf("invalid %s: got=%v, want=%v", tc.key, card.Value, tc.want)
+		}
+	}
+	if got, want := lc.Col(2).Unit, "count/s"; got != want {
+		t.Fatalf("invalid rate unit: got=%q, want=%q", got, want)
+	}
+
+	var got []Bin
+	rows, err := lc.Read(0, lc.NumRows())
+	if err != nil {
+		t.Fatalf("could not read light curve: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bin Bin
+		err = rows.Scan(&bin)
+		if err != nil {
+			t.Fatalf("could not scan bin: %v", err)
+		}
+		got = append(got, bin)
+	}
+	want := []Bin{
+		{11, 3, 1.5, float32(math.Sqrt(3) / 2)},
+		{13, 2, 1, float32(math.Sqrt(2) / 2)},
+		{15, 4, 2, 1},
+		{17, 1, 0.5, 0.5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid light curve:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestLightCurveErrors(t *testing.T) {
+	type Event struct {
+		Time float64 `fits:"TIME"`
+		Name string  `fits:"NAME"`
+	}
+	events, err := NewTableFrom("EVENTS", Event{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer events.Close()
+
+	for _, tc := range []struct {
+		name  string
+		col   string
+		width float64
+	}{
+		{"width", "TIME", 0},
+		{"nan-width", "TIME", math.NaN()},
+		{"column", "TSTART", 1},
+		{"string", "NAME", 1},
+		{"empty", "TIME", 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LightCurve(events, tc.col, tc.width)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// MaskFlag is a set of data quality bit flags of a pixel.
+type MaskFlag uint16
+
+// Data quality flags commonly found in mask (or DQ) extensions.
+const (
+	BadPixel       MaskFlag = 1 << iota // pixel should not be used
+	SaturatedPixel                      // saturated pixel
+	CosmicRayPixel                      // pixel hit by a cosmic ray
+	HotPixel                            // hot pixel
+	DeadPixel                           // dead (or low QE) pixel
+	NonLinearPixel                      // pixel outside of the linear regime
+	EdgePixel                           // pixel on the edge of the detector
+	UserPixel                           // first flag available for user-defined conditions
+)
+
+// AllFlags selects all the data quality flags.
+const AllFlags MaskFlag = math.MaxUint16
+
+// Mask is a data quality image: each pixel holds the set of data quality
+// flags of the corresponding pixel of a science image.
+type Mask struct {
+	Axes  []int      // axes of the mask
+	Flags []MaskFlag // flags of the pixels, in storage order
+}
+
+// NewMask creates a new mask with the provided axes, with no flag set.
+func NewMask(axes []int) *Mask {
+	return &Mask{
+		Axes:  slices.Clone(axes),
+		Flags: make([]MaskFlag, npixels(axes)),
+	}
+}
+
+// MaskFrom creates a mask from the pixels of a data quality image, which
+// must hold 8-bit, or (signed or unsigned, with BZERO=32768) 16-bit integers.
+// Blank pixels are flagged as BadPixel.
+func MaskFrom(img Image) (*Mask, error) {
+	if img == nil {
+		return nil, fmt.Errorf("fitsio: nil image")
+	}
+	pix, err := newPixels(img.Header(), img.Raw())
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case pix.bitpix != 8 && pix.bitpix != 16:
+		return nil, fmt.Errorf("fitsio: invalid mask BITPIX (%d)", pix.bitpix)
+	case pix.bscale != 1 || (pix.bzero != 0 && !(pix.bitpix == 16 && pix.bzero == 32768)):
+		return nil, fmt.Errorf("fitsio: invalid mask scaling (BSCALE=%v, BZERO=%v)", pix.bscale, pix.bzero)
+	}
+
+	mask := NewMask(img.Header().Axes())
+	for i := range mask.Flags {
+		v, ok := pix.at(i)
+		if !ok {
+			mask.Flags[i] = BadPixel
+			continue
+		}
+		// signed 16-bit flags are reinterpreted as unsigned bit sets.
+		mask.Flags[i] = MaskFlag(uint16(int64(v)))
+	}
+	return mask, nil
+}
+
+// Image returns a new image holding the flags of the mask, as 8-bit
+// integers when all the flags fit, and as unsigned 16-bit integers
+// (with BZERO=32768) otherwise.
+func (m *Mask) Image() (Image, error) {
+	if slices.ContainsFunc(m.Flags, func(f MaskFlag) bool { return f > math.MaxUint8 }) {
+		img := NewImage(16, m.Axes)
+		err := img.Header().Append(
+			Card{Name: "BSCALE", Value: 1.0},
+			Card{Name: "BZERO", Value: 32768.0, Comment: "data are unsigned 16-bit integers"},
+		)
+		if err != nil {
+			return nil, err
+		}
+		vs := make([]uint16, len(m.Flags))
+		for i, f := range m.Flags {
+			vs[i] = uint16(f)
+		}
+		err = img.WriteConverted(vs)
+		if err != nil {
+			return nil, err
+		}
+		return img, nil
+	}
+
+	img := NewImage(8, m.Axes)
+	vs := make([]uint8, len(m.Flags))
+	for i, f := range m.Flags {
+		vs[i] = uint8(f)
+	}
+	err := img.Write(vs)
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// Count returns the number of pixels with any of the provided flags set.
+func (m *Mask) Count(flags MaskFlag) int {
+	n := 0
+	for _, f := range m.Flags {
+		if f&flags != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Rebin returns a new mask matching the image rebinned by RebinImage with
+// the same factors: the flags of a block of pixels are combined.
+func (m *Mask) Rebin(factors []int) (*Mask, error) {
+	if len(factors) > len(m.Axes) {
+		return nil, fmt.Errorf(
+			"fitsio: too many rebinning factors (got=%d, want<=%d)",
+			len(factors), len(m.Axes),
+		)
+	}
+	var (
+		fs    = make([]int, len(m.Axes))
+		naxes = make([]int, len(m.Axes))
+	)
+	for i, dim := range m.Axes {
+		fs[i] = 1
+		if i < len(factors) {
+			fs[i] = factors[i]
+		}
+		if fs[i] < 1 {
+			return nil, fmt.Errorf("fitsio: invalid rebinning factor %d for axis %d", fs[i], i+1)
+		}
+		naxes[i] = dim / fs[i]
+	}
+
+	out := NewMask(naxes)
+	if len(out.Flags) == 0 {
+		return out, nil
+	}
+	coord := make([]int, len(m.Axes))
+	for _, f := range m.Flags {
+		j, stride, ok := 0, 1, true
+		for k, c := range coord {
+			c /= fs[k]
+			if c >= naxes[k] {
+				ok = false
+			}
+			j += c * stride
+			stride *= naxes[k]
+		}
+		if ok {
+			out.Flags[j] |= f
+		}
+		nextCoord(coord, m.Axes)
+	}
+	return out, nil
+}
+
+// CombineMasks returns a new mask whose flags are the union of the flags
+// of the provided masks, which must have the same axes.
+// The result is the mask of the images computed by ImageOp or StackImages
+// from the images described by masks.
+func CombineMasks(masks ...*Mask) (*Mask, error) {
+	if len(masks) == 0 {
+		return nil, fmt.Errorf("fitsio: no mask to combine")
+	}
+	out := NewMask(masks[0].Axes)
+	for i, m := range masks {
+		if !slices.Equal(m.Axes, out.Axes) || len(m.Flags) != len(out.Flags) {
+			return nil, fmt.Errorf("fitsio: mask %d axes mismatch (%v != %v)", i, m.Axes, out.Axes)
+		}
+		for j, f := range m.Flags {
+			out.Flags[j] |= f
+		}
+	}
+	return out, nil
+}
+
+// ApplyMask returns a new image holding the pixels of img, where the pixels
+// with any of the provided flags set in mask are blank (or NaN, for floating
+// point images.)
+// Masked pixels are then ignored by Stats, StackImages and RebinImage, and
+// propagated by ImageOp.
+func ApplyMask(img Image, mask *Mask, flags MaskFlag) (Image, error) {
+	if img == nil || mask == nil {
+		return nil, fmt.Errorf("fitsio: nil image or mask")
+	}
+	axes := img.Header().Axes()
+	if !slices.Equal(axes, mask.Axes) || len(mask.Flags) != npixels(axes) {
+		return nil, fmt.Errorf("fitsio: image and mask axes mismatch (%v != %v)", axes, mask.Axes)
+	}
+	pix, err := newPixels(img.Header(), img.Raw())
+	if err != nil {
+		return nil, err
+	}
+	vs := make([]float64, pix.n)
+	for i := range vs {
+		if mask.Flags[i]&flags != 0 {
+			vs[i] = math.NaN()
+			continue
+		}
+		vs[i], _ = pix.at(i)
+	}
+	return newImageOpResult(img.Header(), pix.kind(), axes, vs)
+}
@@ -0,0 +1,149 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMask(t *testing.T) {
+	mask := NewMask([]int{3, 2})
+	mask.Flags[1] = SaturatedPixel
+	mask.Flags[2] = HotPixel | CosmicRayPixel
+	mask.Flags[4] = UserPixel << 2
+
+	if got, want := mask.Count(HotPixel|SaturatedPixel), 2; got != want {
+		t.Fatalf("invalid count: got=%d, want=%d", got, want)
+	}
+
+	for _, test := range []struct {
+		name   string
+		mask   *Mask
+		bitpix int
+	}{
+		{"u16", mask, 16},
+		{"u8", &Mask{Axes: []int{2}, Flags: []MaskFlag{BadPixel, EdgePixel}}, 8},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			img, err := test.mask.Image()
+			if err != nil {
+				t.Fatalf("could not create mask image: %v", err)
+			}
+			if got, want := img.Header().Bitpix(), test.bitpix; got != want {
+				t.Fatalf("invalid BITPIX: got=%d, want=%d", got, want)
+			}
+			got, err := MaskFrom(img)
+			if err != nil {
+				t.Fatalf("could not read mask: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.mask) {
+				t.Fatalf("invalid mask round-trip:\ngot= %v\nwant=%v", got, test.mask)
+			}
+		})
+	}
+
+	t.Run("signed", func(t *testing.T) {
+		img := NewImage(16, []int{2})
+		err := img.Write([]int16{-1, 3})
+		if err != nil {
+			t.Fatalf("could not write image: %v", err)
+		}
+		got, err := MaskFrom(img)
+		if err != nil {
+			t.Fatalf("could not read mask: %v", err)
+		}
+		if want := []MaskFlag{AllFlags, BadPixel | SaturatedPixel}; !reflect.DeepEqual(got.Flags, want) {
+			t.Fatalf("invalid flags: got=%v, want=%v", got.Flags, want)
+		}
+
+		flt := NewImage(-32, []int{2})
+		err = flt.Write([]float32{1, 2})
+		if err != nil {
+			t.Fatalf("could not write image: %v", err)
+		}
+		_, err = MaskFrom(flt)
+		if err == nil {
+			t.Fatalf("expected an error for a floating point mask")
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		for _, test := range []struct {
+			bitpix int
+			data   interface{}
+			want   []float64
+		}{
+			{16, []int16{1, 2, 3, 4, 5, 6}, []float64{1, math.NaN(), math.NaN(), 4, 5, 6}},
+			{-64, []float64{1, 2, 3, 4, 5, 6}, []float64{1, math.NaN(), math.NaN(), 4, 5, 6}},
+		} {
+			img := NewImage(test.bitpix, []int{3, 2})
+			err := img.Write(test.data)
+			if err != nil {
+				t.Fatalf("could not write image: %v", err)
+			}
+			out, err := ApplyMask(img, mask, SaturatedPixel|HotPixel)
+			if err != nil {
+				t.Fatalf("could not apply mask: %v", err)
+			}
+			if got, want := out.Header().Bitpix(), test.bitpix; got != want {
+				t.Fatalf("invalid BITPIX: got=%d, want=%d", got, want)
+			}
+			got, err := out.Scaled()
+			if err != nil {
+				t.Fatalf("could not scale pixels: %v", err)
+			}
+			if !floatsEqual(got, test.want) {
+				t.Fatalf("invalid pixels: got=%v, want=%v", got, test.want)
+			}
+			stats, err := out.Stats()
+			if err != nil {
+				t.Fatalf("could not compute stats: %v", err)
+			}
+			if stats.NBlank != 2 {
+				t.Fatalf("invalid number of blank pixels: got=%d, want=2", stats.NBlank)
+			}
+		}
+
+		_, err := ApplyMask(NewImage(8, []int{2, 3}), mask, AllFlags)
+		if err == nil {
+			t.Fatalf("expected an error for mismatched axes")
+		}
+	})
+
+	t.Run("propagate", func(t *testing.T) {
+		other := NewMask([]int{3, 2})
+		other.Flags[0] = DeadPixel
+		other.Flags[1] = BadPixel
+		got, err := CombineMasks(mask, other)
+		if err != nil {
+			t.Fatalf("could not combine masks: %v", err)
+		}
+		want := []MaskFlag{
+			DeadPixel, BadPixel | SaturatedPixel, HotPixel | CosmicRayPixel,
+			0, UserPixel << 2, 0,
+		}
+		if !reflect.DeepEqual(got.Flags, want) {
+			t.Fatalf("invalid combined flags: got=%v, want=%v", got.Flags, want)
+		}
+
+		_, err = CombineMasks(mask, NewMask([]int{2, 3}))
+		if err == nil {
+			t.Fatalf("expected an error for mismatched axes")
+		}
+
+		rebin, err := got.Rebin([]int{2, 2})
+		if err != nil {
+			t.Fatalf("could not rebin mask: %v", err)
+		}
+		if got, want := rebin.Axes, []int{1, 1}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid rebinned axes: got=%v, want=%v", got, want)
+		}
+		if got, want := rebin.Flags, []MaskFlag{DeadPixel | BadPixel | SaturatedPixel | UserPixel<<2}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid rebinned flags: got=%v, want=%v", got, want)
+		}
+	})
+}
@@ -0,0 +1,257 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MergeOptions configures how tables are merged by MergeTables.
+type MergeOptions struct {
+	// Strict requires all the source tables to hold exactly the columns
+	// of the destination table.
+	// Otherwise, destination columns missing from a source table are filled
+	// with null values and source columns missing from the destination
+	// table are ignored.
+	Strict bool
+}
+
+// MergeTables appends the rows of all the srcs tables to dst.
+//
+// Columns are matched by name.
+// The format of each source column must be compatible with the format of the
+// matching destination column: values are converted to the destination type
+// when possible (e.g. from 'J' to 'K' or from 'E' to 'D'), and an error is
+// returned otherwise.
+//
+// Columns missing from a source table are filled with the TNULL value of the
+// destination column, NaN for floating point columns or the zero value.
+func MergeTables(dst *Table, srcs []*Table, opts MergeOptions) error {
+	if dst == nil {
+		return fmt.Errorf("fitsio: dst pointer is nil")
+	}
+
+	for i, src := range srcs {
+		if src == nil {
+			return fmt.Errorf("fitsio: src pointer #%d is nil", i)
+		}
+		err := mergeTable(dst, src, opts)
+		if err != nil {
+			return fmt.Errorf("fitsio: could not merge table #%d (%s): %v", i, src.Name(), err)
+		}
+	}
+	return nil
+}
+
+func mergeTable(dst, src *Table, opts MergeOptions) error {
+	// icols[i] is the index of the source column matching
+	// the i-th destination column. (-1 if none)
+	icols := make([]int, len(dst.cols))
+	for i := range dst.cols {
+		dcol := &dst.cols[i]
+		icols[i] = src.Index(dcol.Name)
+		if icols[i] < 0 {
+			if opts.Strict {
+				return fmt.Errorf("missing column %q", dcol.Name)
+			}
+			continue
+		}
+		scol := &src.cols[icols[i]]
+		if !convertibleType(dcol.Type(), scol.Type()) {
+			return fmt.Errorf(
+				"incompatible formats for column %q (dst=%q, src=%q)",
+				dcol.Name, dcol.Format, scol.Format,
+			)
+		}
+	}
+
+	if opts.Strict {
+		for i := range src.cols {
+			if dst.Index(src.cols[i].Name) < 0 {
+				return fmt.Errorf("extra column %q", src.cols[i].Name)
+			}
+		}
+	}
+
+	if sameLayout(dst, src) {
+		return CopyTable(dst, src)
+	}
+
+	nulls := make([]reflect.Value, len(dst.cols))
+	for i := range dst.cols {
+		if icols[i] >= 0 {
+			continue
+		}
+		null, err := nullValue(&dst.cols[i])
+		if err != nil {
+			return err
+		}
+		nulls[i] = null
+	}
+
+	rows, err := src.Read(0, src.NumRows())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	sdata := make([]interface{}, len(src.cols))
+	for i := range src.cols {
+		sdata[i] = reflect.New(src.cols[i].Type()).Interface()
+	}
+	ddata := make([]interface{}, len(dst.cols))
+	for i := range dst.cols {
+		ddata[i] = reflect.New(dst.cols[i].Type()).Interface()
+	}
+
+	for rows.Next() {
+		err = rows.Scan(sdata...)
+		if err != nil {
+			return err
+		}
+		for i, icol := range icols {
+			rv := reflect.ValueOf(ddata[i]).Elem()
+			if icol < 0 {
+				rv.Set(nulls[i])
+				continue
+			}
+			convertValue(rv, reflect.ValueOf(sdata[icol]).Elem())
+		}
+		err = dst.Write(ddata...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// sameLayout returns whether the rows of src can be copied verbatim into dst.
+func sameLayout(dst, src *Table) bool {
+	if dst.binary != src.binary || dst.rowsz != src.rowsz || len(dst.cols) != len(src.cols) {
+		return false
+	}
+	for i := range dst.cols {
+		dcol := &dst.cols[i]
+		scol := &src.cols[i]
+		if dcol.Name != scol.Name || dcol.dtype != scol.dtype || dcol.offset != scol.offset {
+			return false
+		}
+	}
+	return true
+}
+
+// convertibleType returns whether values of type src can be
+// converted to values of type dst without loss of meaning.
+func convertibleType(dst, src reflect.Type) bool {
+	if dst == src {
+		return true
+	}
+
+	switch dst.Kind() {
+	case reflect.Bool, reflect.String:
+		return src.Kind() == dst.Kind()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return isIntKind(src.Kind())
+	case reflect.Float32, reflect.Float64:
+		return isIntKind(src.Kind()) || isFloatKind(src.Kind())
+	case reflect.Complex64, reflect.Complex128:
+		return src.Kind() == reflect.Complex64 || src.Kind() == reflect.Complex128
+	case reflect.Array:
+		return src.Kind() == reflect.Array && src.Len() == dst.Len() &&
+			convertibleType(dst.Elem(), src.Elem())
+	case reflect.Slice:
+		return (src.Kind() == reflect.Slice || src.Kind() == reflect.Array) &&
+			convertibleType(dst.Elem(), src.Elem())
+	}
+	return false
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// convertValue sets dst to the value of src, converted to the type of dst.
+// The types of dst and src must be convertible (see convertibleType.)
+func convertValue(dst, src reflect.Value) {
+	switch dst.Kind() {
+	case reflect.Array:
+		for i := 0; i < dst.Len(); i++ {
+			convertValue(dst.Index(i), src.Index(i))
+		}
+	case reflect.Slice:
+		n := src.Len()
+		slice := reflect.MakeSlice(dst.Type(), n, n)
+		for i := 0; i < n; i++ {
+			convertValue(slice.Index(i), src.Index(i))
+		}
+		if src.Kind() == reflect.Slice && src.IsNil() {
+			slice = reflect.Zero(dst.Type())
+		}
+		dst.Set(slice)
+	default:
+		dst.Set(src.Convert(dst.Type()))
+	}
+}
+
+// nullValue returns the value used to fill the column col when
+// no data is available.
+func nullValue(col *Column) (reflect.Value, error) {
+	rt := col.Type()
+	rv := reflect.New(rt).Elem()
+
+	elem := rv
+	switch rt.Kind() {
+	case reflect.Slice:
+		return rv, nil
+	case reflect.Array:
+		if rt.Len() == 0 {
+			return rv, nil
+		}
+		elem = reflect.New(rt.Elem()).Elem()
+	}
+
+	switch {
+	case isFloatKind(elem.Kind()):
+		elem.SetFloat(math.NaN())
+	case isIntKind(elem.Kind()) && col.Null != "":
+		null := strings.TrimSpace(col.Null)
+		switch elem.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v, err := strconv.ParseUint(null, 10, elem.Type().Bits())
+			if err != nil {
+				return rv, fmt.Errorf("invalid TNULL value %q for column %q: %v", col.Null, col.Name, err)
+			}
+			elem.SetUint(v)
+		default:
+			v, err := strconv.ParseInt(null, 10, elem.Type().Bits())
+			if err != nil {
+				return rv, fmt.Errorf("invalid TNULL value %q for column %q: %v", col.Null, col.Name, err)
+			}
+			elem.SetInt(v)
+		}
+	}
+
+	if rt.Kind() == reflect.Array {
+		for i := 0; i < rv.Len(); i++ {
+			rv.Index(i).Set(elem)
+		}
+	}
+	return rv, nil
+}
@@ -0,0 +1,137 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMergeTables(t *testing.T) {
+	newTable := func(cols []Column, rows ...[]interface{}) *Table {
+		tbl, err := NewTable("merge", cols, BINARY_TBL)
+		if err != nil {
+			t.Fatalf("could not create table: %v", err)
+		}
+		for _, row := range rows {
+			err = tbl.Write(row...)
+			if err != nil {
+				t.Fatalf("could not write row: %v", err)
+			}
+		}
+		return tbl
+	}
+
+	schema := []Column{
+		{Name: "ID", Format: "K"},
+		{Name: "X", Format: "D"},
+		{Name: "FLAGS", Format: "2J", Null: "-1"},
+	}
+
+	dst := newTable(schema)
+	defer dst.Close()
+
+	same := newTable(schema, []interface{}{
+		ptr(int64(1)), ptr(1.5), &[2]int32{1, 2},
+	})
+	defer same.Close()
+
+	other := newTable(
+		[]Column{
+			{Name: "EXTRA", Format: "L"},
+			{Name: "X", Format: "E"},
+			{Name: "ID", Format: "J"},
+		},
+		[]interface{}{ptr(true), ptr(float32(2.5)), ptr(int32(2))},
+		[]interface{}{ptr(false), ptr(float32(-1)), ptr(int32(3))},
+	)
+	defer other.Close()
+
+	err := MergeTables(dst, []*Table{same, other}, MergeOptions{})
+	if err != nil {
+		t.Fatalf("could not merge tables: %v", err)
+	}
+
+	type Row struct {
+		ID    int64    `fits:"ID"`
+		X     float64  `fits:"X"`
+		Flags [2]int32 `fits:"FLAGS"`
+	}
+	var got []Row
+	rows, err := dst.Read(0, dst.NumRows())
+	if err != nil {
+		t.Fatalf("could not read rows: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row Row
+		err = rows.Scan(&row)
+		if err != nil {
+			t.Fatalf("could not scan row: %v", err)
+		}
+		got = append(got, row)
+	}
+
+	want := []Row{
+		{1, 1.5, [2]int32{1, 2}},
+		{2, 2.5, [2]int32{-1, -1}},
+		{3, -1, [2]int32{-1, -1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid merged rows.\ngot= %v\nwant=%v", got, want)
+	}
+
+	err = MergeTables(dst, []*Table{other}, MergeOptions{Strict: true})
+	if err == nil {
+		t.Fatalf("expected an error in strict mode")
+	}
+
+	bad := newTable([]Column{{Name: "X", Format: "8A"}})
+	defer bad.Close()
+	err = MergeTables(dst, []*Table{bad}, MergeOptions{})
+	if err == nil {
+		t.Fatalf("expected an error for incompatible formats")
+	}
+}
+
+func TestNullValue(t *testing.T) {
+	for _, test := range []struct {
+		col  Column
+		want interface{}
+	}{
+		{Column{Name: "I", Format: "J"}, int32(0)},
+		{Column{Name: "I", Format: "J", Null: "-99"}, int32(-99)},
+		{Column{Name: "S", Format: "8A"}, ""},
+		{Column{Name: "L", Format: "L"}, false},
+		{Column{Name: "V", Format: "PJ"}, []int32(nil)},
+	} {
+		tbl, err := NewTable("null", []Column{test.col}, BINARY_TBL)
+		if err != nil {
+			t.Fatalf("could not create table: %v", err)
+		}
+		got, err := nullValue(tbl.Col(0))
+		if err != nil {
+			t.Fatalf("could not compute null value: %v", err)
+		}
+		if !reflect.DeepEqual(got.Interface(), test.want) {
+			t.Fatalf("invalid null value for %q. got=%#v, want=%#v", test.col.Format, got.Interface(), test.want)
+		}
+	}
+
+	tbl, err := NewTable("null", []Column{{Name: "D", Format: "D"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	got, err := nullValue(tbl.Col(0))
+	if err != nil {
+		t.Fatalf("could not compute null value: %v", err)
+	}
+	if !math.IsNaN(got.Float()) {
+		t.Fatalf("expected NaN. got=%v", got.Float())
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,315 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Option configures optional behaviours of decoders, encoders, tables and
+// long-running operations.
+type Option func(cfg *config)
+
+type config struct {
+	progress   func(done, total int64)
+	maxHDUSize int64 // maximum size of the data of a decoded HDU
+	maxTotal   int64 // maximum size of the data of all the decoded HDUs
+	lazyLarge  bool  // read the data units exceeding the limits on demand
+
+	dither     bool  // dither quantized pixel values
+	ditherSeed int64 // seed of the dithering noise
+
+	compression Compression  // tile compression algorithm
+	tile        []int        // dimensions of compression tiles
+	quantize    Quantization // quantization method of floating point pixels
+	qlevel      float64      // quantization level
+	qset        bool         // whether the quantization was set
+
+	gzip      bool // compress the whole file with gzip
+	gzipLevel int  // gzip compression level
+
+	heapGap   int  // size of the gap between table data and heap
+	heapDedup bool // deduplicate variable length arrays
+	heapAlign int  // alignment of variable length arrays in the heap
+
+	schemas []HeaderSchema // schemas the encoded headers must follow
+
+	dexp bool // write the exponent of floating point card values with a 'D'
+
+	rawHeaders bool // keep and write back the original header blocks
+	rawCards   bool // keep the cards which could not be parsed
+	pooled     bool // borrow the data units of tables from a pool
+
+	canonical bool // write headers in their canonical order
+	sortKeys  bool // sort the non-mandatory keywords of canonical headers
+
+	dupKeys DuplicateKeys // handling of duplicate keywords of decoded headers
+
+	lazy    bool // read the data units of seekable inputs on demand
+	workers int  // number of goroutines decoding the data units of seekable inputs
+
+	nulls NullPolicy // reading of the undefined fields of ASCII tables
+
+	scaling *Scaling // display scaling of the Image method of decoded images
+}
+
+func newConfig(opts []Option) config {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithProgress registers a callback reporting the progress of long-running
+// operations, as the amount of work done so far out of the total amount of
+// work:
+//   - bytes of the data unit of the HDU being decoded or encoded, for
+//     Open, Create, NewDecoder and NewEncoder,
+//   - rows copied, for CopyTable and CopyTableRange.
+//
+// The callback is invoked from the goroutine performing the operation.
+func WithProgress(fn func(done, total int64)) Option {
+	return func(cfg *config) {
+		cfg.progress = fn
+	}
+}
+
+// WithMaxHDUSize limits to n bytes the size of the data unit (including the
+// heap of binary tables) of the HDUs decoded by Open and NewDecoder.
+// Decoding an HDU whose header describes a larger data unit fails with
+// ErrHDUTooLarge, before any allocation (see also WithLazyOversized.)
+// A zero or negative n means no limit.
+func WithMaxHDUSize(n int64) Option {
+	return func(cfg *config) {
+		cfg.maxHDUSize = n
+	}
+}
+
+// WithLazyData makes Open read only the headers of the HDUs of inputs
+// implementing io.ReaderAt and io.Seeker (such as *os.File or
+// *bytes.Reader), as OpenReaderAt does: the data unit of an HDU is then
+// read when the HDU is first retrieved.
+// Compressed inputs and other readers are decoded eagerly.
+func WithLazyData() Option {
+	return func(cfg *config) {
+		cfg.lazy = true
+	}
+}
+
+// WithConcurrency makes Open decode the data units of the HDUs of inputs
+// implementing io.ReaderAt and io.Seeker with n goroutines, once their
+// headers were read.
+// Compressed inputs and other readers are decoded sequentially, as are
+// all inputs when n is lower than 2.
+// The callback registered with WithProgress may then be invoked
+// concurrently.
+func WithConcurrency(n int) Option {
+	return func(cfg *config) {
+		cfg.workers = n
+	}
+}
+
+// WithMaxTotalSize limits to n bytes the total size of the data units
+// (including the heaps of binary tables) of the HDUs decoded by Open and
+// NewDecoder.
+// Decoding an HDU whose data unit would exceed the limit fails with
+// ErrHDUTooLarge, before any allocation.
+// A zero or negative n means no limit.
+func WithMaxTotalSize(n int64) Option {
+	return func(cfg *config) {
+		cfg.maxTotal = n
+	}
+}
+
+// WithLazyOversized makes Open skip, instead of failing on, the data units
+// exceeding the limits set by WithMaxHDUSize and WithMaxTotalSize, for
+// inputs implementing io.ReaderAt and io.Seeker: the data unit of such an
+// HDU is read, regardless of the limits, when the HDU is first retrieved
+// (as with WithLazyData.)
+// Header gives access to the header of these HDUs without reading their
+// data.
+// Other inputs still fail with ErrHDUTooLarge.
+func WithLazyOversized() Option {
+	return func(cfg *config) {
+		cfg.lazyLarge = true
+	}
+}
+
+// WithRawHeaders makes Open and NewDecoder keep the original header blocks
+// of the decoded HDUs, as returned by Header.Raw.
+// It also makes Create and NewEncoder write back these blocks verbatim for
+// the HDUs whose header was not modified since it was decoded, so that
+// unmodified HDUs are copied byte for byte.
+func WithRawHeaders() Option {
+	return func(cfg *config) {
+		cfg.rawHeaders = true
+	}
+}
+
+// WithRawCards makes Open and NewDecoder keep the header cards which could
+// not be parsed (because of invalid values or vendor specific encodings),
+// instead of failing.
+// These cards hold a RawCard value, and are written back verbatim by
+// Create and NewEncoder.
+func WithRawCards() Option {
+	return func(cfg *config) {
+		cfg.rawCards = true
+	}
+}
+
+// DuplicateKeys specifies how decoders handle the header keywords which
+// appear more than once in a header.
+// COMMENT, HISTORY and blank keywords are never considered as duplicates.
+type DuplicateKeys int
+
+const (
+	KeepAllKeys         DuplicateKeys = iota // keep all the cards: Header.Get returns the first one, Header.GetAll all of them
+	KeepFirstKey                             // keep the first card of each keyword
+	KeepLastKey                              // keep the last card of each keyword, at the position of the first one
+	RejectDuplicateKeys                      // fail with ErrDuplicateKey
+)
+
+func (dk DuplicateKeys) String() string {
+	switch dk {
+	case KeepAllKeys:
+		return "KeepAllKeys"
+	case KeepFirstKey:
+		return "KeepFirstKey"
+	case KeepLastKey:
+		return "KeepLastKey"
+	case RejectDuplicateKeys:
+		return "RejectDuplicateKeys"
+	}
+	return fmt.Sprintf("DuplicateKeys(%d)", int(dk))
+}
+
+// WithDuplicateKeys sets how Open and NewDecoder handle duplicate header
+// keywords. The default is KeepAllKeys.
+func WithDuplicateKeys(policy DuplicateKeys) Option {
+	return func(cfg *config) {
+		cfg.dupKeys = policy
+	}
+}
+
+// NullPolicy specifies how the undefined fields of the numeric columns of
+// ASCII tables (blank fields, or fields equal to the TNULLn value of their
+// column) are read into integer and floating-point values.
+// Undefined fields are always read as nil into pointers (such as a
+// **float64), and as their text into strings.
+type NullPolicy int
+
+const (
+	NullAsNaN   NullPolicy = iota // read NaN into floating-point values, and 0 into integers
+	NullAsZero                    // read 0 into floating-point and integer values
+	NullAsError                   // fail with ErrNull
+)
+
+func (p NullPolicy) String() string {
+	switch p {
+	case NullAsNaN:
+		return "NullAsNaN"
+	case NullAsZero:
+		return "NullAsZero"
+	case NullAsError:
+		return "NullAsError"
+	}
+	return fmt.Sprintf("NullPolicy(%d)", int(p))
+}
+
+// WithNullPolicy sets how the undefined fields of the ASCII tables decoded
+// by Open and NewDecoder, or created by NewTable, are read.
+// The default is NullAsNaN.
+func WithNullPolicy(policy NullPolicy) Option {
+	return func(cfg *config) {
+		cfg.nulls = policy
+	}
+}
+
+// WithScaling makes the Image method of the images decoded by Open and
+// NewDecoder render their pixels with the display scaling s, as
+// ImageWithScaling does, instead of returning the stored pixel values.
+func WithScaling(s Scaling) Option {
+	return func(cfg *config) {
+		cfg.scaling = &s
+	}
+}
+
+// WithDExponent makes Create and NewEncoder write the exponent of floating
+// point card values with a 'D' (as in 1.0D-12), as used for double
+// precision values, instead of an 'E'.
+func WithDExponent() Option {
+	return func(cfg *config) {
+		cfg.dexp = true
+	}
+}
+
+// progressChunk is the maximum number of bytes transferred between
+// two calls to a progress callback, or two checks for cancellation.
+const progressChunk = 1 << 20
+
+// readFull reads len(buf) bytes from r into buf, reporting progress with fn
+// (if any) and aborting when ctx is done.
+// done is the number of bytes of the current operation already transferred
+// and total the total number of bytes of the operation.
+func readFull(ctx context.Context, r io.Reader, buf []byte, done, total int64, fn func(done, total int64)) (int, error) {
+	if fn == nil && ctx.Done() == nil {
+		return io.ReadFull(r, buf)
+	}
+	n := 0
+	for n < len(buf) {
+		if err := ctx.Err(); err != nil {
+			return n, err
+		}
+		end := n + progressChunk
+		if end > len(buf) {
+			end = len(buf)
+		}
+		nn, err := io.ReadFull(r, buf[n:end])
+		n += nn
+		if err != nil {
+			return n, err
+		}
+		if fn != nil {
+			fn(done+int64(n), total)
+		}
+	}
+	return n, nil
+}
+
+// writeFull writes buf to w, reporting progress with fn (if any) and
+// aborting when ctx is done.
+// done is the number of bytes of the current operation already transferred
+// and total the total number of bytes of the operation.
+func writeFull(ctx context.Context, w io.Writer, buf []byte, done, total int64, fn func(done, total int64)) (int, error) {
+	if fn == nil && ctx.Done() == nil {
+		return w.Write(buf)
+	}
+	n := 0
+	for n < len(buf) {
+		if err := ctx.Err(); err != nil {
+			return n, err
+		}
+		end := n + progressChunk
+		if end > len(buf) {
+			end = len(buf)
+		}
+		chunk := buf[n:end]
+		nn, err := w.Write(chunk)
+		n += nn
+		if err != nil {
+			return n, err
+		}
+		if nn != len(chunk) {
+			return n, io.ErrShortWrite
+		}
+		if fn != nil {
+			fn(done+int64(n), total)
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,369 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// progressRecorder records the calls to a progress callback.
+type progressRecorder struct {
+	calls [][2]int64
+}
+
+func (p *progressRecorder) progress(done, total int64) {
+	p.calls = append(p.calls, [2]int64{done, total})
+}
+
+// check verifies that the recorded calls describe n operations
+// with monotonic progress, each completed.
+func (p *progressRecorder) check(t *testing.T, n int) {
+	t.Helper()
+	ops := 0
+	for i, call := range p.calls {
+		done, total := call[0], call[1]
+		if done <= 0 || done > total {
+			t.Fatalf("call #%d: invalid progress %d/%d", i, done, total)
+		}
+		if i > 0 && p.calls[i-1][0] != p.calls[i-1][1] && done <= p.calls[i-1][0] {
+			t.Fatalf("call #%d: non-monotonic progress %v -> %v", i, p.calls[i-1], call)
+		}
+		if done == total {
+			ops++
+		}
+	}
+	if ops != n {
+		t.Fatalf("invalid number of completed operations: got=%d, want=%d (calls=%v)", ops, n, p.calls)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	raw, err := os.ReadFile("testdata/swp06542llg.fits")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	var rprog progressRecorder
+	f, err := Open(bytes.NewReader(raw), WithProgress(rprog.progress))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	// the primary HDU has no data.
+	rprog.check(t, len(f.HDUs())-1)
+
+	var (
+		wprog progressRecorder
+		buf   bytes.Buffer
+	)
+	out, err := Create(&buf, WithProgress(wprog.progress))
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for _, hdu := range f.HDUs() {
+		err = out.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	err = out.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+	wprog.check(t, len(f.HDUs())-1)
+
+	src := f.HDU(1).(*Table)
+	dst, err := NewTable("copy", src.Cols(), src.Type())
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer dst.Close()
+
+	var cprog progressRecorder
+	err = CopyTable(dst, src, WithProgress(cprog.progress))
+	if err != nil {
+		t.Fatalf("could not copy table: %v", err)
+	}
+	cprog.check(t, 1)
+	if got, want := cprog.calls[len(cprog.calls)-1][1], src.NumRows(); got != want {
+		t.Fatalf("invalid total: got=%d, want=%d", got, want)
+	}
+}
+
+func TestContextCancel(t *testing.T) {
+	raw, err := os.ReadFile("testdata/swp06542llg.fits")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = OpenContext(ctx, bytes.NewReader(raw))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, context.Canceled)
+	}
+
+	f, err := Open(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	// cancel the decoding once the data of the first table has been read.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	dec := NewDecoder(bytes.NewReader(raw), WithProgress(func(done, total int64) {
+		cancel()
+	})).(ContextDecoder)
+	for i := 0; i < 2; i++ {
+		_, err = dec.DecodeHDUContext(ctx)
+		if err != nil {
+			t.Fatalf("could not decode HDU #%d: %v", i, err)
+		}
+	}
+	_, err = dec.DecodeHDUContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, context.Canceled)
+	}
+
+	var buf bytes.Buffer
+	out, err := Create(&buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	defer out.Close()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	err = out.WriteContext(ctx, f.HDU(0))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, context.DeadlineExceeded)
+	}
+	if got := len(out.HDUs()); got != 0 {
+		t.Fatalf("aborted HDU was added to the file (nhdus=%d)", got)
+	}
+
+	err = out.WriteContext(context.Background(), f.HDU(0))
+	if err != nil {
+		t.Fatalf("could not write HDU: %v", err)
+	}
+}
+
+func TestContextFallback(t *testing.T) {
+	raw, err := os.ReadFile("testdata/swp06542llg.fits")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	// Decoders and Encoders that are not context-aware only check the
+	// context before decoding or encoding an HDU.
+	var (
+		dec = struct{ Decoder }{NewDecoder(bytes.NewReader(raw))}
+		enc = struct{ Encoder }{NewEncoder(io.Discard)}
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	hdu, err := decodeHDU(ctx, dec)
+	if err != nil {
+		t.Fatalf("could not decode HDU: %v", err)
+	}
+	defer hdu.Close()
+	err = encodeHDU(ctx, enc, hdu)
+	if err != nil {
+		t.Fatalf("could not encode HDU: %v", err)
+	}
+
+	cancel()
+	_, err = decodeHDU(ctx, dec)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, context.Canceled)
+	}
+	err = encodeHDU(ctx, enc, hdu)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, context.Canceled)
+	}
+}
+
+func TestWithMaxHDUSize(t *testing.T) {
+	raw, err := os.ReadFile("testdata/file001.fits")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	f, err := Open(bytes.NewReader(raw), WithMaxHDUSize(98*10))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	f.Close()
+
+	_, err = Open(bytes.NewReader(raw), WithMaxHDUSize(98*10-1))
+	if !errors.Is(err, ErrHDUTooLarge) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrHDUTooLarge)
+	}
+
+	// corrupt replaces the value of the card key of the table header.
+	corrupt := func(key, value string) []byte {
+		i := bytes.Index(raw, []byte(key))
+		if i < 0 {
+			t.Fatalf("could not find card %q", key)
+		}
+		bad := append([]byte(nil), raw...)
+		copy(bad[i+10:i+30], fmt.Sprintf("%20s", value))
+		return bad
+	}
+
+	for _, tc := range []struct {
+		key, value string
+		want       error
+	}{
+		{"NAXIS1  =", "9223372036854775807", ErrHDUTooLarge},
+		{"NAXIS2  =", "4611686018427387904", ErrHDUTooLarge},
+		{"NAXIS2  =", "-1", nil},
+		{"NAXIS   =                    2", "1000", nil},
+		{"PCOUNT  =", "-2880", nil},
+		{"PCOUNT  =", "'x'", nil},
+		{"TFIELDS =", "100000", nil},
+	} {
+		t.Run(strings.TrimSpace(tc.key[:8])+"="+tc.value, func(t *testing.T) {
+			_, err := Open(bytes.NewReader(corrupt(tc.key, tc.value)))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if tc.want != nil && !errors.Is(err, tc.want) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithMaxTotalSize(t *testing.T) {
+	// a file with 3 images of 2880 bytes.
+	buf := new(bytes.Buffer)
+	w, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		img := NewImage(8, []int{BlockSize})
+		err = img.Write(bytes.Repeat([]byte{byte(i)}, BlockSize))
+		if err != nil {
+			t.Fatalf("could not write image: %v", err)
+		}
+		err = w.Write(img)
+		if err != nil {
+			t.Fatalf("could not write HDU #%d: %v", i, err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+	raw := buf.Bytes()
+
+	// stream is an io.Reader which can not seek.
+	type stream struct{ io.Reader }
+
+	for _, tc := range []struct {
+		name string
+		r    io.Reader
+		opts []Option
+		want error
+	}{
+		{"total", stream{bytes.NewReader(raw)}, []Option{WithMaxTotalSize(3 * BlockSize)}, nil},
+		{"total-too-large", stream{bytes.NewReader(raw)}, []Option{WithMaxTotalSize(3*BlockSize - 1)}, ErrHDUTooLarge},
+		{"total-seekable", bytes.NewReader(raw), []Option{WithMaxTotalSize(3*BlockSize - 1), WithConcurrency(2)}, ErrHDUTooLarge},
+		{"lazy-stream", stream{bytes.NewReader(raw)}, []Option{WithMaxHDUSize(BlockSize - 1), WithLazyOversized()}, ErrHDUTooLarge},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := Open(tc.r, tc.opts...)
+			if tc.want != nil {
+				if !errors.Is(err, tc.want) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			f.Close()
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		opt  Option
+		lazy []bool
+	}{
+		{"max-hdu", WithMaxHDUSize(BlockSize - 1), []bool{true, true, true}},
+		{"max-total", WithMaxTotalSize(2*BlockSize + 1), []bool{false, false, true}},
+	} {
+		t.Run("lazy-"+tc.name, func(t *testing.T) {
+			f, err := Open(bytes.NewReader(raw), tc.opt, WithLazyOversized())
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			defer f.Close()
+
+			for i, lazy := range tc.lazy {
+				f.mu.RLock()
+				got := f.hdus[i] == nil
+				f.mu.RUnlock()
+				if got != lazy {
+					t.Fatalf("HDU #%d: invalid lazy state: got=%v, want=%v", i, got, lazy)
+				}
+				if got, want := f.Header(i).Axes(), []int{BlockSize}; !reflect.DeepEqual(got, want) {
+					t.Fatalf("HDU #%d: invalid axes: got=%v, want=%v", i, got, want)
+				}
+				hdu, err := f.LoadHDU(i)
+				if err != nil {
+					t.Fatalf("could not load HDU #%d: %v", i, err)
+				}
+				if got, want := hdu.(Image).Raw()[0], byte(i); got != want {
+					t.Fatalf("HDU #%d: invalid pixels: got=%d, want=%d", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWithDExponent(t *testing.T) {
+	buf := new(bytes.Buffer)
+	f, err := Create(buf, WithDExponent())
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	img := NewImage(8, nil)
+	img.Header().Set("EPSILON", 2.5e-16, "a small value")
+	err = f.Write(img)
+	if err != nil {
+		t.Fatalf("could not write image: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	if want := "EPSILON =              2.5D-16"; !strings.Contains(buf.String(), want) {
+		t.Fatalf("missing D exponent card %q", want)
+	}
+
+	r, err := Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+	if got, want := r.HDU(0).Header().Get("EPSILON").Value, 2.5e-16; got != want {
+		t.Fatalf("invalid value: got=%v, want=%v", got, want)
+	}
+}
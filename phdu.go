@@ -4,8 +4,6 @@
 
 package fitsio
 
-import "reflect"
-
 type primaryHDU struct {
 	imageHDU
 }
@@ -19,14 +17,9 @@ func (hdu *primaryHDU) Name() string {
 	return card.Value.(string)
 }
 
-// Version returns the value of the 'EXTVER' Card (or 1 if none)
+// Version returns the value of the 'EXTVER' Card (or 1 if none or invalid)
 func (hdu *primaryHDU) Version() int {
-	card := hdu.hdr.Get("EXTVER")
-	if card == nil {
-		return 1
-	}
-	rv := reflect.ValueOf(card.Value)
-	return int(rv.Int())
+	return hduVersion(&hdu.hdr)
 }
 
 // NewPrimaryHDU creates a new PrimaryHDU with Header hdr.
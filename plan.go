@@ -0,0 +1,240 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// ScanPlan reads rows of a Table into values of a given struct type.
+//
+// The mapping between struct fields and columns, the type checks and the
+// selection of the decoding functions are performed once, when the plan is
+// created by Table.Planner, so that ScanPlan.Scan does not use reflection
+// for fixed-size columns of binary tables.
+//
+// A ScanPlan may be used concurrently from multiple goroutines.
+type ScanPlan struct {
+	table  *Table
+	ptype  reflect.Type // pointer to the planned struct type
+	fields []planField
+}
+
+// planField describes how to read a column into a struct field.
+type planField struct {
+	icol   int
+	offset uintptr      // offset of the field in the struct
+	rtype  reflect.Type // type of the field
+	beg    int          // offset of the column in a row
+	end    int
+
+	// read decodes the column bytes into the field.
+	// read is nil for columns decoded through Column.read.
+	read func(p unsafe.Pointer, buf []byte)
+}
+
+// Planner returns a ScanPlan reading rows of the table into values of the
+// type of sample, a struct or a pointer to a struct.
+// Struct fields are associated with columns as with Rows.Scan: through the
+// "fits" struct tag or the field name.
+// Unexported fields and fields without a matching column are ignored.
+func (t *Table) Planner(sample interface{}) (*ScanPlan, error) {
+	rt := reflect.TypeOf(sample)
+	if rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt == nil || rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("fitsio: Planner takes a struct or a pointer to a struct. got: %T", sample)
+	}
+
+	plan := &ScanPlan{
+		table: t,
+		ptype: reflect.PtrTo(rt),
+	}
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		n := f.Tag.Get("fits")
+		if n == "" {
+			n = f.Name
+		}
+		icol := t.Index(n)
+		if icol < 0 {
+			continue
+		}
+		col := &t.cols[icol]
+		if f.Type != col.Type() {
+			return nil, fmt.Errorf(
+				"fitsio: invalid type for field %s (column %q): %w",
+				f.Name, col.Name, &ErrTypeMismatch{Want: col.Type(), Got: f.Type},
+			)
+		}
+		plan.fields = append(plan.fields, planField{
+			icol:   icol,
+			offset: f.Offset,
+			rtype:  f.Type,
+			beg:    col.offset,
+			end:    col.offset + col.dtype.dsize*col.dtype.len,
+			read:   planDecoder(t, col),
+		})
+	}
+	return plan, nil
+}
+
+// Scan reads the row irow of the table into ptr, a pointer to a value of
+// the struct type of the plan.
+func (plan *ScanPlan) Scan(irow int64, ptr interface{}) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Type() != plan.ptype {
+		return fmt.Errorf(
+			"fitsio: invalid type for ScanPlan.Scan: %w",
+			&ErrTypeMismatch{Want: plan.ptype, Got: rv.Type()},
+		)
+	}
+	if rv.IsNil() {
+		return fmt.Errorf("fitsio: ScanPlan.Scan takes a non-nil pointer")
+	}
+
+	if irow < 0 || irow >= plan.table.nrows {
+		return fmt.Errorf("fitsio: row index out of range (%d)", irow)
+	}
+	return plan.scan(irow, unsafe.Pointer(rv.Pointer()))
+}
+
+// scan reads the row irow of the table into the struct value at base.
+func (plan *ScanPlan) scan(irow int64, base unsafe.Pointer) error {
+	t := plan.table
+	row := t.data[int(irow)*t.rowsz : int(irow+1)*t.rowsz]
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		p := unsafe.Add(base, f.offset)
+		if f.read != nil {
+			f.read(p, row[f.beg:f.end])
+			continue
+		}
+		err := t.cols[f.icol].read(t, f.icol, irow, reflect.NewAt(f.rtype, p).Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planDecoder returns the function decoding the bytes of the column col
+// into a value of the Go type of the column, or nil if the column needs
+// to be decoded through Column.read.
+func planDecoder(t *Table, col *Column) func(p unsafe.Pointer, buf []byte) {
+	if !t.binary {
+		return nil
+	}
+
+	rt := col.Type()
+	switch rt.Kind() {
+	case reflect.String:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*string)(p) = decodeString(buf)
+		}
+
+	case reflect.Array:
+		dec := scalarDecoder(rt.Elem().Kind())
+		if dec == nil {
+			return nil
+		}
+		var (
+			n     = rt.Len()
+			esize = uintptr(rt.Elem().Size())
+			dsize = col.dtype.dsize
+		)
+		return func(p unsafe.Pointer, buf []byte) {
+			for i := 0; i < n; i++ {
+				dec(unsafe.Add(p, uintptr(i)*esize), buf[i*dsize:(i+1)*dsize])
+			}
+		}
+	}
+
+	return scalarDecoder(rt.Kind())
+}
+
+// scalarDecoder returns the function decoding a big-endian value of the
+// provided kind, or nil if the kind is not a fixed-size scalar kind.
+// Unsigned integers and signed bytes are decoded from values stored with
+// a TZERO offset, as binary tables store them.
+func scalarDecoder(kind reflect.Kind) func(p unsafe.Pointer, buf []byte) {
+	switch kind {
+	case reflect.Bool:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*bool)(p) = decodeBool(buf[0])
+		}
+	case reflect.Int8:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*int8)(p) = int8(buf[0] ^ 0x80)
+		}
+	case reflect.Uint8:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*uint8)(p) = buf[0]
+		}
+	case reflect.Int16:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*int16)(p) = int16(binary.BigEndian.Uint16(buf))
+		}
+	case reflect.Uint16:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*uint16)(p) = binary.BigEndian.Uint16(buf) ^ 1<<15
+		}
+	case reflect.Int32:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*int32)(p) = int32(binary.BigEndian.Uint32(buf))
+		}
+	case reflect.Uint32:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*uint32)(p) = binary.BigEndian.Uint32(buf) ^ 1<<31
+		}
+	case reflect.Int64:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*int64)(p) = int64(binary.BigEndian.Uint64(buf))
+		}
+	case reflect.Uint64:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*uint64)(p) = binary.BigEndian.Uint64(buf) ^ 1<<63
+		}
+	case reflect.Int:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*int)(p) = int(int64(binary.BigEndian.Uint64(buf)))
+		}
+	case reflect.Uint:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*uint)(p) = uint(binary.BigEndian.Uint64(buf) ^ 1<<63)
+		}
+	case reflect.Float32:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*float32)(p) = math.Float32frombits(binary.BigEndian.Uint32(buf))
+		}
+	case reflect.Float64:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*float64)(p) = math.Float64frombits(binary.BigEndian.Uint64(buf))
+		}
+	case reflect.Complex64:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*complex64)(p) = complex(
+				math.Float32frombits(binary.BigEndian.Uint32(buf[0:4])),
+				math.Float32frombits(binary.BigEndian.Uint32(buf[4:8])),
+			)
+		}
+	case reflect.Complex128:
+		return func(p unsafe.Pointer, buf []byte) {
+			*(*complex128)(p) = complex(
+				math.Float64frombits(binary.BigEndian.Uint64(buf[0:8])),
+				math.Float64frombits(binary.BigEndian.Uint64(buf[8:16])),
+			)
+		}
+	}
+	return nil
+}
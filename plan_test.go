@@ -0,0 +1,244 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+type planData struct {
+	U8   uint8 `fits:"B"`
+	I16  int16
+	I32  int32
+	I64  int64
+	F32  float32
+	F64  float64
+	C64  complex64
+	C128 complex128
+	Bool bool
+	Str  string
+	Arr  [3]int16
+	Vla  []float64
+	skip int
+	Miss float64 `fits:"missing"`
+}
+
+func newPlanTable(t testing.TB, n int) *Table {
+	tbl, err := NewTable("plan", []Column{
+		{Name: "B", Format: "B"},
+		{Name: "I16", Format: "I"},
+		{Name: "I32", Format: "J"},
+		{Name: "I64", Format: "K"},
+		{Name: "F32", Format: "E"},
+		{Name: "F64", Format: "D"},
+		{Name: "C64", Format: "C"},
+		{Name: "C128", Format: "M"},
+		{Name: "Bool", Format: "L"},
+		{Name: "Str", Format: "10A"},
+		{Name: "Arr", Format: "3I"},
+		{Name: "Vla", Format: "PD"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+
+	for i := 0; i < n; i++ {
+		data := planData{
+			U8:   uint8(i),
+			I16:  int16(-2 * i),
+			I32:  int32(-3 * i),
+			I64:  int64(-4 * i),
+			F32:  float32(i) / 2,
+			F64:  float64(i) / 4,
+			C64:  complex(float32(i), -float32(i)),
+			C128: complex(float64(i), -2*float64(i)),
+			Bool: i%2 == 0,
+			Str:  fmt.Sprintf("str-%d", i),
+			Arr:  [3]int16{int16(i), int16(i + 1), int16(i + 2)},
+			Vla:  make([]float64, i%4),
+		}
+		for j := range data.Vla {
+			data.Vla[j] = float64(i * j)
+		}
+		err = tbl.Write(&data)
+		if err != nil {
+			t.Fatalf("could not write row %d: %v", i, err)
+		}
+	}
+	return tbl
+}
+
+func TestScanPlan(t *testing.T) {
+	tbl := newPlanTable(t, 10)
+	defer tbl.Close()
+
+	plan, err := tbl.Planner(planData{})
+	if err != nil {
+		t.Fatalf("could not create plan: %v", err)
+	}
+
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	defer rows.Close()
+
+	irow := int64(0)
+	for rows.Next() {
+		var want planData
+		err = rows.Scan(&want)
+		if err != nil {
+			t.Fatalf("could not scan row %d: %v", irow, err)
+		}
+
+		got := planData{skip: 42, Miss: 42}
+		err = plan.Scan(irow, &got)
+		if err != nil {
+			t.Fatalf("could not scan row %d with plan: %v", irow, err)
+		}
+		if got.skip != 42 || got.Miss != 42 {
+			t.Fatalf("row %d: unmapped fields were modified: %+v", irow, got)
+		}
+		got.skip = 0
+		got.Miss = 0
+		if len(got.Vla) == 0 && len(want.Vla) == 0 {
+			got.Vla = want.Vla
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("row %d: invalid data.\ngot= %+v\nwant=%+v", irow, got, want)
+		}
+		irow++
+	}
+
+	for _, tc := range []struct {
+		irow int64
+		ptr  interface{}
+	}{
+		{irow: -1, ptr: &planData{}},
+		{irow: tbl.NumRows(), ptr: &planData{}},
+		{irow: 0, ptr: planData{}},
+		{irow: 0, ptr: (*planData)(nil)},
+		{irow: 0, ptr: &struct{ I16 int16 }{}},
+	} {
+		err = plan.Scan(tc.irow, tc.ptr)
+		if err == nil {
+			t.Fatalf("expected an error scanning row %d into %T", tc.irow, tc.ptr)
+		}
+	}
+
+	_, err = tbl.Planner(42)
+	if err == nil {
+		t.Fatalf("expected an error planning a non-struct")
+	}
+	_, err = tbl.Planner(struct{ I16 int32 }{})
+	if err == nil {
+		t.Fatalf("expected an error planning a mistyped field")
+	}
+}
+
+func TestScanPlanASCII(t *testing.T) {
+	r, err := os.Open("testdata/file001.fits")
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer r.Close()
+
+	f, err := Open(r)
+	if err != nil {
+		t.Fatalf("could not open FITS file: %v", err)
+	}
+	defer f.Close()
+
+	for _, hdu := range f.HDUs() {
+		tbl, ok := hdu.(*Table)
+		if !ok || tbl.Type() != ASCII_TBL {
+			continue
+		}
+
+		fields := make([]reflect.StructField, tbl.NumCols())
+		for i, col := range tbl.Cols() {
+			fields[i] = reflect.StructField{
+				Name: fmt.Sprintf("F%d", i),
+				Type: col.Type(),
+				Tag:  reflect.StructTag(fmt.Sprintf("fits:%q", col.Name)),
+			}
+		}
+		rt := reflect.StructOf(fields)
+
+		plan, err := tbl.Planner(reflect.New(rt).Interface())
+		if err != nil {
+			t.Fatalf("could not create plan: %v", err)
+		}
+
+		rows, err := tbl.Read(0, tbl.NumRows())
+		if err != nil {
+			t.Fatalf("could not read table: %v", err)
+		}
+		irow := int64(0)
+		for rows.Next() {
+			want := reflect.New(rt)
+			err = rows.Scan(want.Interface())
+			if err != nil {
+				t.Fatalf("could not scan row %d: %v", irow, err)
+			}
+			got := reflect.New(rt)
+			err = plan.Scan(irow, got.Interface())
+			if err != nil {
+				t.Fatalf("could not scan row %d with plan: %v", irow, err)
+			}
+			if !reflect.DeepEqual(got.Interface(), want.Interface()) {
+				t.Fatalf("row %d: invalid data.\ngot= %+v\nwant=%+v", irow, got.Elem(), want.Elem())
+			}
+			irow++
+		}
+		rows.Close()
+	}
+}
+
+func BenchmarkRowsScanStruct(b *testing.B) {
+	tbl := newPlanTable(b, 1000)
+	defer tbl.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows, err := tbl.Read(0, tbl.NumRows())
+		if err != nil {
+			b.Fatal(err)
+		}
+		var data planData
+		for rows.Next() {
+			err = rows.Scan(&data)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkScanPlan(b *testing.B) {
+	tbl := newPlanTable(b, 1000)
+	defer tbl.Close()
+
+	plan, err := tbl.Planner(planData{})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var data planData
+		for irow := int64(0); irow < tbl.NumRows(); irow++ {
+			err = plan.Scan(irow, &data)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// WithBufferPool makes Open and NewDecoder read the data units of tables
+// into buffers borrowed from a pool shared by all decoders.
+// These buffers are given back to the pool by Table.Release, for reuse by
+// the decoding of other tables, which reduces the pressure on the garbage
+// collector when scanning many small tables.
+func WithBufferPool() Option {
+	return func(cfg *config) {
+		cfg.pooled = true
+	}
+}
+
+// size classes of pooled buffers: buffers of the class c have a capacity
+// of 1<<c bytes.
+const (
+	minPoolClass = 12 // 4 KiB
+	maxPoolClass = 26 // 64 MiB
+)
+
+var bufPools [maxPoolClass + 1]sync.Pool // *[]byte, by size class
+
+// getBuffer returns an empty buffer from the pool, with a capacity of at
+// least n bytes, or nil if buffers of n bytes are not pooled.
+func getBuffer(n int) *[]byte {
+	if n <= 0 {
+		return nil
+	}
+	c := max(bits.Len(uint(n-1)), minPoolClass)
+	if c > maxPoolClass {
+		return nil
+	}
+	if p, ok := bufPools[c].Get().(*[]byte); ok {
+		return p
+	}
+	buf := make([]byte, 0, 1<<c)
+	return &buf
+}
+
+// putBuffer gives back to the pool a buffer returned by getBuffer.
+func putBuffer(p *[]byte) {
+	c := bits.Len(uint(cap(*p))) - 1
+	if c < minPoolClass || c > maxPoolClass || cap(*p) != 1<<c {
+		return
+	}
+	*p = (*p)[:0]
+	bufPools[c].Put(p)
+}
+
+// Release gives back the buffers holding the data of a table decoded with
+// the WithBufferPool option to the pool.
+// The table is empty after Release: its rows, and the values previously
+// read from its columns sharing its buffers (such as ColumnView values and
+// the byte slices returned by ColumnBytes), must not be used anymore.
+// Release is a no-op for other tables.
+func (t *Table) Release() {
+	if t.pooled == nil {
+		return
+	}
+	putBuffer(t.pooled)
+	t.pooled = nil
+	t.data = nil
+	t.heap = nil
+	t.nrows = 0
+	t.heapIdx = nil
+}
@@ -0,0 +1,110 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestBufferPool(t *testing.T) {
+	type Row struct {
+		ID   int32     `fits:"ID"`
+		Data []float64 `fits:"DATA"`
+	}
+	rows := []Row{
+		{1, []float64{1, 2, 3}},
+		{2, nil},
+		{3, []float64{4}},
+	}
+	tbl, err := NewTableFrom("data", Row{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	err = WriteRows(tbl, rows)
+	if err != nil {
+		t.Fatalf("could not write rows: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	w, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for _, hdu := range []HDU{NewImage(8, nil), tbl} {
+		err = w.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	read := func(tbl *Table) []Row {
+		all, err := tbl.Read(0, tbl.NumRows())
+		if err != nil {
+			t.Fatalf("could not read table: %v", err)
+		}
+		var got []Row
+		err = all.ScanAll(&got)
+		if err != nil {
+			t.Fatalf("could not scan rows: %v", err)
+		}
+		return got
+	}
+
+	for i := 0; i < 3; i++ {
+		f, err := Open(bytes.NewReader(buf.Bytes()), WithBufferPool())
+		if err != nil {
+			t.Fatalf("could not open file: %v", err)
+		}
+		for _, hdu := range f.HDUs()[1:] {
+			tbl := hdu.(*Table)
+			if tbl.pooled == nil {
+				t.Fatalf("table data was not borrowed from the pool")
+			}
+			if got := read(tbl); !reflect.DeepEqual(got, rows) {
+				t.Fatalf("invalid rows:\ngot= %v\nwant=%v", got, rows)
+			}
+
+			// rows appended to the table do not overwrite its heap.
+			err = tbl.Write(&Row{ID: 4, Data: []float64{5, 6}})
+			if err != nil {
+				t.Fatalf("could not write row: %v", err)
+			}
+			want := append(rows[:len(rows):len(rows)], Row{4, []float64{5, 6}})
+			if got := read(tbl); !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid rows:\ngot= %v\nwant=%v", got, want)
+			}
+
+			tbl.Release()
+			if tbl.NumRows() != 0 || tbl.pooled != nil {
+				t.Fatalf("table was not released")
+			}
+			tbl.Release()
+		}
+		f.Close()
+	}
+
+	f, err := Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+	if f.HDU(1).(*Table).pooled != nil {
+		t.Fatalf("table data was borrowed without WithBufferPool")
+	}
+
+	if p := getBuffer(1); cap(*p) != 1<<minPoolClass {
+		t.Fatalf("invalid pooled buffer capacity: %d", cap(*p))
+	}
+	if p := getBuffer(1<<maxPoolClass + 1); p != nil {
+		t.Fatalf("large buffers should not be pooled")
+	}
+}
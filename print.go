@@ -0,0 +1,489 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// PrintOptions configures the pretty-printing of a Table with Fprint.
+type PrintOptions struct {
+	Columns []string // names of the columns to print, in order (all columns if empty)
+	Start   int64    // index of the first row to print
+	NumRows int64    // number of rows to print (all the rows from Start if zero)
+
+	// PageSize is the number of rows of a page. Each page starts with the
+	// column names, and is separated from the previous one by an empty line.
+	// The columns are aligned within a page.
+	// All the rows are printed in a single page if PageSize is zero.
+	PageSize int
+
+	MaxWidth int  // maximum width of a cell, longer cells are truncated (defaults to 40, no limit if negative)
+	MaxElems int  // maximum number of elements printed for array cells (defaults to 8, no limit if negative)
+	Units    bool // whether to print the column units under their names (when a column has a unit)
+	RowIndex bool // whether to print the index of the rows in a first column
+}
+
+const (
+	defaultPrintWidth = 40
+	defaultPrintElems = 8
+)
+
+// Fprint writes the rows of the table t to w, as aligned columns.
+//
+// Values are formatted according to the TDISPn display format of their
+// column, when it is set and valid, and with the shortest representation
+// otherwise. Numbers and logical values are aligned to the right, strings
+// to the left. Null values are left blank.
+func Fprint(w io.Writer, t *Table, opts PrintOptions) error {
+	if t == nil {
+		return fmt.Errorf("fitsio: nil table")
+	}
+	if opts.MaxWidth == 0 {
+		opts.MaxWidth = defaultPrintWidth
+	}
+	if opts.MaxElems == 0 {
+		opts.MaxElems = defaultPrintElems
+	}
+
+	icols := make([]int, len(opts.Columns))
+	for i, name := range opts.Columns {
+		icols[i] = t.Index(name)
+		if icols[i] < 0 {
+			return fmt.Errorf("fitsio: no column %q in table %q", name, t.Name())
+		}
+	}
+	if len(icols) == 0 {
+		icols = make([]int, len(t.cols))
+		for i := range icols {
+			icols[i] = i
+		}
+	}
+
+	nrows := t.NumRows()
+	beg := min(max(opts.Start, 0), nrows)
+	end := nrows
+	if opts.NumRows > 0 {
+		end = min(beg+opts.NumRows, nrows)
+	}
+
+	rows, err := t.Read(beg, end)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	p := tablePrinter{
+		w:     bufio.NewWriter(w),
+		opts:  opts,
+		cols:  make([]*Column, len(icols)),
+		disp:  make([]*displayFormat, len(icols)),
+		right: make([]bool, len(icols)),
+	}
+	data := make([]interface{}, len(t.cols))
+	for i := range t.cols {
+		rt := t.cols[i].Type()
+		if k := rt.Kind(); k != reflect.Array && k != reflect.Slice {
+			// scan scalars into pointers, to tell null values apart.
+			rt = reflect.PointerTo(rt)
+		}
+		data[i] = reflect.New(rt).Interface()
+	}
+	units := false
+	for i, icol := range icols {
+		col := &t.cols[icol]
+		p.cols[i] = col
+		units = units || col.Unit != ""
+		if col.Display != "" {
+			if disp, err := parseDisplay(col.Display); err == nil {
+				p.disp[i] = &disp
+			}
+		}
+		elem := col.Type()
+		if k := elem.Kind(); k == reflect.Array || k == reflect.Slice {
+			elem = elem.Elem()
+		}
+		p.right[i] = elem.Kind() != reflect.String
+	}
+	p.opts.Units = opts.Units && units
+
+	npage := 0
+	for irow := beg; rows.Next(); irow++ {
+		err = rows.Scan(data...)
+		if err != nil {
+			return err
+		}
+		cells := make([]string, 0, len(icols)+1)
+		if opts.RowIndex {
+			cells = append(cells, strconv.FormatInt(irow, 10))
+		}
+		for i, icol := range icols {
+			cells = append(cells, p.format(i, reflect.ValueOf(data[icol]).Elem()))
+		}
+		p.page = append(p.page, cells)
+
+		if opts.PageSize > 0 && len(p.page) == opts.PageSize {
+			p.flush(npage)
+			npage++
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	if len(p.page) > 0 || npage == 0 {
+		p.flush(npage)
+	}
+	return p.w.Flush()
+}
+
+// tablePrinter formats and prints the pages of a table.
+type tablePrinter struct {
+	w     *bufio.Writer
+	opts  PrintOptions
+	cols  []*Column        // printed columns
+	disp  []*displayFormat // display formats of the printed columns, if any
+	right []bool           // whether the printed columns are right-aligned
+	page  [][]string       // formatted cells of the current page
+}
+
+// format formats the value rv of the i-th printed column.
+func (p *tablePrinter) format(i int, rv reflect.Value) string {
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return ""
+		}
+		return p.truncate(p.formatElem(i, rv.Elem()))
+	case reflect.Array, reflect.Slice:
+		n := rv.Len()
+		if p.opts.MaxElems > 0 {
+			n = min(n, p.opts.MaxElems)
+		}
+		elems := make([]string, n, n+1)
+		for j := range elems {
+			elems[j] = p.formatElem(i, rv.Index(j))
+		}
+		if n < rv.Len() {
+			elems = append(elems, "...")
+		}
+		return p.truncate(strings.Join(elems, " "))
+	}
+	return p.truncate(p.formatElem(i, rv))
+}
+
+// formatElem formats the scalar value rv of the i-th printed column.
+func (p *tablePrinter) formatElem(i int, rv reflect.Value) string {
+	if disp := p.disp[i]; disp != nil {
+		if str, ok := disp.format(rv); ok {
+			return strings.TrimSpace(str)
+		}
+	}
+	return formatValue(rv)
+}
+
+// truncate truncates the cell str to the maximum width of cells.
+func (p *tablePrinter) truncate(str string) string {
+	if p.opts.MaxWidth < 0 || utf8.RuneCountInString(str) <= p.opts.MaxWidth {
+		return str
+	}
+	n := max(p.opts.MaxWidth-3, 0)
+	return string([]rune(str)[:n]) + "..."[:min(3, p.opts.MaxWidth)]
+}
+
+// flush prints the current page.
+// Write errors are reported by the final flush of the buffered writer.
+func (p *tablePrinter) flush(npage int) {
+	var (
+		names = make([]string, 0, len(p.cols)+1)
+		units = make([]string, 0, len(p.cols)+1)
+		right = make([]bool, 0, len(p.cols)+1)
+	)
+	if p.opts.RowIndex {
+		names = append(names, "ROW")
+		units = append(units, "")
+		right = append(right, true)
+	}
+	for i, col := range p.cols {
+		names = append(names, col.Name)
+		units = append(units, col.Unit)
+		right = append(right, p.right[i])
+	}
+
+	widths := make([]int, len(names))
+	for j := range names {
+		widths[j] = utf8.RuneCountInString(names[j])
+		if p.opts.Units {
+			widths[j] = max(widths[j], utf8.RuneCountInString(units[j]))
+		}
+		for _, cells := range p.page {
+			widths[j] = max(widths[j], utf8.RuneCountInString(cells[j]))
+		}
+	}
+
+	if npage > 0 {
+		p.w.WriteString("\n")
+	}
+	p.writeLine(names, widths, right)
+	if p.opts.Units {
+		p.writeLine(units, widths, right)
+	}
+	rule := make([]string, len(widths))
+	for j, n := range widths {
+		rule[j] = strings.Repeat("-", n)
+	}
+	p.writeLine(rule, widths, right)
+	for _, cells := range p.page {
+		p.writeLine(cells, widths, right)
+	}
+	p.page = p.page[:0]
+}
+
+// writeLine writes a line of cells, padded to their column width.
+func (p *tablePrinter) writeLine(cells []string, widths []int, right []bool) {
+	var line strings.Builder
+	for j, cell := range cells {
+		if j > 0 {
+			line.WriteString("  ")
+		}
+		pad := strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell))
+		switch {
+		case right[j]:
+			line.WriteString(pad)
+			line.WriteString(cell)
+		default:
+			line.WriteString(cell)
+			line.WriteString(pad)
+		}
+	}
+	p.w.WriteString(strings.TrimRight(line.String(), " "))
+	p.w.WriteString("\n")
+}
+
+// displayFormat is a parsed TDISPn display format, such as "I6", "F8.3"
+// or "E12.4E2".
+type displayFormat struct {
+	code string // A, L, I, B, O, Z, F, E, EN, ES, G or D
+	w    int    // width of the field
+	m    int    // minimum number of digits (I, B, O, Z) or number of decimals (F, E, EN, ES, G, D)
+	e    int    // number of digits of the exponent (E, G, D), if set
+}
+
+// parseDisplay parses the TDISPn display format str.
+func parseDisplay(str string) (displayFormat, error) {
+	var (
+		disp displayFormat
+		s    = strings.ToUpper(strings.TrimSpace(str))
+	)
+
+	switch {
+	case strings.HasPrefix(s, "EN"), strings.HasPrefix(s, "ES"):
+		disp.code, s = s[:2], s[2:]
+	case s == "":
+		return disp, fmt.Errorf("fitsio: empty display format")
+	default:
+		disp.code, s = s[:1], s[1:]
+	}
+
+	// number returns the decimal number at the beginning of s.
+	number := func() (int, bool) {
+		n := 0
+		for n < len(s) && '0' <= s[n] && s[n] <= '9' {
+			n++
+		}
+		if n == 0 {
+			return 0, false
+		}
+		v, err := strconv.Atoi(s[:n])
+		s = s[n:]
+		return v, err == nil
+	}
+
+	var ok bool
+	disp.w, ok = number()
+	if !ok || disp.w == 0 {
+		return disp, fmt.Errorf("fitsio: invalid display format %q", str)
+	}
+
+	switch disp.code {
+	case "A", "L":
+	case "I", "B", "O", "Z":
+		if strings.HasPrefix(s, ".") {
+			s = s[1:]
+			disp.m, ok = number()
+		}
+	case "F", "E", "EN", "ES", "G", "D":
+		ok = strings.HasPrefix(s, ".")
+		if ok {
+			s = s[1:]
+			disp.m, ok = number()
+		}
+		if ok && disp.code != "F" && strings.HasPrefix(s, "E") {
+			s = s[1:]
+			disp.e, ok = number()
+		}
+	default:
+		ok = false
+	}
+	if !ok || s != "" {
+		return disp, fmt.Errorf("fitsio: invalid display format %q", str)
+	}
+	return disp, nil
+}
+
+// format formats the scalar value rv according to the display format.
+// format returns false if the display format does not apply to values of
+// the type of rv.
+// Values too wide for the field are printed as w asterisks.
+func (disp displayFormat) format(rv reflect.Value) (string, bool) {
+	var str string
+	switch rv.Kind() {
+	case reflect.String:
+		if disp.code != "A" {
+			return "", false
+		}
+		str = rv.String()
+
+	case reflect.Bool:
+		if disp.code != "L" {
+			return "", false
+		}
+		str = formatValue(rv)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var (
+			v   int64
+			neg bool
+			u   uint64
+		)
+		switch rv.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u = rv.Uint()
+		default:
+			v = rv.Int()
+			neg = v < 0
+			u = uint64(v)
+			if neg {
+				u = -u
+			}
+		}
+		var verb string
+		switch disp.code {
+		case "I":
+			verb = "d"
+		case "B":
+			verb = "b"
+		case "O":
+			verb = "o"
+		case "Z":
+			verb = "X"
+		case "F", "E", "EN", "ES", "G", "D":
+			if neg {
+				return disp.formatFloat(float64(v)), true
+			}
+			return disp.formatFloat(float64(u)), true
+		default:
+			return "", false
+		}
+		str = fmt.Sprintf("%.*"+verb, disp.m, u)
+		if neg {
+			str = "-" + str
+		}
+
+	case reflect.Float32, reflect.Float64:
+		return disp.formatFloat(rv.Float()), true
+
+	case reflect.Complex64, reflect.Complex128:
+		v := rv.Complex()
+		return "(" + disp.formatFloat(real(v)) + "," + disp.formatFloat(imag(v)) + ")", true
+
+	default:
+		return "", false
+	}
+
+	n := utf8.RuneCountInString(str)
+	switch {
+	case n > disp.w:
+		return strings.Repeat("*", disp.w), true
+	case rv.Kind() == reflect.String:
+		return str + strings.Repeat(" ", disp.w-n), true
+	}
+	return strings.Repeat(" ", disp.w-n) + str, true
+}
+
+// formatFloat formats the floating-point value v according to the
+// display format.
+// Integer display formats are applied to the integer part of v.
+func (disp displayFormat) formatFloat(v float64) string {
+	var str string
+	switch {
+	case math.IsNaN(v) || math.IsInf(v, 0):
+		str = formatValue(reflect.ValueOf(v))
+	default:
+		switch disp.code {
+		case "F":
+			str = strconv.FormatFloat(v, 'f', disp.m, 64)
+		case "E", "ES":
+			str = disp.exponent(strconv.FormatFloat(v, 'E', disp.m, 64))
+		case "D":
+			str = strings.Replace(disp.exponent(strconv.FormatFloat(v, 'E', disp.m, 64)), "E", "D", 1)
+		case "EN":
+			str = disp.engineering(v)
+		case "G":
+			str = strconv.FormatFloat(v, 'G', max(disp.m, 1), 64)
+			if strings.Contains(str, "E") {
+				str = disp.exponent(str)
+			}
+		case "I", "B", "O", "Z":
+			str, _ = disp.format(reflect.ValueOf(int64(math.Trunc(v))))
+			return str
+		default:
+			str = formatValue(reflect.ValueOf(v))
+		}
+	}
+	if len(str) > disp.w {
+		return strings.Repeat("*", disp.w)
+	}
+	return strings.Repeat(" ", disp.w-len(str)) + str
+}
+
+// exponent pads the exponent of the number str (in E notation) to the
+// number of digits of the display format.
+func (disp displayFormat) exponent(str string) string {
+	i := strings.LastIndexAny(str, "+-")
+	if i < 0 || disp.e == 0 {
+		return str
+	}
+	digits := str[i+1:]
+	if n := disp.e - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+	return str[:i+1] + digits
+}
+
+// engineering formats v in engineering notation, with an exponent
+// multiple of 3 and a mantissa in [1, 1000).
+func (disp displayFormat) engineering(v float64) string {
+	exp := 0
+	if v != 0 {
+		exp = int(math.Floor(math.Log10(math.Abs(v))/3)) * 3
+	}
+	mant := v / math.Pow10(exp)
+	if r, _ := strconv.ParseFloat(strconv.FormatFloat(mant, 'f', disp.m, 64), 64); math.Abs(r) >= 1000 {
+		// rounding carried the mantissa over 1000.
+		exp += 3
+		mant /= 1000
+	}
+	return disp.exponent(fmt.Sprintf("%.*fE%+03d", disp.m, mant, exp))
+}
@@ -0,0 +1,170 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFprint(t *testing.T) {
+	tbl, err := NewTable("print", []Column{
+		{Name: "ID", Format: "J", Display: "I4.3"},
+		{Name: "NAME", Format: "12A"},
+		{Name: "FLUX", Format: "D", Unit: "Jy", Display: "F8.2"},
+		{Name: "SPEC", Format: "10E"},
+		{Name: "OK", Format: "L"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	spec := [10]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for _, row := range []struct {
+		id   int32
+		name string
+		flux *float64
+		ok   *bool
+	}{
+		{1, "alpha", ptr(1.5), ptr(true)},
+		{22, "a very long name", ptr(-123.456), ptr(false)},
+		{333, "c", nil, nil},
+	} {
+		err = tbl.Write(&row.id, &row.name, &row.flux, &spec, &row.ok)
+		if err != nil {
+			t.Fatalf("could not write row: %v", err)
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		opts PrintOptions
+		want string
+	}{
+		{
+			name: "default",
+			want: ` ID  NAME            FLUX                 SPEC  OK
+---  -----------  -------  -------------------  --
+001  alpha           1.50  1 2 3 4 5 6 7 8 ...   T
+022  a very long  -123.46  1 2 3 4 5 6 7 8 ...   F
+333  c                     1 2 3 4 5 6 7 8 ...
+`,
+		},
+		{
+			name: "select",
+			opts: PrintOptions{
+				Columns:  []string{"FLUX", "ID"},
+				Start:    1,
+				NumRows:  5,
+				Units:    true,
+				RowIndex: true,
+			},
+			want: `ROW     FLUX   ID
+          Jy
+---  -------  ---
+  1  -123.46  022
+  2           333
+`,
+		},
+		{
+			name: "pages",
+			opts: PrintOptions{
+				Columns:  []string{"NAME", "SPEC"},
+				PageSize: 2,
+				MaxWidth: 10,
+				MaxElems: -1,
+			},
+			want: `NAME              SPEC
+----------  ----------
+alpha       1 2 3 4...
+a very ...  1 2 3 4...
+
+NAME        SPEC
+----  ----------
+c     1 2 3 4...
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var buf strings.Builder
+			err := Fprint(&buf, tbl, test.opts)
+			if err != nil {
+				t.Fatalf("could not print table: %v", err)
+			}
+			if got, want := buf.String(), test.want; got != want {
+				t.Fatalf("invalid output.\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+
+	err = Fprint(new(strings.Builder), tbl, PrintOptions{Columns: []string{"NOPE"}})
+	if err == nil {
+		t.Fatalf("expected an error for an unknown column")
+	}
+}
+
+func TestDisplayFormat(t *testing.T) {
+	for _, test := range []struct {
+		disp string
+		v    interface{}
+		want string
+	}{
+		{"A6", "abc", "abc   "},
+		{"A2", "abc", "**"},
+		{"L3", true, "  T"},
+		{"I5", int16(-42), "  -42"},
+		{"I6.4", int64(-42), " -0042"},
+		{"I2", 1234, "**"},
+		{"B8.8", uint8(5), "00000101"},
+		{"O4", 8, "  10"},
+		{"Z4", 255, "  FF"},
+		{"F8.3", math.Pi, "   3.142"},
+		{"F6.1", int32(12), "  12.0"},
+		{"f6.1", 1e6, "******"},
+		{"E10.3", 12345.678, " 1.235E+04"},
+		{"ES10.3", -0.00012367, "-1.237E-04"},
+		{"E12.3E3", 12345.678, "  1.235E+004"},
+		{"D10.3", 12345.678, " 1.235D+04"},
+		{"EN10.2", 12345.678, " 12.35E+03"},
+		{"EN10.2", 999999.0, "  1.00E+06"},
+		{"EN10.2", 0.0, "  0.00E+00"},
+		{"G8.3", 12.345, "    12.3"},
+		{"G10.3", 1.2345e10, "  1.23E+10"},
+		{"F6.2", math.NaN(), "   NaN"},
+		{"F8.2", complex(1, -2), "(    1.00,   -2.00)"},
+	} {
+		t.Run(test.disp, func(t *testing.T) {
+			disp, err := parseDisplay(test.disp)
+			if err != nil {
+				t.Fatalf("could not parse display format: %v", err)
+			}
+			got, ok := disp.format(reflect.ValueOf(test.v))
+			if !ok {
+				t.Fatalf("display format does not apply to %T", test.v)
+			}
+			if got != test.want {
+				t.Fatalf("invalid formatted value: got=%q, want=%q", got, test.want)
+			}
+		})
+	}
+
+	for _, str := range []string{"", "X4", "I", "I0", "F8", "F8.", "E10.3E", "A4.2", "I4x"} {
+		_, err := parseDisplay(str)
+		if err == nil {
+			t.Errorf("expected an error parsing %q", str)
+		}
+	}
+
+	disp, err := parseDisplay("I4")
+	if err != nil {
+		t.Fatalf("could not parse display format: %v", err)
+	}
+	if _, ok := disp.format(reflect.ValueOf("abc")); ok {
+		t.Fatalf("integer display format applied to a string")
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// promote stores src, a value read from the column named name, into dst,
+// a scalar of another type, following the promotion rules of Rows.Scan:
+//
+//   - any value may be stored into an empty interface,
+//   - integers may be stored into integers of any size and signedness,
+//     as long as the value fits, and into floating-point or complex numbers,
+//   - floating-point numbers may be stored into floating-point numbers of
+//     any size, as long as the value fits, and into complex numbers,
+//   - complex numbers may be stored into complex numbers of any size, as
+//     long as the value fits,
+//   - booleans and strings may be stored into booleans and strings
+//     (including types defined from them.)
+//
+// Other conversions, in particular the ones losing the fractional part of
+// a floating-point number, are rejected with an ErrTypeMismatch.
+func promote(name string, dst, src reflect.Value) error {
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		dst.Set(src)
+		return nil
+	}
+
+	overflow := false
+	switch src.Kind() {
+	case reflect.Bool:
+		if dst.Kind() != reflect.Bool {
+			return promoteMismatch(name, dst, src)
+		}
+		dst.SetBool(src.Bool())
+
+	case reflect.String:
+		if dst.Kind() != reflect.String {
+			return promoteMismatch(name, dst, src)
+		}
+		dst.SetString(src.String())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := src.Int()
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if overflow = dst.OverflowInt(v); !overflow {
+				dst.SetInt(v)
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if overflow = v < 0 || dst.OverflowUint(uint64(v)); !overflow {
+				dst.SetUint(uint64(v))
+			}
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(float64(v))
+		case reflect.Complex64, reflect.Complex128:
+			dst.SetComplex(complex(float64(v), 0))
+		default:
+			return promoteMismatch(name, dst, src)
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v := src.Uint()
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if overflow = int64(v) < 0 || dst.OverflowInt(int64(v)); !overflow {
+				dst.SetInt(int64(v))
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if overflow = dst.OverflowUint(v); !overflow {
+				dst.SetUint(v)
+			}
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(float64(v))
+		case reflect.Complex64, reflect.Complex128:
+			dst.SetComplex(complex(float64(v), 0))
+		default:
+			return promoteMismatch(name, dst, src)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		v := src.Float()
+		switch dst.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if overflow = dst.OverflowFloat(v); !overflow {
+				dst.SetFloat(v)
+			}
+		case reflect.Complex64, reflect.Complex128:
+			dst.SetComplex(complex(v, 0))
+		default:
+			return promoteMismatch(name, dst, src)
+		}
+
+	case reflect.Complex64, reflect.Complex128:
+		v := src.Complex()
+		switch dst.Kind() {
+		case reflect.Complex64, reflect.Complex128:
+			if overflow = dst.OverflowComplex(v); !overflow {
+				dst.SetComplex(v)
+			}
+		default:
+			return promoteMismatch(name, dst, src)
+		}
+
+	default:
+		return promoteMismatch(name, dst, src)
+	}
+
+	if overflow {
+		return fmt.Errorf("fitsio: value %v of column %q overflows %v", src, name, dst.Type())
+	}
+	return nil
+}
+
+func promoteMismatch(name string, dst, src reflect.Value) error {
+	return fmt.Errorf("fitsio: can not convert column %q: %w", name, &ErrTypeMismatch{
+		Want: src.Type(),
+		Got:  dst.Type(),
+	})
+}
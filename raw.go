@@ -0,0 +1,308 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CopyHDURaw copies the next HDU of the FITS stream src into dst.
+//
+// The header and data blocks are transferred verbatim, without decoding
+// the data, so memory usage does not depend on the size of the HDU.
+// CopyHDURaw returns io.EOF when src holds no more HDU.
+//
+// If checksum is true, the DATASUM and CHECKSUM keywords of the HDU are
+// (re)computed. This requires dst to implement io.WriteSeeker (the header is
+// then updated once the data has been written) or src to implement
+// io.ReadSeeker (the data is then read twice).
+func CopyHDURaw(dst io.Writer, src io.Reader, checksum bool) error {
+	hdr, cards, err := readRawHeader(src)
+	if err != nil {
+		return err
+	}
+
+	size, err := rawDataSize(cards)
+	if err != nil {
+		return err
+	}
+	size += int64(PadBlock(int(size % BlockSize)))
+
+	if !checksum {
+		_, err = dst.Write(hdr)
+		if err != nil {
+			return fmt.Errorf("fitsio: could not write header: %v", err)
+		}
+		return copyRawData(dst, src, size)
+	}
+
+	switch {
+	case isWriteSeeker(dst):
+		w := dst.(io.WriteSeeker)
+		beg, err := w.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return fmt.Errorf("fitsio: could not locate header: %v", err)
+		}
+		hdr, err = checksumHeader(hdr, 0)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(hdr)
+		if err != nil {
+			return fmt.Errorf("fitsio: could not write header: %v", err)
+		}
+
+		var sum onesSum
+		err = copyRawData(io.MultiWriter(w, &sum), src, size)
+		if err != nil {
+			return err
+		}
+		end, err := w.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return fmt.Errorf("fitsio: could not locate end of HDU: %v", err)
+		}
+
+		hdr, err = checksumHeader(hdr, sum.Sum32())
+		if err != nil {
+			return err
+		}
+		_, err = w.Seek(beg, io.SeekStart)
+		if err == nil {
+			_, err = w.Write(hdr)
+		}
+		if err == nil {
+			_, err = w.Seek(end, io.SeekStart)
+		}
+		if err != nil {
+			return fmt.Errorf("fitsio: could not update header: %v", err)
+		}
+		return nil
+
+	case isReadSeeker(src):
+		r := src.(io.ReadSeeker)
+		beg, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return fmt.Errorf("fitsio: could not locate data: %v", err)
+		}
+		var sum onesSum
+		err = copyRawData(&sum, r, size)
+		if err != nil {
+			return err
+		}
+		_, err = r.Seek(beg, io.SeekStart)
+		if err != nil {
+			return fmt.Errorf("fitsio: could not rewind data: %v", err)
+		}
+
+		hdr, err = checksumHeader(hdr, sum.Sum32())
+		if err != nil {
+			return err
+		}
+		_, err = dst.Write(hdr)
+		if err != nil {
+			return fmt.Errorf("fitsio: could not write header: %v", err)
+		}
+		return copyRawData(dst, r, size)
+	}
+
+	return fmt.Errorf("fitsio: checksum computation requires a seekable source or destination")
+}
+
+// isWriteSeeker returns whether w can seek.
+// Pipes and terminals (such as os.Stdout) implement io.Seeker but fail
+// when seeking.
+func isWriteSeeker(w io.Writer) bool {
+	ws, ok := w.(io.WriteSeeker)
+	if !ok {
+		return false
+	}
+	_, err := ws.Seek(0, io.SeekCurrent)
+	return err == nil
+}
+
+// isReadSeeker returns whether r can seek.
+func isReadSeeker(r io.Reader) bool {
+	rs, ok := r.(io.ReadSeeker)
+	if !ok {
+		return false
+	}
+	_, err := rs.Seek(0, io.SeekCurrent)
+	return err == nil
+}
+
+// copyRawData copies n bytes of data blocks from src to dst.
+func copyRawData(dst io.Writer, src io.Reader, n int64) error {
+	_, err := io.CopyN(dst, src, n)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("fitsio: could not copy data: %v", err)
+	}
+	return nil
+}
+
+// readRawHeader reads the header blocks of the next HDU of r.
+// It returns the raw header blocks and the decoded cards.
+// Cards which could not be parsed hold a RawCard value.
+// readRawHeader returns io.EOF if r holds no more HDU.
+func readRawHeader(r io.Reader) ([]byte, []Card, error) {
+	var (
+		raw   []byte
+		cards []Card
+		buf   = make([]byte, BlockSize)
+	)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if err != nil {
+			if err != io.EOF || len(raw) > 0 {
+				err = shortRead(err, ErrShortHeader, n, len(buf))
+			}
+			return nil, nil, err
+		}
+		raw = append(raw, buf...)
+
+		for i := 0; i < BlockSize; i += 80 {
+			line := buf[i : i+80]
+			card, err := parseHeaderLine(line)
+			if err != nil {
+				// the header is copied verbatim: keep the cards which
+				// could not be parsed.
+				card = &Card{Value: RawCard(line)}
+			}
+			if card.Name == "END" {
+				return raw, cards, nil
+			}
+			cards = append(cards, *card)
+		}
+	}
+}
+
+// rawDataSize returns the size in bytes (without padding) of the data of
+// an HDU described by cards.
+func rawDataSize(cards []Card) (int64, error) {
+	get := func(name string) *Card {
+		for i := range cards {
+			if cards[i].Name == name {
+				return &cards[i]
+			}
+		}
+		return nil
+	}
+	getInt := func(name string, def int64) (int64, error) {
+		card := get(name)
+		if card == nil {
+			if def < 0 {
+				return 0, fmt.Errorf("fitsio: missing '%s' key", name)
+			}
+			return def, nil
+		}
+		switch v := card.Value.(type) {
+		case int:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		}
+		return 0, fmt.Errorf("fitsio: invalid '%s' value (%v)", name, card.Value)
+	}
+
+	bitpix, err := getInt("BITPIX", -1)
+	if err != nil {
+		return 0, err
+	}
+	naxis, err := getInt("NAXIS", -1)
+	if err != nil {
+		return 0, err
+	}
+	if naxis == 0 {
+		return 0, nil
+	}
+
+	groups := false
+	if card := get("GROUPS"); card != nil {
+		groups, _ = card.Value.(bool)
+	}
+
+	nelmts := int64(1)
+	for i := int64(1); i <= naxis; i++ {
+		n, err := getInt(fmt.Sprintf("NAXIS%d", i), -1)
+		if err != nil {
+			return 0, err
+		}
+		if i == 1 && n == 0 && groups {
+			// random groups
+			continue
+		}
+		nelmts *= n
+	}
+
+	pcount, err := getInt("PCOUNT", 0)
+	if err != nil {
+		return 0, err
+	}
+	gcount, err := getInt("GCOUNT", 1)
+	if err != nil {
+		return 0, err
+	}
+
+	if bitpix < 0 {
+		bitpix = -bitpix
+	}
+	return bitpix / 8 * gcount * (pcount + nelmts), nil
+}
+
+// checksumHeader returns the raw header hdr, with its DATASUM and CHECKSUM
+// keywords set for a data unit whose checksum is datasum.
+func checksumHeader(hdr []byte, datasum uint32) ([]byte, error) {
+	lines := make([][]byte, 0, len(hdr)/80+2)
+loop:
+	for i := 0; i < len(hdr); i += 80 {
+		line := hdr[i : i+80]
+		switch strings.TrimSpace(string(line[:8])) {
+		case "CHECKSUM", "DATASUM":
+			continue
+		case "END":
+			break loop
+		}
+		lines = append(lines, line)
+	}
+	// offset of the CHECKSUM card.
+	pos := 80 * (len(lines) + 1)
+
+	for _, card := range []Card{
+		{
+			Name:    "DATASUM",
+			Value:   strconv.FormatUint(uint64(datasum), 10),
+			Comment: "data unit checksum",
+		},
+		{
+			Name:    "CHECKSUM",
+			Value:   "0000000000000000",
+			Comment: "HDU checksum",
+		},
+		{Name: "END"},
+	} {
+		line, err := makeHeaderLine(&card)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	out := bytes.Join(lines, nil)
+	out = append(out, bytes.Repeat([]byte(" "), PadBlock(len(out)))...)
+
+	var sum onesSum
+	_, _ = sum.Write(out)
+	enc := encodeChecksum(addOnes(sum.Sum32(), datasum))
+
+	i := bytes.IndexByte(out[pos:pos+80], '\'')
+	copy(out[pos+i+1:], enc)
+	return out, nil
+}
@@ -0,0 +1,160 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCopyHDURaw(t *testing.T) {
+	for _, fname := range []string{
+		"testdata/file001.fits",
+		"testdata/swp06542llg.fits",
+		"testdata/file-img2-bitpix-32.fits",
+	} {
+		t.Run(filepath.Base(fname), func(t *testing.T) {
+			raw, err := os.ReadFile(fname)
+			if err != nil {
+				t.Fatalf("could not read file: %v", err)
+			}
+
+			var out bytes.Buffer
+			src := bytes.NewReader(raw)
+			nhdus := 0
+			for {
+				err = CopyHDURaw(&out, src, false)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("could not copy HDU #%d: %v", nhdus, err)
+				}
+				nhdus++
+			}
+			if !bytes.Equal(out.Bytes(), raw) {
+				t.Fatalf("raw copy differs from input")
+			}
+
+			f, err := Open(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			defer f.Close()
+			if got, want := nhdus, len(f.HDUs()); got != want {
+				t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+			}
+
+			// checksums, with a seekable source.
+			out.Reset()
+			src = bytes.NewReader(raw)
+			for {
+				err = CopyHDURaw(&out, src, true)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("could not copy HDU with checksum: %v", err)
+				}
+			}
+			checkChecksums(t, out.Bytes(), nhdus)
+
+			// checksums, with a seekable destination.
+			tmp, err := os.Create(filepath.Join(t.TempDir(), "out.fits"))
+			if err != nil {
+				t.Fatalf("could not create output file: %v", err)
+			}
+			defer tmp.Close()
+			r := io.MultiReader(bytes.NewReader(raw)) // hide io.Seeker
+			for {
+				err = CopyHDURaw(tmp, r, true)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("could not copy HDU with checksum: %v", err)
+				}
+			}
+			err = tmp.Close()
+			if err != nil {
+				t.Fatalf("could not close output file: %v", err)
+			}
+			got, err := os.ReadFile(tmp.Name())
+			if err != nil {
+				t.Fatalf("could not read output file: %v", err)
+			}
+			if !bytes.Equal(got, out.Bytes()) {
+				t.Fatalf("seekable source and destination copies differ")
+			}
+
+			// unseekable source and destination.
+			err = CopyHDURaw(io.MultiWriter(&out), io.MultiReader(bytes.NewReader(raw)), true)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+// checkChecksums verifies the CHECKSUM and DATASUM keywords of
+// all the HDUs of the provided FITS stream.
+func checkChecksums(t *testing.T, raw []byte, nhdus int) {
+	t.Helper()
+
+	f, err := Open(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not decode checksummed file: %v", err)
+	}
+	defer f.Close()
+	if got, want := len(f.HDUs()), nhdus; got != want {
+		t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+	}
+
+	r := bytes.NewReader(raw)
+	for i := 0; i < nhdus; i++ {
+		hdr, cards, err := readRawHeader(r)
+		if err != nil {
+			t.Fatalf("could not read header #%d: %v", i, err)
+		}
+		size, err := rawDataSize(cards)
+		if err != nil {
+			t.Fatalf("could not compute data size #%d: %v", i, err)
+		}
+		data := make([]byte, AlignBlock(int(size)))
+		_, err = io.ReadFull(r, data)
+		if err != nil {
+			t.Fatalf("could not read data #%d: %v", i, err)
+		}
+
+		var dsum onesSum
+		_, _ = dsum.Write(data)
+		var datasum, chksum string
+		for _, card := range cards {
+			switch card.Name {
+			case "DATASUM":
+				datasum = card.Value.(string)
+			case "CHECKSUM":
+				chksum = card.Value.(string)
+			}
+		}
+		if got, want := strings.TrimSpace(datasum), strconv.FormatUint(uint64(dsum.Sum32()), 10); got != want {
+			t.Fatalf("HDU #%d: invalid DATASUM: got=%q, want=%q", i, got, want)
+		}
+		if len(chksum) != 16 {
+			t.Fatalf("HDU #%d: invalid CHECKSUM %q", i, chksum)
+		}
+
+		var hsum onesSum
+		_, _ = hsum.Write(hdr)
+		if got, want := addOnes(hsum.Sum32(), dsum.Sum32()), uint32(0xffffffff); got != want {
+			t.Fatalf("HDU #%d: invalid HDU checksum: got=0x%x, want=0x%x", i, got, want)
+		}
+	}
+}
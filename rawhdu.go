@@ -0,0 +1,123 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// rawHDU is a conforming extension of a type unknown to fitsio, such as
+// 'FOREIGN' or 'DUMP'.
+// Its data unit is not interpreted: it is kept verbatim, so that the HDU
+// can be skipped over and copied as is to other files.
+type rawHDU struct {
+	hdr  Header
+	data []byte
+}
+
+// Close closes this HDU, cleaning up cycles (if any) for garbage collection
+func (hdu *rawHDU) Close() error {
+	return nil
+}
+
+// Header returns the Header part of this HDU block.
+func (hdu *rawHDU) Header() *Header {
+	return &hdu.hdr
+}
+
+// Type returns the Type of this HDU
+func (hdu *rawHDU) Type() HDUType {
+	return UNKNOWN_HDU
+}
+
+// Name returns the value of the 'EXTNAME' Card.
+func (hdu *rawHDU) Name() string {
+	card := hdu.hdr.Get("EXTNAME")
+	if card == nil {
+		return ""
+	}
+	name, _ := card.Value.(string)
+	return name
+}
+
+// Version returns the value of the 'EXTVER' Card (or 1 if none or invalid)
+func (hdu *rawHDU) Version() int {
+	return hduVersion(&hdu.hdr)
+}
+
+// DataSize returns the size in bytes of the data unit of the extension,
+// as described by its header.
+func (hdu *rawHDU) DataSize() int64 {
+	size, err := rawDataSize(hdu.hdr.cards)
+	if err != nil {
+		return int64(len(hdu.data))
+	}
+	return size
+}
+
+// Data returns the bytes of the data unit of the extension, as Raw.
+func (hdu *rawHDU) Data() (Value, error) {
+	return hdu.data, nil
+}
+
+// Raw returns the bytes of the data unit of the extension, padding excluded.
+func (hdu *rawHDU) Raw() []byte {
+	return hdu.data
+}
+
+// xtension returns the value of the 'XTENSION' card of hdr.
+func xtension(hdr *Header) string {
+	card := hdr.Get("XTENSION")
+	if card == nil {
+		return ""
+	}
+	str, _ := card.Value.(string)
+	return strings.TrimSpace(str)
+}
+
+// loadRaw reads the data unit of a conforming extension of an unknown type.
+// Its size follows from the BITPIX, NAXISn, PCOUNT and GCOUNT cards.
+func (dec *streamDecoder) loadRaw(ctx context.Context, hdr *Header) (*rawHDU, error) {
+	size, err := rawDataSize(hdr.cards)
+	if err != nil {
+		return nil, err
+	}
+	err = dec.checkSize(int(size))
+	if err != nil {
+		return nil, err
+	}
+
+	hdu := &rawHDU{hdr: *hdr, data: make([]byte, 0)}
+	if size == 0 {
+		return hdu, nil
+	}
+	hdu.data, err = dec.readUnit(ctx, int(size), nil)
+	if err != nil {
+		return nil, err
+	}
+	return hdu, nil
+}
+
+// saveRaw writes the data unit of a conforming extension of an unknown type.
+func (enc *streamEncoder) saveRaw(ctx context.Context, hdu *rawHDU) error {
+	if size := hdu.DataSize(); size != int64(len(hdu.data)) {
+		return fmt.Errorf("fitsio: data unit size mismatch (header=%d bytes, data=%d bytes)", size, len(hdu.data))
+	}
+	n, err := writeFull(ctx, enc.w, hdu.data, 0, int64(len(hdu.data)), enc.cfg.progress)
+	if err != nil {
+		return err
+	}
+
+	padsz := PadBlock(n)
+	if padsz > 0 {
+		_, err = enc.w.Write(make([]byte, padsz))
+		if err != nil {
+			return fmt.Errorf("fitsio: error while padding data block: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUnknownExtension(t *testing.T) {
+	// a file with a primary HDU, a 'FOREIGN' extension and a binary table.
+	src := new(bytes.Buffer)
+	f, err := Create(src)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	err = f.Write(NewImage(8, nil))
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %v", err)
+	}
+
+	var hdr []byte
+	for _, card := range []Card{
+		{Name: "XTENSION", Value: "FOREIGN"},
+		{Name: "BITPIX", Value: 8},
+		{Name: "NAXIS", Value: 1},
+		{Name: "NAXIS1", Value: 10},
+		{Name: "PCOUNT", Value: 3},
+		{Name: "GCOUNT", Value: 1},
+		{Name: "EXTNAME", Value: "BLOB"},
+		{Name: "END"},
+	} {
+		line, err := makeHeaderLine(&card)
+		if err != nil {
+			t.Fatalf("could not format card %q: %v", card.Name, err)
+		}
+		hdr = append(hdr, line...)
+	}
+	hdr = append(hdr, bytes.Repeat([]byte(" "), PadBlock(len(hdr)))...)
+	payload := []byte("0123456789abc")
+	src.Write(hdr)
+	src.Write(payload)
+	src.Write(make([]byte, PadBlock(len(payload))))
+
+	tbl, err := NewTable("tbl", []Column{{Name: "x", Format: "D"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	x := 42.0
+	err = tbl.Write(&x)
+	if err != nil {
+		t.Fatalf("could not write row: %v", err)
+	}
+	err = f.Write(tbl)
+	if err != nil {
+		t.Fatalf("could not write table: %v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	check := func(f *File) {
+		t.Helper()
+		if got, want := f.NumHDUs(), 3; got != want {
+			t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+		}
+		hdu, err := f.LoadHDU(1)
+		if err != nil {
+			t.Fatalf("could not load extension: %v", err)
+		}
+		if got, want := hdu.Type(), UNKNOWN_HDU; got != want {
+			t.Fatalf("invalid HDU type: got=%v, want=%v", got, want)
+		}
+		if got, want := hdu.Name(), "BLOB"; got != want {
+			t.Fatalf("invalid HDU name: got=%q, want=%q", got, want)
+		}
+		if got, want := hdu.DataSize(), int64(len(payload)); got != want {
+			t.Fatalf("invalid data size: got=%d, want=%d", got, want)
+		}
+		raw := hdu.(interface{ Raw() []byte }).Raw()
+		if !bytes.Equal(raw, payload) {
+			t.Fatalf("invalid payload: got=%q, want=%q", raw, payload)
+		}
+		hdu, err = f.LoadHDU(2)
+		if err != nil {
+			t.Fatalf("could not load table: %v", err)
+		}
+		if _, ok := hdu.(*Table); !ok {
+			t.Fatalf("invalid HDU after the extension: %T", hdu)
+		}
+	}
+
+	f, err = Open(bytes.NewReader(src.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+	check(f)
+
+	ra, err := OpenReaderAt(bytes.NewReader(src.Bytes()), int64(src.Len()))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer ra.Close()
+	check(ra)
+
+	// the extension is copied verbatim.
+	dst := new(bytes.Buffer)
+	w, err := Create(dst)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for _, hdu := range f.HDUs() {
+		err = w.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write %v HDU: %v", hdu.Type(), err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+	offs := f.Offsets()[1]
+	ext := src.Bytes()[offs.Header:offs.End]
+	if got := dst.Bytes()[offs.Header:offs.End]; !bytes.Equal(got, ext) {
+		t.Fatalf("extension not copied verbatim")
+	}
+}
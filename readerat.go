@@ -0,0 +1,324 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// OpenReaderAt opens in read-only mode the FITS file of size bytes held by
+// ra, reading only the headers of its HDUs.
+//
+// The data unit of an HDU is read when the HDU is first retrieved, with
+// HDU, LoadHDU, Get, GetVersioned, GetAll or HDUs (which retrieves all the
+// HDUs.)
+// Header gives access to the header of an HDU without reading its data.
+// Reading a data unit issues a single ReadAt call for the whole HDU.
+//
+// OpenReaderAt is meant for files held by remote or object storage, such
+// as the files served over HTTP by package remote.
+// Objects held by S3 (or GCS) may be read with an adapter issuing ranged
+// GetObject requests:
+//
+//	type s3Object struct {
+//		client *s3.Client
+//		bucket string
+//		key    string
+//	}
+//
+//	func (o *s3Object) ReadAt(p []byte, off int64) (int, error) {
+//		out, err := o.client.GetObject(context.Background(), &s3.GetObjectInput{
+//			Bucket: aws.String(o.bucket),
+//			Key:    aws.String(o.key),
+//			Range:  aws.String(fmt.Sprintf("bytes=%d-%d", off, off+int64(len(p))-1)),
+//		})
+//		if err != nil {
+//			return 0, err
+//		}
+//		defer out.Body.Close()
+//		return io.ReadFull(out.Body, p)
+//	}
+//
+//	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: &bucket, Key: &key})
+//	// handle error
+//	f, err := fitsio.OpenReaderAt(&s3Object{client, bucket, key}, *head.ContentLength)
+//	// handle error
+//	tbl := f.Get("EVENTS").(*fitsio.Table)
+//
+// The file is decoded with the provided options, as with Open.
+func OpenReaderAt(ra io.ReaderAt, size int64, opts ...Option) (*File, error) {
+	type namer interface {
+		Name() string
+	}
+	name := ""
+	if ra, ok := ra.(namer); ok {
+		name = ra.Name()
+	}
+
+	f := &File{
+		name: name,
+		mode: ReadOnly,
+		ra:   ra,
+		opts: opts,
+	}
+
+	cfg := newConfig(opts)
+	for off := int64(0); off < size; {
+		dec := &streamDecoder{r: &countReader{r: io.NewSectionReader(ra, off, size-off)}, cfg: cfg}
+		hdr, primary, err := dec.decodeHeader(context.Background())
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("fitsio: could not decode HDU #%d: %w", len(f.hdus), err)
+		}
+
+		datasz, err := rawDataSize(hdr.cards)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not decode HDU #%d: %w", len(f.hdus), err)
+		}
+		if datasz < 0 {
+			return nil, fmt.Errorf("fitsio: could not decode HDU #%d: %w (size overflow)", len(f.hdus), ErrHDUTooLarge)
+		}
+		offs := HDUOffsets{
+			Header: off,
+			Data:   off + dec.r.n,
+		}
+		offs.End = offs.Data + datasz + int64(PadBlock(int(datasz%BlockSize)))
+		if offs.End > size {
+			return nil, &ErrTruncatedHDU{
+				HDU:    len(f.hdus),
+				Offset: offs.Data,
+				Want:   offs.End - offs.Data,
+				Got:    size - offs.Data,
+			}
+		}
+
+		var stub HDU
+		switch {
+		case primary:
+			stub = &primaryHDU{imageHDU: imageHDU{hdr: *hdr}}
+		case hdr.Type() == IMAGE_HDU:
+			stub = &imageHDU{hdr: *hdr}
+		case hdr.Type() == UNKNOWN_HDU:
+			stub = &rawHDU{hdr: *hdr}
+		default:
+			stub = &Table{hdr: *hdr}
+		}
+		f.hdus = append(f.hdus, nil)
+		f.stubs = append(f.stubs, stub)
+		f.offs = append(f.offs, offs)
+		off = offs.End
+	}
+
+	return f, nil
+}
+
+// NumHDUs returns the number of HDUs in the file.
+func (f *File) NumHDUs() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.hdus)
+}
+
+// Header returns the header of the i-th HDU.
+// For files opened with OpenReaderAt, Header does not read the data unit
+// of the HDU: until the HDU is loaded, the returned header is distinct
+// from the header of the HDU and should not be modified.
+func (f *File) Header(i int) *Header {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.hdus[i] == nil {
+		return f.stubs[i].Header()
+	}
+	return f.hdus[i].Header()
+}
+
+// LoadHDU returns the i-th HDU, reading its data unit if it was not
+// already read (for files opened with OpenReaderAt.)
+func (f *File) LoadHDU(i int) (HDU, error) {
+	f.mu.RLock()
+	hdu, n := f.hdus[i], len(f.hdus)
+	f.mu.RUnlock()
+	if hdu != nil || f.ra == nil {
+		return hdu, nil
+	}
+
+	f.lmu.Lock()
+	defer f.lmu.Unlock()
+
+	// the HDU may have been loaded while waiting for the lock.
+	f.mu.RLock()
+	hdu, offs := f.hdus[i], f.offs[i]
+	f.mu.RUnlock()
+	if hdu != nil {
+		return hdu, nil
+	}
+
+	hdu, err := f.decodeAt(context.Background(), offs)
+	if err != nil {
+		return nil, fmt.Errorf("fitsio: could not decode HDU #%d (of %d): %w", i, n, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.hdus == nil {
+		return nil, fmt.Errorf("fitsio: file closed")
+	}
+	f.hdus[i] = hdu
+	return hdu, nil
+}
+
+// decodeAt decodes the HDU located at offs in the io.ReaderAt of the file.
+func (f *File) decodeAt(ctx context.Context, offs HDUOffsets) (HDU, error) {
+	opts := f.opts
+	if newConfig(opts).lazyLarge {
+		// HDUs left aside by Open are read regardless of the limits.
+		opts = append(opts[:len(opts):len(opts)], WithMaxHDUSize(0), WithMaxTotalSize(0))
+	}
+	r := io.NewSectionReader(f.ra, offs.Header, offs.End-offs.Header)
+	return decodeHDU(ctx, NewDecoder(r, opts...))
+}
+
+// readSeekerAt is implemented by the inputs of Open whose HDUs may be
+// decoded independently.
+type readSeekerAt interface {
+	io.ReaderAt
+	io.Seeker
+}
+
+// openSeekable opens the file held by r, from its current offset, as
+// OpenReaderAt does, and decodes the data units of its HDUs with
+// cfg.workers goroutines unless cfg.lazy is set.
+// openSeekable returns a nil File and a nil error for compressed inputs.
+func openSeekable(ctx context.Context, r readSeekerAt, opts []Option, cfg config) (*File, error) {
+	beg, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, nil
+	}
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, nil
+	}
+	magic := make([]byte, 3)
+	n, _ := r.ReadAt(magic, beg)
+	if bytes.HasPrefix(magic[:n], gzipMagic) || bytes.HasPrefix(magic[:n], bzip2Magic) {
+		_, err = r.Seek(beg, io.SeekStart)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not rewind input: %w", err)
+		}
+		return nil, nil
+	}
+
+	f, err := OpenReaderAt(io.NewSectionReader(r, beg, end-beg), end-beg, opts...)
+	if err != nil || cfg.lazy {
+		return f, err
+	}
+	idx, err := f.eager(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = f.loadAll(ctx, idx, cfg.workers)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// eager returns the indices of the HDUs of a file opened with OpenReaderAt
+// whose data units fit in the limits set by WithMaxHDUSize and
+// WithMaxTotalSize.
+// The other HDUs are left to be read on demand if cfg.lazyLarge is set,
+// and make eager fail otherwise.
+func (f *File) eager(cfg config) ([]int, error) {
+	var (
+		idx   = make([]int, 0, len(f.stubs))
+		total int64
+	)
+	for i, stub := range f.stubs {
+		size, err := rawDataSize(stub.Header().cards)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not decode HDU #%d: %w", i, err)
+		}
+		switch {
+		case cfg.maxHDUSize > 0 && size > cfg.maxHDUSize:
+			err = fmt.Errorf("%w (size=%d bytes, max=%d bytes)", ErrHDUTooLarge, size, cfg.maxHDUSize)
+		case cfg.maxTotal > 0 && total+size > cfg.maxTotal:
+			err = fmt.Errorf("%w (total size=%d bytes, max=%d bytes)", ErrHDUTooLarge, total+size, cfg.maxTotal)
+		}
+		if err != nil {
+			if cfg.lazyLarge {
+				continue
+			}
+			return nil, fmt.Errorf("fitsio: could not decode HDU #%d: %w", i, err)
+		}
+		total += size
+		idx = append(idx, i)
+	}
+	return idx, nil
+}
+
+// loadAll decodes the data units of the HDUs of indices idx of a file
+// opened with OpenReaderAt, with n goroutines.
+func (f *File) loadAll(ctx context.Context, idx []int, n int) error {
+	var (
+		hdus = make([]HDU, len(f.offs))
+		errs = make([]error, len(f.offs))
+		next = make(chan int)
+		wg   sync.WaitGroup
+	)
+	for range max(1, min(n, len(idx))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				hdus[i], errs[i] = f.decodeAt(ctx, f.offs[i])
+			}
+		}()
+	}
+	for _, i := range idx {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("fitsio: could not decode HDU #%d: %w", i, err)
+		}
+	}
+	f.hdus = hdus
+	if len(idx) == len(hdus) {
+		f.stubs = nil
+		f.ra = nil
+	}
+	return nil
+}
+
+// hdu returns the i-th HDU, or nil if it could not be loaded.
+func (f *File) hdu(i int) HDU {
+	hdu, err := f.LoadHDU(i)
+	if err != nil {
+		return nil
+	}
+	return hdu
+}
+
+// peek returns the i-th HDU, or its header-only stand-in if the HDU was
+// not loaded yet, to inspect its name and version.
+// f.mu must be held.
+func (f *File) peek(i int) HDU {
+	if f.hdus[i] == nil {
+		return f.stubs[i]
+	}
+	return f.hdus[i]
+}
@@ -0,0 +1,301 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RebinMethod describes how the pixels of a bin are combined by RebinImage.
+type RebinMethod int
+
+const (
+	SumRebin  RebinMethod = iota // sum of the pixels of a bin
+	MeanRebin                    // mean of the pixels of a bin
+)
+
+// Interpolation describes how pixel values are interpolated by ResampleImage.
+type Interpolation int
+
+const (
+	NearestInterpolation Interpolation = iota // value of the nearest pixel
+	LinearInterpolation                       // (multi-)linear interpolation between the nearest pixels
+)
+
+// RebinImage returns a new image whose pixels combine blocks of
+// factors[i] pixels of img along its i-th axis.
+// Axes without a factor are not rebinned. Trailing pixels which do not
+// fill a complete block are dropped.
+//
+// Blank and NaN pixels are ignored: blocks with no valid pixel are blank.
+// Sums of integer images are 32-bit (for 8 and 16 bits pixels) or
+// 64-bit integer images, other rebinned images are floating point images.
+//
+// The CRPIXn, CDELTn and CDi_j WCS keywords of img are updated to describe
+// the new pixel grid.
+func RebinImage(img Image, factors []int, method RebinMethod) (Image, error) {
+	if img == nil {
+		return nil, fmt.Errorf("fitsio: nil image")
+	}
+	switch method {
+	case SumRebin, MeanRebin:
+	default:
+		return nil, fmt.Errorf("fitsio: invalid rebinning method %d", method)
+	}
+	axes := img.Header().Axes()
+	if len(factors) > len(axes) {
+		return nil, fmt.Errorf(
+			"fitsio: too many rebinning factors (got=%d, want<=%d)",
+			len(factors), len(axes),
+		)
+	}
+	pix, err := newPixels(img.Header(), img.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		fs     = make([]int, len(axes))
+		scales = make([]float64, len(axes))
+		naxes  = make([]int, len(axes))
+	)
+	for i, dim := range axes {
+		fs[i] = 1
+		if i < len(factors) {
+			fs[i] = factors[i]
+		}
+		if fs[i] < 1 {
+			return nil, fmt.Errorf("fitsio: invalid rebinning factor %d for axis %d", fs[i], i+1)
+		}
+		scales[i] = float64(fs[i])
+		naxes[i] = dim / fs[i]
+	}
+
+	var (
+		nout  = npixels(naxes)
+		sums  = make([]float64, nout)
+		ns    = make([]int, nout)
+		coord = make([]int, len(axes))
+	)
+	if nout > 0 {
+		for i := 0; i < pix.n; i++ {
+			// index of the output pixel holding the i-th pixel.
+			j, stride, ok := 0, 1, true
+			for k, c := range coord {
+				c /= fs[k]
+				if c >= naxes[k] {
+					ok = false
+				}
+				j += c * stride
+				stride *= naxes[k]
+			}
+			if v, valid := pix.at(i); ok && valid {
+				sums[j] += v
+				ns[j]++
+			}
+			nextCoord(coord, axes)
+		}
+	}
+	for j, n := range ns {
+		switch {
+		case n == 0:
+			sums[j] = math.NaN()
+		case method == MeanRebin:
+			sums[j] /= float64(n)
+		}
+	}
+
+	kind := pix.kind()
+	bitpix := promoteBitpix(DivOp, kind, kind)
+	if method == SumRebin && kind > 0 {
+		bitpix = max(kind, 32)
+	}
+	return newResampled(img.Header(), bitpix, naxes, scales, sums)
+}
+
+// ResampleImage returns a new image with the provided axes, holding the
+// pixel values of img interpolated on the new pixel grid.
+// Both grids span the same extent, the centers of the corner pixels
+// being aligned.
+//
+// Nearest neighbour interpolation preserves the BITPIX of img, while
+// linear interpolation yields floating point images. Blank and NaN pixels
+// propagate to the interpolated pixels depending on them.
+//
+// The CRPIXn, CDELTn and CDi_j WCS keywords of img are updated to describe
+// the new pixel grid.
+func ResampleImage(img Image, axes []int, interp Interpolation) (Image, error) {
+	if img == nil {
+		return nil, fmt.Errorf("fitsio: nil image")
+	}
+	switch interp {
+	case NearestInterpolation, LinearInterpolation:
+	default:
+		return nil, fmt.Errorf("fitsio: invalid interpolation %d", interp)
+	}
+	old := img.Header().Axes()
+	if len(axes) != len(old) {
+		return nil, fmt.Errorf(
+			"fitsio: invalid number of axes (got=%d, want=%d)",
+			len(axes), len(old),
+		)
+	}
+	scales := make([]float64, len(axes))
+	for i, dim := range axes {
+		if dim < 1 || old[i] < 1 {
+			return nil, fmt.Errorf("fitsio: invalid size %d for axis %d", dim, i+1)
+		}
+		scales[i] = float64(old[i]) / float64(dim)
+	}
+	pix, err := newPixels(img.Header(), img.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		vs     = make([]float64, npixels(axes))
+		coord  = make([]int, len(axes))
+		lo     = make([]int, len(axes))
+		fracs  = make([]float64, len(axes))
+		ncorns = 1 << len(axes)
+	)
+	for i := range vs {
+		for k, c := range coord {
+			// position of the center of the output pixel, in the
+			// 0-based pixel coordinates of the input image.
+			p := (float64(c)+0.5)*scales[k] - 0.5
+			switch interp {
+			case NearestInterpolation:
+				lo[k] = min(max(int(math.Round(p)), 0), old[k]-1)
+				fracs[k] = 0
+			case LinearInterpolation:
+				p = min(max(p, 0), float64(old[k]-1))
+				lo[k] = min(int(p), max(old[k]-2, 0))
+				fracs[k] = p - float64(lo[k])
+			}
+		}
+
+		var v float64
+		for corner := 0; corner < ncorns; corner++ {
+			w, j, stride := 1.0, 0, 1
+			for k := range coord {
+				c := lo[k]
+				if corner&(1<<k) != 0 {
+					w *= fracs[k]
+					c++
+				} else {
+					w *= 1 - fracs[k]
+				}
+				if w == 0 {
+					break
+				}
+				j += c * stride
+				stride *= old[k]
+			}
+			if w == 0 {
+				continue
+			}
+			x, _ := pix.at(j)
+			v += w * x
+		}
+		vs[i] = v
+		nextCoord(coord, axes)
+	}
+
+	kind := pix.kind()
+	bitpix := kind
+	if interp == LinearInterpolation {
+		bitpix = promoteBitpix(DivOp, kind, kind)
+	}
+	return newResampled(img.Header(), bitpix, axes, scales, vs)
+}
+
+// nextCoord increments the coordinates coord of a pixel in an image with
+// the provided axes, the first axis varying fastest.
+func nextCoord(coord, axes []int) {
+	for k := range coord {
+		coord[k]++
+		if coord[k] < axes[k] {
+			return
+		}
+		coord[k] = 0
+	}
+}
+
+// npixels returns the number of pixels of an image with the provided axes.
+func npixels(axes []int) int {
+	n := 1
+	for _, dim := range axes {
+		n *= dim
+	}
+	return n
+}
+
+// newResampled creates an image with the keywords of hdr, holding the vs
+// physical values on a pixel grid scaled by scales, and updates its WCS
+// keywords accordingly.
+func newResampled(hdr *Header, bitpix int, axes []int, scales []float64, vs []float64) (Image, error) {
+	img, err := newImageOpResult(hdr, bitpix, axes, vs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := img.Header()
+	for i := range out.cards {
+		card := &out.cards[i]
+		switch {
+		case strings.HasPrefix(card.Name, "CRPIX"):
+			n, ok := wcsAxis(card.Name[len("CRPIX"):], len(scales))
+			if !ok {
+				continue
+			}
+			crpix, err := cardFloat(card)
+			if err != nil {
+				return nil, err
+			}
+			card.Value = (crpix-0.5)/scales[n] + 0.5
+		case strings.HasPrefix(card.Name, "CDELT"):
+			n, ok := wcsAxis(card.Name[len("CDELT"):], len(scales))
+			if !ok {
+				continue
+			}
+			cdelt, err := cardFloat(card)
+			if err != nil {
+				return nil, err
+			}
+			card.Value = cdelt * scales[n]
+		case strings.HasPrefix(card.Name, "CD") && strings.Contains(card.Name, "_"):
+			// CDi_j: j is the pixel axis.
+			_, j, _ := strings.Cut(card.Name, "_")
+			n, ok := wcsAxis(j, len(scales))
+			if !ok {
+				continue
+			}
+			cd, err := cardFloat(card)
+			if err != nil {
+				return nil, err
+			}
+			card.Value = cd * scales[n]
+		}
+	}
+	return img, nil
+}
+
+// wcsAxis parses the 1-based axis number suffix of a WCS keyword, with
+// an optional alternate WCS letter, and returns the corresponding 0-based
+// axis index.
+func wcsAxis(suffix string, naxis int) (int, bool) {
+	if n := len(suffix); n > 1 && 'A' <= suffix[n-1] && suffix[n-1] <= 'Z' {
+		suffix = suffix[:n-1]
+	}
+	n, err := strconv.Atoi(suffix)
+	if err != nil || n < 1 || n > naxis {
+		return 0, false
+	}
+	return n - 1, true
+}
@@ -0,0 +1,155 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRebinImage(t *testing.T) {
+	img := NewImage(16, []int{5, 2})
+	err := img.Header().Append(
+		Card{Name: "BLANK", Value: -1},
+		Card{Name: "CRPIX1", Value: 1.0},
+		Card{Name: "CRPIX2", Value: 2.5},
+		Card{Name: "CDELT1", Value: 0.5},
+		Card{Name: "CD1_2", Value: 0.1},
+		Card{Name: "CRPIX3", Value: 7.0},
+	)
+	if err != nil {
+		t.Fatalf("could not append cards: %v", err)
+	}
+	err = img.Write([]int16{
+		1, 2, 3, -1, 100,
+		5, 6, -1, -1, 100,
+	})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+
+	for _, test := range []struct {
+		name   string
+		method RebinMethod
+		bitpix int
+		want   []float64
+	}{
+		{"sum", SumRebin, 32, []float64{14, 3}},
+		{"mean", MeanRebin, -32, []float64{3.5, 3}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := RebinImage(img, []int{2, 2}, test.method)
+			if err != nil {
+				t.Fatalf("could not rebin image: %v", err)
+			}
+			if got, want := out.Header().Axes(), []int{2, 1}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid axes: got=%v, want=%v", got, want)
+			}
+			if got, want := out.Header().Bitpix(), test.bitpix; got != want {
+				t.Fatalf("invalid BITPIX: got=%d, want=%d", got, want)
+			}
+			got, err := out.Scaled()
+			if err != nil {
+				t.Fatalf("could not scale pixels: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("invalid pixels: got=%v, want=%v", got, test.want)
+			}
+			for _, card := range []Card{
+				{Name: "CRPIX1", Value: 0.75},
+				{Name: "CRPIX2", Value: 1.5},
+				{Name: "CDELT1", Value: 1.0},
+				{Name: "CD1_2", Value: 0.2},
+				{Name: "CRPIX3", Value: 7.0},
+			} {
+				if got := out.Header().Get(card.Name); got == nil || got.Value != card.Value {
+					t.Fatalf("invalid %s card: got=%v, want=%v", card.Name, got, card.Value)
+				}
+			}
+		})
+	}
+
+	for _, factors := range [][]int{{0}, {1, 1, 1}} {
+		_, err := RebinImage(img, factors, SumRebin)
+		if err == nil {
+			t.Fatalf("expected an error for factors %v", factors)
+		}
+	}
+}
+
+func TestResampleImage(t *testing.T) {
+	img := NewImage(16, []int{2, 2})
+	err := img.Header().Append(
+		Card{Name: "CRPIX1", Value: 1.0},
+		Card{Name: "CDELT1", Value: 2.0},
+	)
+	if err != nil {
+		t.Fatalf("could not append cards: %v", err)
+	}
+	err = img.Write([]int16{0, 1, 2, 3})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+
+	for _, test := range []struct {
+		name   string
+		interp Interpolation
+		bitpix int
+		want   []float64
+	}{
+		{
+			name:   "nearest",
+			interp: NearestInterpolation,
+			bitpix: 16,
+			want: []float64{
+				0, 0, 1, 1,
+				0, 0, 1, 1,
+				2, 2, 3, 3,
+				2, 2, 3, 3,
+			},
+		},
+		{
+			name:   "linear",
+			interp: LinearInterpolation,
+			bitpix: -32,
+			want: []float64{
+				0, 0.25, 0.75, 1,
+				0.5, 0.75, 1.25, 1.5,
+				1.5, 1.75, 2.25, 2.5,
+				2, 2.25, 2.75, 3,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := ResampleImage(img, []int{4, 4}, test.interp)
+			if err != nil {
+				t.Fatalf("could not resample image: %v", err)
+			}
+			if got, want := out.Header().Bitpix(), test.bitpix; got != want {
+				t.Fatalf("invalid BITPIX: got=%d, want=%d", got, want)
+			}
+			got, err := out.Scaled()
+			if err != nil {
+				t.Fatalf("could not scale pixels: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("invalid pixels:\ngot= %v\nwant=%v", got, test.want)
+			}
+			if got := out.Header().Get("CRPIX1"); got == nil || got.Value != 1.5 {
+				t.Fatalf("invalid CRPIX1 card: %v", got)
+			}
+			if got := out.Header().Get("CDELT1"); got == nil || got.Value != 1.0 {
+				t.Fatalf("invalid CDELT1 card: %v", got)
+			}
+		})
+	}
+
+	for _, axes := range [][]int{{4}, {4, 0}} {
+		_, err := ResampleImage(img, axes, LinearInterpolation)
+		if err == nil {
+			t.Fatalf("expected an error for axes %v", axes)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ShapeKind is the kind of a region shape.
+type ShapeKind int
+
+const (
+	CircleShape  ShapeKind = iota // circle(x, y, r)
+	BoxShape                      // box(x, y, width, height [, angle])
+	EllipseShape                  // ellipse(x, y, rx, ry [, angle])
+	PolygonShape                  // polygon(x1, y1, x2, y2, x3, y3, ...)
+)
+
+func (k ShapeKind) String() string {
+	switch k {
+	case CircleShape:
+		return "circle"
+	case BoxShape:
+		return "box"
+	case EllipseShape:
+		return "ellipse"
+	case PolygonShape:
+		return "polygon"
+	}
+	return fmt.Sprintf("ShapeKind(%d)", int(k))
+}
+
+// CoordSys is the coordinate system of a region shape.
+type CoordSys int
+
+const (
+	ImageCoords CoordSys = iota // pixel coordinates
+	FK5Coords                   // equatorial (RA, Dec) coordinates, in degrees
+)
+
+func (cs CoordSys) String() string {
+	switch cs {
+	case ImageCoords:
+		return "image"
+	case FK5Coords:
+		return "fk5"
+	}
+	return fmt.Sprintf("CoordSys(%d)", int(cs))
+}
+
+// Shape is a geometrical shape of a region.
+//
+// Params holds the parameters of the shape, in the order of the DS9 region
+// file format.
+// For FK5Coords shapes, positions, sizes and radii are expressed in degrees.
+// Angles are always expressed in degrees.
+type Shape struct {
+	Kind    ShapeKind
+	Coords  CoordSys
+	Params  []float64
+	Exclude bool // whether the shape excludes points from the region
+}
+
+// Region is a set of shapes, as described by a DS9 region file.
+//
+// A point belongs to the region if it is inside any of the included shapes
+// (or if the region has no included shape) and outside all the excluded
+// shapes.
+type Region struct {
+	Shapes []Shape
+}
+
+// ParseRegion parses a DS9 region file.
+//
+// The circle, box, ellipse and polygon shapes are supported, expressed in
+// image, physical or fk5 (icrs, j2000) coordinates.
+func ParseRegion(r io.Reader) (Region, error) {
+	var (
+		reg   Region
+		sys   = ImageCoords
+		scan  = bufio.NewScanner(r)
+		iline = 0
+	)
+	for scan.Scan() {
+		iline++
+		line := scan.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		for _, stmt := range strings.Split(line, ";") {
+			stmt = strings.TrimSpace(stmt)
+			if stmt == "" {
+				continue
+			}
+			name := strings.ToLower(stmt)
+			if i := strings.IndexAny(name, "( \t"); i >= 0 {
+				name = name[:i]
+			}
+			switch name {
+			case "global":
+				continue
+			case "image", "physical":
+				sys = ImageCoords
+				continue
+			case "fk5", "icrs", "j2000":
+				sys = FK5Coords
+				continue
+			case "fk4", "b1950", "galactic", "ecliptic", "linear", "amplifier", "detector", "wcs":
+				return reg, fmt.Errorf("fitsio: line %d: unsupported region coordinate system %q", iline, name)
+			}
+
+			shape, err := parseShape(stmt, sys)
+			if err != nil {
+				return reg, fmt.Errorf("fitsio: line %d: %v", iline, err)
+			}
+			reg.Shapes = append(reg.Shapes, shape)
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return reg, fmt.Errorf("fitsio: could not read region: %v", err)
+	}
+	return reg, nil
+}
+
+// parseShape parses a single shape statement, such as "-circle(1,2,3)".
+func parseShape(stmt string, sys CoordSys) (Shape, error) {
+	shape := Shape{Coords: sys}
+	switch stmt[0] {
+	case '-':
+		shape.Exclude = true
+		stmt = stmt[1:]
+	case '+':
+		stmt = stmt[1:]
+	}
+	if strings.TrimSpace(stmt) == "" {
+		return shape, fmt.Errorf("missing region shape")
+	}
+
+	var (
+		name string
+		args []string
+	)
+	switch beg := strings.Index(stmt, "("); {
+	case beg >= 0:
+		end := strings.LastIndex(stmt, ")")
+		if end < beg {
+			return shape, fmt.Errorf("invalid region shape %q", stmt)
+		}
+		name = stmt[:beg]
+		args = strings.FieldsFunc(stmt[beg+1:end], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+	default:
+		toks := strings.Fields(stmt)
+		name, args = toks[0], toks[1:]
+	}
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "circle":
+		shape.Kind = CircleShape
+		if len(args) != 3 {
+			return shape, fmt.Errorf("invalid number of circle parameters (got=%d, want=3)", len(args))
+		}
+	case "box":
+		shape.Kind = BoxShape
+		if len(args) != 4 && len(args) != 5 {
+			return shape, fmt.Errorf("invalid number of box parameters (got=%d, want=4 or 5)", len(args))
+		}
+	case "ellipse":
+		shape.Kind = EllipseShape
+		if len(args) != 4 && len(args) != 5 {
+			return shape, fmt.Errorf("invalid number of ellipse parameters (got=%d, want=4 or 5)", len(args))
+		}
+	case "polygon":
+		shape.Kind = PolygonShape
+		if len(args) < 6 || len(args)%2 != 0 {
+			return shape, fmt.Errorf("invalid number of polygon parameters (got=%d)", len(args))
+		}
+	default:
+		return shape, fmt.Errorf("unsupported region shape %q", name)
+	}
+
+	shape.Params = make([]float64, len(args))
+	for i, arg := range args {
+		var (
+			v   float64
+			err error
+		)
+		switch {
+		case shape.Kind == PolygonShape || i < 2:
+			v, err = parseRegionCoord(arg, sys, i%2 == 0)
+		case i == 4:
+			v, err = strconv.ParseFloat(arg, 64)
+		default:
+			v, err = parseRegionSize(arg, sys)
+		}
+		if err != nil {
+			return shape, fmt.Errorf("invalid %s parameter %q: %v", name, arg, err)
+		}
+		shape.Params[i] = v
+	}
+	return shape, nil
+}
+
+// parseRegionCoord parses a position along the x (or RA) axis, or the
+// y (or Dec) axis.
+// Sexagesimal FK5 values are interpreted as hours for RA and degrees for Dec.
+func parseRegionCoord(arg string, sys CoordSys, isX bool) (float64, error) {
+	if sys != FK5Coords || !strings.Contains(arg, ":") {
+		arg = strings.TrimSuffix(arg, "d")
+		return strconv.ParseFloat(arg, 64)
+	}
+
+	sign := 1.0
+	switch arg[0] {
+	case '-':
+		sign = -1
+		arg = arg[1:]
+	case '+':
+		arg = arg[1:]
+	}
+	toks := strings.Split(arg, ":")
+	if len(toks) != 3 {
+		return 0, fmt.Errorf("invalid sexagesimal value")
+	}
+	v := 0.0
+	for i, tok := range toks {
+		x, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return 0, err
+		}
+		v += x / math.Pow(60, float64(i))
+	}
+	if isX {
+		v *= 15
+	}
+	return sign * v, nil
+}
+
+// parseRegionSize parses a size or a radius.
+// FK5 sizes may be suffixed by " (arcsec), ' (arcmin) or d (degrees),
+// and default to degrees.
+func parseRegionSize(arg string, sys CoordSys) (float64, error) {
+	scale := 1.0
+	if sys == FK5Coords {
+		switch {
+		case strings.HasSuffix(arg, `"`):
+			scale = 1.0 / 3600
+		case strings.HasSuffix(arg, "'"):
+			scale = 1.0 / 60
+		}
+		arg = strings.TrimRight(arg, `"'d`)
+	}
+	v, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v * scale, nil
+}
+
+// contains returns whether the point (x, y) belongs to the region.
+// All the shapes of the region must be expressed in the coordinate system
+// of the point.
+func (reg Region) contains(x, y float64) bool {
+	in := true
+	for _, shape := range reg.Shapes {
+		if !shape.Exclude {
+			in = false
+			break
+		}
+	}
+	for _, shape := range reg.Shapes {
+		switch {
+		case shape.Exclude:
+			if shape.contains(x, y) {
+				return false
+			}
+		case !in:
+			in = shape.contains(x, y)
+		}
+	}
+	return in
+}
+
+// contains returns whether the point (x, y) is inside the shape.
+func (shape Shape) contains(x, y float64) bool {
+	p := shape.Params
+	switch shape.Kind {
+	case CircleShape:
+		dx, dy := x-p[0], y-p[1]
+		return dx*dx+dy*dy <= p[2]*p[2]
+	case BoxShape, EllipseShape:
+		dx, dy := x-p[0], y-p[1]
+		if len(p) == 5 && p[4] != 0 {
+			sin, cos := math.Sincos(-p[4] * math.Pi / 180)
+			dx, dy = dx*cos-dy*sin, dx*sin+dy*cos
+		}
+		if shape.Kind == BoxShape {
+			return math.Abs(dx) <= 0.5*p[2] && math.Abs(dy) <= 0.5*p[3]
+		}
+		if p[2] == 0 || p[3] == 0 {
+			return false
+		}
+		dx /= p[2]
+		dy /= p[3]
+		return dx*dx+dy*dy <= 1
+	case PolygonShape:
+		// ray casting.
+		in := false
+		n := len(p) / 2
+		for i, j := 0, n-1; i < n; j, i = i, i+1 {
+			xi, yi := p[2*i], p[2*i+1]
+			xj, yj := p[2*j], p[2*j+1]
+			if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+				in = !in
+			}
+		}
+		return in
+	}
+	return false
+}
+
+// toPixels returns the shape expressed in pixel coordinates, using w to
+// convert sky coordinates.
+func (shape Shape) toPixels(w *tanWCS) Shape {
+	if shape.Coords == ImageCoords {
+		return shape
+	}
+
+	p := shape.Params
+	out := Shape{
+		Kind:    shape.Kind,
+		Coords:  ImageCoords,
+		Params:  make([]float64, len(p)),
+		Exclude: shape.Exclude,
+	}
+	switch shape.Kind {
+	case PolygonShape:
+		for i := 0; i < len(p); i += 2 {
+			out.Params[i], out.Params[i+1] = w.toPixel(p[i], p[i+1])
+		}
+	default:
+		out.Params[0], out.Params[1] = w.toPixel(p[0], p[1])
+		for i := 2; i < len(p); i++ {
+			switch i {
+			case 2:
+				out.Params[i] = p[i] / math.Abs(w.cdelt[0])
+			case 3:
+				out.Params[i] = p[i] / math.Abs(w.cdelt[1])
+			default:
+				out.Params[i] = p[i]
+			}
+		}
+	}
+	return out
+}
+
+// tanWCS is a gnomonic (TAN) world coordinate system,
+// mapping pixel coordinates to (RA, Dec) coordinates.
+type tanWCS struct {
+	crpix [2]float64
+	crval [2]float64 // in degrees
+	cdelt [2]float64 // in degrees per pixel
+}
+
+// newColumnWCS returns the world coordinate system associated with the
+// pair of columns ix and iy of t, from their TCTYPn, TCRPXn, TCRVLn and
+// TCDLTn keywords.
+func newColumnWCS(t *Table, ix, iy int) (*tanWCS, error) {
+	var w tanWCS
+	for i, icol := range []int{ix, iy} {
+		n := icol + 1
+		name := t.cols[icol].Name
+		if card := t.hdr.Get(fmt.Sprintf("TCTYP%d", n)); card != nil {
+			ctype, _ := card.Value.(string)
+			if !strings.HasSuffix(strings.TrimSpace(ctype), "-TAN") {
+				return nil, fmt.Errorf("fitsio: unsupported projection %q for column %q", ctype, name)
+			}
+		}
+		for _, v := range []struct {
+			key string
+			ptr *float64
+		}{
+			{"TCRPX", &w.crpix[i]},
+			{"TCRVL", &w.crval[i]},
+			{"TCDLT", &w.cdelt[i]},
+		} {
+			card := t.hdr.Get(fmt.Sprintf("%s%d", v.key, n))
+			if card == nil {
+				return nil, fmt.Errorf("fitsio: missing %s%d keyword for column %q", v.key, n, name)
+			}
+			f, err := cardFloat(card)
+			if err != nil {
+				return nil, err
+			}
+			*v.ptr = f
+		}
+		if w.cdelt[i] == 0 {
+			return nil, fmt.Errorf("fitsio: invalid TCDLT%d value for column %q", n, name)
+		}
+	}
+	return &w, nil
+}
+
+// toPixel converts the (ra, dec) sky position (in degrees) to pixel
+// coordinates.
+func (w *tanWCS) toPixel(ra, dec float64) (x, y float64) {
+	const deg = math.Pi / 180
+	ra0, dec0 := w.crval[0]*deg, w.crval[1]*deg
+	ra, dec = ra*deg, dec*deg
+
+	sind, cosd := math.Sincos(dec)
+	sind0, cosd0 := math.Sincos(dec0)
+	sina, cosa := math.Sincos(ra - ra0)
+
+	cosc := sind0*sind + cosd0*cosd*cosa
+	xi := cosd * sina / cosc / deg
+	eta := (cosd0*sind - sind0*cosd*cosa) / cosc / deg
+
+	return w.crpix[0] + xi/w.cdelt[0], w.crpix[1] + eta/w.cdelt[1]
+}
+
+// FilterByRegion returns a new table holding the rows of t whose position,
+// read from the xcol and ycol columns, belongs to the region reg.
+//
+// Shapes expressed in sky coordinates are converted to the coordinates of
+// the columns, using the TCTYPn, TCRPXn, TCRVLn and TCDLTn keywords of
+// these columns. Only the TAN projection is supported.
+func FilterByRegion(t *Table, xcol, ycol string, reg Region) (*Table, error) {
+	if t == nil {
+		return nil, fmt.Errorf("fitsio: nil table")
+	}
+
+	ix, err := floatColumnIndex(t, xcol)
+	if err != nil {
+		return nil, err
+	}
+	iy, err := floatColumnIndex(t, ycol)
+	if err != nil {
+		return nil, err
+	}
+
+	var w *tanWCS
+	shapes := make([]Shape, len(reg.Shapes))
+	for i, shape := range reg.Shapes {
+		if shape.Coords != ImageCoords && w == nil {
+			w, err = newColumnWCS(t, ix, iy)
+			if err != nil {
+				return nil, err
+			}
+		}
+		shapes[i] = shape.toPixels(w)
+	}
+	reg = Region{Shapes: shapes}
+
+	xs, err := readFloatColumn(t, ix)
+	if err != nil {
+		return nil, err
+	}
+	ys, err := readFloatColumn(t, iy)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make([]bool, len(xs))
+	for i := range keep {
+		keep[i] = reg.contains(xs[i], ys[i])
+	}
+
+	return selectRows(t, keep)
+}
@@ -0,0 +1,177 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRegion(t *testing.T) {
+	const src = `# Region file format: DS9 version 4.1
+global color=green dashlist=8 3 width=1 font="helvetica 10 normal roman"
+image
+circle(100,100,20) # color=red
+box(50.5,60,10,20,45);-ellipse(100,100,5,2.5)
+fk5
+circle(05:34:31.940,+22:00:52.20,30")
+polygon(83.6,22.0,83.7,22.0,83.7,22.1)
+physical;circle 1 2 3
+`
+
+	reg, err := ParseRegion(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("could not parse region: %v", err)
+	}
+
+	want := []Shape{
+		{Kind: CircleShape, Coords: ImageCoords, Params: []float64{100, 100, 20}},
+		{Kind: BoxShape, Coords: ImageCoords, Params: []float64{50.5, 60, 10, 20, 45}},
+		{Kind: EllipseShape, Coords: ImageCoords, Params: []float64{100, 100, 5, 2.5}, Exclude: true},
+		{Kind: CircleShape, Coords: FK5Coords, Params: []float64{
+			(5 + 34.0/60 + 31.94/3600) * 15,
+			22 + 0.0/60 + 52.2/3600,
+			30.0 / 3600,
+		}},
+		{Kind: PolygonShape, Coords: FK5Coords, Params: []float64{83.6, 22.0, 83.7, 22.0, 83.7, 22.1}},
+		{Kind: CircleShape, Coords: ImageCoords, Params: []float64{1, 2, 3}},
+	}
+
+	if len(reg.Shapes) != len(want) {
+		t.Fatalf("invalid number of shapes: got=%d, want=%d", len(reg.Shapes), len(want))
+	}
+	for i, got := range reg.Shapes {
+		w := want[i]
+		if got.Kind != w.Kind || got.Coords != w.Coords || got.Exclude != w.Exclude {
+			t.Fatalf("shape #%d: got=%+v, want=%+v", i, got, w)
+		}
+		if len(got.Params) != len(w.Params) {
+			t.Fatalf("shape #%d: invalid params: got=%v, want=%v", i, got.Params, w.Params)
+		}
+		for j := range got.Params {
+			if math.Abs(got.Params[j]-w.Params[j]) > 1e-9 {
+				t.Fatalf("shape #%d: invalid params: got=%v, want=%v", i, got.Params, w.Params)
+			}
+		}
+	}
+
+	for _, src := range []string{
+		"circle(1,2)",
+		"star(1,2,3)",
+		"polygon(1,2,3,4)",
+		"galactic;circle(1,2,3)",
+		"circle(a,2,3)",
+	} {
+		_, err := ParseRegion(strings.NewReader(src))
+		if err == nil {
+			t.Fatalf("%s: expected an error", src)
+		}
+	}
+}
+
+func TestFilterByRegion(t *testing.T) {
+	type Event struct {
+		X float32 `fits:"X"`
+		Y float32 `fits:"Y"`
+	}
+
+	tbl, err := NewTableFrom("EVENTS", Event{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	err = tbl.Header().Append(
+		Card{Name: "TCTYP1", Value: "RA---TAN"},
+		Card{Name: "TCRPX1", Value: 100.0},
+		Card{Name: "TCRVL1", Value: 83.6},
+		Card{Name: "TCDLT1", Value: -1.0 / 3600},
+		Card{Name: "TCTYP2", Value: "DEC--TAN"},
+		Card{Name: "TCRPX2", Value: 100.0},
+		Card{Name: "TCRVL2", Value: 22.0},
+		Card{Name: "TCDLT2", Value: 1.0 / 3600},
+	)
+	if err != nil {
+		t.Fatalf("could not append cards: %v", err)
+	}
+
+	for _, evt := range []Event{
+		{100, 100}, {105, 100}, {100, 115}, {80, 80}, {120, 121}, {101, 100.5},
+	} {
+		err = tbl.Write(&evt)
+		if err != nil {
+			t.Fatalf("could not write event: %v", err)
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		reg  string
+		want []Event
+	}{
+		{
+			name: "image-circle",
+			reg:  "image;circle(100,100,10)",
+			want: []Event{{100, 100}, {105, 100}, {101, 100.5}},
+		},
+		{
+			name: "image-exclude",
+			reg:  "image;circle(100,100,10);-box(101,100.5,1,1)",
+			want: []Event{{100, 100}, {105, 100}},
+		},
+		{
+			name: "image-only-exclude",
+			reg:  "image;-circle(100,100,10)",
+			want: []Event{{100, 115}, {80, 80}, {120, 121}},
+		},
+		{
+			name: "image-polygon",
+			reg:  "image;polygon(91,90,131,90,131,130)",
+			want: []Event{{105, 100}},
+		},
+		{
+			name: "fk5-circle",
+			reg:  `fk5;circle(83.6,22.0,10")`,
+			want: []Event{{100, 100}, {105, 100}, {101, 100.5}},
+		},
+		{
+			name: "fk5-ellipse",
+			reg:  `fk5;ellipse(83.6,22.0,0.5',2")`,
+			want: []Event{{100, 100}, {105, 100}, {101, 100.5}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			reg, err := ParseRegion(strings.NewReader(test.reg))
+			if err != nil {
+				t.Fatalf("could not parse region: %v", err)
+			}
+			out, err := FilterByRegion(tbl, "X", "Y", reg)
+			if err != nil {
+				t.Fatalf("could not filter table: %v", err)
+			}
+			defer out.Close()
+
+			var got []Event
+			rows, err := out.Read(0, out.NumRows())
+			if err != nil {
+				t.Fatalf("could not read rows: %v", err)
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var evt Event
+				err = rows.Scan(&evt)
+				if err != nil {
+					t.Fatalf("could not scan row: %v", err)
+				}
+				got = append(got, evt)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("invalid rows:\ngot= %v\nwant=%v", got, test.want)
+			}
+		})
+	}
+}
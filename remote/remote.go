@@ -0,0 +1,295 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package remote gives random access to remote files over HTTP(S), with
+// Range requests.
+//
+// Only the blocks of the file which are read are downloaded, and the most
+// recently used blocks are cached, so that the headers and a few cutouts
+// of a large remote FITS file can be read without downloading it:
+//
+//	r, err := remote.Open("https://example.org/data/big.fits")
+//	if err != nil {
+//		return err
+//	}
+//	defer r.Close()
+//
+//	// only the headers are downloaded.
+//	f, err := fitsio.OpenReaderAt(r, r.Size())
+package remote
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrNoRange is returned when the server does not support Range requests.
+var ErrNoRange = errors.New("remote: server does not support range requests")
+
+const (
+	defaultBlockSize = 1 << 20
+	defaultCacheSize = 64
+)
+
+// Option configures a File.
+type Option func(f *File)
+
+// WithClient sets the HTTP client used to send requests.
+// The default is http.DefaultClient.
+func WithClient(c *http.Client) Option {
+	return func(f *File) {
+		f.client = c
+	}
+}
+
+// WithBlockSize sets the size in bytes of the blocks downloaded and cached.
+// The default is 1 MiB.
+func WithBlockSize(n int64) Option {
+	return func(f *File) {
+		if n > 0 {
+			f.bsize = n
+		}
+	}
+}
+
+// WithCacheSize sets the maximum number of blocks held in the cache.
+// The default is 64 blocks.
+func WithCacheSize(n int) Option {
+	return func(f *File) {
+		if n > 0 {
+			f.nblocks = n
+		}
+	}
+}
+
+// File is a remote file, read with HTTP Range requests.
+//
+// ReadAt may be called concurrently from multiple goroutines.
+// Read and Seek share a current offset and must not be called concurrently.
+type File struct {
+	ctx     context.Context
+	client  *http.Client
+	url     string
+	size    int64
+	bsize   int64 // size of the blocks
+	nblocks int   // maximum number of cached blocks
+
+	mu     sync.Mutex
+	blocks map[int64]*list.Element // cached blocks, by index
+	lru    *list.List              // cached blocks, most recently used first
+
+	pos int64 // offset of Read
+}
+
+type block struct {
+	idx  int64
+	data []byte
+}
+
+// Open opens the remote file at url.
+func Open(url string, opts ...Option) (*File, error) {
+	return OpenContext(context.Background(), url, opts...)
+}
+
+// OpenContext is like Open, but all the requests sent to read the file
+// are aborted when ctx is done.
+func OpenContext(ctx context.Context, url string, opts ...Option) (*File, error) {
+	f := &File{
+		ctx:     ctx,
+		client:  http.DefaultClient,
+		url:     url,
+		bsize:   defaultBlockSize,
+		nblocks: defaultCacheSize,
+		blocks:  make(map[int64]*list.Element),
+		lru:     list.New(),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	// the size of the file is given by the Content-Range of the response
+	// to a request for its first byte.
+	resp, err := f.get(0, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	f.size, err = totalSize(resp.Header.Get("Content-Range"))
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Name returns the URL of the file.
+func (f *File) Name() string {
+	return f.url
+}
+
+// Size returns the size of the file in bytes.
+func (f *File) Size() int64 {
+	return f.size
+}
+
+// Close releases the cached blocks.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blocks = make(map[int64]*list.Element)
+	f.lru.Init()
+	return nil
+}
+
+// ReadAt implements io.ReaderAt.
+func (f *File) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("remote: negative offset %d", off)
+	}
+	if off >= f.size {
+		return 0, io.EOF
+	}
+
+	end := min(off+int64(len(p)), f.size)
+	n := 0
+	for cur := off; cur < end; {
+		idx := cur / f.bsize
+		data, err := f.block(idx, (end-1)/f.bsize)
+		if err != nil {
+			return n, err
+		}
+		n += copy(p[n:end-off], data[cur-idx*f.bsize:])
+		cur = off + int64(n)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Read implements io.Reader.
+func (f *File) Read(p []byte) (int, error) {
+	n, err := f.ReadAt(p, f.pos)
+	f.pos += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// Seek implements io.Seeker.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, fmt.Errorf("remote: invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("remote: negative offset %d", offset)
+	}
+	f.pos = offset
+	return offset, nil
+}
+
+// block returns the data of the idx-th block, downloading it if it is not
+// cached.
+// Missing blocks up to the last-th block are downloaded with the same
+// request.
+func (f *File) block(idx, last int64) ([]byte, error) {
+	f.mu.Lock()
+	if elem, ok := f.blocks[idx]; ok {
+		f.lru.MoveToFront(elem)
+		f.mu.Unlock()
+		return elem.Value.(*block).data, nil
+	}
+	// download the run of missing blocks starting at idx.
+	end := idx + 1
+	for end <= last && end-idx < int64(f.nblocks) {
+		if _, ok := f.blocks[end]; ok {
+			break
+		}
+		end++
+	}
+	f.mu.Unlock()
+
+	beg := idx * f.bsize
+	size := min(end*f.bsize, f.size) - beg
+	resp, err := f.get(beg, size)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	buf := make([]byte, size)
+	_, err = io.ReadFull(resp.Body, buf)
+	if err != nil {
+		return nil, fmt.Errorf("remote: could not read bytes [%d, %d) of %s: %w", beg, beg+size, f.url, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := idx; i < end; i++ {
+		data := buf[(i-idx)*f.bsize : min((i-idx+1)*f.bsize, size)]
+		if elem, ok := f.blocks[i]; ok {
+			f.lru.MoveToFront(elem)
+			continue
+		}
+		f.blocks[i] = f.lru.PushFront(&block{idx: i, data: data})
+	}
+	for f.lru.Len() > f.nblocks {
+		elem := f.lru.Back()
+		delete(f.blocks, elem.Value.(*block).idx)
+		f.lru.Remove(elem)
+	}
+	return buf[:min(f.bsize, size)], nil
+}
+
+// get requests the n bytes of the file starting at offset beg.
+func (f *File) get(beg, n int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(f.ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("remote: could not create request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", beg, beg+n-1))
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote: could not send request: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return resp, nil
+	case http.StatusOK:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w (url=%s)", ErrNoRange, f.url)
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("remote: could not get %s: %s", f.url, resp.Status)
+	}
+}
+
+// totalSize returns the complete length of a resource from the value of a
+// Content-Range header (e.g. "bytes 0-0/1234").
+func totalSize(v string) (int64, error) {
+	i := strings.LastIndexByte(v, '/')
+	if !strings.HasPrefix(v, "bytes ") || i < 0 {
+		return 0, fmt.Errorf("remote: invalid Content-Range %q", v)
+	}
+	size, err := strconv.ParseInt(v[i+1:], 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("remote: unknown size in Content-Range %q", v)
+	}
+	return size, nil
+}
@@ -0,0 +1,188 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	fits "github.com/astrogo/fitsio"
+)
+
+func newServer(t *testing.T, raw []byte, nreqs *int64) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(nreqs, 1)
+		http.ServeContent(w, r, "file.fits", time.Time{}, bytes.NewReader(raw))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestReadAt(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/swp06542llg.fits")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	var nreqs int64
+	srv := newServer(t, raw, &nreqs)
+
+	f, err := Open(srv.URL, WithBlockSize(1000), WithCacheSize(4))
+	if err != nil {
+		t.Fatalf("could not open remote file: %v", err)
+	}
+	defer f.Close()
+
+	if got, want := f.Size(), int64(len(raw)); got != want {
+		t.Fatalf("invalid size: got=%d, want=%d", got, want)
+	}
+	if got, want := f.Name(), srv.URL; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		off := rnd.Int63n(int64(len(raw)))
+		buf := make([]byte, rnd.Intn(5000))
+		n, err := f.ReadAt(buf, off)
+		want := raw[off:min(off+int64(len(buf)), int64(len(raw)))]
+		switch {
+		case n < len(buf):
+			if err != io.EOF {
+				t.Fatalf("short read at %d: %d bytes, err=%v", off, n, err)
+			}
+		case err != nil:
+			t.Fatalf("could not read at %d: %v", off, err)
+		}
+		if !bytes.Equal(buf[:n], want) {
+			t.Fatalf("invalid content at offset %d", off)
+		}
+	}
+
+	// cached blocks are not downloaded again.
+	buf := make([]byte, 10)
+	_, err = f.ReadAt(buf, 100)
+	if err != nil {
+		t.Fatalf("could not read: %v", err)
+	}
+	n := atomic.LoadInt64(&nreqs)
+	_, err = f.ReadAt(buf, 200)
+	if err != nil {
+		t.Fatalf("could not read: %v", err)
+	}
+	if got := atomic.LoadInt64(&nreqs); got != n {
+		t.Fatalf("cached block was downloaded again (%d requests, want %d)", got, n)
+	}
+
+	_, err = f.ReadAt(buf, f.Size())
+	if err != io.EOF {
+		t.Fatalf("invalid error reading past the end: %v", err)
+	}
+}
+
+func TestOpenFITS(t *testing.T) {
+	const fname = "../testdata/swp06542llg.fits"
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	var nreqs int64
+	srv := newServer(t, raw, &nreqs)
+
+	r, err := Open(srv.URL, WithBlockSize(4096))
+	if err != nil {
+		t.Fatalf("could not open remote file: %v", err)
+	}
+	defer r.Close()
+
+	f, err := fits.Open(r)
+	if err != nil {
+		t.Fatalf("could not open FITS file: %v", err)
+	}
+	defer f.Close()
+
+	ref, err := fits.Open(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not open FITS file: %v", err)
+	}
+	defer ref.Close()
+
+	if got, want := f.Name(), srv.URL; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := len(f.HDUs()), len(ref.HDUs()); got != want {
+		t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+	}
+	for i, hdu := range f.HDUs() {
+		if got, want := hdu.Header().Text(), ref.HDU(i).Header().Text(); got != want {
+			t.Fatalf("HDU #%d: invalid header:\ngot:\n%s\nwant:\n%s", i, got, want)
+		}
+	}
+}
+
+func TestNoRange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("SIMPLE"))
+	}))
+	defer srv.Close()
+
+	_, err := Open(srv.URL)
+	if !errors.Is(err, ErrNoRange) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrNoRange)
+	}
+
+	srv404 := httptest.NewServer(http.NotFoundHandler())
+	defer srv404.Close()
+	_, err = Open(srv404.URL)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestOpenReaderAt(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/file-img2-bitpix-64.fits")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	var nreqs int64
+	srv := newServer(t, raw, &nreqs)
+
+	r, err := Open(srv.URL, WithBlockSize(2880))
+	if err != nil {
+		t.Fatalf("could not open remote file: %v", err)
+	}
+	defer r.Close()
+
+	f, err := fits.OpenReaderAt(r, r.Size())
+	if err != nil {
+		t.Fatalf("could not open FITS file: %v", err)
+	}
+	defer f.Close()
+
+	// one request for the size, one per header.
+	if got, want := atomic.LoadInt64(&nreqs), int64(1+f.NumHDUs()); got != want {
+		t.Fatalf("invalid number of requests: got=%d, want=%d", got, want)
+	}
+
+	img, ok := f.HDU(1).(fits.Image)
+	if !ok {
+		t.Fatalf("invalid HDU type %T", f.HDU(1))
+	}
+	offs := f.Offsets()[1]
+	if !bytes.Equal(img.Raw(), raw[offs.Data:offs.Data+img.DataSize()]) {
+		t.Fatalf("invalid pixels")
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+)
+
+// riceParams holds the parameters of the Rice coding of pixels of a given
+// size, as defined by the FITS tiled image compression convention.
+type riceParams struct {
+	fsbits int // number of bits of the split position of a block
+	fsmax  int // split position denoting a block of raw differences
+	bbits  int // number of bits of a pixel
+}
+
+func newRiceParams(bytepix int) (riceParams, error) {
+	switch bytepix {
+	case 1:
+		return riceParams{fsbits: 3, fsmax: 6, bbits: 8}, nil
+	case 2:
+		return riceParams{fsbits: 4, fsmax: 14, bbits: 16}, nil
+	case 4:
+		return riceParams{fsbits: 5, fsmax: 25, bbits: 32}, nil
+	}
+	return riceParams{}, fmt.Errorf("fitsio: invalid RICE_1 BYTEPIX value (%d)", bytepix)
+}
+
+// wrap returns the difference of two pixels, as a signed integer of
+// p.bbits bits.
+func (p riceParams) wrap(v int64) int64 {
+	switch p.bbits {
+	case 8:
+		return int64(int8(v))
+	case 16:
+		return int64(int16(v))
+	}
+	return int64(int32(v))
+}
+
+// riceEncode compresses the pixels vs, stored with bytepix bytes, in blocks
+// of nblock pixels.
+func riceEncode(vs []int64, bytepix, nblock int) ([]byte, error) {
+	p, err := newRiceParams(bytepix)
+	if err != nil {
+		return nil, err
+	}
+	if len(vs) == 0 {
+		return nil, nil
+	}
+
+	var (
+		w     bitWriter
+		mask  = uint64(1)<<p.bbits - 1
+		diffs = make([]uint64, nblock)
+	)
+	w.write(uint64(vs[0])&mask, p.bbits)
+	last := vs[0]
+	for i := 0; i < len(vs); i += nblock {
+		block := vs[i:min(i+nblock, len(vs))]
+
+		sum := 0.0
+		for j, v := range block {
+			d := p.wrap(v - last)
+			if d < 0 {
+				diffs[j] = uint64(^(d << 1)) & mask
+			} else {
+				diffs[j] = uint64(d<<1) & mask
+			}
+			sum += float64(diffs[j])
+			last = v
+		}
+
+		// compute the number of bits to split from the sum.
+		dpsum := (sum - float64(len(block)/2) - 1) / float64(len(block))
+		if dpsum < 0 {
+			dpsum = 0
+		}
+		fs := 0
+		for psum := uint64(dpsum) >> 1; psum > 0; psum >>= 1 {
+			fs++
+		}
+
+		switch {
+		case fs >= p.fsmax:
+			// high entropy: raw differences.
+			w.write(uint64(p.fsmax+1), p.fsbits)
+			for _, d := range diffs[:len(block)] {
+				w.write(d, p.bbits)
+			}
+		case fs == 0 && sum == 0:
+			// low entropy: all differences are zero.
+			w.write(0, p.fsbits)
+		default:
+			w.write(uint64(fs+1), p.fsbits)
+			fsmask := uint64(1)<<fs - 1
+			for _, d := range diffs[:len(block)] {
+				// top bits in unary, followed by the fs bottom bits.
+				for top := d >> fs; top > 0; top-- {
+					w.write(0, 1)
+				}
+				w.write(1, 1)
+				w.write(d&fsmask, fs)
+			}
+		}
+	}
+	return w.bytes(), nil
+}
+
+// riceDecode decompresses the n pixels, stored with bytepix bytes in blocks
+// of nblock pixels, held by buf.
+func riceDecode(buf []byte, n, bytepix, nblock int) ([]int64, error) {
+	p, err := newRiceParams(bytepix)
+	if err != nil {
+		return nil, err
+	}
+	vs := make([]int64, n)
+	if n == 0 {
+		return vs, nil
+	}
+
+	r := bitReader{buf: buf}
+	last := p.wrap(int64(r.read(p.bbits)))
+	for i := 0; i < n; i += nblock {
+		block := vs[i:min(i+nblock, n)]
+		fs := int(r.read(p.fsbits)) - 1
+		for j := range block {
+			var d uint64
+			switch {
+			case fs < 0:
+				d = 0
+			case fs == p.fsmax:
+				d = r.read(p.bbits)
+			default:
+				top := uint64(0)
+				for r.read(1) == 0 {
+					if r.err != nil {
+						break
+					}
+					top++
+				}
+				d = top<<fs | r.read(fs)
+			}
+			if r.err != nil {
+				return nil, fmt.Errorf("fitsio: corrupted RICE_1 data: %w", r.err)
+			}
+			var v int64
+			if d&1 == 0 {
+				v = int64(d >> 1)
+			} else {
+				v = ^int64(d >> 1)
+			}
+			last = p.wrap(last + v)
+			block[j] = last
+		}
+	}
+	return vs, nil
+}
+
+// bitWriter writes bits, most significant bit first.
+type bitWriter struct {
+	buf  []byte
+	cur  uint64 // pending bits
+	ncur int    // number of pending bits
+}
+
+// write writes the n least significant bits of v.
+func (w *bitWriter) write(v uint64, n int) {
+	for n > 0 {
+		k := min(n, 32)
+		n -= k
+		w.cur = w.cur<<k | (v>>n)&(1<<k-1)
+		w.ncur += k
+		for w.ncur >= 8 {
+			w.ncur -= 8
+			w.buf = append(w.buf, byte(w.cur>>w.ncur))
+		}
+	}
+}
+
+// bytes flushes the pending bits, padded with zeros, and returns the
+// written bytes.
+func (w *bitWriter) bytes() []byte {
+	if w.ncur > 0 {
+		w.buf = append(w.buf, byte(w.cur<<(8-w.ncur)))
+		w.ncur = 0
+	}
+	return w.buf
+}
+
+// bitReader reads bits, most significant bit first.
+type bitReader struct {
+	buf []byte
+	pos int // position in bits
+	err error
+}
+
+// read reads n bits.
+func (r *bitReader) read(n int) uint64 {
+	if r.pos+n > 8*len(r.buf) {
+		r.err = fmt.Errorf("fitsio: not enough bits (want %d at bit %d of %d)", n, r.pos, 8*len(r.buf))
+		r.pos = 8 * len(r.buf)
+		return 0
+	}
+	var v uint64
+	for ; n > 0; n-- {
+		bit := r.buf[r.pos>>3] >> (7 - r.pos&7) & 1
+		v = v<<1 | uint64(bit)
+		r.pos++
+	}
+	return v
+}
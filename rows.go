@@ -5,6 +5,7 @@
 package fitsio
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
@@ -24,6 +25,8 @@ import (
 //  err = rows.Err() // get any error encountered during iteration
 //  ...
 //
+// A Rows is not safe for concurrent use, but multiple Rows may iterate
+// concurrently over the same Table.
 type Rows struct {
 	table  *Table
 	cols   []int // list of (active) column indices
@@ -34,9 +37,35 @@ type Rows struct {
 	closed bool
 	err    error // last error
 
+	// pred selects the rows to iterate over, if not nil.
+	pred func(irow int64) bool
+
 	// cache of type -> slice of (struct-field-index,col-index)
 	// used by scanStruct
 	icols map[reflect.Type][][2]int
+
+	// last struct type scanned and its (struct-field-index,col-index)
+	// pairs, to spare the icols lookup when scanning rows into values
+	// of the same type.
+	lastType reflect.Type
+	lastCols [][2]int
+}
+
+// Row is a row of a Table, as yielded by Table.Rows.
+// A Row is only valid during the iteration step it was yielded for.
+type Row struct {
+	rows *Rows
+}
+
+// Index returns the index of the row in the table.
+func (row *Row) Index() int64 {
+	return row.rows.cur
+}
+
+// Scan copies the columns of the row into the values pointed at by args,
+// as with Rows.Scan.
+func (row *Row) Scan(args ...interface{}) error {
+	return row.rows.Scan(args...)
 }
 
 // Err returns the error, if any, that was encountered during iteration.
@@ -58,6 +87,17 @@ func (rows *Rows) Close() error {
 
 // Scan copies the columns in the current row into the values pointed at by
 // dest.
+//
+// Scalar columns of binary tables may be read into values of a wider type
+// than the one of the column, such as an int16 column into an int64 or a
+// float64, or into an empty interface. Narrower integer or floating-point
+// types are accepted as long as the value fits. Floating-point columns can
+// not be read into integers.
+//
+// Values may also be read into pointers (such as a **float64, or a struct
+// field of type *float64), which are set to nil for null values: values
+// equal to the TNULL value of the column, NaN values of floating-point
+// columns, or undefined (0) values of logical columns.
 func (rows *Rows) Scan(args ...interface{}) error {
 	var err error
 	defer func() {
@@ -84,97 +124,153 @@ func (rows *Rows) Scan(args ...interface{}) error {
 }
 
 func (rows *Rows) scan(args ...interface{}) error {
-	var err error
-	if len(args) != len(rows.cols) {
-		return fmt.Errorf(
-			"fitsio.Rows.Scan: invalid number of arguments (got %d. expected %d)",
-			len(args),
-			len(rows.cols),
-		)
+	return rows.table.read(rows.cur, args...)
+}
+
+func (rows *Rows) scanMap(data map[string]interface{}) error {
+	return rows.table.readMap(rows.cur, data)
+}
+
+func (rows *Rows) scanStruct(data interface{}) error {
+	rv := reflect.ValueOf(data).Elem()
+	return rows.readStruct(rv, rows.structCols(rv.Type()))
+}
+
+// structCols returns the (struct-field-index,col-index) pairs of the struct
+// type rt.
+func (rows *Rows) structCols(rt reflect.Type) [][2]int {
+	if rt == rows.lastType {
+		return rows.lastCols
 	}
-	for i, icol := range rows.cols {
-		err = rows.table.cols[icol].read(rows.table, icol, rows.cur, args[i])
-		if err != nil {
-			return err
-		}
+	icols, ok := rows.icols[rt]
+	if !ok {
+		icols = rows.table.fieldCols(rt)
+		rows.icols[rt] = icols
 	}
-	return err
+	rows.lastType = rt
+	rows.lastCols = icols
+	return icols
 }
 
-func (rows *Rows) scanMap(data map[string]interface{}) error {
+// readStruct reads the current row into the struct value rv, following the
+// (struct-field-index,col-index) pairs icols.
+func (rows *Rows) readStruct(rv reflect.Value, icols [][2]int) error {
+	return rows.table.readFields(rows.cur, rv, icols)
+}
+
+// ScanAll reads all the remaining rows into dst, a pointer to a slice.
+// The slice is reset to hold one element per row.
+// The element type of the slice may be a struct or a map[string]interface{},
+// filled as with Scan, or the Go type of the column if rows iterates over
+// a single column.
+//
+// Unlike repeated calls to Scan, the type of the elements is inspected once.
+func (rows *Rows) ScanAll(dst interface{}) error {
 	var err error
-	icols := make([]int, 0, len(data))
-	switch len(data) {
-	case 0:
-		icols = make([]int, len(rows.cols))
-		for i := range rows.cols {
-			icols[i] = i
+	defer func() {
+		rows.err = err
+	}()
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		err = fmt.Errorf("fitsio: Rows.ScanAll takes a pointer to a slice. got: %T", dst)
+		return err
+	}
+	slice := rv.Elem()
+	rt := slice.Type().Elem()
+
+	var scan func(v reflect.Value) error
+	switch {
+	case rt.Kind() == reflect.Struct:
+		icols := rows.structCols(rt)
+		scan = func(v reflect.Value) error {
+			return rows.readStruct(v, icols)
+		}
+	case rt == reflect.TypeOf(map[string]interface{}(nil)):
+		scan = func(v reflect.Value) error {
+			data := make(map[string]interface{}, len(rows.cols))
+			v.Set(reflect.ValueOf(data))
+			return rows.scanMap(data)
 		}
 	default:
-		for k := range data {
-			icol := rows.table.Index(k)
-			if icol >= 0 {
-				icols = append(icols, icol)
-			}
+		if len(rows.cols) != 1 {
+			err = fmt.Errorf(
+				"fitsio: Rows.ScanAll can not read %d columns into a %v",
+				len(rows.cols), slice.Type(),
+			)
+			return err
+		}
+		scan = func(v reflect.Value) error {
+			return rows.scan(v.Addr().Interface())
 		}
 	}
 
-	for _, icol := range icols {
-		col := rows.table.Col(icol)
-		val := reflect.New(col.Type())
-		err = col.read(rows.table, icol, rows.cur, val.Interface())
+	slice = slice.Slice(0, 0)
+	for rows.Next() {
+		slice = reflect.Append(slice, reflect.Zero(rt))
+		err = scan(slice.Index(slice.Len() - 1))
 		if err != nil {
-			return err
+			slice = slice.Slice(0, slice.Len()-1)
+			break
 		}
-		data[col.Name] = val.Elem().Interface()
 	}
+	rv.Elem().Set(slice)
 	return err
 }
 
-func (rows *Rows) scanStruct(data interface{}) error {
-	var err error
-
-	rt := reflect.TypeOf(data).Elem()
-	rv := reflect.ValueOf(data).Elem()
-	if _, ok := rows.icols[rt]; !ok {
-		icols := make([][2]int, 0, rt.NumField())
-		for i := 0; i < rt.NumField(); i++ {
-			f := rt.Field(i)
-			n := f.Tag.Get("fits")
-			if n == "" {
-				n = f.Name
+// ScanChan returns a channel delivering the remaining rows of rows, each
+// scanned into a value of type T as with Rows.Scan.
+// A map[string]interface{} T is allocated for each row.
+//
+// The channel is closed once all the rows have been delivered, an error
+// occurred or ctx is done.
+// The error, if any, is then reported by rows.Err.
+// rows must not be used until the channel is closed.
+func ScanChan[T any](ctx context.Context, rows *Rows) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for rows.Next() {
+			if err := ctx.Err(); err != nil {
+				rows.err = err
+				return
 			}
-			icol := rows.table.Index(n)
-			if icol >= 0 {
-				icols = append(icols, [2]int{i, icol})
+			var v T
+			if m, ok := interface{}(&v).(*map[string]interface{}); ok {
+				*m = make(map[string]interface{}, len(rows.cols))
+			}
+			err := rows.Scan(&v)
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				rows.err = ctx.Err()
+				return
 			}
 		}
-		rows.icols[rt] = icols
-	}
-	icols := rows.icols[rt]
-	for _, icol := range icols {
-		col := &rows.table.cols[icol[1]]
-		value := rv.Field(icol[0]).Addr().Interface()
-		err = col.read(rows.table, icol[1], rows.cur, value)
-		if err != nil {
-			return err
-		}
-	}
-	return err
+	}()
+	return ch
 }
 
 // Next prepares the next result row for reading with the Scan method.
 // It returns true on success, false if there is no next result row.
 // Every call to Scan, even the first one, must be preceded by a call to Next.
 func (rows *Rows) Next() bool {
-	if rows.closed {
-		return false
-	}
-	next := rows.i < rows.n
-	rows.cur += rows.inc
-	rows.i += rows.inc
-	if !next {
-		rows.err = rows.Close()
+	for {
+		if rows.closed {
+			return false
+		}
+		next := rows.i < rows.n
+		rows.cur += rows.inc
+		rows.i += rows.inc
+		if !next {
+			rows.err = rows.Close()
+			return false
+		}
+		if rows.pred == nil || rows.pred(rows.cur) {
+			return true
+		}
 	}
-	return next
 }
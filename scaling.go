@@ -0,0 +1,303 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+)
+
+// Interval describes how the range of physical values displayed
+// by an image is selected.
+type Interval int
+
+const (
+	MinMaxInterval     Interval = iota // full range of pixel values
+	ZScaleInterval                     // IRAF's zscale algorithm
+	PercentileInterval                 // range between two percentiles of the pixel values
+)
+
+// Stretch describes how physical values within the display range are
+// mapped to display intensities.
+type Stretch int
+
+const (
+	LinearStretch Stretch = iota // linear mapping
+	LogStretch                   // logarithmic mapping
+	SqrtStretch                  // square-root mapping
+	AsinhStretch                 // inverse hyperbolic sine mapping
+	HistEqStretch                // histogram equalization
+)
+
+// Scaling describes how physical pixel values are mapped to display
+// intensities when rendering an image.
+type Scaling struct {
+	Interval Interval
+	Stretch  Stretch
+
+	// Lower and Upper are the percentiles (in the [0, 100] range)
+	// delimiting the display range for PercentileInterval.
+	Lower, Upper float64
+}
+
+// Predefined scalings.
+var (
+	LinearScale = Scaling{Interval: MinMaxInterval, Stretch: LinearStretch}
+	ZScale      = Scaling{Interval: ZScaleInterval, Stretch: LinearStretch}
+	AsinhScale  = Scaling{Interval: PercentileInterval, Stretch: AsinhStretch, Lower: 0.5, Upper: 99.5}
+	LogScale    = Scaling{Interval: PercentileInterval, Stretch: LogStretch, Lower: 0.5, Upper: 99.5}
+	HistEqScale = Scaling{Interval: MinMaxInterval, Stretch: HistEqStretch}
+)
+
+// PercentileScale returns a linear scaling clipping pixel values outside
+// of the [lower, upper] percentiles.
+func PercentileScale(lower, upper float64) Scaling {
+	return Scaling{
+		Interval: PercentileInterval,
+		Stretch:  LinearStretch,
+		Lower:    lower,
+		Upper:    upper,
+	}
+}
+
+// ImageWithScaling returns a grayscale image.Image value, where the physical
+// pixel values have been mapped to 8-bit intensities according to s.
+// Blank and NaN pixels are rendered as black.
+func (img *imageHDU) ImageWithScaling(s Scaling) (image.Image, error) {
+	w, h, err := img.planeSize()
+	if err != nil {
+		return nil, err
+	}
+
+	gray := image.NewGray(image.Rect(0, 0, w, h))
+	err = img.render(s, func(i int, x float64) {
+		gray.Pix[i] = uint8(math.Round(255 * x))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gray, nil
+}
+
+// planeSize returns the dimensions of the first plane of the image.
+func (img *imageHDU) planeSize() (w, h int, err error) {
+	axes := img.hdr.Axes()
+	if len(axes) < 2 || axes[0] <= 0 || axes[1] <= 0 {
+		return 0, 0, fmt.Errorf("fitsio: invalid image dimensions %v", axes)
+	}
+	return axes[0], axes[1], nil
+}
+
+// render maps the physical values of the pixels of the first plane of the
+// image to display intensities in [0, 1], according to s.
+// fn is called with the index and intensity of each valid pixel.
+func (img *imageHDU) render(s Scaling, fn func(i int, x float64)) error {
+	w, h, err := img.planeSize()
+	if err != nil {
+		return err
+	}
+
+	pix, err := newPixels(&img.hdr, img.raw)
+	if err != nil {
+		return err
+	}
+
+	n := w * h
+	vs := make([]float64, n)
+	sorted := make([]float64, 0, n)
+	for i := range vs {
+		v, ok := pix.at(i)
+		vs[i] = v
+		if ok {
+			sorted = append(sorted, v)
+		}
+	}
+	sort.Float64s(sorted)
+
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	lo, hi, err := s.limits(sorted, vs)
+	if err != nil {
+		return err
+	}
+
+	stretch := s.stretchFunc(sorted, lo, hi)
+	for i, v := range vs {
+		if math.IsNaN(v) {
+			continue
+		}
+		x := 0.0
+		if hi > lo {
+			x = (v - lo) / (hi - lo)
+		}
+		x = stretch(math.Max(0, math.Min(1, x)))
+		fn(i, math.Max(0, math.Min(1, x)))
+	}
+
+	return nil
+}
+
+// limits returns the display range of the provided pixel values.
+// sorted holds the valid pixel values, in increasing order.
+// vs holds all the pixel values, in image order.
+func (s Scaling) limits(sorted, vs []float64) (lo, hi float64, err error) {
+	switch s.Interval {
+	case MinMaxInterval:
+		return sorted[0], sorted[len(sorted)-1], nil
+	case ZScaleInterval:
+		lo, hi = zscale(vs, zscaleSamples, zscaleContrast)
+		return lo, hi, nil
+	case PercentileInterval:
+		if s.Lower < 0 || s.Upper > 100 || s.Lower >= s.Upper {
+			return 0, 0, fmt.Errorf(
+				"fitsio: invalid percentile interval [%v, %v]",
+				s.Lower, s.Upper,
+			)
+		}
+		return quantile(sorted, s.Lower/100), quantile(sorted, s.Upper/100), nil
+	}
+	return 0, 0, fmt.Errorf("fitsio: invalid scaling interval (%d)", s.Interval)
+}
+
+// stretchFunc returns the function mapping normalized values in [0, 1]
+// to display intensities in [0, 1].
+func (s Scaling) stretchFunc(sorted []float64, lo, hi float64) func(x float64) float64 {
+	switch s.Stretch {
+	case LogStretch:
+		const a = 1000
+		return func(x float64) float64 {
+			return math.Log10(a*x+1) / math.Log10(a+1)
+		}
+	case SqrtStretch:
+		return math.Sqrt
+	case AsinhStretch:
+		const a = 0.1
+		return func(x float64) float64 {
+			return math.Asinh(x/a) / math.Asinh(1/a)
+		}
+	case HistEqStretch:
+		// map values to their rank in the cumulative distribution
+		// of the pixel values within the display range.
+		beg := sort.SearchFloat64s(sorted, lo)
+		end := sort.Search(len(sorted), func(i int) bool { return sorted[i] > hi })
+		cdf := sorted[beg:end]
+		if len(cdf) == 0 {
+			return func(x float64) float64 { return x }
+		}
+		return func(x float64) float64 {
+			v := lo + x*(hi-lo)
+			i := sort.Search(len(cdf), func(i int) bool { return cdf[i] > v })
+			return float64(i) / float64(len(cdf))
+		}
+	}
+	return func(x float64) float64 { return x }
+}
+
+const (
+	zscaleSamples  = 1000 // maximum number of pixels sampled by zscale
+	zscaleContrast = 0.25 // contrast parameter of zscale
+	zscaleKrej     = 2.5  // rejection threshold (in sigmas) of zscale
+	zscaleMaxIter  = 5    // maximum number of rejection iterations of zscale
+)
+
+// zscale implements the IRAF zscale algorithm on the provided values,
+// ignoring NaNs.
+// zscale samples the values, fits a line through the sorted samples with
+// iterative outlier rejection and derives the display range from the slope
+// of that line.
+func zscale(vs []float64, nsamples int, contrast float64) (lo, hi float64) {
+	stride := 1
+	if len(vs) > nsamples {
+		stride = len(vs) / nsamples
+	}
+	samples := make([]float64, 0, nsamples)
+	for i := 0; i < len(vs) && len(samples) < nsamples; i += stride {
+		if v := vs[i]; !math.IsNaN(v) {
+			samples = append(samples, v)
+		}
+	}
+	if len(samples) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	sort.Float64s(samples)
+
+	npix := len(samples)
+	lo = samples[0]
+	hi = samples[npix-1]
+	median := quantile(samples, 0.5)
+	minpix := npix / 2
+	if minpix < 5 {
+		minpix = 5
+	}
+	if npix < minpix {
+		return lo, hi
+	}
+
+	// iterative least-squares fit of samples[i] = a + b*i.
+	good := make([]bool, npix)
+	for i := range good {
+		good[i] = true
+	}
+	ngood := npix
+	slope := 0.0
+	for iter := 0; iter < zscaleMaxIter; iter++ {
+		var sx, sy, sxx, sxy, n float64
+		for i, v := range samples {
+			if !good[i] {
+				continue
+			}
+			x := float64(i)
+			sx += x
+			sy += v
+			sxx += x * x
+			sxy += x * v
+			n++
+		}
+		den := n*sxx - sx*sx
+		if den == 0 {
+			break
+		}
+		slope = (n*sxy - sx*sy) / den
+		inter := (sy - slope*sx) / n
+
+		var ss float64
+		for i, v := range samples {
+			if good[i] {
+				r := v - (inter + slope*float64(i))
+				ss += r * r
+			}
+		}
+		sigma := math.Sqrt(ss / n)
+
+		nrej := 0
+		for i, v := range samples {
+			r := v - (inter + slope*float64(i))
+			if good[i] && math.Abs(r) > zscaleKrej*sigma {
+				good[i] = false
+				nrej++
+			}
+		}
+		ngood -= nrej
+		if nrej == 0 || ngood < minpix {
+			break
+		}
+	}
+
+	if ngood < minpix {
+		return lo, hi
+	}
+
+	if contrast > 0 {
+		slope /= contrast
+	}
+	center := float64(npix-1) / 2
+	zlo := math.Max(lo, median-center*slope)
+	zhi := math.Min(hi, median+center*slope)
+	return zlo, zhi
+}
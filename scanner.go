@@ -0,0 +1,355 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+)
+
+// binScanner decodes the values of a fixed-size scalar column of a binary
+// table, without reflection nor allocation.
+//
+// The scanners are built once per column, when the layout of the table is
+// known, and spare Rows.Scan the reflect-based decoding of readBin.
+// Their functions are spelled out for each type, so the decoding of the
+// values is inlined.
+type binScanner struct {
+	kind reflect.Kind // kind of the Go type of the column
+
+	// scan decodes buf into ptr and reports whether ptr is a pointer to
+	// a value of the Go type of the column.
+	scan func(buf []byte, ptr interface{}) bool
+
+	// value returns the value decoded from buf.
+	value func(buf []byte) interface{}
+
+	// set decodes buf into v, a settable value of the kind of the Go
+	// type of the column.
+	set func(v reflect.Value, buf []byte)
+}
+
+// scannerFor returns the scanner of the values of the binary column col,
+// or nil if col does not hold fixed-size scalar values.
+// The scanner honors the TZERO offset of unsigned integer columns.
+func scannerFor(col *Column) *binScanner {
+	rt := col.dtype.gotype
+	if col.dtype.tc <= 0 || col.dtype.len != 1 || rt == nil {
+		return nil
+	}
+	flip := col.zeroSize(rt) != 0
+
+	switch rt.Kind() {
+	case reflect.Bool:
+		return &binScanner{
+			kind: reflect.Bool,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*bool)
+				if ok {
+					*p = decodeBool(buf[0])
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return decodeBool(buf[0])
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetBool(decodeBool(buf[0]))
+			},
+		}
+	case reflect.Int8:
+		if flip {
+			return &binScanner{
+				kind: reflect.Int8,
+				scan: func(buf []byte, ptr interface{}) bool {
+					p, ok := ptr.(*int8)
+					if ok {
+						*p = int8(buf[0] ^ 0x80)
+					}
+					return ok
+				},
+				value: func(buf []byte) interface{} {
+					return int8(buf[0] ^ 0x80)
+				},
+				set: func(v reflect.Value, buf []byte) {
+					v.SetInt(int64(int8(buf[0] ^ 0x80)))
+				},
+			}
+		}
+		return &binScanner{
+			kind: reflect.Int8,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*int8)
+				if ok {
+					*p = int8(buf[0])
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return int8(buf[0])
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetInt(int64(int8(buf[0])))
+			},
+		}
+	case reflect.Uint8:
+		return &binScanner{
+			kind: reflect.Uint8,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*uint8)
+				if ok {
+					*p = buf[0]
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return buf[0]
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetUint(uint64(buf[0]))
+			},
+		}
+	case reflect.Int16:
+		return &binScanner{
+			kind: reflect.Int16,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*int16)
+				if ok {
+					*p = int16(binary.BigEndian.Uint16(buf))
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return int16(binary.BigEndian.Uint16(buf))
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetInt(int64(int16(binary.BigEndian.Uint16(buf))))
+			},
+		}
+	case reflect.Uint16:
+		if flip {
+			return &binScanner{
+				kind: reflect.Uint16,
+				scan: func(buf []byte, ptr interface{}) bool {
+					p, ok := ptr.(*uint16)
+					if ok {
+						*p = binary.BigEndian.Uint16(buf) ^ (1 << 15)
+					}
+					return ok
+				},
+				value: func(buf []byte) interface{} {
+					return binary.BigEndian.Uint16(buf) ^ (1 << 15)
+				},
+				set: func(v reflect.Value, buf []byte) {
+					v.SetUint(uint64(binary.BigEndian.Uint16(buf) ^ (1 << 15)))
+				},
+			}
+		}
+		return &binScanner{
+			kind: reflect.Uint16,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*uint16)
+				if ok {
+					*p = binary.BigEndian.Uint16(buf)
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return binary.BigEndian.Uint16(buf)
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetUint(uint64(binary.BigEndian.Uint16(buf)))
+			},
+		}
+	case reflect.Int32:
+		return &binScanner{
+			kind: reflect.Int32,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*int32)
+				if ok {
+					*p = int32(binary.BigEndian.Uint32(buf))
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return int32(binary.BigEndian.Uint32(buf))
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetInt(int64(int32(binary.BigEndian.Uint32(buf))))
+			},
+		}
+	case reflect.Uint32:
+		if flip {
+			return &binScanner{
+				kind: reflect.Uint32,
+				scan: func(buf []byte, ptr interface{}) bool {
+					p, ok := ptr.(*uint32)
+					if ok {
+						*p = binary.BigEndian.Uint32(buf) ^ (1 << 31)
+					}
+					return ok
+				},
+				value: func(buf []byte) interface{} {
+					return binary.BigEndian.Uint32(buf) ^ (1 << 31)
+				},
+				set: func(v reflect.Value, buf []byte) {
+					v.SetUint(uint64(binary.BigEndian.Uint32(buf) ^ (1 << 31)))
+				},
+			}
+		}
+		return &binScanner{
+			kind: reflect.Uint32,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*uint32)
+				if ok {
+					*p = binary.BigEndian.Uint32(buf)
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return binary.BigEndian.Uint32(buf)
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetUint(uint64(binary.BigEndian.Uint32(buf)))
+			},
+		}
+	case reflect.Int64:
+		return &binScanner{
+			kind: reflect.Int64,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*int64)
+				if ok {
+					*p = int64(binary.BigEndian.Uint64(buf))
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return int64(binary.BigEndian.Uint64(buf))
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetInt(int64(binary.BigEndian.Uint64(buf)))
+			},
+		}
+	case reflect.Uint64:
+		if flip {
+			return &binScanner{
+				kind: reflect.Uint64,
+				scan: func(buf []byte, ptr interface{}) bool {
+					p, ok := ptr.(*uint64)
+					if ok {
+						*p = binary.BigEndian.Uint64(buf) ^ (1 << 63)
+					}
+					return ok
+				},
+				value: func(buf []byte) interface{} {
+					return binary.BigEndian.Uint64(buf) ^ (1 << 63)
+				},
+				set: func(v reflect.Value, buf []byte) {
+					v.SetUint(binary.BigEndian.Uint64(buf) ^ (1 << 63))
+				},
+			}
+		}
+		return &binScanner{
+			kind: reflect.Uint64,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*uint64)
+				if ok {
+					*p = binary.BigEndian.Uint64(buf)
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return binary.BigEndian.Uint64(buf)
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetUint(binary.BigEndian.Uint64(buf))
+			},
+		}
+	case reflect.Float32:
+		return &binScanner{
+			kind: reflect.Float32,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*float32)
+				if ok {
+					*p = math.Float32frombits(binary.BigEndian.Uint32(buf))
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return math.Float32frombits(binary.BigEndian.Uint32(buf))
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetFloat(float64(math.Float32frombits(binary.BigEndian.Uint32(buf))))
+			},
+		}
+	case reflect.Float64:
+		return &binScanner{
+			kind: reflect.Float64,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*float64)
+				if ok {
+					*p = math.Float64frombits(binary.BigEndian.Uint64(buf))
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return math.Float64frombits(binary.BigEndian.Uint64(buf))
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetFloat(math.Float64frombits(binary.BigEndian.Uint64(buf)))
+			},
+		}
+	case reflect.Complex64:
+		return &binScanner{
+			kind: reflect.Complex64,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*complex64)
+				if ok {
+					*p = decodeC64(buf)
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return decodeC64(buf)
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetComplex(complex128(decodeC64(buf)))
+			},
+		}
+	case reflect.Complex128:
+		return &binScanner{
+			kind: reflect.Complex128,
+			scan: func(buf []byte, ptr interface{}) bool {
+				p, ok := ptr.(*complex128)
+				if ok {
+					*p = decodeC128(buf)
+				}
+				return ok
+			},
+			value: func(buf []byte) interface{} {
+				return decodeC128(buf)
+			},
+			set: func(v reflect.Value, buf []byte) {
+				v.SetComplex(decodeC128(buf))
+			},
+		}
+	}
+	return nil
+}
+
+func decodeC64(buf []byte) complex64 {
+	return complex(
+		math.Float32frombits(binary.BigEndian.Uint32(buf[0:4])),
+		math.Float32frombits(binary.BigEndian.Uint32(buf[4:8])),
+	)
+}
+
+func decodeC128(buf []byte) complex128 {
+	return complex(
+		math.Float64frombits(binary.BigEndian.Uint64(buf[0:8])),
+		math.Float64frombits(binary.BigEndian.Uint64(buf[8:16])),
+	)
+}
@@ -0,0 +1,265 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// HeaderSchema describes the keywords a header must hold, as defined by
+// the keyword dictionary of an observatory or pipeline:
+//
+//	schema := fitsio.HeaderSchema{
+//		Required: []string{"OBS-MODE", "EXPTIME"},
+//		Types: map[string]fitsio.CardType{
+//			"EXPTIME": fitsio.CardFloat,
+//		},
+//		Ranges: map[string]fitsio.Range{
+//			"OBS-MODE": fitsio.OneOf("IMAGING", "SPECTRO"),
+//			"EXPTIME":  fitsio.GreaterThan(0),
+//		},
+//	}
+//
+// Types and ranges only constrain the keywords present in the header.
+type HeaderSchema struct {
+	// HDU is the name of the HDUs the schema applies to, as given by
+	// their EXTNAME card, or PRIMARY for the primary HDU.
+	// The schema applies to all the HDUs if HDU is empty.
+	HDU string
+
+	Required []string            // keywords which must be present
+	Types    map[string]CardType // types of the values of keywords
+	Ranges   map[string]Range    // allowed values of keywords
+}
+
+// WithSchema makes Create and NewEncoder validate the header of the HDUs
+// against the schema s, before encoding them.
+// HDUs which do not validate are not written.
+// WithSchema may be given multiple times, to validate HDUs against
+// multiple schemas.
+func WithSchema(s HeaderSchema) Option {
+	return func(cfg *config) {
+		cfg.schemas = append(cfg.schemas, s)
+	}
+}
+
+// Validate checks that the header hdr follows the schema.
+// All the violations of the schema are reported in the returned error.
+func (s *HeaderSchema) Validate(hdr *Header) error {
+	var errs []error
+	for _, key := range s.Required {
+		if hdr.Get(key) == nil {
+			errs = append(errs, fmt.Errorf("fitsio: missing required keyword %s", key))
+		}
+	}
+
+	for _, key := range sortedKeys(s.Types) {
+		card := hdr.Get(key)
+		if card == nil {
+			continue
+		}
+		typ := s.Types[key]
+		if !typ.match(card.Value) {
+			errs = append(errs, fmt.Errorf(
+				"fitsio: invalid type for keyword %s: got %T, want %v",
+				key, card.Value, typ,
+			))
+		}
+	}
+
+	for _, key := range sortedKeys(s.Ranges) {
+		card := hdr.Get(key)
+		if card == nil {
+			continue
+		}
+		err := s.Ranges[key].check(card.Value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fitsio: invalid value for keyword %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// applies returns whether the schema applies to the HDU with header hdr.
+func (s *HeaderSchema) applies(hdr *Header) bool {
+	switch s.HDU {
+	case "":
+		return true
+	case "PRIMARY":
+		return hdr.Get("SIMPLE") != nil
+	}
+	card := hdr.Get("EXTNAME")
+	if card == nil {
+		return false
+	}
+	name, ok := card.Value.(string)
+	return ok && strings.TrimSpace(name) == s.HDU
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// CardType is the type of the value of a header card.
+type CardType int
+
+const (
+	CardString  CardType = iota + 1 // character string
+	CardLogical                     // logical (T or F)
+	CardInt                         // integer
+	CardFloat                       // real number (integers are accepted too)
+	CardComplex                     // complex number
+)
+
+func (t CardType) String() string {
+	switch t {
+	case CardString:
+		return "string"
+	case CardLogical:
+		return "logical"
+	case CardInt:
+		return "integer"
+	case CardFloat:
+		return "float"
+	case CardComplex:
+		return "complex"
+	}
+	return fmt.Sprintf("CardType(%d)", int(t))
+}
+
+func (t CardType) match(v Value) bool {
+	switch v.(type) {
+	case string:
+		return t == CardString
+	case bool:
+		return t == CardLogical
+	case int, int64, big.Int:
+		return t == CardInt || t == CardFloat
+	case float64:
+		return t == CardFloat
+	case complex128:
+		return t == CardComplex
+	}
+	return false
+}
+
+// Range constrains the value of a keyword, to an interval of numbers or
+// to a set of values.
+// Ranges are created with Between, GreaterThan, AtLeast, LessThan, AtMost
+// and OneOf.
+type Range struct {
+	min, max   float64 // bounds of the interval (infinite if unbounded)
+	xmin, xmax bool    // whether the bounds are excluded
+	values     []Value // allowed values (interval if nil)
+}
+
+// Between returns the range of the numbers between min and max, inclusive.
+func Between(min, max float64) Range {
+	return Range{min: min, max: max}
+}
+
+// GreaterThan returns the range of the numbers greater than x.
+func GreaterThan(x float64) Range {
+	return Range{min: x, max: math.Inf(+1), xmin: true}
+}
+
+// AtLeast returns the range of the numbers greater than or equal to x.
+func AtLeast(x float64) Range {
+	return Range{min: x, max: math.Inf(+1)}
+}
+
+// LessThan returns the range of the numbers less than x.
+func LessThan(x float64) Range {
+	return Range{min: math.Inf(-1), max: x, xmax: true}
+}
+
+// AtMost returns the range of the numbers less than or equal to x.
+func AtMost(x float64) Range {
+	return Range{min: math.Inf(-1), max: x}
+}
+
+// OneOf returns the range made of the provided values.
+// Integer values match integer and real card values.
+func OneOf(values ...Value) Range {
+	if values == nil {
+		values = []Value{}
+	}
+	return Range{values: values}
+}
+
+func (r Range) String() string {
+	if r.values != nil {
+		strs := make([]string, len(r.values))
+		for i, v := range r.values {
+			strs[i] = fmt.Sprintf("%v", v)
+		}
+		return "{" + strings.Join(strs, ", ") + "}"
+	}
+	lo, hi := "[", "]"
+	if r.xmin {
+		lo = "("
+	}
+	if r.xmax {
+		hi = ")"
+	}
+	return fmt.Sprintf("%s%v, %v%s", lo, r.min, r.max, hi)
+}
+
+func (r Range) check(v Value) error {
+	if r.values != nil {
+		for _, want := range r.values {
+			if valueEqual(v, want) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%v not in %v", v, r)
+	}
+
+	x, ok := numberValue(v)
+	if !ok {
+		return fmt.Errorf("%v (%T) is not a number", v, v)
+	}
+	switch {
+	case math.IsNaN(x),
+		x < r.min, r.xmin && x == r.min,
+		x > r.max, r.xmax && x == r.max:
+		return fmt.Errorf("%v not in %v", v, r)
+	}
+	return nil
+}
+
+// valueEqual returns whether the value of a card equals v, comparing
+// numbers by value.
+func valueEqual(card, v Value) bool {
+	x, okx := numberValue(card)
+	y, oky := numberValue(v)
+	if okx && oky {
+		return x == y
+	}
+	return reflect.DeepEqual(card, v)
+}
+
+// numberValue returns the value of a numerical card as a float64.
+func numberValue(v Value) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return toFloat(rv), true
+	}
+	return 0, false
+}
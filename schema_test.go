@@ -0,0 +1,126 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHeaderSchema(t *testing.T) {
+	schema := HeaderSchema{
+		Required: []string{"OBS-MODE", "EXPTIME"},
+		Types: map[string]CardType{
+			"EXPTIME":  CardFloat,
+			"NCOMBINE": CardInt,
+		},
+		Ranges: map[string]Range{
+			"OBS-MODE": OneOf("IMAGING", "SPECTRO"),
+			"EXPTIME":  GreaterThan(0),
+			"AIRMASS":  Between(1, 10),
+		},
+	}
+
+	for _, tc := range []struct {
+		name  string
+		cards []Card
+		errs  []string
+	}{
+		{
+			name: "valid",
+			cards: []Card{
+				{Name: "OBS-MODE", Value: "IMAGING"},
+				{Name: "EXPTIME", Value: 30},
+				{Name: "AIRMASS", Value: 1.0},
+			},
+		},
+		{
+			name:  "missing",
+			cards: []Card{{Name: "OBS-MODE", Value: "SPECTRO"}},
+			errs:  []string{"missing required keyword EXPTIME"},
+		},
+		{
+			name: "invalid",
+			cards: []Card{
+				{Name: "OBS-MODE", Value: "DARK"},
+				{Name: "EXPTIME", Value: 0.0},
+				{Name: "NCOMBINE", Value: 2.5},
+				{Name: "AIRMASS", Value: "high"},
+			},
+			errs: []string{
+				"invalid type for keyword NCOMBINE",
+				"invalid value for keyword AIRMASS: high (string) is not a number",
+				"invalid value for keyword EXPTIME: 0 not in (0, +Inf]",
+				"invalid value for keyword OBS-MODE: DARK not in {IMAGING, SPECTRO}",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hdr := NewHeader(tc.cards, IMAGE_HDU, 8, nil)
+			err := schema.Validate(hdr)
+			if tc.errs == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %+v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tc.errs) {
+				t.Fatalf("invalid number of errors: got=%d, want=%d\n%v", len(lines), len(tc.errs), err)
+			}
+			for i, want := range tc.errs {
+				if !strings.Contains(lines[i], want) {
+					t.Fatalf("invalid error #%d:\ngot= %q\nwant=%q", i, lines[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestWithSchema(t *testing.T) {
+	buf := new(bytes.Buffer)
+	f, err := Create(buf,
+		WithSchema(HeaderSchema{HDU: "PRIMARY", Required: []string{"TELESCOP"}}),
+		WithSchema(HeaderSchema{HDU: "SCI", Ranges: map[string]Range{"GAIN": AtLeast(1)}}),
+	)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	phdu := NewImage(8, nil)
+	err = f.Write(phdu)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("invalid HDU was written")
+	}
+
+	phdu.Header().Set("TELESCOP", "HST", "")
+	err = f.Write(phdu)
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %+v", err)
+	}
+
+	img := NewImage(8, []int{2})
+	img.Header().Set("EXTNAME", "SCI", "")
+	img.Header().Set("GAIN", 0.5, "")
+	err = f.Write(img)
+	if err == nil || !strings.Contains(err.Error(), "GAIN") {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	// the schema does not apply to other extensions.
+	img.Header().Set("EXTNAME", "ERR", "")
+	err = f.Write(img)
+	if err != nil {
+		t.Fatalf("could not write image: %+v", err)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Combine describes how the values of a pixel across a stack of images
+// are combined.
+type Combine int
+
+const (
+	MeanCombine      Combine = iota // (weighted) mean of the values
+	MedianCombine                   // median of the values
+	SigmaClipCombine                // (weighted) mean of the values after sigma-clipping
+)
+
+// StackMethod describes how a stack of images is combined.
+type StackMethod struct {
+	Combine Combine
+
+	// Sigma is the clipping threshold, in units of standard deviation
+	// around the median, for SigmaClipCombine.
+	Sigma float64
+
+	// Iters is the maximum number of clipping iterations for
+	// SigmaClipCombine. Zero means clipping until convergence.
+	Iters int
+
+	// Weights are the optional per-image weights of MeanCombine and
+	// SigmaClipCombine. Nil means uniform weights.
+	Weights []float64
+}
+
+// Predefined stacking methods.
+var (
+	MeanStack      = StackMethod{Combine: MeanCombine}
+	MedianStack    = StackMethod{Combine: MedianCombine}
+	SigmaClipStack = StackMethod{Combine: SigmaClipCombine, Sigma: 3, Iters: 5}
+)
+
+// stackChunk is the number of pixels combined at once by StackImages.
+const stackChunk = 1 << 14
+
+// StackImages combines the physical pixel values of the provided images,
+// which must have the same axes, according to method.
+//
+// Blank and NaN pixels are ignored: pixels with no valid value in any of
+// the images are NaN in the returned image.
+// The returned image has a BITPIX of -32 when all the images fit into
+// a float32, and -64 otherwise. Its header holds the non-structural
+// keywords of the first image, and the number of combined images as
+// NCOMBINE.
+//
+// Images are combined in chunks of pixels, so that the additional memory
+// needed is bounded, whatever the number of images.
+func StackImages(imgs []Image, method StackMethod) (Image, error) {
+	if len(imgs) == 0 {
+		return nil, fmt.Errorf("fitsio: no image to stack")
+	}
+	switch method.Combine {
+	case MeanCombine:
+	case MedianCombine:
+		if method.Weights != nil {
+			return nil, fmt.Errorf("fitsio: weights are not supported by median stacking")
+		}
+	case SigmaClipCombine:
+		if !(method.Sigma > 0) {
+			return nil, fmt.Errorf("fitsio: invalid clipping threshold (%v)", method.Sigma)
+		}
+		if method.Iters < 0 {
+			return nil, fmt.Errorf("fitsio: invalid number of clipping iterations (%d)", method.Iters)
+		}
+	default:
+		return nil, fmt.Errorf("fitsio: invalid stacking method %d", method.Combine)
+	}
+	if method.Weights != nil {
+		if len(method.Weights) != len(imgs) {
+			return nil, fmt.Errorf(
+				"fitsio: invalid number of weights (got=%d, want=%d)",
+				len(method.Weights), len(imgs),
+			)
+		}
+		for i, w := range method.Weights {
+			if !(w >= 0) || math.IsInf(w, 0) {
+				return nil, fmt.Errorf("fitsio: invalid weight for image %d (%v)", i, w)
+			}
+		}
+	}
+
+	var (
+		axes   = imgs[0].Header().Axes()
+		pixs   = make([]pixels, len(imgs))
+		bitpix = -32
+	)
+	for i, img := range imgs {
+		if img == nil {
+			return nil, fmt.Errorf("fitsio: nil image %d", i)
+		}
+		if !slices.Equal(img.Header().Axes(), axes) {
+			return nil, fmt.Errorf(
+				"fitsio: image %d axes mismatch (%v != %v)",
+				i, img.Header().Axes(), axes,
+			)
+		}
+		pix, err := newPixels(img.Header(), img.Raw())
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not read image %d: %w", i, err)
+		}
+		pixs[i] = pix
+		bitpix = promoteBitpix(DivOp, bitpix, pix.kind())
+	}
+
+	var (
+		npix = pixs[0].n
+		out  = make([]float64, npix)
+		buf  = make([]float64, min(npix, stackChunk)*len(imgs))
+		vs   = make([]float64, 0, len(imgs))
+		ws   = make([]float64, 0, len(imgs))
+	)
+	for beg := 0; beg < npix; beg += stackChunk {
+		end := min(beg+stackChunk, npix)
+		n := end - beg
+		for j := range pixs {
+			for i := 0; i < n; i++ {
+				v, ok := pixs[j].at(beg + i)
+				if !ok {
+					v = math.NaN()
+				}
+				buf[i*len(imgs)+j] = v
+			}
+		}
+		for i := 0; i < n; i++ {
+			vs, ws = vs[:0], ws[:0]
+			for j, v := range buf[i*len(imgs) : (i+1)*len(imgs)] {
+				if math.IsNaN(v) {
+					continue
+				}
+				vs = append(vs, v)
+				if method.Weights != nil {
+					ws = append(ws, method.Weights[j])
+				}
+			}
+			out[beg+i] = method.combine(vs, ws)
+		}
+	}
+
+	img, err := newImageOpResult(imgs[0].Header(), bitpix, axes, out)
+	if err != nil {
+		return nil, err
+	}
+	img.Header().Set("NCOMBINE", len(imgs), "number of combined images")
+	return img, nil
+}
+
+// combine returns the combination of the vs values, with the optional
+// ws weights. vs may be modified.
+func (m StackMethod) combine(vs, ws []float64) float64 {
+	if len(vs) == 0 {
+		return math.NaN()
+	}
+	switch m.Combine {
+	case MedianCombine:
+		sort.Float64s(vs)
+		return quantile(vs, 0.5)
+	case SigmaClipCombine:
+		vs, ws = sigmaClip(vs, ws, m.Sigma, m.Iters)
+	}
+	return weightedMean(vs, ws)
+}
+
+// weightedMean returns the mean of vs, weighted by ws if not empty.
+func weightedMean(vs, ws []float64) float64 {
+	var sum, wsum float64
+	for i, v := range vs {
+		w := 1.0
+		if len(ws) > 0 {
+			w = ws[i]
+		}
+		sum += w * v
+		wsum += w
+	}
+	if wsum == 0 {
+		return math.NaN()
+	}
+	return sum / wsum
+}
+
+// sigmaClip iteratively rejects the values of vs further than sigma
+// standard deviations away from their median, and returns the remaining
+// values and their weights. vs and ws are modified in place.
+func sigmaClip(vs, ws []float64, sigma float64, iters int) ([]float64, []float64) {
+	sorted := make([]float64, 0, len(vs))
+	for iter := 0; iters == 0 || iter < iters; iter++ {
+		if len(vs) < 3 {
+			break
+		}
+		sorted = append(sorted[:0], vs...)
+		sort.Float64s(sorted)
+		med := quantile(sorted, 0.5)
+
+		var mean, std float64
+		for _, v := range vs {
+			mean += v
+		}
+		mean /= float64(len(vs))
+		for _, v := range vs {
+			std += (v - mean) * (v - mean)
+		}
+		std = math.Sqrt(std / float64(len(vs)))
+
+		n := 0
+		for i, v := range vs {
+			if math.Abs(v-med) > sigma*std {
+				continue
+			}
+			vs[n] = v
+			if len(ws) > 0 {
+				ws[n] = ws[i]
+			}
+			n++
+		}
+		if n == len(vs) {
+			break
+		}
+		vs = vs[:n]
+		if len(ws) > 0 {
+			ws = ws[:n]
+		}
+	}
+	return vs, ws
+}
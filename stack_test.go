@@ -0,0 +1,145 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStackImages(t *testing.T) {
+	// 10 images of 2x1 pixels: the first pixel holds an outlier in the
+	// last image, the second pixel is blank in the first image.
+	imgs := make([]Image, 10)
+	for i := range imgs {
+		img := NewImage(16, []int{2, 1})
+		err := img.Header().Append(
+			Card{Name: "BLANK", Value: -1},
+			Card{Name: "OBJECT", Value: "M42"},
+		)
+		if err != nil {
+			t.Fatalf("could not append cards: %v", err)
+		}
+		data := []int16{1, int16(i)}
+		if i == 9 {
+			data[0] = 100
+		}
+		if i == 0 {
+			data[1] = -1
+		}
+		err = img.Write(data)
+		if err != nil {
+			t.Fatalf("could not write image: %v", err)
+		}
+		imgs[i] = img
+	}
+	weights := make([]float64, len(imgs))
+	for i := range weights {
+		weights[i] = 1
+	}
+	weights[9] = 10
+
+	for _, test := range []struct {
+		name   string
+		method StackMethod
+		want   []float64
+	}{
+		{"mean", MeanStack, []float64{10.9, 5}},
+		{"median", MedianStack, []float64{1, 5}},
+		{"sigma-clip", SigmaClipStack, []float64{1, 5}},
+		{"weighted-mean", StackMethod{Combine: MeanCombine, Weights: weights}, []float64{1009.0 / 19, 126.0 / 18}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			img, err := StackImages(imgs, test.method)
+			if err != nil {
+				t.Fatalf("could not stack images: %v", err)
+			}
+			if got, want := img.Header().Bitpix(), -32; got != want {
+				t.Fatalf("invalid BITPIX: got=%d, want=%d", got, want)
+			}
+			if got := img.Header().Get("NCOMBINE"); got == nil || got.Value != 10 {
+				t.Fatalf("invalid NCOMBINE card: %v", got)
+			}
+			if got := img.Header().Get("OBJECT"); got == nil || got.Value != "M42" {
+				t.Fatalf("invalid OBJECT card: %v", got)
+			}
+			got, err := img.Scaled()
+			if err != nil {
+				t.Fatalf("could not scale pixels: %v", err)
+			}
+			for i, v := range got {
+				if want := float64(float32(test.want[i])); v != want {
+					t.Fatalf("invalid pixel %d: got=%v, want=%v", i, v, want)
+				}
+			}
+		})
+	}
+
+	t.Run("chunks", func(t *testing.T) {
+		const n = stackChunk + 10
+		a := NewImage(-64, []int{n})
+		b := NewImage(-64, []int{n})
+		va := make([]float64, n)
+		vb := make([]float64, n)
+		for i := range va {
+			va[i] = float64(i)
+			vb[i] = float64(3 * i)
+		}
+		vb[n-1] = math.NaN()
+		if err := a.Write(va); err != nil {
+			t.Fatalf("could not write image: %v", err)
+		}
+		if err := b.Write(vb); err != nil {
+			t.Fatalf("could not write image: %v", err)
+		}
+		img, err := StackImages([]Image{a, b}, MeanStack)
+		if err != nil {
+			t.Fatalf("could not stack images: %v", err)
+		}
+		if got, want := img.Header().Bitpix(), -64; got != want {
+			t.Fatalf("invalid BITPIX: got=%d, want=%d", got, want)
+		}
+		got, err := img.Scaled()
+		if err != nil {
+			t.Fatalf("could not scale pixels: %v", err)
+		}
+		for i, v := range got {
+			want := float64(2 * i)
+			if i == n-1 {
+				want = float64(i)
+			}
+			if v != want {
+				t.Fatalf("invalid pixel %d: got=%v, want=%v", i, v, want)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		other := NewImage(16, []int{1, 2})
+		if err := other.Write([]int16{1, 2}); err != nil {
+			t.Fatalf("could not write image: %v", err)
+		}
+		for _, test := range []struct {
+			name   string
+			imgs   []Image
+			method StackMethod
+		}{
+			{"empty", nil, MeanStack},
+			{"axes", []Image{imgs[0], other}, MeanStack},
+			{"method", imgs, StackMethod{Combine: 42}},
+			{"sigma", imgs, StackMethod{Combine: SigmaClipCombine}},
+			{"median-weights", imgs, StackMethod{Combine: MedianCombine, Weights: weights}},
+			{"weights", imgs, StackMethod{Combine: MeanCombine, Weights: weights[:2]}},
+			{"negative-weight", imgs[:1], StackMethod{Combine: MeanCombine, Weights: []float64{-1}}},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				_, err := StackImages(test.imgs, test.method)
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,238 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+)
+
+// ImageStats holds summary statistics of the physical pixel values
+// of an image (ie: after BSCALE/BZERO rescaling.)
+// Blank (BLANK) and NaN pixels are not taken into account.
+type ImageStats struct {
+	N      int     // number of valid pixels
+	NBlank int     // number of blank or NaN pixels
+	Min    float64 // minimum pixel value
+	Max    float64 // maximum pixel value
+	Mean   float64 // mean pixel value
+	StdDev float64 // standard deviation of pixel values
+	Median float64 // median pixel value
+
+	// Percentiles holds the values of the percentiles requested
+	// to Stats, in the same order.
+	Percentiles []float64
+}
+
+// Stats computes summary statistics of the image pixels in a single
+// pass over the raw data.
+// Percentiles are given in the [0, 100] range and are computed by linear
+// interpolation between the closest ranks.
+//
+// All statistics are NaN if the image has no valid pixel.
+func (img *imageHDU) Stats(percentiles ...float64) (ImageStats, error) {
+	stats := ImageStats{
+		Min:         math.NaN(),
+		Max:         math.NaN(),
+		Mean:        math.NaN(),
+		StdDev:      math.NaN(),
+		Median:      math.NaN(),
+		Percentiles: make([]float64, len(percentiles)),
+	}
+	for i, p := range percentiles {
+		if p < 0 || p > 100 || math.IsNaN(p) {
+			return stats, fmt.Errorf("fitsio: invalid percentile %v", p)
+		}
+		stats.Percentiles[i] = math.NaN()
+	}
+
+	pix, err := newPixels(&img.hdr, img.raw)
+	if err != nil {
+		return stats, err
+	}
+
+	var (
+		vs   = make([]float64, 0, pix.n)
+		mean = 0.0
+		m2   = 0.0
+	)
+	for i := 0; i < pix.n; i++ {
+		v, ok := pix.at(i)
+		if !ok {
+			stats.NBlank++
+			continue
+		}
+		vs = append(vs, v)
+
+		// Welford's online algorithm.
+		n := float64(len(vs))
+		delta := v - mean
+		mean += delta / n
+		m2 += delta * (v - mean)
+	}
+
+	stats.N = len(vs)
+	if stats.N == 0 {
+		return stats, nil
+	}
+
+	sort.Float64s(vs)
+	stats.Min = vs[0]
+	stats.Max = vs[len(vs)-1]
+	stats.Mean = mean
+	stats.StdDev = 0
+	if stats.N > 1 {
+		stats.StdDev = math.Sqrt(m2 / float64(stats.N-1))
+	}
+	stats.Median = quantile(vs, 0.5)
+	for i, p := range percentiles {
+		stats.Percentiles[i] = quantile(vs, p/100)
+	}
+
+	return stats, nil
+}
+
+// quantile returns the q-quantile of the sorted values vs,
+// linearly interpolating between the closest ranks.
+func quantile(vs []float64, q float64) float64 {
+	pos := q * float64(len(vs)-1)
+	i := int(pos)
+	if i >= len(vs)-1 {
+		return vs[len(vs)-1]
+	}
+	frac := pos - float64(i)
+	return vs[i] + frac*(vs[i+1]-vs[i])
+}
+
+// pixels gives access to the physical values of the raw pixels of an image.
+type pixels struct {
+	raw    []byte
+	bitpix int
+	n      int // number of pixels
+
+	bscale float64
+	bzero  float64
+	blank  int64
+	hasBlk bool // whether the image declares a BLANK value
+}
+
+func newPixels(hdr *Header, raw []byte) (pixels, error) {
+	pix := pixels{
+		raw:    raw,
+		bitpix: hdr.Bitpix(),
+		bscale: 1,
+		bzero:  0,
+	}
+
+	switch pix.bitpix {
+	case 8, 16, 32, 64, -32, -64:
+	default:
+		return pix, fmt.Errorf("fitsio: image with unknown BITPIX value of %d", pix.bitpix)
+	}
+
+	if axes := hdr.Axes(); len(axes) > 0 {
+		pix.n = 1
+		for _, dim := range axes {
+			pix.n *= dim
+		}
+	}
+
+	pixsz := pix.bitpix / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
+	if len(raw) < pix.n*pixsz {
+		return pix, fmt.Errorf(
+			"fitsio: image raw data too short (got=%d bytes, want=%d bytes)",
+			len(raw), pix.n*pixsz,
+		)
+	}
+
+	var err error
+	if card := hdr.Get("BSCALE"); card != nil {
+		pix.bscale, err = cardFloat(card)
+		if err != nil {
+			return pix, err
+		}
+	}
+	if card := hdr.Get("BZERO"); card != nil {
+		pix.bzero, err = cardFloat(card)
+		if err != nil {
+			return pix, err
+		}
+	}
+	if card := hdr.Get("BLANK"); card != nil && pix.bitpix > 0 {
+		switch v := card.Value.(type) {
+		case int:
+			pix.blank = int64(v)
+		case int64:
+			pix.blank = v
+		default:
+			return pix, fmt.Errorf("fitsio: invalid BLANK value type (%T)", card.Value)
+		}
+		pix.hasBlk = true
+	}
+
+	return pix, nil
+}
+
+// at returns the physical value of the i-th pixel, and whether that
+// pixel holds a valid (non-blank, non-NaN) value.
+func (pix *pixels) at(i int) (float64, bool) {
+	var v float64
+	switch pix.bitpix {
+	case 8:
+		iv := int64(pix.raw[i])
+		if pix.hasBlk && iv == pix.blank {
+			return math.NaN(), false
+		}
+		v = float64(iv)
+	case 16:
+		iv := int64(int16(binary.BigEndian.Uint16(pix.raw[2*i:])))
+		if pix.hasBlk && iv == pix.blank {
+			return math.NaN(), false
+		}
+		v = float64(iv)
+	case 32:
+		iv := int64(int32(binary.BigEndian.Uint32(pix.raw[4*i:])))
+		if pix.hasBlk && iv == pix.blank {
+			return math.NaN(), false
+		}
+		v = float64(iv)
+	case 64:
+		iv := int64(binary.BigEndian.Uint64(pix.raw[8*i:]))
+		if pix.hasBlk && iv == pix.blank {
+			return math.NaN(), false
+		}
+		v = float64(iv)
+	case -32:
+		v = float64(math.Float32frombits(binary.BigEndian.Uint32(pix.raw[4*i:])))
+	case -64:
+		v = math.Float64frombits(binary.BigEndian.Uint64(pix.raw[8*i:]))
+	}
+	if math.IsNaN(v) {
+		return v, false
+	}
+	return pix.bzero + pix.bscale*v, true
+}
+
+// cardFloat returns the value of a numerical card as a float64.
+func cardFloat(card *Card) (float64, error) {
+	switch v := card.Value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case big.Int:
+		f, _ := new(big.Float).SetInt(&v).Float64()
+		return f, nil
+	}
+	return 0, fmt.Errorf("fitsio: invalid %s value type (%T)", card.Name, card.Value)
+}
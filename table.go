@@ -6,9 +6,15 @@ package fitsio
 
 import (
 	"fmt"
+	"iter"
 	"reflect"
 )
 
+// Table is a FITS ASCII or binary table.
+//
+// Reading a Table does not modify it: multiple goroutines may read the same
+// Table concurrently, with ReadColumn or each with its own Rows iterator.
+// Writing to a Table must not happen concurrently with any other access.
 type Table struct {
 	hdr    Header
 	binary bool
@@ -20,6 +26,14 @@ type Table struct {
 	nrows  int64 // number of rows (ie: NAXIS2)
 	cols   []Column
 	colidx map[string]int // associates a column name to its index
+
+	gap       int        // size of the gap between the data and the heap, in bytes
+	heapIdx   *heapIndex // index of the arrays stored in the heap, for deduplication
+	heapAlign int        // alignment of the arrays stored in the heap, in bytes
+
+	pooled *[]byte // buffer holding data and heap (see WithBufferPool)
+
+	nulls NullPolicy // reading of the undefined fields of ASCII tables
 }
 
 // Close closes this HDU, cleaning up cycles (if any) for garbage collection
@@ -46,18 +60,58 @@ func (t *Table) Name() string {
 	return card.Value.(string)
 }
 
-// Version returns the value of the 'EXTVER' Card (or 1 if none)
+// Version returns the value of the 'EXTVER' Card (or 1 if none or invalid)
 func (t *Table) Version() int {
-	card := t.hdr.Get("EXTVER")
-	if card == nil {
-		return 1
+	return hduVersion(&t.hdr)
+}
+
+// DataSize returns the size in bytes of the data unit of the table,
+// including the gap before the heap and the heap itself.
+func (t *Table) DataSize() int64 {
+	return int64(len(t.data) + t.gap + len(t.heap))
+}
+
+// TableData is the data unit of a table, as returned by Table.Data, in
+// columnar form.
+type TableData struct {
+	Names []string // names of the columns
+
+	// Columns holds the values of each column, as a slice of the Go type
+	// of the column (see Column.Type), with one element per row.
+	Columns []interface{}
+}
+
+// Column returns the values of the column named name, or nil.
+func (data *TableData) Column(name string) interface{} {
+	for i, n := range data.Names {
+		if n == name {
+			return data.Columns[i]
+		}
 	}
-	return card.Value.(int)
+	return nil
 }
 
-// Data returns the image payload
+// Data returns the values of all the columns of the table, as a
+// *TableData.
 func (t *Table) Data() (Value, error) {
-	panic("not implemented")
+	data := &TableData{
+		Names:   make([]string, len(t.cols)),
+		Columns: make([]interface{}, len(t.cols)),
+	}
+	n := int(t.nrows)
+	for icol := range t.cols {
+		col := &t.cols[icol]
+		vs := reflect.MakeSlice(reflect.SliceOf(col.Type()), n, n)
+		for irow := 0; irow < n; irow++ {
+			err := col.read(t, icol, int64(irow), vs.Index(irow).Addr().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("fitsio: could not read row %d of column %q: %w", irow, col.Name, err)
+			}
+		}
+		data.Names[icol] = col.Name
+		data.Columns[icol] = vs.Interface()
+	}
+	return data, nil
 }
 
 func (t *Table) NumRows() int64 {
@@ -85,6 +139,40 @@ func (t *Table) Index(n string) int {
 	return idx
 }
 
+// ReadColumn reads all the values of the i-th column into ptr, a pointer to a slice.
+// The element type of the slice must be the Go type of the column:
+// scalar columns are read into []T, fixed-size array columns into [][N]T
+// and variable length array columns into [][]T.
+func (t *Table) ReadColumn(i int, ptr interface{}) error {
+	var err error
+	if i < 0 || i >= len(t.cols) {
+		return fmt.Errorf("fitsio: column index out of range (%d)", i)
+	}
+	col := &t.cols[i]
+
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("fitsio: ReadColumn takes a pointer to a slice. got: %T", ptr)
+	}
+	rv = rv.Elem()
+	if rv.Type().Elem() != col.Type() {
+		return fmt.Errorf(
+			"fitsio: invalid slice element type for column %q: %w",
+			col.Name, &ErrTypeMismatch{Want: col.Type(), Got: rv.Type().Elem()},
+		)
+	}
+
+	nrows := int(t.nrows)
+	rv.Set(reflect.MakeSlice(rv.Type(), nrows, nrows))
+	for irow := 0; irow < nrows; irow++ {
+		err = col.read(t, i, int64(irow), rv.Index(irow).Addr().Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
 // ReadRange reads rows over the range [beg, end) and returns the corresponding iterator.
 // if end > maxrows, the iteration will stop at maxrows
 // ReadRange has the same semantics than a `for i=0; i < max; i+=inc {...}` loop
@@ -126,9 +214,31 @@ func (t *Table) Read(beg, end int64) (*Rows, error) {
 	return t.ReadRange(beg, end, 1)
 }
 
-// NewTable creates a new table in the given FITS file
-func NewTable(name string, cols []Column, hdutype HDUType) (*Table, error) {
+// Rows returns an iterator over the rows of the range [beg, end).
+// As with Read, the iteration stops at the last row of the table.
+func (t *Table) Rows(beg, end int64) iter.Seq[*Row] {
+	return func(yield func(*Row) bool) {
+		rows, err := t.Read(beg, end)
+		if err != nil {
+			return
+		}
+		defer rows.Close()
+		row := &Row{rows: rows}
+		for rows.Next() {
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
+
+// NewTable creates a new table in the given FITS file.
+//
+// The storage of variable length arrays in the heap of a binary table can
+// be configured with the WithHeapGap, WithHeapDedup and WithHeapAlign options.
+func NewTable(name string, cols []Column, hdutype HDUType, opts ...Option) (*Table, error) {
 	var err error
+	cfg := newConfig(opts)
 
 	isbinary := true
 	switch hdutype {
@@ -150,6 +260,13 @@ func NewTable(name string, cols []Column, hdutype HDUType) (*Table, error) {
 		nrows:  0, // NAXIS2
 		cols:   make([]Column, ncols),
 		colidx: make(map[string]int, ncols),
+
+		gap:       cfg.heapGap,
+		heapAlign: cfg.heapAlign,
+		nulls:     cfg.nulls,
+	}
+	if cfg.heapDedup {
+		table.heapIdx = newHeapIndex()
 	}
 
 	copy(table.cols, cols)
@@ -165,8 +282,13 @@ func NewTable(name string, cols []Column, hdutype HDUType) (*Table, error) {
 	)
 
 	offset := 0
+	rowsz := 0
 	for i := 0; i < ncols; i++ {
 		col := &table.cols[i]
+		if !isbinary && col.Start > 0 {
+			// fields of ASCII tables may be separated by spacer characters.
+			offset = int(col.Start) - 1
+		}
 		col.offset = offset
 		switch hdutype {
 		case BINARY_TBL:
@@ -200,10 +322,21 @@ func NewTable(name string, cols []Column, hdutype HDUType) (*Table, error) {
 
 		col.dtype, err = typeFromForm(col.Format, hdutype)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("fitsio: invalid format for column %q: %w", col.Name, err)
+		}
+		if isbinary && col.Bscale == 0 && col.Bzero != 0 {
+			// an unset TSCAL of a column with a TZERO offset.
+			col.Bscale = 1
+		}
+		if isbinary {
+			col.dtype = zeroType(col.dtype, col.Bscale, col.Bzero)
+			col.scanner = scannerFor(col)
 		}
 
 		offset += col.dtype.dsize * col.dtype.len
+		if offset > rowsz {
+			rowsz = offset
+		}
 		col.txtfmt = txtfmtFromForm(col.Format)
 
 		if offset == 0 && i > 0 {
@@ -241,7 +374,7 @@ func NewTable(name string, cols []Column, hdutype HDUType) (*Table, error) {
 		cards = append(cards,
 			Card{
 				Name:    fmt.Sprintf("TZERO%d", i+1),
-				Value:   col.Bzero,
+				Value:   tzeroValue(col),
 				Comment: fmt.Sprintf("zero value for column %d", i+1),
 			},
 		)
@@ -301,9 +434,9 @@ func NewTable(name string, cols []Column, hdutype HDUType) (*Table, error) {
 	)
 
 	bitpix := 8
-	hdr := newHeader(cards, hdutype, bitpix, []int{offset, 0})
+	hdr := newHeader(cards, hdutype, bitpix, []int{rowsz, 0})
 	table.hdr = *hdr
-	table.rowsz = offset
+	table.rowsz = rowsz
 
 	return table, err
 }
@@ -328,22 +461,66 @@ func NewTableFrom(name string, v Value, hdutype HDUType) (*Table, error) {
 		if form == "" {
 			return nil, fmt.Errorf("fitsio: no FITS TFORM for field [%d] %#v", i, field.Interface())
 		}
-		cols = append(cols,
-			Column{
-				Name:   name,
-				Format: form,
-			},
-		)
+		col := Column{
+			Name:   name,
+			Format: form,
+		}
+		if zero := zeroFromGoType(field.Type(), hdutype); zero != 0 {
+			col.Bscale = 1
+			col.Bzero = zero
+		}
+		cols = append(cols, col)
 	}
 	return NewTable(name, cols, hdutype)
 }
 
 // Write writes the data into the columns at the current row.
+//
+// Scalar values of binary tables may be written from pointers (such as
+// a **bool, or a struct field of type *bool): nil pointers are written as
+// the undefined value of the column, a 0 byte for logical columns, NaN for
+// floating-point columns, or the TNULL value of integer columns.
+//
+// Scalar values of another type than the one of their binary table column
+// are converted with the rules of Rows.Scan: values which do not fit in
+// the column type, such as a uint16 65535 written to an 'I' column, are
+// rejected.
 func (t *Table) Write(args ...interface{}) error {
 	var err error
 
-	t.data = append(t.data, make([]byte, t.rowsz)...)
+	heapsz := len(t.heap)
+	t.appendRow()
+
+	err = t.writeRow(t.nrows, args...)
+	if err != nil {
+		// drop the partially written row, and the arrays it stored.
+		t.data = t.data[:t.nrows*int64(t.rowsz)]
+		t.heapTruncate(heapsz)
+		return err
+	}
+
+	t.nrows += 1
+	t.hdr.axes[1] += 1
+	return err
+}
 
+// UpdateRow overwrites the columns of the irow-th row with the data
+// pointed at by args, as with Write.
+// When args is a single pointer to a struct or a map, only the columns
+// matching its fields or keys are modified.
+//
+// Variable length arrays are appended to the heap: the heap space used by
+// their previous values is not reclaimed.
+func (t *Table) UpdateRow(irow int64, args ...interface{}) error {
+	if irow < 0 || irow >= t.nrows {
+		return fmt.Errorf("fitsio: row index out of range (%d/%d)", irow, t.nrows)
+	}
+	return t.writeRow(irow, args...)
+}
+
+// writeRow writes the data pointed at by args into the columns of the
+// irow-th row.
+func (t *Table) writeRow(irow int64, args ...interface{}) error {
 	switch len(args) {
 	case 0:
 		return fmt.Errorf("fitsio: Rows.Scan needs at least one argument")
@@ -353,26 +530,139 @@ func (t *Table) Write(args ...interface{}) error {
 		rt := reflect.TypeOf(args[0]).Elem()
 		switch rt.Kind() {
 		case reflect.Map:
-			err = t.writeMap(*args[0].(*map[string]interface{}))
+			return t.writeMap(irow, *args[0].(*map[string]interface{}))
 		case reflect.Struct:
-			err = t.writeStruct(args[0])
-		default:
-			err = t.write(args[0])
+			return t.writeStruct(irow, args[0])
+		}
+	}
+	return t.write(irow, args...)
+}
+
+// ScanRow copies the columns of the irow-th row into the values pointed at
+// by args, as with Rows.Scan, without creating a Rows iterator.
+func (t *Table) ScanRow(irow int64, args ...interface{}) error {
+	if irow < 0 || irow >= t.nrows {
+		return fmt.Errorf("fitsio: row index out of range (%d/%d)", irow, t.nrows)
+	}
+
+	switch len(args) {
+	case 0:
+		return fmt.Errorf("fitsio: Table.ScanRow needs at least one argument")
+
+	case 1:
+		rt := reflect.TypeOf(args[0]).Elem()
+		switch rt.Kind() {
+		case reflect.Map:
+			return t.readMap(irow, *args[0].(*map[string]interface{}))
+		case reflect.Struct:
+			rv := reflect.ValueOf(args[0]).Elem()
+			return t.readFields(irow, rv, t.fieldCols(rt))
+		}
+	}
+	return t.read(irow, args...)
+}
+
+// read reads the columns of the irow-th row into the values pointed at by args.
+func (t *Table) read(irow int64, args ...interface{}) error {
+	var err error
+	if len(args) != len(t.cols) {
+		return fmt.Errorf(
+			"fitsio.Rows.Scan: invalid number of arguments (got %d. expected %d)",
+			len(args),
+			len(t.cols),
+		)
+	}
+	var (
+		beg = t.rowsz * int(irow)
+		row = t.data[beg : beg+t.rowsz]
+	)
+	for i := range t.cols {
+		col := &t.cols[i]
+		if sc := col.scanner; sc != nil && sc.scan(row[col.offset:col.offset+col.dtype.dsize], args[i]) {
+			continue
+		}
+		err = col.read(t, i, irow, args[i])
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// readMap reads the columns of the irow-th row named by the keys of data,
+// or all the columns if data is empty, into data.
+func (t *Table) readMap(irow int64, data map[string]interface{}) error {
+	var err error
+	icols := make([]int, 0, len(data))
+	switch len(data) {
+	case 0:
+		icols = make([]int, len(t.cols))
+		for i := range t.cols {
+			icols[i] = i
 		}
 	default:
-		err = t.write(args...)
+		for k := range data {
+			icol := t.Index(k)
+			if icol >= 0 {
+				icols = append(icols, icol)
+			}
+		}
 	}
 
-	if err != nil {
-		return err
+	for _, icol := range icols {
+		col := &t.cols[icol]
+		if col.scanner != nil {
+			beg := t.rowsz*int(irow) + col.offset
+			data[col.Name] = col.scanner.value(t.data[beg : beg+col.dtype.dsize])
+			continue
+		}
+		val := reflect.New(col.Type())
+		err = col.read(t, icol, irow, val.Interface())
+		if err != nil {
+			return err
+		}
+		data[col.Name] = val.Elem().Interface()
 	}
+	return err
+}
 
-	t.nrows += 1
-	t.hdr.axes[1] += 1
+// readFields reads the irow-th row into the struct value rv, following the
+// (struct-field-index,col-index) pairs icols.
+func (t *Table) readFields(irow int64, rv reflect.Value, icols [][2]int) error {
+	var (
+		err error
+		beg = t.rowsz * int(irow)
+		row = t.data[beg : beg+t.rowsz]
+	)
+	for _, icol := range icols {
+		col := &t.cols[icol[1]]
+		field := rv.Field(icol[0])
+		if sc := col.scanner; sc != nil && field.Kind() == sc.kind {
+			sc.set(field, row[col.offset:col.offset+col.dtype.dsize])
+			continue
+		}
+		value := field.Addr().Interface()
+		err = col.read(t, icol[1], irow, value)
+		if err != nil {
+			return err
+		}
+	}
 	return err
 }
 
-func (t *Table) write(args ...interface{}) error {
+// appendRow appends a new, blank, row to the table buffer.
+func (t *Table) appendRow() {
+	row := make([]byte, t.rowsz)
+	if !t.binary {
+		// blank spacer characters between the fields of ASCII tables.
+		for i := range row {
+			row[i] = ' '
+		}
+	}
+	t.data = append(t.data, row...)
+}
+
+func (t *Table) write(irow int64, args ...interface{}) error {
 	var err error
 	if len(args) != len(t.cols) {
 		return fmt.Errorf(
@@ -383,7 +673,7 @@ func (t *Table) write(args ...interface{}) error {
 	}
 
 	for i := range t.cols {
-		err = t.cols[i].write(t, i, t.nrows, args[i])
+		err = t.cols[i].write(t, i, irow, args[i])
 		if err != nil {
 			return err
 		}
@@ -392,7 +682,7 @@ func (t *Table) write(args ...interface{}) error {
 	return err
 }
 
-func (t *Table) writeMap(data map[string]interface{}) error {
+func (t *Table) writeMap(irow int64, data map[string]interface{}) error {
 	var err error
 	icols := make([]int, 0, len(data))
 	switch len(data) {
@@ -413,40 +703,52 @@ func (t *Table) writeMap(data map[string]interface{}) error {
 	for _, icol := range icols {
 		col := t.Col(icol)
 		val := reflect.New(col.Type())
-		err = col.write(t, icol, t.nrows, val.Interface())
+		if v, ok := data[col.Name]; ok && v != nil {
+			val = reflect.New(reflect.TypeOf(v))
+			val.Elem().Set(reflect.ValueOf(v))
+		}
+		err = col.write(t, icol, irow, val.Interface())
 		if err != nil {
 			return err
 		}
-		data[col.Name] = val.Elem().Interface()
 	}
 	return err
 }
 
-func (t *Table) writeStruct(data interface{}) error {
-	var err error
+func (t *Table) writeStruct(irow int64, data interface{}) error {
 	rt := reflect.TypeOf(data).Elem()
 	rv := reflect.ValueOf(data).Elem()
-	icols := make([][2]int, 0, rt.NumField())
+	return t.writeFields(irow, rv, t.fieldCols(rt))
+}
 
-	if true { // fixme: devise a cache ?
-		for i := 0; i < rt.NumField(); i++ {
-			f := rt.Field(i)
-			n := f.Tag.Get("fits")
-			if n == "" {
-				n = f.Name
-			}
-			icol := t.Index(n)
-			if icol >= 0 {
-				icols = append(icols, [2]int{i, icol})
-			}
+// fieldCols returns the (struct-field-index,col-index) pairs associating
+// the fields of the struct type rt with the columns of the table, through
+// the "fits" struct tag or the field name.
+func (t *Table) fieldCols(rt reflect.Type) [][2]int {
+	icols := make([][2]int, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		n := f.Tag.Get("fits")
+		if n == "" {
+			n = f.Name
+		}
+		icol := t.Index(n)
+		if icol >= 0 {
+			icols = append(icols, [2]int{i, icol})
 		}
 	}
+	return icols
+}
 
+// writeFields writes the fields of the struct value rv to the irow-th row,
+// following the (struct-field-index,col-index) pairs icols.
+func (t *Table) writeFields(irow int64, rv reflect.Value, icols [][2]int) error {
+	var err error
 	for _, icol := range icols {
 		col := &t.cols[icol[1]]
 		field := rv.Field(icol[0])
 		value := field.Addr().Interface()
-		err = col.write(t, icol[1], t.nrows, value)
+		err = col.write(t, icol[1], irow, value)
 		if err != nil {
 			return err
 		}
@@ -495,7 +797,7 @@ func (t *Table) freeze() error {
 			},
 			{
 				Name:    "PCOUNT",
-				Value:   len(t.heap),
+				Value:   t.gap + len(t.heap),
 				Comment: "heap area size (bytes)",
 			},
 			{
@@ -511,26 +813,62 @@ func (t *Table) freeze() error {
 		}
 	}
 
-	if card := t.Header().Get("THEAP"); card == nil {
+	// update the size-dependent cards of tables modified since they were
+	// decoded or last written.
+	for _, card := range []Card{
+		{Name: "NAXIS1", Value: t.rowsz},
+		{Name: "NAXIS2", Value: int(nrows)},
+		{Name: "PCOUNT", Value: t.gap + len(t.heap)},
+	} {
+		if c := t.hdr.Get(card.Name); c != nil {
+			c.Value = card.Value
+		}
+	}
+
+	for _, card := range []Card{
+		{Name: "PCOUNT", Value: t.gap + len(t.heap), Comment: "heap area size (bytes)"},
+		{Name: "GCOUNT", Value: 1, Comment: "one data group"},
+	} {
+		err = t.hdr.setMandatory(card)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !t.binary {
+		return err
+	}
+
+	theap := len(t.data) + t.gap
+	switch card := t.Header().Get("THEAP"); {
+	case card == nil && t.gap == 0 && t.hdr.raw != nil:
+		// THEAP is optional when the heap directly follows the data:
+		// leave it out of headers kept with WithRawHeaders.
+	case card == nil:
 		err = t.hdr.Append([]Card{
 			{
 				Name:    "THEAP",
-				Value:   0,
-				Comment: "gap size (bytes)",
+				Value:   theap,
+				Comment: "offset of the heap (bytes)",
 			},
 		}...)
+	default:
+		card.Value = theap
 	}
 
 	return err
 }
 
 // CopyTable copies all the rows from src into dst.
-func CopyTable(dst, src *Table) error {
-	return CopyTableRange(dst, src, 0, src.NumRows())
+// The metadata of the columns of src (see ColumnMeta) is copied into the
+// columns of dst with the same name, unless dst already defines it.
+func CopyTable(dst, src *Table, opts ...Option) error {
+	return CopyTableRange(dst, src, 0, src.NumRows(), opts...)
 }
 
-// CopyTableRange copies the rows interval [beg,end) from src into dst
-func CopyTableRange(dst, src *Table, beg, end int64) error {
+// CopyTableRange copies the rows interval [beg,end) from src into dst,
+// along with the metadata of the columns, as CopyTable.
+func CopyTableRange(dst, src *Table, beg, end int64, opts ...Option) error {
 	var err error
 	if dst == nil {
 		return fmt.Errorf("fitsio: dst pointer is nil")
@@ -539,6 +877,18 @@ func CopyTableRange(dst, src *Table, beg, end int64) error {
 		return fmt.Errorf("fitsio: src pointer is nil")
 	}
 
+	err = copyColMeta(dst, src)
+	if err != nil {
+		return fmt.Errorf("fitsio: could not copy column metadata: %w", err)
+	}
+
+	cfg := newConfig(opts)
+	progress := func(done int64) {
+		if cfg.progress != nil {
+			cfg.progress(done, end-beg)
+		}
+	}
+
 	vla := false
 	for _, col := range src.Cols() {
 		if col.dtype.tc < 0 {
@@ -571,6 +921,7 @@ func CopyTableRange(dst, src *Table, beg, end int64) error {
 			xx := rv.Interface()
 			data[i] = xx
 		}
+		done := int64(0)
 		for rows.Next() {
 			err = rows.Scan(data...)
 			if err != nil {
@@ -580,6 +931,8 @@ func CopyTableRange(dst, src *Table, beg, end int64) error {
 			if err != nil {
 				return err
 			}
+			done++
+			progress(done)
 		}
 		err = rows.Err()
 		if err != nil {
@@ -591,7 +944,11 @@ func CopyTableRange(dst, src *Table, beg, end int64) error {
 	case false:
 		nrows := end - beg
 		// reserve enough capacity for the new rows
-		dst.data = dst.data[:len(dst.data) : len(dst.data)+int(nrows)*src.rowsz]
+		if n := len(dst.data) + int(nrows)*src.rowsz; cap(dst.data) < n {
+			data := make([]byte, len(dst.data), n)
+			copy(data, dst.data)
+			dst.data = data
+		}
 		for irow := beg; irow < end; irow++ {
 			pstart := src.rowsz * int(irow)
 			pend := pstart + src.rowsz
@@ -600,6 +957,7 @@ func CopyTableRange(dst, src *Table, beg, end int64) error {
 		}
 		dst.nrows += nrows
 		dst.hdr.Axes()[1] += int(nrows)
+		progress(nrows)
 	}
 
 	return err
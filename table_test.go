@@ -5,8 +5,12 @@
 package fitsio
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"reflect"
 	"sort"
@@ -2189,6 +2193,202 @@ func TestTableMapsRW(t *testing.T) {
 	}
 }
 
+func TestTableReadColumn(t *testing.T) {
+	tbl, err := NewTable("cols", []Column{
+		{Name: "i32", Format: "J"},
+		{Name: "arr", Format: "2D"},
+		{Name: "vla", Format: "PE"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	for i := 0; i < 3; i++ {
+		var (
+			i32 = int32(i)
+			arr = [2]float64{float64(i), -float64(i)}
+			vla = make([]float32, i)
+		)
+		for j := range vla {
+			vla[j] = float32(i * j)
+		}
+		err = tbl.Write(&i32, &arr, &vla)
+		if err != nil {
+			t.Fatalf("could not write row %d: %v", i, err)
+		}
+	}
+
+	var i32s []int32
+	err = tbl.ReadColumn(0, &i32s)
+	if err != nil {
+		t.Fatalf("could not read column: %v", err)
+	}
+	if got, want := i32s, []int32{0, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid column.\ngot= %v\nwant=%v", got, want)
+	}
+
+	var arrs [][2]float64
+	err = tbl.ReadColumn(1, &arrs)
+	if err != nil {
+		t.Fatalf("could not read column: %v", err)
+	}
+	if got, want := arrs, [][2]float64{{0, 0}, {1, -1}, {2, -2}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid column.\ngot= %v\nwant=%v", got, want)
+	}
+
+	var vlas [][]float32
+	err = tbl.ReadColumn(2, &vlas)
+	if err != nil {
+		t.Fatalf("could not read column: %v", err)
+	}
+	if got, want := vlas, [][]float32{nil, {0}, {0, 2}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid column.\ngot= %v\nwant=%v", got, want)
+	}
+
+	var bad []float64
+	err = tbl.ReadColumn(0, &bad)
+	if err == nil {
+		t.Fatalf("expected an error reading an int32 column into []float64")
+	}
+}
+
+func TestASCIITableGaps(t *testing.T) {
+	type rowData struct {
+		Name string  `fits:"NAME"`
+		X    int32   `fits:"X"`
+		Y    float64 `fits:"Y"`
+	}
+
+	tbl, err := NewTable("gaps", []Column{
+		{Name: "NAME", Format: "A8", Start: 3},
+		{Name: "X", Format: "I6", Start: 14},
+		{Name: "Y", Format: "E12.5"},
+	}, ASCII_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	want := []rowData{
+		{Name: "abcdefgh", X: 1, Y: 1.5},
+		{Name: "ijklmnop", X: -42, Y: -2.25e10},
+		{Name: "qrstuvwx", X: 12345, Y: 0},
+	}
+	for i := range want {
+		err = tbl.Write(&want[i])
+		if err != nil {
+			t.Fatalf("could not write row %d: %v", i, err)
+		}
+	}
+	if got, want := tbl.Header().Axes()[0], 13+6+12; got != want {
+		t.Fatalf("invalid NAXIS1: got=%d, want=%d", got, want)
+	}
+	for i, want := range []int{3, 14, 20} {
+		if got := tbl.Header().Get(fmt.Sprintf("TBCOL%d", i+1)); got == nil || got.Value != want {
+			t.Fatalf("invalid TBCOL%d: got=%v, want=%d", i+1, got, want)
+		}
+	}
+
+	var buf bytes.Buffer
+	f, err := Create(&buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	err = f.Write(NewImage(8, nil))
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %v", err)
+	}
+	err = f.Write(tbl)
+	if err != nil {
+		t.Fatalf("could not write table: %v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+	raw := buf.Bytes()
+
+	r, err := Open(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer r.Close()
+
+	got := r.HDU(1).(*Table)
+	for irow := 0; irow < len(want); irow++ {
+		row := got.data[irow*got.rowsz : (irow+1)*got.rowsz]
+		for _, gap := range [][2]int{{0, 2}, {10, 13}} {
+			if !bytes.Equal(row[gap[0]:gap[1]], bytes.Repeat([]byte(" "), gap[1]-gap[0])) {
+				t.Fatalf("row %d: invalid spacer characters: %q", irow, row)
+			}
+		}
+	}
+
+	rows, err := got.Read(0, got.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	defer rows.Close()
+	var data []rowData
+	err = rows.ScanAll(&data)
+	if err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Fatalf("invalid data.\ngot= %+v\nwant=%+v", data, want)
+	}
+
+	// TBCOLn pointing past the end of the row.
+	i := bytes.Index(raw, []byte("TBCOL3  ="))
+	if i < 0 || i%80 != 0 {
+		t.Fatalf("could not find TBCOL3 card")
+	}
+	bad := append([]byte(nil), raw...)
+	line := []byte(fmt.Sprintf("TBCOL3  = %20d", 21))
+	copy(bad[i:i+80], append(line, bytes.Repeat([]byte(" "), 80-len(line))...))
+	_, err = Open(bytes.NewReader(bad))
+	if err == nil {
+		t.Fatalf("expected an error with an out of range TBCOL3")
+	}
+}
+
+func TestTableRows(t *testing.T) {
+	tbl := newPlanTable(t, 10)
+	defer tbl.Close()
+
+	var (
+		idx  []int64
+		i32s []int32
+	)
+	for row := range tbl.Rows(2, 20) {
+		var data planData
+		err := row.Scan(&data)
+		if err != nil {
+			t.Fatalf("could not scan row %d: %v", row.Index(), err)
+		}
+		idx = append(idx, row.Index())
+		i32s = append(i32s, data.I32)
+	}
+	if got, want := idx, []int64{2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid row indices.\ngot= %v\nwant=%v", got, want)
+	}
+	if got, want := i32s, []int32{-6, -9, -12, -15, -18, -21, -24, -27}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows.\ngot= %v\nwant=%v", got, want)
+	}
+
+	n := 0
+	for row := range tbl.Rows(0, tbl.NumRows()) {
+		if row.Index() == 3 {
+			break
+		}
+		n++
+	}
+	if n != 3 {
+		t.Fatalf("iteration did not stop (n=%d)", n)
+	}
+}
+
 func BenchmarkTableWriteF64s_10(b *testing.B)     { benchTableWriteF64s(b, 10) }
 func BenchmarkTableWriteF64s_100(b *testing.B)    { benchTableWriteF64s(b, 100) }
 func BenchmarkTableWriteF64s_1000(b *testing.B)   { benchTableWriteF64s(b, 1000) }
@@ -2304,3 +2504,1094 @@ func benchTableWriteF64(b *testing.B, n int) {
 		}
 	}
 }
+
+func TestRowsScanAll(t *testing.T) {
+	type Data struct {
+		I32 int32      `fits:"i32"`
+		Arr [2]float64 `fits:"arr"`
+		Str string     `fits:"str"`
+	}
+
+	tbl, err := NewTable("scan", []Column{
+		{Name: "i32", Format: "J"},
+		{Name: "arr", Format: "2D"},
+		{Name: "str", Format: "8A"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	var want []Data
+	for i := 0; i < 5; i++ {
+		data := Data{
+			I32: int32(i),
+			Arr: [2]float64{float64(i), -float64(i)},
+			Str: fmt.Sprintf("row-%d", i),
+		}
+		err = tbl.Write(&data)
+		if err != nil {
+			t.Fatalf("could not write row %d: %v", i, err)
+		}
+		want = append(want, data)
+	}
+
+	rows, err := tbl.Read(1, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	got := make([]Data, 10)
+	err = rows.ScanAll(&got)
+	if err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+	if !reflect.DeepEqual(got, want[1:]) {
+		t.Fatalf("invalid rows.\ngot= %v\nwant=%v", got, want[1:])
+	}
+	if rows.Next() {
+		t.Fatalf("rows should be exhausted")
+	}
+
+	rows, err = tbl.Read(0, 2)
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	var maps []map[string]interface{}
+	err = rows.ScanAll(&maps)
+	if err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+	if len(maps) != 2 || maps[1]["i32"] != int32(1) || maps[1]["str"] != "row-1" {
+		t.Fatalf("invalid rows: %v", maps)
+	}
+
+	rows, err = tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	var i32s []int32
+	err = rows.ScanAll(&i32s)
+	if err == nil {
+		t.Fatalf("expected an error scanning 3 columns into %T", i32s)
+	}
+	err = rows.ScanAll(got)
+	if err == nil {
+		t.Fatalf("expected an error scanning into a non-pointer")
+	}
+}
+
+func TestScanChan(t *testing.T) {
+	tbl, err := NewTable("chan", []Column{
+		{Name: "i64", Format: "K"},
+		{Name: "f64", Format: "D"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	const n = 10
+	for i := 0; i < n; i++ {
+		var (
+			i64 = int64(i)
+			f64 = float64(i) / 2
+		)
+		err = tbl.Write(&i64, &f64)
+		if err != nil {
+			t.Fatalf("could not write row %d: %v", i, err)
+		}
+	}
+
+	type Data struct {
+		I64 int64   `fits:"i64"`
+		F64 float64 `fits:"f64"`
+	}
+
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	i := 0
+	for data := range ScanChan[Data](context.Background(), rows) {
+		if want := (Data{int64(i), float64(i) / 2}); data != want {
+			t.Fatalf("row %d: got=%v, want=%v", i, data, want)
+		}
+		i++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+	if i != n {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", i, n)
+	}
+
+	rows, err = tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	i = 0
+	for data := range ScanChan[map[string]interface{}](context.Background(), rows) {
+		if got, want := data["i64"], int64(i); got != want {
+			t.Fatalf("row %d: got=%v, want=%v", i, got, want)
+		}
+		i++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+	if i != n {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", i, n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rows, err = tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	ch := ScanChan[Data](ctx, rows)
+	<-ch
+	cancel()
+	for range ch {
+	}
+	if err := rows.Err(); err != context.Canceled {
+		t.Fatalf("invalid error: got=%v, want=%v", err, context.Canceled)
+	}
+}
+
+func TestTableScanUpdateRow(t *testing.T) {
+	type Star struct {
+		ID   int32     `fits:"ID"`
+		Name string    `fits:"NAME"`
+		Mag  float64   `fits:"MAG"`
+		Obs  []float32 `fits:"OBS"`
+	}
+
+	for _, htype := range []HDUType{BINARY_TBL, ASCII_TBL} {
+		t.Run(htype.String(), func(t *testing.T) {
+			cols := []Column{
+				{Name: "ID", Format: "J"},
+				{Name: "NAME", Format: "8A"},
+				{Name: "MAG", Format: "D"},
+				{Name: "OBS", Format: "PE"},
+			}
+			if htype == ASCII_TBL {
+				cols = []Column{
+					{Name: "ID", Format: "I6"},
+					{Name: "NAME", Format: "A8"},
+					{Name: "MAG", Format: "D12.4"},
+				}
+			}
+			tbl, err := NewTable("stars", cols, htype)
+			if err != nil {
+				t.Fatalf("could not create table: %v", err)
+			}
+			defer tbl.Close()
+
+			want := []Star{
+				{1, "vega", 0.03, []float32{1}},
+				{2, "deneb", 1.25, []float32{2, 3}},
+				{3, "altair", 0.77, nil},
+			}
+			for i := range want {
+				if htype == ASCII_TBL {
+					want[i].Obs = nil
+				}
+				err = tbl.Write(&want[i])
+				if err != nil {
+					t.Fatalf("could not write row %d: %v", i, err)
+				}
+			}
+
+			err = tbl.UpdateRow(1, &map[string]interface{}{"MAG": 1.5})
+			if err != nil {
+				t.Fatalf("could not update row: %v", err)
+			}
+			want[1].Mag = 1.5
+
+			upd := Star{ID: 4, Name: "rigel", Mag: 0.13}
+			if htype == BINARY_TBL {
+				upd.Obs = []float32{4, 5, 6}
+			}
+			err = tbl.UpdateRow(2, &upd)
+			if err != nil {
+				t.Fatalf("could not update row: %v", err)
+			}
+			want[2] = upd
+
+			for i := len(want) - 1; i >= 0; i-- {
+				var got Star
+				err = tbl.ScanRow(int64(i), &got)
+				if err != nil {
+					t.Fatalf("could not scan row %d: %v", i, err)
+				}
+				if !reflect.DeepEqual(got, want[i]) {
+					t.Fatalf("row %d: got=%+v, want=%+v", i, got, want[i])
+				}
+			}
+
+			var (
+				id   int32
+				name string
+			)
+			data := map[string]interface{}{"NAME": nil}
+			err = tbl.ScanRow(0, &data)
+			if err != nil {
+				t.Fatalf("could not scan row into map: %v", err)
+			}
+			if got, want := data["NAME"], "vega"; got != want {
+				t.Fatalf("invalid NAME: got=%v, want=%v", got, want)
+			}
+			if htype == ASCII_TBL {
+				var mag float64
+				err = tbl.ScanRow(2, &id, &name, &mag)
+				if err != nil {
+					t.Fatalf("could not scan row: %v", err)
+				}
+				if id != 4 || name != "rigel" || mag != 0.13 {
+					t.Fatalf("invalid row: got=(%d, %q, %v)", id, name, mag)
+				}
+			}
+
+			for _, irow := range []int64{-1, 3} {
+				if err := tbl.ScanRow(irow, &id); err == nil {
+					t.Fatalf("expected an error scanning row %d", irow)
+				}
+				if err := tbl.UpdateRow(irow, &upd); err == nil {
+					t.Fatalf("expected an error updating row %d", irow)
+				}
+			}
+			if err := tbl.ScanRow(0, &id); err == nil {
+				t.Fatalf("expected an error for an invalid number of arguments")
+			}
+		})
+	}
+}
+
+func TestBinaryTableStrings(t *testing.T) {
+	tbl, err := NewTable("strings", []Column{{Name: "NAME", Format: "8A"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	for _, str := range []string{"vega", "", "betelgeuse", "  a b"} {
+		err = tbl.Write(&str)
+		if err != nil {
+			t.Fatalf("could not write string %q: %v", str, err)
+		}
+	}
+
+	// strings are stored as space-padded (and truncated) ASCII fields.
+	if got, want := string(tbl.data), "vega            betelgeu  a b   "; got != want {
+		t.Fatalf("invalid table data:\ngot= %q\nwant=%q", got, want)
+	}
+
+	buf := new(bytes.Buffer)
+	f, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	err = f.Write(NewImage(8, nil))
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %v", err)
+	}
+	err = f.Write(tbl)
+	if err != nil {
+		t.Fatalf("could not write table: %v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("vega            betelgeu")) {
+		t.Fatalf("could not find space-padded strings in file")
+	}
+
+	f, err = Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+	rtbl := f.HDU(1).(*Table)
+
+	// cells as written by this package, by CFITSIO (space padding),
+	// by astropy (NUL padding) and by previous versions of this package
+	// (leading NUL and NUL padding.)
+	copy(rtbl.data[8:], "ab\x00cd   ")
+	rtbl.data = append(rtbl.data, "\x00vega\x00\x00\x00"...)
+	rtbl.nrows++
+
+	want := []string{"vega", "ab", "betelgeu", "  a b", "vega"}
+	var names []string
+	err = rtbl.ReadColumn(0, &names)
+	if err != nil {
+		t.Fatalf("could not read column: %v", err)
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("invalid strings:\ngot= %q\nwant=%q", names, want)
+	}
+
+	type Row struct {
+		Name string `fits:"NAME"`
+	}
+	plan, err := rtbl.Planner(Row{})
+	if err != nil {
+		t.Fatalf("could not create plan: %v", err)
+	}
+	for i, want := range want {
+		var row Row
+		err = plan.Scan(int64(i), &row)
+		if err != nil {
+			t.Fatalf("could not scan row %d: %v", i, err)
+		}
+		if row.Name != want {
+			t.Fatalf("row %d: got=%q, want=%q", i, row.Name, want)
+		}
+	}
+}
+
+func TestUnsignedColumns(t *testing.T) {
+	type Row struct {
+		I8  int8      `fits:"I8"`
+		U16 uint16    `fits:"U16"`
+		U32 uint32    `fits:"U32"`
+		U64 uint64    `fits:"U64"`
+		Arr [2]uint16 `fits:"ARR"`
+		VLA []uint32  `fits:"VLA"`
+	}
+	rows := []Row{
+		{-128, 0, 0, 0, [2]uint16{0, 1}, []uint32{0}},
+		{127, math.MaxUint16, math.MaxUint32, math.MaxUint64, [2]uint16{32768, 65535}, []uint32{1 << 31, 42}},
+	}
+
+	tbl, err := NewTableFrom("unsigned", Row{}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	err = WriteRows(tbl, rows)
+	if err != nil {
+		t.Fatalf("could not write rows: %v", err)
+	}
+
+	// values are stored as signed integers (bytes for int8), offset by TZERO.
+	want := []byte{
+		0x00,
+		0x80, 0x00,
+		0x80, 0x00, 0x00, 0x00,
+		0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x80, 0x00, 0x80, 0x01,
+	}
+	if got := tbl.data[:len(want)]; !bytes.Equal(got, want) {
+		t.Fatalf("invalid table data:\ngot= % x\nwant=% x", got, want)
+	}
+
+	buf := new(bytes.Buffer)
+	f, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for _, hdu := range []HDU{NewImage(8, nil), tbl} {
+		err = f.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	f, err = Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+	rtbl := f.HDU(1).(*Table)
+
+	hdr := rtbl.Header()
+	for i, want := range []struct {
+		form string
+		zero float64
+	}{
+		{"B", -128},
+		{"I", 1 << 15},
+		{"J", 1 << 31},
+		{"K", 1 << 63},
+		{"2I", 1 << 15},
+		{"QJ", 1 << 31},
+	} {
+		if got := hdr.Get(fmt.Sprintf("TFORM%d", i+1)).Value; got != want.form {
+			t.Fatalf("invalid TFORM%d: got=%v, want=%v", i+1, got, want.form)
+		}
+		zero, err := cardFloat(hdr.Get(fmt.Sprintf("TZERO%d", i+1)))
+		if err != nil {
+			t.Fatalf("invalid TZERO%d: %v", i+1, err)
+		}
+		if got := zero; got != want.zero {
+			t.Fatalf("invalid TZERO%d: got=%v, want=%v", i+1, got, want.zero)
+		}
+		if got := fmt.Sprint(hdr.Get(fmt.Sprintf("TSCAL%d", i+1)).Value); got != "1" {
+			t.Fatalf("invalid TSCAL%d: got=%v, want=1", i+1, got)
+		}
+	}
+
+	all, err := rtbl.Read(0, rtbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	var got []Row
+	err = all.ScanAll(&got)
+	if err != nil {
+		t.Fatalf("could not read rows: %v", err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Fatalf("invalid rows:\ngot= %v\nwant=%v", got, rows)
+	}
+
+	all, err = rtbl.Read(1, rtbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	if !all.Next() {
+		t.Fatalf("could not iterate over rows: %v", all.Err())
+	}
+	m := make(map[string]interface{})
+	err = all.Scan(&m)
+	if err != nil {
+		t.Fatalf("could not scan row into map: %v", err)
+	}
+	for k, want := range map[string]interface{}{
+		"I8": rows[1].I8, "U16": rows[1].U16, "U32": rows[1].U32, "U64": rows[1].U64,
+	} {
+		if got := m[k]; got != want {
+			t.Fatalf("invalid %s value: got=%v (%T), want=%v (%T)", k, got, got, want, want)
+		}
+	}
+	var (
+		row1 Row
+		vla  []uint32
+	)
+	err = all.Scan(&row1.I8, &row1.U16, &row1.U32, &row1.U64, &row1.Arr, &vla)
+	if err != nil {
+		t.Fatalf("could not scan row: %v", err)
+	}
+	row1.VLA = vla
+	if !reflect.DeepEqual(row1, rows[1]) {
+		t.Fatalf("invalid scanned row:\ngot= %v\nwant=%v", row1, rows[1])
+	}
+
+	plan, err := rtbl.Planner(Row{})
+	if err != nil {
+		t.Fatalf("could not create plan: %v", err)
+	}
+	var row Row
+	err = plan.Scan(1, &row)
+	if err != nil {
+		t.Fatalf("could not scan row: %v", err)
+	}
+	if !reflect.DeepEqual(row, rows[1]) {
+		t.Fatalf("invalid planned row:\ngot= %v\nwant=%v", row, rows[1])
+	}
+}
+
+func BenchmarkTableScanStruct_1000(b *testing.B)    { benchTableScan(b, "struct", 1000) }
+func BenchmarkTableScanStruct_1000000(b *testing.B) { benchTableScan(b, "struct", 1000000) }
+
+func BenchmarkTableScanMap_1000(b *testing.B)    { benchTableScan(b, "map", 1000) }
+func BenchmarkTableScanMap_1000000(b *testing.B) { benchTableScan(b, "map", 1000000) }
+
+func BenchmarkTableScanArgs_1000(b *testing.B)    { benchTableScan(b, "args", 1000) }
+func BenchmarkTableScanArgs_1000000(b *testing.B) { benchTableScan(b, "args", 1000000) }
+
+func benchTableScan(b *testing.B, kind string, n int) {
+	type Data struct {
+		X float64 `fits:"x"`
+		Y float64 `fits:"y"`
+		Z float64 `fits:"z"`
+		W float64 `fits:"w"`
+	}
+
+	tbl, err := NewTable("scan", []Column{
+		{Name: "x", Format: "D"},
+		{Name: "y", Format: "D"},
+		{Name: "z", Format: "D"},
+		{Name: "w", Format: "D"},
+	}, BINARY_TBL)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer tbl.Close()
+
+	for i := 0; i < n; i++ {
+		v := float64(i)
+		data := Data{X: v, Y: -v, Z: 2 * v, W: v / 2}
+		err = tbl.Write(&data)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	var scan func(rows *Rows) error
+	switch kind {
+	case "struct":
+		var data Data
+		scan = func(rows *Rows) error { return rows.Scan(&data) }
+	case "map":
+		data := make(map[string]interface{}, 4)
+		scan = func(rows *Rows) error { return rows.Scan(&data) }
+	case "args":
+		var x, y, z, w float64
+		scan = func(rows *Rows) error { return rows.Scan(&x, &y, &z, &w) }
+	}
+
+	b.SetBytes(int64(n * tbl.rowsz))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rows, err := tbl.Read(0, tbl.NumRows())
+		if err != nil {
+			b.Fatal(err)
+		}
+		for rows.Next() {
+			err = scan(rows)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+		err = rows.Err()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestScanPromotion(t *testing.T) {
+	tbl, err := NewTable("promote", []Column{
+		{Name: "I", Format: "I"},
+		{Name: "E", Format: "E"},
+		{Name: "J", Format: "J"},
+		{Name: "L", Format: "L"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	for _, v := range []struct {
+		i int16
+		e float32
+		j int32
+		l bool
+	}{
+		{-12, 1.5, 100, true},
+		{300, -2.25, 1 << 20, false},
+	} {
+		err = tbl.Write(&v.i, &v.e, &v.j, &v.l)
+		if err != nil {
+			t.Fatalf("could not write row: %v", err)
+		}
+	}
+
+	type Energy float64
+	type Row struct {
+		I int64   `fits:"I"`
+		E Energy  `fits:"E"`
+		J float64 `fits:"J"`
+		L bool    `fits:"L"`
+	}
+	want := []Row{
+		{-12, 1.5, 100, true},
+		{300, -2.25, 1 << 20, false},
+	}
+
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	n := 0
+	for i := 0; rows.Next(); i++ {
+		n++
+		var row Row
+		err = rows.Scan(&row)
+		if err != nil {
+			t.Fatalf("could not scan row %d into a struct: %v", i, err)
+		}
+		if row != want[i] {
+			t.Fatalf("invalid row %d: got=%+v, want=%+v", i, row, want[i])
+		}
+
+		var (
+			vi float64
+			ve complex128
+			vj uint64
+			vl interface{}
+		)
+		err = rows.Scan(&vi, &ve, &vj, &vl)
+		if err != nil {
+			t.Fatalf("could not scan row %d: %v", i, err)
+		}
+		if vi != float64(want[i].I) || ve != complex(float64(want[i].E), 0) || vj != uint64(want[i].J) || vl != want[i].L {
+			t.Fatalf("invalid row %d: got=(%v, %v, %v, %v)", i, vi, ve, vj, vl)
+		}
+	}
+	if n != len(want) {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", n, len(want))
+	}
+
+	rows, err = tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatalf("could not read first row")
+	}
+	var (
+		i  int16
+		e  float32
+		j  int32
+		l  bool
+		i8 int8
+		u  uint32
+	)
+	err = rows.Scan(&i8, &e, &j, &l)
+	if err != nil {
+		t.Fatalf("could not scan -12 into an int8: %v", err)
+	}
+	err = rows.Scan(&u, &e, &j, &l)
+	if err == nil {
+		t.Fatalf("expected an error scanning -12 into a uint32")
+	}
+	err = rows.Scan(&i, &j, &j, &l)
+	if !errors.As(err, new(*ErrTypeMismatch)) {
+		t.Fatalf("expected a type mismatch scanning a float32 into an int32, got: %v", err)
+	}
+	err = rows.Scan(&i, &e, &j, &i)
+	if !errors.As(err, new(*ErrTypeMismatch)) {
+		t.Fatalf("expected a type mismatch scanning a bool into an int16, got: %v", err)
+	}
+	if !rows.Next() {
+		t.Fatalf("could not read second row")
+	}
+	err = rows.Scan(&i8, &e, &j, &l)
+	if err == nil {
+		t.Fatalf("expected an error scanning 300 into an int8")
+	}
+}
+
+func TestWritePromotion(t *testing.T) {
+	tbl, err := NewTable("promote", []Column{
+		{Name: "VLA", Format: "PJ"},
+		{Name: "U16", Format: "I"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	write := func(v interface{}) error {
+		t.Helper()
+		vla := []int32{1, 2, 3}
+		return tbl.Write(&vla, v)
+	}
+
+	for _, v := range []uint16{0, 1} {
+		err = write(&v)
+		if err != nil {
+			t.Fatalf("could not write %d: %v", v, err)
+		}
+	}
+	u16 := uint16(math.MaxUint16)
+	err = write(&u16)
+	if err == nil {
+		t.Fatalf("expected an error writing %d to an 'I' column", u16)
+	}
+	f := 1.5
+	err = write(&f)
+	var mismatch *ErrTypeMismatch
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected a type mismatch writing a float64 to an 'I' column, got: %v", err)
+	}
+	if mismatch.Want != reflect.TypeOf(int16(0)) || mismatch.Got != reflect.TypeOf(f) {
+		t.Fatalf("invalid type mismatch: %v", mismatch)
+	}
+	u16 = math.MaxInt16
+	err = write(&u16)
+	if err != nil {
+		t.Fatalf("could not write %d: %v", u16, err)
+	}
+	if got, want := tbl.NumRows(), int64(3); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+
+	// rejected rows leave no data behind.
+	buf := new(bytes.Buffer)
+	w, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for _, hdu := range []HDU{NewImage(8, nil), tbl} {
+		err = w.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+	r, err := Open(buf)
+	if err != nil {
+		t.Fatalf("could not reopen file: %v", err)
+	}
+	defer r.Close()
+	back := r.HDU(1).(*Table)
+	if got, want := back.DataSize(), tbl.DataSize(); got != want {
+		t.Fatalf("invalid data size: got=%d, want=%d", got, want)
+	}
+
+	rows, err := back.Read(0, back.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	defer rows.Close()
+	var got []uint16
+	for rows.Next() {
+		var (
+			vla []int32
+			v   uint16
+		)
+		err = rows.Scan(&vla, &v)
+		if err != nil {
+			t.Fatalf("could not scan row: %v", err)
+		}
+		if want := []int32{1, 2, 3}; !reflect.DeepEqual(vla, want) {
+			t.Fatalf("invalid VLA: got=%v, want=%v", vla, want)
+		}
+		got = append(got, v)
+	}
+	if want := []uint16{0, 1, math.MaxInt16}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid values: got=%v, want=%v", got, want)
+	}
+}
+
+func TestScanNullable(t *testing.T) {
+	for _, htype := range []HDUType{BINARY_TBL, ASCII_TBL} {
+		t.Run(htype.String(), func(t *testing.T) {
+			cols := []Column{
+				{Name: "ID", Format: "J", Null: "-1"},
+				{Name: "X", Format: "E"},
+			}
+			if htype == ASCII_TBL {
+				cols = []Column{
+					{Name: "ID", Format: "I6", Null: "-1"},
+					{Name: "X", Format: "E15.7"},
+				}
+			}
+			tbl, err := NewTable("nulls", cols, htype)
+			if err != nil {
+				t.Fatalf("could not create table: %v", err)
+			}
+			defer tbl.Close()
+
+			for _, v := range []struct {
+				id int32
+				x  float32
+			}{
+				{1, 1.5},
+				{-1, float32(math.NaN())},
+			} {
+				err = tbl.Write(&v.id, &v.x)
+				if err != nil {
+					t.Fatalf("could not write row: %v", err)
+				}
+			}
+
+			type Row struct {
+				ID *int32   `fits:"ID"`
+				X  *float64 `fits:"X"`
+			}
+			rows, err := tbl.Read(0, tbl.NumRows())
+			if err != nil {
+				t.Fatalf("could not read table: %v", err)
+			}
+			defer rows.Close()
+
+			if !rows.Next() {
+				t.Fatalf("could not read first row")
+			}
+			var row Row
+			err = rows.Scan(&row)
+			if err != nil {
+				t.Fatalf("could not scan first row: %v", err)
+			}
+			if row.ID == nil || *row.ID != 1 || row.X == nil || *row.X != 1.5 {
+				t.Fatalf("invalid first row: %+v", row)
+			}
+
+			if !rows.Next() {
+				t.Fatalf("could not read second row")
+			}
+			var (
+				id = new(int64)
+				x  = new(float32)
+			)
+			err = rows.Scan(&id, &x)
+			if err != nil {
+				t.Fatalf("could not scan second row: %v", err)
+			}
+			if id != nil {
+				t.Fatalf("invalid null ID: got=%v", *id)
+			}
+			if htype == BINARY_TBL && x != nil {
+				t.Fatalf("invalid null X: got=%v", *x)
+			}
+		})
+	}
+}
+
+func TestTableLogicalArrays(t *testing.T) {
+	tbl, err := NewTable("logicals", []Column{
+		{Name: "ARR", Format: "3L"},
+		{Name: "VLA", Format: "PL"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	var (
+		arr = [3]bool{true, false, true}
+		vla = []bool{false, true}
+	)
+	err = tbl.Write(&arr, &vla)
+	if err != nil {
+		t.Fatalf("could not write row: %v", err)
+	}
+
+	if got, want := string(tbl.data[:3]), "TFT"; got != want {
+		t.Fatalf("invalid logical array bytes: got=%q, want=%q", got, want)
+	}
+	if got, want := string(tbl.heap), "FT"; got != want {
+		t.Fatalf("invalid logical heap bytes: got=%q, want=%q", got, want)
+	}
+
+	// older versions of fitsio stored logical values as 1 and 0.
+	err = tbl.Write(&arr, &vla)
+	if err != nil {
+		t.Fatalf("could not write row: %v", err)
+	}
+	copy(tbl.data[tbl.rowsz:], []byte{1, 0, 1})
+
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	defer rows.Close()
+	for i := 0; rows.Next(); i++ {
+		var (
+			gotArr [3]bool
+			gotVLA []bool
+		)
+		err = rows.Scan(&gotArr, &gotVLA)
+		if err != nil {
+			t.Fatalf("could not scan row %d: %v", i, err)
+		}
+		if gotArr != arr {
+			t.Fatalf("row %d: invalid array: got=%v, want=%v", i, gotArr, arr)
+		}
+		if !reflect.DeepEqual(gotVLA, vla) {
+			t.Fatalf("row %d: invalid VLA: got=%v, want=%v", i, gotVLA, vla)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("error iterating rows: %v", err)
+	}
+
+	type Flags []bool
+	flags := Flags{true}
+	err = tbl.Write(&arr, &flags)
+	if err == nil {
+		t.Fatalf("expected an error writing a %T", flags)
+	}
+}
+
+func TestTableLogicalNulls(t *testing.T) {
+	tbl, err := NewTable("logicals", []Column{{Name: "FLAG", Format: "L"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	type Row struct {
+		Flag *bool `fits:"FLAG"`
+	}
+	var (
+		yes = true
+		no  = false
+	)
+	for _, row := range []Row{{&yes}, {&no}, {nil}} {
+		err = tbl.Write(&row)
+		if err != nil {
+			t.Fatalf("could not write row: %v", err)
+		}
+	}
+	if got, want := tbl.data, []byte{'T', 'F', 0}; !bytes.Equal(got, want) {
+		t.Fatalf("invalid logical bytes: got=%q, want=%q", got, want)
+	}
+
+	// older versions of fitsio stored logical values as 1 and 0.
+	for _, v := range []bool{true, false} {
+		err = tbl.Write(&v)
+		if err != nil {
+			t.Fatalf("could not write row: %v", err)
+		}
+	}
+	copy(tbl.data[3:], []byte{1, 0})
+
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	defer rows.Close()
+
+	var (
+		vals []bool
+		ptrs []*bool
+	)
+	for rows.Next() {
+		var (
+			v bool
+			p *bool
+		)
+		err = rows.Scan(&v)
+		if err != nil {
+			t.Fatalf("could not scan bool: %v", err)
+		}
+		err = rows.Scan(&p)
+		if err != nil {
+			t.Fatalf("could not scan *bool: %v", err)
+		}
+		vals = append(vals, v)
+		ptrs = append(ptrs, p)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("error iterating rows: %v", err)
+	}
+	if want := []bool{true, false, false, true, false}; !reflect.DeepEqual(vals, want) {
+		t.Fatalf("invalid values: got=%v, want=%v", vals, want)
+	}
+	if want := []*bool{&yes, &no, nil, &yes, nil}; !reflect.DeepEqual(ptrs, want) {
+		t.Fatalf("invalid pointers: got=%v, want=%v", ptrs, want)
+	}
+}
+
+func TestASCIITableNulls(t *testing.T) {
+	tbl, err := NewTable("nulls", []Column{
+		{Name: "ID", Format: "I6", Null: "-1"},
+		{Name: "X", Format: "E15.7"},
+		{Name: "NAME", Format: "A4"},
+	}, ASCII_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	for _, v := range []struct {
+		id   int64
+		x    float64
+		name string
+	}{
+		{1, 1.5, "a"},
+		{-1, 2.5, "b"},
+	} {
+		err = tbl.Write(&v.id, &v.x, &v.name)
+		if err != nil {
+			t.Fatalf("could not write row: %v", err)
+		}
+	}
+	// blank the X and NAME fields of the second row.
+	copy(tbl.data[tbl.rowsz+6:], bytes.Repeat([]byte(" "), 19))
+
+	buf := new(bytes.Buffer)
+	f, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	err = f.Write(NewImage(8, nil))
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %v", err)
+	}
+	err = f.Write(tbl)
+	if err != nil {
+		t.Fatalf("could not write table: %v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	for _, tc := range []struct {
+		policy NullPolicy
+		id     int64
+		x      float64
+		err    error
+	}{
+		{policy: NullAsNaN, id: 0, x: math.NaN()},
+		{policy: NullAsZero, id: 0, x: 0},
+		{policy: NullAsError, err: ErrNull},
+	} {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			f, err := Open(bytes.NewReader(buf.Bytes()), WithNullPolicy(tc.policy))
+			if err != nil {
+				t.Fatalf("could not open file: %v", err)
+			}
+			defer f.Close()
+
+			var (
+				tbl  = f.HDU(1).(*Table)
+				id   int64
+				x    float64
+				name string
+			)
+			err = tbl.ScanRow(1, &id, &x, &name)
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not scan row: %v", err)
+			}
+			if id != tc.id {
+				t.Fatalf("invalid ID: got=%d, want=%d", id, tc.id)
+			}
+			if x != tc.x && !(math.IsNaN(x) && math.IsNaN(tc.x)) {
+				t.Fatalf("invalid X: got=%v, want=%v", x, tc.x)
+			}
+			if name != "" {
+				t.Fatalf("invalid NAME: got=%q, want=%q", name, "")
+			}
+
+			var (
+				pid *int64
+				px  *float64
+			)
+			err = tbl.ScanRow(1, &pid, &px, &name)
+			if err != nil {
+				t.Fatalf("could not scan row into pointers: %v", err)
+			}
+			if pid != nil || px != nil {
+				t.Fatalf("invalid pointers: got=(%v, %v), want=(nil, nil)", pid, px)
+			}
+			err = tbl.ScanRow(0, &pid, &px, &name)
+			if err != nil {
+				t.Fatalf("could not scan row into pointers: %v", err)
+			}
+			if pid == nil || *pid != 1 || px == nil || *px != 1.5 || name != "a" {
+				t.Fatalf("invalid first row: got=(%v, %v, %q)", pid, px, name)
+			}
+		})
+	}
+}
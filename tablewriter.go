@@ -0,0 +1,249 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+// seekEncoder is an encoder writing to an io.WriteSeeker.
+// It can go back to the header of an HDU once its data unit was written,
+// to update the cards describing the size of the data unit.
+type seekEncoder struct {
+	*streamEncoder
+	ws   io.WriteSeeker
+	base int64 // offset in ws of the first byte written by the encoder
+}
+
+// endTable writes the heap of the table t and the padding of its data unit,
+// after ndata bytes of rows, and replaces the header of the table with
+// the one of t, which must have the same size as the one written by
+// beginHDU.
+func (enc *seekEncoder) endTable(t *Table, ndata int64, hdrsz int) error {
+	if t.gap > 0 {
+		_, err := enc.w.Write(make([]byte, t.gap))
+		if err != nil {
+			return fmt.Errorf("fitsio: error writing table-heap gap: %v", err)
+		}
+	}
+	_, err := enc.w.Write(t.heap)
+	if err != nil {
+		return fmt.Errorf("fitsio: error writing table-heap: %v", err)
+	}
+
+	padsz := PadBlock(int((ndata + int64(t.gap+len(t.heap))) % BlockSize))
+	if padsz > 0 {
+		pad := make([]byte, padsz)
+		if !t.binary {
+			pad = bytes.Repeat([]byte(" "), padsz)
+		}
+		_, err = enc.w.Write(pad)
+		if err != nil {
+			return fmt.Errorf("fitsio: error while padding table-data block: %v", err)
+		}
+	}
+
+	block, err := enc.encodeHeader(&t.hdr)
+	if err != nil {
+		return err
+	}
+	if len(block) != hdrsz {
+		return fmt.Errorf("fitsio: header size changed while writing table (%d -> %d bytes)", hdrsz, len(block))
+	}
+	_, err = enc.ws.Seek(enc.base+enc.cur.Header, io.SeekStart)
+	if err == nil {
+		_, err = enc.ws.Write(block)
+	}
+	if err == nil {
+		_, err = enc.ws.Seek(enc.base+enc.w.n, io.SeekStart)
+	}
+	if err != nil {
+		return fmt.Errorf("fitsio: could not update table header: %v", err)
+	}
+	enc.cur.End = enc.w.n
+	return nil
+}
+
+// tableChunk is the size of the rows buffered by a TableWriter before they
+// are written out.
+const tableChunk = 64 << 10
+
+// TableWriter writes the rows of a table extension of a File directly to
+// the underlying io.WriteSeeker, so that the memory needed to write a table
+// does not depend on its number of rows.
+//
+// The header of the table is written by File.NewTableWriter, and updated
+// with the final number of rows (NAXIS2) and heap size (PCOUNT) by Close.
+// The heap of the variable length arrays of the table is kept in memory
+// until Close.
+type TableWriter struct {
+	f     *File
+	enc   *seekEncoder
+	table *Table
+
+	nrows int64 // number of rows written
+	ndata int64 // number of bytes of rows written to the encoder
+	hdrsz int   // size of the header blocks
+	err   error // first error encountered, if any
+}
+
+// NewTableWriter writes the header of a new table extension with the given
+// name, columns and type (ASCII_TBL or BINARY_TBL) to the file, and returns
+// a TableWriter to write its rows.
+//
+// The file must have been created with Create over an io.WriteSeeker (such
+// as an *os.File), and already hold a primary HDU.
+// No other HDU may be written to the file until the TableWriter is closed.
+func (f *File) NewTableWriter(name string, cols []Column, htype HDUType) (*TableWriter, error) {
+	if f.mode != WriteOnly && f.mode != ReadWrite {
+		return nil, fmt.Errorf("fitsio: file not open for write")
+	}
+	enc, ok := f.enc.(*seekEncoder)
+	if !ok {
+		return nil, fmt.Errorf("fitsio: streaming tables requires an io.WriteSeeker output")
+	}
+
+	f.wmu.Lock()
+	defer f.wmu.Unlock()
+
+	if f.sw != nil {
+		return nil, fmt.Errorf("fitsio: an HDU is already being written with a %T", f.sw)
+	}
+	f.mu.RLock()
+	nhdus := len(f.hdus)
+	var phdr *Header
+	if nhdus > 0 {
+		phdr = f.hdus[0].Header()
+	}
+	f.mu.RUnlock()
+	if nhdus == 0 {
+		return nil, fmt.Errorf("fitsio: file has no primary header. create one first")
+	}
+	if card := phdr.Get("EXTEND"); card != nil && card.Value == false {
+		return nil, fmt.Errorf("fitsio: primary HDU does not allow extensions (EXTEND=F)")
+	}
+
+	table, err := NewTable(name, cols, htype)
+	if err != nil {
+		return nil, err
+	}
+	err = table.freeze()
+	if err != nil {
+		return nil, err
+	}
+	hdrsz, err := enc.beginHDU(context.Background(), &table.hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &TableWriter{
+		f:     f,
+		enc:   enc,
+		table: table,
+		hdrsz: hdrsz,
+	}
+	f.sw = w
+	return w, nil
+}
+
+// Header returns the header of the table.
+// Cards modified before Close are written out by Close, as long as the
+// size of the header does not change.
+func (w *TableWriter) Header() *Header {
+	return &w.table.hdr
+}
+
+// NumRows returns the number of rows written so far.
+func (w *TableWriter) NumRows() int64 {
+	return w.nrows
+}
+
+// Write writes a new row, from the data pointed at by args, as with
+// Table.Write.
+func (w *TableWriter) Write(args ...interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.f == nil {
+		return fmt.Errorf("fitsio: write to closed TableWriter")
+	}
+	err := w.table.Write(args...)
+	if err != nil {
+		return err
+	}
+	w.nrows++
+	if len(w.table.data) >= tableChunk {
+		w.err = w.flush()
+	}
+	return w.err
+}
+
+// flush writes the buffered rows out.
+func (w *TableWriter) flush() error {
+	t := w.table
+	if len(t.data) == 0 {
+		return nil
+	}
+	_, err := w.enc.w.Write(t.data)
+	if err != nil {
+		return fmt.Errorf("fitsio: error writing table-data: %v", err)
+	}
+	w.ndata += int64(len(t.data))
+	t.data = t.data[:0]
+	t.nrows = 0
+	t.hdr.axes[1] = 0
+	return nil
+}
+
+// Close writes the remaining rows and the heap of the table, and updates
+// its header.
+// The table is then added to the HDUs of the file: its header is available
+// from File.HDU, but not its rows.
+func (w *TableWriter) Close() error {
+	if w.f == nil {
+		return w.err
+	}
+	f := w.f
+	f.wmu.Lock()
+	defer f.wmu.Unlock()
+	f.sw = nil
+	w.f = nil
+
+	if w.err == nil {
+		w.err = w.flush()
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	t := w.table
+	theap := w.ndata + int64(t.gap)
+	for _, card := range []Card{
+		{Name: "NAXIS2", Value: int(w.nrows)},
+		{Name: "PCOUNT", Value: t.gap + len(t.heap)},
+		{Name: "THEAP", Value: int(theap)},
+	} {
+		if c := t.hdr.Get(card.Name); c != nil {
+			c.Value = card.Value
+		}
+	}
+	t.hdr.axes[1] = int(w.nrows)
+
+	w.err = w.enc.endTable(t, w.ndata, w.hdrsz)
+	if w.err != nil {
+		return w.err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.err = f.append(t)
+	if w.err == nil {
+		f.offs = appendOffsets(f.offs, f.enc)
+	}
+	return w.err
+}
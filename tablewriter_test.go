@@ -0,0 +1,154 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTableWriter(t *testing.T) {
+	type Row struct {
+		ID  int32     `fits:"ID"`
+		X   float64   `fits:"X"`
+		VLA []float64 `fits:"VLA"`
+	}
+	const nrows = 10000
+	row := func(i int) Row {
+		vla := make([]float64, 1+i%4)
+		for j := range vla {
+			vla[j] = float64(i + j)
+		}
+		return Row{ID: int32(i), X: float64(i) / 2, VLA: vla}
+	}
+
+	fname := filepath.Join(t.TempDir(), "stream.fits")
+	w, err := os.Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	f, err := Create(w)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	_, err = f.NewTableWriter("stream", []Column{{Name: "ID", Format: "J"}}, BINARY_TBL)
+	if err == nil {
+		t.Fatalf("expected an error without a primary HDU")
+	}
+	err = f.Write(NewImage(8, nil))
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %v", err)
+	}
+
+	tw, err := f.NewTableWriter("stream", []Column{
+		{Name: "ID", Format: "J"},
+		{Name: "X", Format: "D"},
+		{Name: "VLA", Format: "QD"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table writer: %v", err)
+	}
+	err = f.Write(NewImage(8, nil))
+	if err == nil {
+		t.Fatalf("expected an error writing an HDU while streaming a table")
+	}
+	for i := 0; i < nrows; i++ {
+		r := row(i)
+		err = tw.Write(&r)
+		if err != nil {
+			t.Fatalf("could not write row %d: %v", i, err)
+		}
+	}
+	if got := len(tw.table.data); got >= tableChunk {
+		t.Fatalf("rows were not flushed: %d bytes buffered", got)
+	}
+	err = tw.Close()
+	if err != nil {
+		t.Fatalf("could not close table writer: %v", err)
+	}
+	err = tw.Write(&Row{})
+	if err == nil {
+		t.Fatalf("expected an error writing to a closed table writer")
+	}
+
+	img := NewImage(8, []int{2})
+	err = img.Write([]byte{1, 2})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	err = f.Write(img)
+	if err != nil {
+		t.Fatalf("could not write image HDU: %v", err)
+	}
+	offs := f.Offsets()
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := Open(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer r.Close()
+	if got, want := r.NumHDUs(), 3; got != want {
+		t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+	}
+	if got, want := r.Offsets(), offs; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid offsets:\ngot= %+v\nwant=%+v", got, want)
+	}
+
+	tbl := r.HDU(1).(*Table)
+	if got, want := tbl.NumRows(), int64(nrows); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	for i := 0; rows.Next(); i++ {
+		var got Row
+		err = rows.Scan(&got)
+		if err != nil {
+			t.Fatalf("could not scan row %d: %v", i, err)
+		}
+		if want := row(i); !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid row %d: got=%+v, want=%+v", i, got, want)
+		}
+	}
+
+	pix := make([]byte, 2)
+	err = r.HDU(2).(Image).Read(&pix)
+	if err != nil {
+		t.Fatalf("could not read image: %v", err)
+	}
+	if want := []byte{1, 2}; !bytes.Equal(pix, want) {
+		t.Fatalf("invalid image: got=%v, want=%v", pix, want)
+	}
+
+	// streaming requires a seekable output.
+	f, err = Create(new(bytes.Buffer))
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	defer f.Close()
+	err = f.Write(NewImage(8, nil))
+	if err != nil {
+		t.Fatalf("could not write primary HDU: %v", err)
+	}
+	_, err = f.NewTableWriter("stream", []Column{{Name: "ID", Format: "J"}}, BINARY_TBL)
+	if err == nil {
+		t.Fatalf("expected an error for a non-seekable output")
+	}
+}
@@ -0,0 +1,443 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CreateFromTemplate creates a new FITS file, written to w, holding the
+// skeleton HDUs described by the CFITSIO-style template tmpl.
+//
+// Each line of a template describes a header card:
+//
+//	KEYWORD = value / comment
+//
+// where the '=' sign is optional, string values may be left unquoted when
+// they hold a single word, and keyword names are case insensitive.
+// Blank lines and lines starting with '#' are ignored.
+//
+// A SIMPLE or XTENSION (IMAGE, BINTABLE or TABLE) card starts a new HDU.
+// The mandatory cards (SIMPLE, XTENSION, BITPIX, NAXIS, NAXISn, PCOUNT,
+// GCOUNT and TFIELDS) are generated from the template, in the order
+// required by the FITS standard, and the other cards are written in the
+// order of the template.
+// An empty primary HDU is created when the template starts with an
+// extension.
+//
+// A '#' in a keyword name is replaced by a counter, incremented each time
+// a keyword is repeated, so that table columns may be described without
+// numbering them:
+//
+//	XTENSION = BINTABLE
+//	EXTNAME  = EVENTS
+//	NAXIS2   = 0
+//	TTYPE#   = TIME
+//	TFORM#   = 1D
+//	TUNIT#   = s
+//	TTYPE#   = ENERGY
+//	TFORM#   = 1E
+//	TUNIT#   = keV
+//
+// Images are filled with zeros and tables with NAXIS2 empty rows.
+// The returned File may be used to write more HDUs and must be closed.
+func CreateFromTemplate(w io.Writer, tmpl string, opts ...Option) (*File, error) {
+	hdus, err := parseTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := Create(w, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, hdu := range hdus {
+		err = f.Write(hdu)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not write HDU #%d of template: %w", i, err)
+		}
+	}
+
+	return f, nil
+}
+
+// tmplHDU is an HDU described by a template.
+type tmplHDU struct {
+	htype   HDUType
+	primary bool
+	cards   []Card // cards of the template, in order
+}
+
+func (h *tmplHDU) get(name string) *Card {
+	for i := range h.cards {
+		if h.cards[i].Name == name {
+			return &h.cards[i]
+		}
+	}
+	return nil
+}
+
+func (h *tmplHDU) getInt(name string, def int) (int, error) {
+	card := h.get(name)
+	if card == nil || card.Value == nil {
+		return def, nil
+	}
+	return cardInt(card)
+}
+
+// parseTemplate parses a CFITSIO-style template into the HDUs it
+// describes.
+func parseTemplate(tmpl string) ([]HDU, error) {
+	var (
+		hdus []*tmplHDU
+		cur  *tmplHDU
+		idx  int                 // current value of the '#' counter
+		seen map[string]struct{} // '#' keywords seen at the current index
+	)
+
+	sc := bufio.NewScanner(strings.NewReader(tmpl))
+	for iline := 1; sc.Scan(); iline++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if line[0] == '\\' {
+			return nil, fmt.Errorf("fitsio: template line %d: unsupported directive %q", iline, line)
+		}
+
+		card, err := parseTemplateLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: template line %d: %w", iline, err)
+		}
+
+		switch card.Name {
+		case "END":
+			continue
+		case "SIMPLE", "XTENSION":
+			htype := IMAGE_HDU
+			if card.Name == "XTENSION" {
+				if len(hdus) == 0 {
+					// the template starts with an extension.
+					hdus = append(hdus, &tmplHDU{htype: IMAGE_HDU, primary: true})
+				}
+				v, ok := card.Value.(string)
+				if !ok {
+					return nil, fmt.Errorf("fitsio: template line %d: invalid XTENSION value %v", iline, card.Value)
+				}
+				switch strings.ToUpper(strings.TrimSpace(v)) {
+				case "IMAGE":
+					htype = IMAGE_HDU
+				case "BINTABLE":
+					htype = BINARY_TBL
+				case "TABLE":
+					htype = ASCII_TBL
+				default:
+					return nil, fmt.Errorf("fitsio: template line %d: unsupported extension type %q", iline, v)
+				}
+			} else if len(hdus) > 0 {
+				return nil, fmt.Errorf("fitsio: template line %d: SIMPLE card in an extension", iline)
+			}
+			cur = &tmplHDU{htype: htype, primary: card.Name == "SIMPLE"}
+			hdus = append(hdus, cur)
+			idx, seen = 1, make(map[string]struct{})
+			continue
+		}
+
+		if cur == nil {
+			cur = &tmplHDU{htype: IMAGE_HDU, primary: true}
+			hdus = append(hdus, cur)
+			idx, seen = 1, make(map[string]struct{})
+		}
+
+		if strings.Contains(card.Name, "#") {
+			if _, dup := seen[card.Name]; dup {
+				idx++
+				clear(seen)
+			}
+			seen[card.Name] = struct{}{}
+			card.Name = strings.ReplaceAll(card.Name, "#", strconv.Itoa(idx))
+		}
+		cur.cards = append(cur.cards, card)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("fitsio: could not read template: %w", err)
+	}
+	if len(hdus) == 0 {
+		return nil, fmt.Errorf("fitsio: empty template")
+	}
+
+	out := make([]HDU, len(hdus))
+	for i, h := range hdus {
+		hdu, err := h.build()
+		if err != nil {
+			return nil, fmt.Errorf("fitsio: could not create HDU #%d of template: %w", i, err)
+		}
+		out[i] = hdu
+	}
+	return out, nil
+}
+
+// parseTemplateLine parses a 'KEYWORD = value / comment' template line.
+func parseTemplateLine(line string) (Card, error) {
+	var card Card
+
+	end := strings.IndexFunc(line, func(r rune) bool {
+		return r == '=' || r == ' ' || r == '\t'
+	})
+	if end < 0 {
+		end = len(line)
+	}
+	card.Name = strings.ToUpper(line[:end])
+	if len(card.Name) > 8 {
+		return card, fmt.Errorf("invalid keyword name %q (more than 8 characters)", card.Name)
+	}
+	rest := strings.TrimSpace(line[end:])
+
+	switch card.Name {
+	case "COMMENT", "HISTORY":
+		card.Comment = rest
+		return card, nil
+	}
+
+	rest = strings.TrimSpace(strings.TrimPrefix(rest, "="))
+	if rest == "" {
+		return card, nil
+	}
+
+	var com string
+	switch rest[0] {
+	case '/':
+		com = rest[1:]
+	case '\'':
+		str, n, err := processString(rest)
+		if err != nil {
+			return card, err
+		}
+		card.Value = str
+		rest = strings.TrimSpace(rest[n:])
+		if rest != "" {
+			if rest[0] != '/' {
+				return card, fmt.Errorf("invalid content %q after string value", rest)
+			}
+			com = rest[1:]
+		}
+	default:
+		tok := rest
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			tok, com = strings.TrimSpace(rest[:i]), rest[i+1:]
+		}
+		v, err := parseTemplateValue(tok)
+		if err != nil {
+			return card, err
+		}
+		card.Value = v
+	}
+	card.Comment = strings.TrimSpace(com)
+	return card, nil
+}
+
+// parseTemplateValue parses an unquoted template value: a logical, an
+// integer, a floating point or complex number, or else a string.
+func parseTemplateValue(tok string) (Value, error) {
+	switch tok {
+	case "T":
+		return true, nil
+	case "F":
+		return false, nil
+	}
+
+	if strings.HasPrefix(tok, "(") {
+		var x, y float64
+		_, err := fmt.Sscanf(tok, "(%f,%f)", &x, &y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid complex value %q: %w", tok, err)
+		}
+		return complex(x, y), nil
+	}
+
+	if tok == "" || !strings.ContainsRune("0123456789+-.", rune(tok[0])) {
+		return tok, nil
+	}
+	if v, err := strconv.ParseInt(tok, 10, 64); err == nil {
+		return int(v), nil
+	}
+	if v, err := strconv.ParseFloat(strings.NewReplacer("D", "E", "d", "e").Replace(tok), 64); err == nil {
+		return v, nil
+	}
+	return tok, nil
+}
+
+// build creates the HDU described by the template, with its mandatory
+// cards and a zeroed data unit.
+func (h *tmplHDU) build() (HDU, error) {
+	var (
+		cards  []Card
+		datasz int
+		fill   byte
+	)
+
+	switch h.htype {
+	case IMAGE_HDU:
+		bitpix, err := h.getInt("BITPIX", 8)
+		if err != nil {
+			return nil, err
+		}
+		naxis, err := h.getInt("NAXIS", 0)
+		if err != nil {
+			return nil, err
+		}
+		if naxis < 0 || naxis > 999 {
+			return nil, fmt.Errorf("fitsio: invalid NAXIS value %d", naxis)
+		}
+
+		if h.primary {
+			cards = append(cards, Card{Name: "SIMPLE", Value: true, Comment: "primary HDU"})
+		} else {
+			cards = append(cards, Card{Name: "XTENSION", Value: "IMAGE   ", Comment: "IMAGE extension"})
+		}
+		cards = append(cards,
+			Card{Name: "BITPIX", Value: bitpix, Comment: "number of bits per data pixel"},
+			Card{Name: "NAXIS", Value: naxis, Comment: "number of data axes"},
+		)
+		if naxis > 0 {
+			datasz = bitpix / 8
+			if datasz < 0 {
+				datasz = -datasz
+			}
+		}
+		for i := 1; i <= naxis; i++ {
+			key := "NAXIS" + strconv.Itoa(i)
+			dim, err := h.getInt(key, 0)
+			if err != nil {
+				return nil, err
+			}
+			if dim < 0 {
+				return nil, fmt.Errorf("fitsio: invalid %s value %d", key, dim)
+			}
+			cards = append(cards, Card{Name: key, Value: dim, Comment: "length of data axis " + strconv.Itoa(i)})
+			datasz = mulSize(datasz, dim)
+		}
+		if !h.primary {
+			cards = append(cards,
+				Card{Name: "PCOUNT", Value: 0, Comment: "number of parameters"},
+				Card{Name: "GCOUNT", Value: 1, Comment: "number of groups"},
+			)
+		}
+
+	case BINARY_TBL, ASCII_TBL:
+		nrows, err := h.getInt("NAXIS2", 0)
+		if err != nil {
+			return nil, err
+		}
+		if nrows < 0 {
+			return nil, fmt.Errorf("fitsio: invalid NAXIS2 value %d", nrows)
+		}
+
+		ncols := 0
+		for i := range h.cards {
+			if n, ok := strings.CutPrefix(h.cards[i].Name, "TFORM"); ok {
+				if v, err := strconv.Atoi(n); err == nil && v > ncols {
+					ncols = v
+				}
+			}
+		}
+
+		rowsz := 0
+		for i := 1; i <= ncols; i++ {
+			key := "TFORM" + strconv.Itoa(i)
+			card := h.get(key)
+			if card == nil {
+				return nil, fmt.Errorf("fitsio: missing %q for column %d", key, i)
+			}
+			form, err := cardString(card)
+			if err != nil {
+				return nil, err
+			}
+			dtype, err := typeFromForm(form, h.htype)
+			if err != nil {
+				return nil, fmt.Errorf("fitsio: invalid %s: %w", key, err)
+			}
+			width := mulSize(dtype.dsize, dtype.len)
+			switch h.htype {
+			case BINARY_TBL:
+				rowsz += width
+			case ASCII_TBL:
+				start, err := h.getInt("TBCOL"+strconv.Itoa(i), 0)
+				if err != nil {
+					return nil, err
+				}
+				if start <= 0 {
+					// columns follow each other.
+					start = rowsz + 1
+				}
+				rowsz = max(rowsz, start-1+width)
+			}
+		}
+
+		xtension := "BINTABLE"
+		if h.htype == ASCII_TBL {
+			xtension = "TABLE   "
+			fill = ' '
+		}
+		cards = append(cards,
+			Card{Name: "XTENSION", Value: xtension, Comment: "table extension"},
+			Card{Name: "BITPIX", Value: 8, Comment: "number of bits per data pixel"},
+			Card{Name: "NAXIS", Value: 2, Comment: "number of data axes"},
+			Card{Name: "NAXIS1", Value: rowsz, Comment: "length of data axis 1"},
+			Card{Name: "NAXIS2", Value: nrows, Comment: "length of data axis 2"},
+			Card{Name: "PCOUNT", Value: 0, Comment: "heap area size (bytes)"},
+			Card{Name: "GCOUNT", Value: 1, Comment: "one data group"},
+			Card{Name: "TFIELDS", Value: ncols, Comment: "number of fields in each row"},
+		)
+		datasz = mulSize(rowsz, nrows)
+	}
+	if datasz < 0 {
+		return nil, fmt.Errorf("fitsio: %w (size overflow)", ErrHDUTooLarge)
+	}
+
+	for _, card := range h.cards {
+		if isTemplateStructural(card.Name) {
+			continue
+		}
+		cards = append(cards, card)
+	}
+	cards = append(cards, Card{Name: "END"})
+
+	// encode the HDU and decode it back, to validate the cards and set up
+	// the columns of tables as when reading a file.
+	buf := new(bytes.Buffer)
+	for i := range cards {
+		line, err := makeHeaderLine(&cards[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+	}
+	buf.Write(bytes.Repeat([]byte(" "), PadBlock(buf.Len())))
+	buf.Write(bytes.Repeat([]byte{fill}, datasz))
+	buf.Write(make([]byte, PadBlock(datasz)))
+
+	return NewDecoder(buf).DecodeHDU()
+}
+
+// isTemplateStructural returns whether the keyword is one of the mandatory
+// keywords generated from a template.
+func isTemplateStructural(name string) bool {
+	switch name {
+	case "SIMPLE", "XTENSION", "BITPIX", "NAXIS", "PCOUNT", "GCOUNT", "TFIELDS", "THEAP":
+		return true
+	}
+	if n, ok := strings.CutPrefix(name, "NAXIS"); ok {
+		_, err := strconv.Atoi(n)
+		return err == nil
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestCreateFromTemplate(t *testing.T) {
+	const tmpl = `
+# primary HDU
+SIMPLE   = T
+BITPIX   = 16
+NAXIS    = 0
+TELESCOP = 'Example Telescope' / telescope name
+instrume   CAM1 / instrument name
+EXPTIME  = 1.5D2 / exposure time (s)
+COMMENT  a template comment
+
+XTENSION = BINTABLE
+EXTNAME  = EVENTS
+NAXIS2   = 3
+TTYPE#   = TIME
+TFORM#   = 1D
+TUNIT#   = s
+TTYPE#   = PHA
+TFORM#   = 1J
+TNULL#   = -1
+TTYPE#   = FLAGS
+TFORM#   = 3B
+TDIM#    = '(3)'
+END
+
+XTENSION = IMAGE
+BITPIX   = -32
+NAXIS    = 2
+NAXIS1   = 10
+NAXIS2   = 5
+EXTNAME  = 'SCI'
+BUNIT    = adu
+`
+	buf := new(bytes.Buffer)
+	f, err := CreateFromTemplate(buf, tmpl)
+	if err != nil {
+		t.Fatalf("could not create file from template: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	hdus := r.HDUs()
+	if got, want := len(hdus), 3; got != want {
+		t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+	}
+
+	phdr := hdus[0].Header()
+	if got, want := phdr.Bitpix(), 16; got != want {
+		t.Fatalf("invalid primary BITPIX: got=%d, want=%d", got, want)
+	}
+	for _, tc := range []struct {
+		name    string
+		value   Value
+		comment string
+	}{
+		{"TELESCOP", "Example Telescope", "telescope name"},
+		{"INSTRUME", "CAM1", "instrument name"},
+		{"EXPTIME", 150.0, "exposure time (s)"},
+	} {
+		card := phdr.Get(tc.name)
+		if card == nil {
+			t.Fatalf("missing card %q", tc.name)
+		}
+		if card.Value != tc.value || card.Comment != tc.comment {
+			t.Fatalf("invalid card %q: got=(%v, %q), want=(%v, %q)",
+				tc.name, card.Value, card.Comment, tc.value, tc.comment,
+			)
+		}
+	}
+	if card := phdr.Get("COMMENT"); card == nil || card.Comment != "a template comment" {
+		t.Fatalf("invalid COMMENT card: %+v", card)
+	}
+
+	tbl, ok := hdus[1].(*Table)
+	if !ok {
+		t.Fatalf("invalid HDU #1 type %T", hdus[1])
+	}
+	if got, want := tbl.Name(), "EVENTS"; got != want {
+		t.Fatalf("invalid table name: got=%q, want=%q", got, want)
+	}
+	if got, want := tbl.NumRows(), int64(3); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	var names, units []string
+	for _, col := range tbl.Cols() {
+		names = append(names, col.Name)
+		units = append(units, col.Unit)
+	}
+	if got, want := names, []string{"TIME", "PHA", "FLAGS"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid columns: got=%q, want=%q", got, want)
+	}
+	if got, want := units, []string{"s", "", ""}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid units: got=%q, want=%q", got, want)
+	}
+	if got, want := tbl.Col(1).Null, "-1"; got != want {
+		t.Fatalf("invalid TNULL2: got=%q, want=%q", got, want)
+	}
+	if got, want := tbl.Col(2).Dim, []int64{3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid TDIM3: got=%v, want=%v", got, want)
+	}
+
+	img, ok := hdus[2].(Image)
+	if !ok {
+		t.Fatalf("invalid HDU #2 type %T", hdus[2])
+	}
+	if got, want := img.Header().Axes(), []int{10, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid axes: got=%v, want=%v", got, want)
+	}
+	if got, want := img.Name(), "SCI"; got != want {
+		t.Fatalf("invalid image name: got=%q, want=%q", got, want)
+	}
+	if got, want := len(img.Raw()), 10*5*4; got != want {
+		t.Fatalf("invalid image size: got=%d, want=%d", got, want)
+	}
+}
+
+func TestCreateFromTemplateExtension(t *testing.T) {
+	// a template starting with an extension gets an empty primary HDU.
+	const tmpl = `
+XTENSION = TABLE
+EXTNAME  = CATALOG
+NAXIS2   = 2
+TTYPE1   = ID
+TFORM1   = I6
+TTYPE2   = MAG
+TFORM2   = F8.3
+TBCOL2   = 10
+`
+	buf := new(bytes.Buffer)
+	f, err := CreateFromTemplate(buf, tmpl)
+	if err != nil {
+		t.Fatalf("could not create file from template: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := Open(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	if got, want := len(r.HDUs()), 2; got != want {
+		t.Fatalf("invalid number of HDUs: got=%d, want=%d", got, want)
+	}
+	if got, want := len(r.HDU(0).(Image).Header().Axes()), 0; got != want {
+		t.Fatalf("invalid primary NAXIS: got=%d, want=%d", got, want)
+	}
+	tbl := r.Get("CATALOG").(*Table)
+	if got, want := tbl.Type(), ASCII_TBL; got != want {
+		t.Fatalf("invalid table type: got=%v, want=%v", got, want)
+	}
+	if got, want := tbl.Header().Axes(), []int{17, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid table axes: got=%v, want=%v", got, want)
+	}
+}
+
+func TestCreateFromTemplateErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		tmpl string
+	}{
+		{"empty", "# nothing\n"},
+		{"directive", "SIMPLE = T\n\\include other.tpl\n"},
+		{"extension", "XTENSION = FOREIGN\n"},
+		{"simple", "SIMPLE = T\nXTENSION = IMAGE\nSIMPLE = T\n"},
+		{"keyword", "SIMPLE = T\nLONGKEYWORD = 1\n"},
+		{"tform", "XTENSION = BINTABLE\nTTYPE1 = A\nTFORM2 = 1J\n"},
+		{"string", "SIMPLE = T\nOBJECT = 'M31' junk\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := CreateFromTemplate(new(bytes.Buffer), tc.tmpl)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
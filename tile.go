@@ -0,0 +1,166 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// Tile is a rectangular window of the pixels of an image.
+type Tile struct {
+	Origin []int // coordinates of the first pixel of the tile in the image, starting at 0
+	Shape  []int // number of pixels of the tile along each axis
+
+	// Data holds the raw pixels of the tile, as a slice of the Go type
+	// of the pixels of the image (uint8, int16, int32, int64, float32 or
+	// float64), in the order of the image data unit: the first axis
+	// varies fastest.
+	// BSCALE and BZERO are not applied.
+	Data interface{}
+}
+
+// Len returns the number of pixels of the tile.
+func (t Tile) Len() int {
+	n := 1
+	for _, dim := range t.Shape {
+		n *= dim
+	}
+	return n
+}
+
+// Tiles returns an iterator over the tiles of the image, of shape
+// tileShape, in the order of the image data unit.
+//
+// Tiles at the upper edges of the image are truncated to the image.
+// A value of tileShape lower than 1 or greater than the size of the
+// image along the corresponding axis spans the whole axis. Missing values
+// of tileShape span the whole first axis, and a single pixel along the
+// other ones (as the default ZTILEn values of tile-compressed images.)
+// Tiles panics if tileShape has more dimensions than the image.
+//
+// Each tile holds its own copy of its pixels, so that tiles may be
+// processed concurrently, and the memory used is bounded by the size of
+// the tiles held by the caller.
+// Tiles yields no tile if the image holds no pixels.
+func (img *imageHDU) Tiles(tileShape []int) iter.Seq[Tile] {
+	axes := img.hdr.Axes()
+	if len(tileShape) > len(axes) {
+		panic(fmt.Errorf("fitsio: %d-dimensional tiles for a %d-dimensional image", len(tileShape), len(axes)))
+	}
+
+	bitpix := img.hdr.Bitpix()
+	pixsz := bitpix / 8
+	if pixsz < 0 {
+		pixsz = -pixsz
+	}
+	var (
+		shape  = make([]int, len(axes))
+		ntiles = make([]int, len(axes))
+		stride = make([]int, len(axes)) // number of pixels between consecutive values along each axis
+		npix   = 1
+	)
+	for i, dim := range axes {
+		n := 1
+		switch {
+		case i < len(tileShape):
+			n = tileShape[i]
+		case i == 0:
+			n = dim
+		}
+		if n < 1 || n > dim {
+			n = dim
+		}
+		shape[i] = n
+		if n > 0 {
+			ntiles[i] = (dim + n - 1) / n
+		}
+		stride[i] = npix
+		npix *= dim
+	}
+
+	return func(yield func(Tile) bool) {
+		if len(axes) == 0 || npix == 0 || len(img.raw) < npix*pixsz || pixelType(bitpix) == nil {
+			return
+		}
+		idx := make([]int, len(axes))
+		for {
+			tile := img.tile(idx, shape, stride, pixsz)
+			if !yield(tile) {
+				return
+			}
+			i := 0
+			for ; i < len(idx); i++ {
+				idx[i]++
+				if idx[i] < ntiles[i] {
+					break
+				}
+				idx[i] = 0
+			}
+			if i == len(idx) {
+				return
+			}
+		}
+	}
+}
+
+// tile returns the tile of index idx in the grid of tiles of the given
+// shape.
+func (img *imageHDU) tile(idx, shape, stride []int, pixsz int) Tile {
+	axes := img.hdr.Axes()
+	tile := Tile{
+		Origin: make([]int, len(axes)),
+		Shape:  make([]int, len(axes)),
+	}
+	for i := range axes {
+		tile.Origin[i] = idx[i] * shape[i]
+		tile.Shape[i] = min(shape[i], axes[i]-tile.Origin[i])
+	}
+
+	n := tile.Len()
+	data := reflect.MakeSlice(reflect.SliceOf(pixelType(img.hdr.Bitpix())), n, n).Interface()
+	tile.Data = data
+
+	var (
+		nrow = tile.Shape[0]
+		read func(j int, r *rbuf)
+	)
+	switch data := data.(type) {
+	case []uint8:
+		read = func(j int, r *rbuf) { r.readU8s(data[j : j+nrow]) }
+	case []int16:
+		read = func(j int, r *rbuf) { r.readI16s(data[j : j+nrow]) }
+	case []int32:
+		read = func(j int, r *rbuf) { r.readI32s(data[j : j+nrow]) }
+	case []int64:
+		read = func(j int, r *rbuf) { r.readI64s(data[j : j+nrow]) }
+	case []float32:
+		read = func(j int, r *rbuf) { r.readF32s(data[j : j+nrow]) }
+	case []float64:
+		read = func(j int, r *rbuf) { r.readF64s(data[j : j+nrow]) }
+	}
+
+	// pos holds the coordinates, within the tile, of the current row of
+	// pixels along the first axis.
+	pos := make([]int, len(axes))
+	for j := 0; j < n; j += nrow {
+		beg := 0
+		for i := range axes {
+			beg += (tile.Origin[i] + pos[i]) * stride[i]
+		}
+		beg *= pixsz
+		read(j, newReader(img.raw[beg:beg+nrow*pixsz]))
+
+		for i := 1; i < len(pos); i++ {
+			pos[i]++
+			if pos[i] < tile.Shape[i] {
+				break
+			}
+			pos[i] = 0
+		}
+	}
+	return tile
+}
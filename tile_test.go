@@ -0,0 +1,97 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImageTiles(t *testing.T) {
+	const (
+		nx = 5
+		ny = 7
+	)
+	pix := make([]int16, nx*ny)
+	for i := range pix {
+		pix[i] = int16(i - 10)
+	}
+	img := NewImage(16, []int{nx, ny})
+	err := img.Write(pix)
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+
+	for _, test := range []struct {
+		shape  []int
+		ntiles int
+		first  []int
+		last   []int
+	}{
+		{shape: []int{2, 3}, ntiles: 9, first: []int{2, 3}, last: []int{1, 1}},
+		{shape: []int{nx, ny}, ntiles: 1, first: []int{nx, ny}, last: []int{nx, ny}},
+		{shape: []int{0, 4}, ntiles: 2, first: []int{nx, 4}, last: []int{nx, 3}},
+		{shape: []int{3}, ntiles: 14, first: []int{3, 1}, last: []int{2, 1}},
+		{shape: nil, ntiles: ny, first: []int{nx, 1}, last: []int{nx, 1}},
+	} {
+		var (
+			tiles []Tile
+			seen  = make([]int, len(pix))
+		)
+		for tile := range img.Tiles(test.shape) {
+			tiles = append(tiles, tile)
+			data := tile.Data.([]int16)
+			if got, want := len(data), tile.Len(); got != want {
+				t.Fatalf("shape=%v: invalid tile data length: got=%d, want=%d", test.shape, got, want)
+			}
+			for j, v := range data {
+				x := tile.Origin[0] + j%tile.Shape[0]
+				y := tile.Origin[1] + j/tile.Shape[0]
+				if want := pix[y*nx+x]; v != want {
+					t.Fatalf("shape=%v: invalid pixel (%d,%d): got=%d, want=%d", test.shape, x, y, v, want)
+				}
+				seen[y*nx+x]++
+			}
+		}
+		if got, want := len(tiles), test.ntiles; got != want {
+			t.Fatalf("shape=%v: invalid number of tiles: got=%d, want=%d", test.shape, got, want)
+		}
+		if got := tiles[0].Shape; !reflect.DeepEqual(got, test.first) {
+			t.Fatalf("shape=%v: invalid first tile shape: got=%v, want=%v", test.shape, got, test.first)
+		}
+		if got := tiles[len(tiles)-1].Shape; !reflect.DeepEqual(got, test.last) {
+			t.Fatalf("shape=%v: invalid last tile shape: got=%v, want=%v", test.shape, got, test.last)
+		}
+		for i, n := range seen {
+			if n != 1 {
+				t.Fatalf("shape=%v: pixel %d covered by %d tiles", test.shape, i, n)
+			}
+		}
+	}
+
+	n := 0
+	for range img.Tiles([]int{1, 1}) {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Fatalf("invalid number of tiles after break: %d", n)
+	}
+
+	for range NewImage(16, []int{nx, ny}).Tiles(nil) {
+		t.Fatalf("expected no tile for an image without pixels")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected a panic for 3-dimensional tiles")
+			}
+		}()
+		img.Tiles([]int{1, 1, 1})
+	}()
+}
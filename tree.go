@@ -0,0 +1,129 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"strings"
+)
+
+// HDUNode is a node of the hierarchy of the HDUs of a file, as described
+// by the EXTNAME, EXTVER and EXTLEVEL keywords of the hierarchical grouping
+// convention.
+type HDUNode struct {
+	Index   int    // index of the HDU in the file
+	Name    string // name of the HDU, from the ``EXTNAME`` keyword
+	Version int    // version of the HDU, from the ``EXTVER`` keyword
+	Level   int    // level of the HDU in the hierarchy, from the ``EXTLEVEL`` keyword
+
+	Parent   *HDUNode   // parent node, nil for the primary HDU
+	Children []*HDUNode // child nodes, in file order
+
+	file *File
+}
+
+// Tree returns the hierarchy of the HDUs of the file, rooted at the primary
+// HDU (of level 0.)
+//
+// Extensions without an EXTLEVEL card are of level 1. Each extension is a
+// child of the closest HDU preceding it with a lower level: in a file
+// without EXTLEVEL cards, all the extensions are children of the primary
+// HDU.
+// Tree only inspects the headers of the HDUs: it does not read the data of
+// files opened with OpenReaderAt.
+// Tree returns nil if the file holds no HDU.
+func (f *File) Tree() *HDUNode {
+	n := f.NumHDUs()
+	if n == 0 {
+		return nil
+	}
+
+	var (
+		root  = &HDUNode{Index: 0, Version: 1, file: f}
+		stack = []*HDUNode{root}
+	)
+	root.fill(f.Header(0))
+	root.Level = 0
+	for i := 1; i < n; i++ {
+		node := &HDUNode{Index: i, Version: 1, Level: 1, file: f}
+		node.fill(f.Header(i))
+		if node.Level < 1 {
+			node.Level = 1
+		}
+		for stack[len(stack)-1].Level >= node.Level {
+			stack = stack[:len(stack)-1]
+		}
+		node.Parent = stack[len(stack)-1]
+		node.Parent.Children = append(node.Parent.Children, node)
+		stack = append(stack, node)
+	}
+	return root
+}
+
+// fill sets the name, version and level of the node from the header hdr.
+func (node *HDUNode) fill(hdr *Header) {
+	if card := hdr.Get("EXTNAME"); card != nil {
+		node.Name, _ = card.Value.(string)
+	}
+	if card := hdr.Get("EXTVER"); card != nil {
+		if v, err := cardInt(card); err == nil {
+			node.Version = v
+		}
+	}
+	if card := hdr.Get("EXTLEVEL"); card != nil {
+		if v, err := cardInt(card); err == nil {
+			node.Level = v
+		}
+	}
+}
+
+// HDU returns the HDU of the node, reading its data unit if it was not
+// already read (for files opened with OpenReaderAt.)
+func (node *HDUNode) HDU() (HDU, error) {
+	return node.file.LoadHDU(node.Index)
+}
+
+// Lookup returns the node designated by path, relative to node, or nil.
+//
+// path is a slash-separated list of HDU names, each of them naming a child
+// of the node designated by the previous ones.
+// The last HDU of the path must be of version ver, the other ones are the
+// first child with the given name.
+func (node *HDUNode) Lookup(path string, ver int) *HDUNode {
+	names := strings.Split(path, "/")
+	cur := node
+	for i, name := range names {
+		last := i == len(names)-1
+		var next *HDUNode
+		for _, child := range cur.Children {
+			if child.Name == name && (!last || child.Version == ver) {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		cur = next
+	}
+	return cur
+}
+
+// Lookup returns the HDU designated by path, of version ver, in the
+// hierarchy of the HDUs of the file (see File.Tree and HDUNode.Lookup), or
+// nil.
+// For example, f.Lookup("SCI", 2) returns the second science extension of
+// a multi-extension file, and f.Lookup("ASN/SCI", 1) the first science
+// extension of the ASN extension of a file with EXTLEVEL cards.
+func (f *File) Lookup(path string, ver int) HDU {
+	root := f.Tree()
+	if root == nil {
+		return nil
+	}
+	node := root.Lookup(path, ver)
+	if node == nil {
+		return nil
+	}
+	return f.hdu(node.Index)
+}
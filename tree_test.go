@@ -0,0 +1,125 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	buf := new(bytes.Buffer)
+	f, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for _, ext := range []struct {
+		name  string
+		ver   int
+		level int
+	}{
+		{"", 0, 0},
+		{"SCI", 1, 0},
+		{"SCI", 2, 0},
+		{"ASN", 1, 1},
+		{"SCI", 1, 2},
+		{"ERR", 1, 3},
+		{"SCI", 2, 2},
+		{"DQ", 1, 1},
+	} {
+		img := NewImage(8, nil)
+		var cards []Card
+		if ext.name != "" {
+			cards = append(cards, Card{Name: "EXTNAME", Value: ext.name})
+		}
+		if ext.ver != 0 {
+			cards = append(cards, Card{Name: "EXTVER", Value: ext.ver})
+		}
+		if ext.level != 0 {
+			cards = append(cards, Card{Name: "EXTLEVEL", Value: ext.level})
+		}
+		err = img.Header().Append(cards...)
+		if err != nil {
+			t.Fatalf("could not append cards: %v", err)
+		}
+		err = f.Write(img)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	for _, open := range []func() (*File, error){
+		func() (*File, error) { return Open(bytes.NewReader(buf.Bytes())) },
+		func() (*File, error) { return OpenReaderAt(bytes.NewReader(buf.Bytes()), int64(buf.Len())) },
+	} {
+		f, err := open()
+		if err != nil {
+			t.Fatalf("could not open file: %v", err)
+		}
+		defer f.Close()
+
+		root := f.Tree()
+		children := func(node *HDUNode) []int {
+			var idx []int
+			for _, child := range node.Children {
+				idx = append(idx, child.Index)
+			}
+			return idx
+		}
+		for _, test := range []struct {
+			node *HDUNode
+			want []int
+		}{
+			{root, []int{1, 2, 3, 7}},
+			{root.Children[2], []int{4, 6}},
+			{root.Children[2].Children[0], []int{5}},
+		} {
+			if got := children(test.node); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("invalid children of HDU #%d: got=%v, want=%v", test.node.Index, got, test.want)
+			}
+		}
+		if got, want := root.Children[2].Children[0].Children[0].Parent.Parent, root.Children[2]; got != want {
+			t.Fatalf("invalid parent: got=%+v, want=%+v", got, want)
+		}
+
+		for _, test := range []struct {
+			path string
+			ver  int
+			want int
+		}{
+			{"SCI", 1, 1},
+			{"SCI", 2, 2},
+			{"ASN", 1, 3},
+			{"ASN/SCI", 1, 4},
+			{"ASN/SCI", 2, 6},
+			{"ASN/SCI/ERR", 1, 5},
+			{"DQ", 1, 7},
+			{"SCI", 3, -1},
+			{"ERR", 1, -1},
+			{"ASN/DQ", 1, -1},
+		} {
+			node := root.Lookup(test.path, test.ver)
+			got := -1
+			if node != nil {
+				got = node.Index
+			}
+			if got != test.want {
+				t.Fatalf("invalid lookup(%q, %d): got=%d, want=%d", test.path, test.ver, got, test.want)
+			}
+			hdu := f.Lookup(test.path, test.ver)
+			if (hdu != nil) != (test.want >= 0) {
+				t.Fatalf("invalid HDU lookup(%q, %d): got=%v", test.path, test.ver, hdu)
+			}
+			if hdu != nil && hdu != f.HDU(test.want) {
+				t.Fatalf("invalid HDU lookup(%q, %d)", test.path, test.ver)
+			}
+		}
+	}
+}
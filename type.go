@@ -5,6 +5,7 @@
 package fitsio
 
 import (
+	"fmt"
 	"reflect"
 )
 
@@ -52,3 +53,35 @@ type Type struct {
 	hsize  int          // type size in bytes in heap area
 	gotype reflect.Type // associated go type
 }
+
+// TypeFromForm returns the type of the values of a column of a table of
+// type htype (ASCII_TBL or BINARY_TBL) described by the TFORM value form,
+// such as "1J", "3E", "20A" or "PD(100)" for binary tables, and "I10" or
+// "E15.7" for ASCII tables.
+func TypeFromForm(form string, htype HDUType) (Type, error) {
+	switch htype {
+	case ASCII_TBL, BINARY_TBL:
+		return typeFromForm(form, htype)
+	}
+	return Type{}, fmt.Errorf("fitsio: invalid table type %v", htype)
+}
+
+// GoType returns the Go type of the values, as returned by Column.Type.
+func (t Type) GoType() reflect.Type {
+	return t.gotype
+}
+
+// IsVarLen returns whether the values are variable length arrays, stored
+// in the heap of a binary table.
+func (t Type) IsVarLen() bool {
+	return t.tc < 0
+}
+
+// String returns the name of the Go type of the values, such as "int32",
+// "[3]float32" or "[]float64".
+func (t Type) String() string {
+	if t.gotype == nil {
+		return "invalid"
+	}
+	return t.gotype.String()
+}
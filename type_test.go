@@ -0,0 +1,64 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTypeFromForm(t *testing.T) {
+	for _, tc := range []struct {
+		form   string
+		htype  HDUType
+		want   reflect.Type
+		name   string
+		varlen bool
+	}{
+		{"J", BINARY_TBL, reflect.TypeOf(int32(0)), "int32", false},
+		{"3E", BINARY_TBL, reflect.TypeOf([3]float32{}), "[3]float32", false},
+		{"20A", BINARY_TBL, reflect.TypeOf(""), "string", false},
+		{"PD(100)", BINARY_TBL, reflect.TypeOf([]float64(nil)), "[]float64", true},
+		{"I10", ASCII_TBL, reflect.TypeOf(int(0)), "int", false},
+		{"E15.7", ASCII_TBL, reflect.TypeOf(float64(0)), "float64", false},
+	} {
+		typ, err := TypeFromForm(tc.form, tc.htype)
+		if err != nil {
+			t.Fatalf("could not parse %q: %v", tc.form, err)
+		}
+		if got := typ.GoType(); got != tc.want {
+			t.Fatalf("invalid Go type for %q: got=%v, want=%v", tc.form, got, tc.want)
+		}
+		if got := typ.String(); got != tc.name {
+			t.Fatalf("invalid name for %q: got=%q, want=%q", tc.form, got, tc.name)
+		}
+		if got := typ.IsVarLen(); got != tc.varlen {
+			t.Fatalf("invalid varlen for %q: got=%v, want=%v", tc.form, got, tc.varlen)
+		}
+	}
+
+	for _, tc := range []struct {
+		form  string
+		htype HDUType
+	}{
+		{"Z", BINARY_TBL},
+		{"J", IMAGE_HDU},
+	} {
+		_, err := TypeFromForm(tc.form, tc.htype)
+		if err == nil {
+			t.Fatalf("expected an error for %q (%v)", tc.form, tc.htype)
+		}
+	}
+
+	col := Column{Name: "x", Format: "2D"}
+	tbl, err := NewTable("t", []Column{col}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	if got, want := tbl.Col(0).DataType().String(), "[2]float64"; got != want {
+		t.Fatalf("invalid column type: got=%q, want=%q", got, want)
+	}
+}
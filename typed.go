@@ -0,0 +1,121 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"unsafe"
+)
+
+// ScanRows returns an iterator over the remaining rows of rows, each
+// scanned into a value of type T.
+//
+// T may be a struct, whose fields are associated with columns as with
+// Rows.Scan, a map[string]interface{}, or the Go type of the column if rows
+// iterates over a single column.
+// The mapping between T and the columns is computed once, before the first
+// row is read.
+//
+// Iteration stops at the first error, which is yielded with the value of
+// the row that could not be read, and is also reported by rows.Err.
+func ScanRows[T any](rows *Rows) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		scan, err := rowScanner[T](rows)
+		if err != nil {
+			rows.err = err
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for rows.Next() {
+			var v T
+			err = scan(&v)
+			if err != nil {
+				rows.err = err
+				yield(v, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// rowScanner returns the function reading the current row of rows into
+// a value of type T.
+func rowScanner[T any](rows *Rows) (func(v *T) error, error) {
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	switch {
+	case rt.Kind() == reflect.Struct:
+		plan, err := rows.table.Planner((*T)(nil))
+		if err != nil {
+			return nil, err
+		}
+		return func(v *T) error {
+			return plan.scan(rows.cur, unsafe.Pointer(v))
+		}, nil
+
+	case rt == reflect.TypeOf(map[string]interface{}(nil)):
+		return func(v *T) error {
+			data := make(map[string]interface{}, len(rows.cols))
+			*(*map[string]interface{})(unsafe.Pointer(v)) = data
+			return rows.scanMap(data)
+		}, nil
+	}
+
+	if len(rows.cols) != 1 {
+		return nil, fmt.Errorf("fitsio: can not scan %d columns into a %v", len(rows.cols), rt)
+	}
+	return func(v *T) error {
+		return rows.scan(v)
+	}, nil
+}
+
+// WriteRows appends the values of rows to the table t, one row per value.
+//
+// T may be a struct, whose fields are associated with columns as with
+// Table.Write, or the Go type of the column if the table has a single
+// column.
+// The mapping between T and the columns is computed once for all the rows.
+func WriteRows[T any](t *Table, rows []T) error {
+	var write func(v *T) error
+
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	switch {
+	case rt.Kind() == reflect.Struct:
+		icols := t.fieldCols(rt)
+		write = func(v *T) error {
+			return t.writeFields(t.nrows, reflect.ValueOf(v).Elem(), icols)
+		}
+	case len(t.cols) != 1:
+		return fmt.Errorf("fitsio: can not write a %v into %d columns", rt, len(t.cols))
+	default:
+		write = func(v *T) error {
+			return t.cols[0].write(t, 0, t.nrows, v)
+		}
+	}
+
+	if n := len(t.data) + len(rows)*t.rowsz; n > cap(t.data) {
+		data := make([]byte, len(t.data), n)
+		copy(data, t.data)
+		t.data = data
+	}
+
+	for i := range rows {
+		n := len(t.data)
+		t.appendRow()
+		err := write(&rows[i])
+		if err != nil {
+			t.data = t.data[:n]
+			return err
+		}
+		t.nrows++
+		t.hdr.axes[1]++
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScanRows(t *testing.T) {
+	tbl := newPlanTable(t, 10)
+	defer tbl.Close()
+
+	var want []planData
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	err = rows.ScanAll(&want)
+	if err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+
+	rows, err = tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	var got []planData
+	for v, err := range ScanRows[planData](rows) {
+		if err != nil {
+			t.Fatalf("could not scan row %d: %v", len(got), err)
+		}
+		if len(v.Vla) == 0 {
+			v.Vla = want[len(got)].Vla
+		}
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows.\ngot= %+v\nwant=%+v", got, want)
+	}
+
+	// early exit.
+	rows, err = tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	n := 0
+	for range ScanRows[map[string]interface{}](rows) {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	var m map[string]interface{}
+	for v, err := range ScanRows[map[string]interface{}](rows) {
+		if err != nil {
+			t.Fatalf("could not scan row: %v", err)
+		}
+		m = v
+		break
+	}
+	if got, want := m["I32"], int32(-9); got != want {
+		t.Fatalf("invalid map value: got=%v, want=%v", got, want)
+	}
+
+	// single column.
+	rows, err = tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	for _, err := range ScanRows[int32](rows) {
+		if err == nil {
+			t.Fatalf("expected an error scanning %d columns into an int32", tbl.NumCols())
+		}
+	}
+	if rows.Err() == nil {
+		t.Fatalf("expected an error")
+	}
+
+	// mistyped struct.
+	rows, err = tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	for _, err := range ScanRows[struct{ I16 float64 }](rows) {
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	}
+}
+
+func TestWriteRows(t *testing.T) {
+	src := newPlanTable(t, 10)
+	defer src.Close()
+
+	rows, err := src.Read(0, src.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	var want []planData
+	err = rows.ScanAll(&want)
+	if err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+
+	tbl, err := NewTable("plan", src.Cols(), BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+
+	err = WriteRows(tbl, want[:4])
+	if err != nil {
+		t.Fatalf("could not write rows: %v", err)
+	}
+	err = WriteRows(tbl, want[4:])
+	if err != nil {
+		t.Fatalf("could not write rows: %v", err)
+	}
+	if got, want := tbl.NumRows(), src.NumRows(); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+
+	rows, err = tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	var got []planData
+	err = rows.ScanAll(&got)
+	if err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows.\ngot= %+v\nwant=%+v", got, want)
+	}
+
+	single, err := NewTable("single", []Column{{Name: "x", Format: "D"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer single.Close()
+
+	err = WriteRows(single, []float64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("could not write rows: %v", err)
+	}
+	err = WriteRows(single, []float64{4})
+	if err != nil {
+		t.Fatalf("could not write rows: %v", err)
+	}
+	var xs []float64
+	err = single.ReadColumn(0, &xs)
+	if err != nil {
+		t.Fatalf("could not read column: %v", err)
+	}
+	if got, want := xs, []float64{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid column.\ngot= %v\nwant=%v", got, want)
+	}
+
+	err = WriteRows(tbl, []float64{1})
+	if err == nil {
+		t.Fatalf("expected an error writing a float64 into %d columns", tbl.NumCols())
+	}
+}
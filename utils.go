@@ -7,6 +7,7 @@ package fitsio
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
 	"strconv"
@@ -23,18 +24,6 @@ import (
 // 	return 0, nil
 // }
 
-// alignBlock returns a size adjusted to align at a FITS block size
-func alignBlock(sz int) int {
-	padding := padBlock(sz)
-	return sz + padding
-}
-
-// padBlock returns the amount of padding to align to a FITS block size
-func padBlock(sz int) int {
-	padding := (blockSize - (sz % blockSize)) % blockSize
-	return padding
-}
-
 // processString is utilized by DecodeHDU to process string-type values in the header
 // it uses a 3-state machine to process double single quotes
 func processString(s string) (string, int, error) {
@@ -236,8 +225,11 @@ func parseHeaderLine(bline []byte) (*Card, error) {
 					default:
 						return nil, err
 					}
-				} else {
+				} else if x >= math.MinInt && x <= math.MaxInt {
 					card.Value = int(x)
+				} else {
+					// only on platforms with 32-bit ints.
+					card.Value = x
 				}
 			}
 		} else if v0 == 'T' {
@@ -263,6 +255,12 @@ func parseHeaderLine(bline []byte) (*Card, error) {
 // makeHeaderLine makes a 80-byte line (or more) for a header FITS block from a Card.
 // transliterated from CFITSIO's ffmkky.
 func makeHeaderLine(card *Card) ([]byte, error) {
+	return formatHeaderLine(card, 'E')
+}
+
+// formatHeaderLine is like makeHeaderLine, writing the exponent of
+// floating point values with the exp character ('E' or 'D'.)
+func formatHeaderLine(card *Card, exp byte) ([]byte, error) {
 	var err error
 	const kLINE = 80
 	var (
@@ -276,6 +274,10 @@ func makeHeaderLine(card *Card) ([]byte, error) {
 		return nil, fmt.Errorf("fitsio: nil Card")
 	}
 
+	if raw, ok := card.Value.(RawCard); ok {
+		return raw[:], nil
+	}
+
 	switch card.Name {
 	case "", "COMMENT", "HISTORY":
 		str := card.Comment
@@ -353,52 +355,49 @@ func makeHeaderLine(card *Card) ([]byte, error) {
 		n := 0
 		switch v := card.Value.(type) {
 		case string:
-			vstr := "''"
-			if v != "" {
-				vstr = fmt.Sprintf("'%-8s'", v)
+			esc := strings.ReplaceAll(v, "'", "''")
+			if card.Name == "XTENSION" && len(esc) < 8 {
+				// the value of XTENSION is padded to 8 characters.
+				esc = fmt.Sprintf("%-8s", esc)
 			}
-			if len(vstr) < kLINE-buflen {
+			if vstr := "'" + esc + "'"; len(vstr) <= kLINE-buflen {
 				n, err = fmt.Fprintf(buf, "%-20s", vstr)
 				if err != nil {
-					return nil, fmt.Errorf("fitsio: error writing card value [%s]: %v", card.Name, err)
+					return nil, fmt.Errorf("fitsio: error writing card value [%s]: %w", card.Name, err)
 				}
 			} else {
 				// string too long.
-				// use CONTINUE blocks.
-				// replace last character of string with '&'
-				ampersand := len("&")
-				quotes := len("''")
-				spacesz := len("  ")
-				sz := kLINE - buflen - ampersand - quotes
-				vstr = fmt.Sprintf("'%-8s'", v[:sz]+"&")
-				n, err = fmt.Fprintf(buf, "%-20s", vstr)
-				if err != nil {
-					return nil, fmt.Errorf("fitsio: error writing card value [%s]: %v", card.Name, err)
-				}
-				contlen := len(kCONTINUE)
-				blocksz := kLINE - contlen - ampersand - quotes - spacesz
-				for i := sz; i < len(v); i += blocksz {
-					end := i + blocksz
-					amper := "&"
-					if end > len(v) {
-						end = len(v)
-						amper = ""
+				// use CONTINUE blocks, each part but the last one
+				// ending with a '&'.
+				const (
+					quotes    = len("''")
+					ampersand = len("&")
+				)
+				chunks := splitString(v,
+					kLINE-buflen-quotes-ampersand,
+					kLINE-len(kCONTINUE)-len("  ")-quotes-ampersand,
+				)
+				for i, chunk := range chunks {
+					if i > 0 {
+						_, err = fmt.Fprintf(buf, "%s  ", kCONTINUE)
+						if err != nil {
+							return nil, fmt.Errorf("fitsio: error writing card value [%s]: %w", card.Name, err)
+						}
 					}
-					vv := v[i:end]
-					vstr := fmt.Sprintf("'%-8s'", vv+amper)
-					n, err = fmt.Fprintf(buf, "%s  %-20s", string(kCONTINUE), vstr)
-					if err != nil {
-						return nil, fmt.Errorf("fitsio: error writing card value [%s]: %v", card.Name, err)
+					if i < len(chunks)-1 {
+						chunk += "&"
 					}
-				}
-				// fill buffer up to 80-byte mark so any remaining comment
-				// will have to be handled by a separate 'COMMENT' line
-				n = buf.Len()
-				align80 := (kLINE - (n % kLINE)) % kLINE
-				if align80 > 0 {
-					_, err = buf.Write(bytes.Repeat([]byte(" "), align80))
+					_, err = fmt.Fprintf(buf, "%-20s", "'"+chunk+"'")
 					if err != nil {
-						return nil, err
+						return nil, fmt.Errorf("fitsio: error writing card value [%s]: %w", card.Name, err)
+					}
+					// fill buffer up to 80-byte mark so any remaining comment
+					// will have to be handled by a separate 'COMMENT' line
+					if align80 := (kLINE - buf.Len()%kLINE) % kLINE; align80 > 0 {
+						_, err = buf.Write(bytes.Repeat([]byte(" "), align80))
+						if err != nil {
+							return nil, err
+						}
 					}
 				}
 
@@ -413,31 +412,47 @@ func makeHeaderLine(card *Card) ([]byte, error) {
 			}
 			n, err = fmt.Fprintf(buf, "%20s", vv)
 			if err != nil {
-				return nil, fmt.Errorf("fitsio: error writing card value [%s]: %v", card.Name, err)
+				return nil, fmt.Errorf("fitsio: error writing card value [%s]: %w", card.Name, err)
 			}
 
-		case int:
+		case int, int64:
 			n, err = fmt.Fprintf(buf, "%20d", v)
 			if err != nil {
-				return nil, fmt.Errorf("fitsio: error writing card value [%s]: %v", card.Name, err)
+				return nil, fmt.Errorf("fitsio: error writing card value [%s]: %w", card.Name, err)
 			}
 
 		case float64:
-			n, err = fmt.Fprintf(buf, "%#20G", v)
+			format := formatFloat
+			if isFixedFloatKey(card.Name) {
+				format = formatFixedFloat
+			}
+			str, err := format(v, exp)
+			if err != nil {
+				return nil, fmt.Errorf("fitsio: invalid card value [%s]: %w", card.Name, err)
+			}
+			n, err = fmt.Fprintf(buf, "%20s", str)
 			if err != nil {
-				return nil, fmt.Errorf("fitsio: error writing card value [%s]: %v", card.Name, err)
+				return nil, fmt.Errorf("fitsio: error writing card value [%s]: %w", card.Name, err)
 			}
 
 		case complex128:
-			n, err = fmt.Fprintf(buf, "(%10f,%10f)", real(v), imag(v))
+			re, err := formatFloat(real(v), 'E')
+			if err != nil {
+				return nil, fmt.Errorf("fitsio: invalid card value [%s]: %w", card.Name, err)
+			}
+			im, err := formatFloat(imag(v), 'E')
 			if err != nil {
-				return nil, fmt.Errorf("fitsio: error writing card value [%s]: %v", card.Name, err)
+				return nil, fmt.Errorf("fitsio: invalid card value [%s]: %w", card.Name, err)
+			}
+			n, err = fmt.Fprintf(buf, "%20s", "("+re+","+im+")")
+			if err != nil {
+				return nil, fmt.Errorf("fitsio: error writing card value [%s]: %w", card.Name, err)
 			}
 
 		case big.Int:
 			n, err = fmt.Fprintf(buf, "%s", v.String())
 			if err != nil {
-				return nil, fmt.Errorf("fitsio: error writing card value [%s]: %v", card.Name, err)
+				return nil, fmt.Errorf("fitsio: error writing card value [%s]: %w", card.Name, err)
 			}
 
 		default:
@@ -469,7 +484,7 @@ func makeHeaderLine(card *Card) ([]byte, error) {
 					return nil, err
 				}
 			}
-			comline, err := makeHeaderLine(&Card{Name: "COMMENT", Comment: card.Comment})
+			comline, err := formatHeaderLine(&Card{Name: "COMMENT", Comment: card.Comment}, exp)
 			if err != nil {
 				return nil, err
 			}
@@ -494,6 +509,128 @@ func makeHeaderLine(card *Card) ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+// splitString splits the string v into parts, with quotes escaped, of at
+// most first characters for the first part and next characters for the
+// other ones.
+// Escaped quotes are never split across parts.
+func splitString(v string, first, next int) []string {
+	var (
+		parts []string
+		part  []byte
+		size  = first
+	)
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		w := 1
+		if c == '\'' {
+			w = 2
+		}
+		if len(part)+w > size {
+			parts = append(parts, string(part))
+			part = part[:0]
+			size = next
+		}
+		part = append(part, c)
+		if c == '\'' {
+			part = append(part, c)
+		}
+	}
+	return append(parts, string(part))
+}
+
+// formatFloat formats a floating point card value, with the shortest
+// representation which reads back as v: in decimal notation when it fits
+// in the 20 characters of a fixed-format value, in exponential notation
+// otherwise. Values which need more than 20 characters, such as
+// math.MaxFloat64, are not truncated: they extend past column 30, as
+// free-format values do (except for the keywords written by
+// formatFixedFloat.)
+// The exponent is introduced by exp ('E' or 'D'.)
+func formatFloat(v float64, exp byte) (string, error) {
+	const width = 20
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "", fmt.Errorf("%v can not be represented in a FITS header", v)
+	}
+
+	str := strconv.FormatFloat(v, 'E', -1, 64)
+	iexp := strings.IndexByte(str, 'E')
+	x, _ := strconv.Atoi(str[iexp+1:])
+	if x >= -4 && x < 15 {
+		fix := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.Contains(fix, ".") {
+			fix += ".0"
+		}
+		if len(fix) <= width {
+			return fix, nil
+		}
+	}
+
+	if !strings.Contains(str, ".") {
+		str = strings.Replace(str, "E", ".0E", 1)
+	}
+	if exp != 'E' {
+		str = strings.Replace(str, "E", string(exp), 1)
+	}
+	return str, nil
+}
+
+// formatFixedFloat is like formatFloat, but keeps the value within the 20
+// characters of a fixed-format value, reducing its precision as the
+// "%20.*G" format does when its shortest exact representation is longer.
+// Integral values, such as the 9223372036854775808 offset of unsigned
+// 64-bit integers, are written exactly with a trailing decimal point.
+func formatFixedFloat(v float64, exp byte) (string, error) {
+	const width = 20
+	str, err := formatFloat(v, exp)
+	if err != nil || len(str) <= width {
+		return str, err
+	}
+
+	if v == math.Trunc(v) {
+		str = strconv.FormatFloat(v, 'f', 0, 64) + "."
+		if len(str) <= width {
+			return str, nil
+		}
+	}
+
+	for prec := 16; prec > 0; prec-- {
+		str = strconv.FormatFloat(v, 'G', prec, 64)
+		if len(str) <= width {
+			break
+		}
+	}
+	switch {
+	case strings.Contains(str, "."):
+	case strings.Contains(str, "E"):
+		str = strings.Replace(str, "E", ".0E", 1)
+	default:
+		str += ".0"
+	}
+	if exp != 'E' {
+		str = strings.Replace(str, "E", string(exp), 1)
+	}
+	return str, nil
+}
+
+// isFixedFloatKey returns whether the floating point value of the keyword
+// name is written in fixed format: BSCALE, BZERO, TSCALn and TZEROn, whose
+// values are read by many programs from columns 11 to 30.
+func isFixedFloatKey(name string) bool {
+	switch name {
+	case "BSCALE", "BZERO":
+		return true
+	}
+	for _, prefix := range []string{"TSCAL", "TZERO"} {
+		n, ok := strings.CutPrefix(name, prefix)
+		if !ok || n == "" {
+			continue
+		}
+		_, err := strconv.Atoi(n)
+		return err == nil
+	}
+	return false
+}
+
 // verifyCardName verifies a Card name conforms to the FITS standard.
 // Must contain only capital letters, digits, minus or underscore chars.
 // Trailing spaces are allowed.
@@ -536,13 +673,13 @@ func typeFromForm(form string, htype HDUType) (Type, error) {
 	case BINARY_TBL:
 		j := strings.IndexAny(form, "PQABCDEIJKLMX")
 		if j < 0 {
-			return typ, fmt.Errorf("fitsio: invalid TFORM format (%s)", form)
+			return typ, &ErrBadTFORM{Form: form}
 		}
 		repeat := 1
 		if j > 0 {
 			r, err := strconv.ParseInt(form[:j], 10, 32)
-			if err != nil {
-				return typ, fmt.Errorf("fitsio: invalid TFORM format (%s)", form)
+			if err != nil || r < 0 {
+				return typ, &ErrBadTFORM{Form: form}
 			}
 			repeat = int(r)
 		}
@@ -559,13 +696,16 @@ func typeFromForm(form string, htype HDUType) (Type, error) {
 			slice = true
 			dsize = 2 * 8
 		}
+		if j >= len(form) {
+			return typ, &ErrBadTFORM{Form: form, Reason: "missing array type"}
+		}
 		tc, ok := g_fits2tc[BINARY_TBL][form[j]]
 		if !ok {
-			return typ, fmt.Errorf("fitsio: invalid TFORM format (%s) (no typecode found)", form)
+			return typ, &ErrBadTFORM{Form: form, Reason: "no typecode found"}
 		}
 		rt, ok := g_fits2go[BINARY_TBL][form[j]]
 		if !ok {
-			return typ, fmt.Errorf("fitsio: invalid TFORM format (%s) (no Type found)", form)
+			return typ, &ErrBadTFORM{Form: form, Reason: "no Type found"}
 		}
 
 		elemsz := 0
@@ -625,7 +765,7 @@ func typeFromForm(form string, htype HDUType) (Type, error) {
 
 		if typ.dsize*typ.len == 0 {
 			if form != "0A" {
-				return typ, fmt.Errorf("fitsio: invalid dtype! form=%q typ=%#v\n", form, typ)
+				return typ, &ErrBadTFORM{Form: form, Reason: "zero-sized type"}
 			}
 		}
 
@@ -633,26 +773,29 @@ func typeFromForm(form string, htype HDUType) (Type, error) {
 		// fmt.Printf("### form %q\n", form)
 		j := strings.IndexAny(form, "ADEFI")
 		if j < 0 {
-			return typ, fmt.Errorf("fitsio: invalid TFORM format (%s)", form)
+			return typ, &ErrBadTFORM{Form: form}
 		}
 		j = strings.Index(form, ".")
 		if j == -1 {
 			j = len(form)
 		}
+		if j < 1 {
+			return typ, &ErrBadTFORM{Form: form}
+		}
 		repeat := 1
 		r, err := strconv.ParseInt(form[1:j], 10, 32)
-		if err != nil {
-			return typ, fmt.Errorf("fitsio: invalid TFORM format (%s)", form)
+		if err != nil || r < 0 {
+			return typ, &ErrBadTFORM{Form: form}
 		}
 		repeat = int(r)
 
 		tc, ok := g_fits2tc[ASCII_TBL][form[0]]
 		if !ok {
-			return typ, fmt.Errorf("fitsio: invalid TFORM format (%s) (no typecode found)", form)
+			return typ, &ErrBadTFORM{Form: form, Reason: "no typecode found"}
 		}
 		rt, ok := g_fits2go[ASCII_TBL][form[0]]
 		if !ok {
-			return typ, fmt.Errorf("fitsio: invalid TFORM format (%s) (no Type found)", form)
+			return typ, &ErrBadTFORM{Form: form, Reason: "no Type found"}
 		}
 
 		dsize := 0
@@ -684,7 +827,7 @@ func typeFromForm(form string, htype HDUType) (Type, error) {
 
 		if typ.dsize*typ.len == 0 {
 			if form != "0A" {
-				return typ, fmt.Errorf("fitsio: invalid dtype! form=%q typ=%#v\n", form, typ)
+				return typ, &ErrBadTFORM{Form: form, Reason: "zero-sized type"}
 			}
 		}
 	}
@@ -762,6 +905,93 @@ func formFromGoType(rt reflect.Type, htype HDUType) string {
 	return hdr + form
 }
 
+// zeroFromGoType returns the TZERO offset of the FITS column storing values
+// of type rt, as returned by formFromGoType.
+// Unsigned integers (and signed bytes) are stored in binary tables as signed
+// integers (and unsigned bytes) of the same size, offset by TZERO.
+func zeroFromGoType(rt reflect.Type, htype HDUType) float64 {
+	if htype != BINARY_TBL {
+		return 0
+	}
+	switch rt.Kind() {
+	case reflect.Array, reflect.Slice:
+		rt = rt.Elem()
+	}
+	switch rt.Kind() {
+	case reflect.Int8:
+		return g_tzero[tcInt8].zero
+	case reflect.Uint16:
+		return g_tzero[tcUint16].zero
+	case reflect.Uint32:
+		return g_tzero[tcUint32].zero
+	case reflect.Uint, reflect.Uint64:
+		return g_tzero[tcUint64].zero
+	}
+	return 0
+}
+
+// g_tzero describes, by typecode, the integers stored in binary tables
+// through a TZERO offset flipping the sign bit of the stored values.
+var g_tzero = map[typecode]struct {
+	form   typecode // typecode of the stored values
+	zero   float64  // TZERO offset
+	gotype reflect.Type
+}{
+	tcInt8:   {tcByte, -128, reflect.TypeOf(int8(0))},
+	tcUint16: {tcInt16, 1 << 15, reflect.TypeOf(uint16(0))},
+	tcUint32: {tcInt32, 1 << 31, reflect.TypeOf(uint32(0))},
+	tcUint64: {tcInt64, 1 << 63, reflect.TypeOf(uint64(0))},
+}
+
+// zeroType returns the type of the values of a binary table column of type
+// typ, with the provided TSCAL and TZERO values.
+// Columns of integers whose TZERO offset flips the sign bit of the stored
+// values hold unsigned integers (or signed bytes).
+func zeroType(typ Type, bscale, bzero float64) Type {
+	if bscale != 1 || bzero == 0 {
+		return typ
+	}
+	tc := typ.tc
+	if tc < 0 {
+		tc = -tc
+	}
+	for utc, z := range g_tzero {
+		if z.form != tc || z.zero != bzero {
+			continue
+		}
+		switch {
+		case typ.tc < 0:
+			typ.tc = -utc
+			typ.gotype = reflect.SliceOf(z.gotype)
+		case typ.gotype.Kind() == reflect.Array:
+			typ.tc = utc
+			typ.gotype = reflect.ArrayOf(typ.len, z.gotype)
+		default:
+			typ.tc = utc
+			typ.gotype = z.gotype
+		}
+	}
+	return typ
+}
+
+// tzeroValue returns the value of the TZERO card of the column col.
+// The offsets of unsigned integer columns are written as integers.
+func tzeroValue(col *Column) interface{} {
+	tc := col.dtype.tc
+	if tc < 0 {
+		tc = -tc
+	}
+	if _, ok := g_tzero[tc]; !ok {
+		return col.Bzero
+	}
+	if tc == tcUint64 {
+		var z big.Int
+		z.SetUint64(1 << 63)
+		return z
+	}
+	return int(col.Bzero)
+}
+
 var g_gotype2FITS = map[reflect.Kind]map[HDUType]string{
 
 	reflect.Bool: {
@@ -796,7 +1026,7 @@ var g_gotype2FITS = map[reflect.Kind]map[HDUType]string{
 
 	reflect.Uint: {
 		ASCII_TBL:  "I4",
-		BINARY_TBL: "V",
+		BINARY_TBL: "K",
 	},
 
 	reflect.Uint8: {
@@ -806,17 +1036,17 @@ var g_gotype2FITS = map[reflect.Kind]map[HDUType]string{
 
 	reflect.Uint16: {
 		ASCII_TBL:  "I4",
-		BINARY_TBL: "U",
+		BINARY_TBL: "I",
 	},
 
 	reflect.Uint32: {
 		ASCII_TBL:  "I4",
-		BINARY_TBL: "V",
+		BINARY_TBL: "J",
 	},
 
 	reflect.Uint64: {
 		ASCII_TBL:  "I4",
-		BINARY_TBL: "V",
+		BINARY_TBL: "K",
 	},
 
 	reflect.Uintptr: {
@@ -0,0 +1,381 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Severity is the severity of a Finding.
+type Severity int
+
+const (
+	SeverityWarning Severity = iota + 1 // deviation from the FITS standard most readers tolerate
+	SeverityError                       // violation of the FITS standard
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Finding is a deviation from the FITS standard, as reported by Verify.
+type Finding struct {
+	HDU      int      `json:"hdu"`               // index of the HDU, or -1 for the file as a whole
+	Offset   int64    `json:"offset"`            // offset in the file of the header of the HDU, or of the end of the file
+	Keyword  string   `json:"keyword,omitempty"` // keyword of the offending card, if any
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	var where string
+	switch {
+	case f.HDU < 0:
+		where = "file"
+	case f.Keyword != "":
+		where = fmt.Sprintf("HDU #%d: %s", f.HDU, f.Keyword)
+	default:
+		where = fmt.Sprintf("HDU #%d", f.HDU)
+	}
+	return fmt.Sprintf("%s: %v: %s", where, f.Severity, f.Message)
+}
+
+// Verify reads the FITS stream r and checks that it follows the FITS
+// standard: structure of the headers and of their cards, mandatory
+// keywords, size and padding of the data units, CHECKSUM and DATASUM
+// keywords (when present), and decoding of each HDU by this package.
+//
+// Deviations from the standard are returned as findings, in the order of
+// the stream. Verify returns an error only when r could not be read.
+// HDUs are read one at a time, so that the memory used is bounded by the
+// size of the largest HDU.
+func Verify(r io.Reader) ([]Finding, error) {
+	v := verifier{r: NewBlockReader(r)}
+	for {
+		done, err := v.hdu()
+		if err != nil {
+			return v.findings, err
+		}
+		if done {
+			return v.findings, nil
+		}
+	}
+}
+
+type verifier struct {
+	r        *BlockReader
+	findings []Finding
+
+	ihdu   int   // index of the current HDU
+	offset int64 // offset of the header of the current HDU
+	extend bool  // whether the primary header holds EXTEND = T
+}
+
+func (v *verifier) addf(sev Severity, key, format string, args ...interface{}) {
+	v.findings = append(v.findings, Finding{
+		HDU:      v.ihdu,
+		Offset:   v.offset,
+		Keyword:  key,
+		Severity: sev,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// hdu verifies the next HDU and returns whether the end of the stream, or
+// a structural error preventing to locate the next HDU, was reached.
+func (v *verifier) hdu() (bool, error) {
+	v.offset = v.r.Offset()
+
+	var hdr []byte
+	for end := false; !end; {
+		block, err := v.r.ReadBlock()
+		switch {
+		case err == io.EOF && len(hdr) == 0:
+			if v.ihdu == 0 {
+				v.findings = append(v.findings, Finding{HDU: -1, Severity: SeverityError, Message: "empty file"})
+			}
+			return true, nil
+		case errors.Is(err, ErrShortData) || err == io.EOF:
+			v.addf(SeverityError, "", "header truncated at offset %d (missing END card)", v.r.Offset())
+			return true, nil
+		case err != nil:
+			return true, err
+		}
+		hdr = append(hdr, block...)
+		end = v.endCard(hdr) >= 0
+	}
+
+	cards := v.cards(hdr)
+	if v.ihdu == 0 {
+		v.primary(cards)
+	} else {
+		v.extension(cards)
+	}
+
+	size, err := rawDataSize(cards)
+	if err != nil || size < 0 {
+		v.addf(SeverityError, "", "could not compute the size of the data unit: %v", err)
+		return true, nil
+	}
+	size += int64(PadBlock(int(size % BlockSize)))
+
+	raw := bytes.NewBuffer(hdr)
+	n, err := io.CopyN(raw, v.r.r, size)
+	v.r.n += n
+	switch {
+	case err == io.EOF:
+		v.addf(SeverityError, "", "data unit truncated: %d bytes missing", size-n)
+		return true, nil
+	case err != nil:
+		return true, err
+	}
+	data := raw.Bytes()[len(hdr):]
+
+	v.padding(cards, data)
+	v.checksums(cards, hdr, data)
+
+	dec := &streamDecoder{
+		r:     &countReader{r: bytes.NewReader(raw.Bytes())},
+		nhdus: v.ihdu,
+	}
+	hdu, err := dec.DecodeHDUContext(context.Background())
+	if err != nil {
+		v.addf(SeverityError, "", "could not decode HDU: %v", err)
+	} else {
+		hdu.Close()
+	}
+
+	v.ihdu++
+	return false, nil
+}
+
+// endCard returns the index of the END card of the raw header hdr, or -1.
+func (v *verifier) endCard(hdr []byte) int {
+	for i := 0; i < len(hdr); i += 80 {
+		if string(hdr[i:i+8]) == "END     " {
+			return i / 80
+		}
+	}
+	return -1
+}
+
+// cards checks and parses the cards of the raw header hdr.
+func (v *verifier) cards(hdr []byte) []Card {
+	var (
+		cards []Card
+		iend  = v.endCard(hdr)
+		seen  = make(map[string]bool)
+	)
+	for i := 0; i < iend; i++ {
+		line := hdr[80*i : 80*(i+1)]
+		if j := bytes.IndexFunc(line, func(r rune) bool { return r < 0x20 || r > 0x7e }); j >= 0 {
+			v.addf(SeverityError, "", "card #%d holds a non-printable character (0x%02x) at column %d", i+1, line[j], j+1)
+			continue
+		}
+		card, err := parseHeaderLine(line)
+		if err != nil {
+			v.addf(SeverityError, strings.TrimSpace(string(line[:8])), "could not parse card #%d: %v", i+1, err)
+			continue
+		}
+		key := strings.TrimSpace(string(line[:8]))
+		if strings.Trim(key, "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_") != "" {
+			v.addf(SeverityWarning, key, "invalid keyword (only upper-case letters, digits, '-' and '_' are allowed)")
+		}
+		switch {
+		case isCommentaryKey(card.Name) || card.Name == "CONTINUE":
+		case seen[card.Name]:
+			v.addf(SeverityWarning, card.Name, "duplicate keyword")
+		default:
+			seen[card.Name] = true
+		}
+		cards = append(cards, *card)
+	}
+	if fill := hdr[80*(iend+1):]; len(bytes.Trim(fill, " ")) != 0 {
+		v.addf(SeverityWarning, "END", "header fill after the END card is not made of spaces")
+	}
+	return cards
+}
+
+// mandatory checks that cards start with the given keywords, in order.
+// It returns whether they do.
+func (v *verifier) mandatory(cards []Card, keys ...string) bool {
+	for i, key := range keys {
+		switch {
+		case i >= len(cards):
+			v.addf(SeverityError, key, "missing mandatory keyword")
+			return false
+		case cards[i].Name != key:
+			v.addf(SeverityError, key, "mandatory keyword missing or out of order (found %s at card #%d)", cards[i].Name, i+1)
+			return false
+		}
+	}
+	return true
+}
+
+// axes checks the BITPIX, NAXIS and NAXISn keywords of cards, which must
+// start at card #2, and returns the keywords of these cards.
+func (v *verifier) axes(cards []Card) ([]string, bool) {
+	keys := []string{cards[0].Name, "BITPIX", "NAXIS"}
+	if !v.mandatory(cards, keys...) {
+		return nil, false
+	}
+	switch bitpix := cards[1].Value; bitpix {
+	case 8, 16, 32, 64, -32, -64:
+	default:
+		v.addf(SeverityError, "BITPIX", "invalid value (%v)", bitpix)
+	}
+	naxis, ok := cards[2].Value.(int)
+	if !ok || naxis < 0 || naxis > 999 {
+		v.addf(SeverityError, "NAXIS", "invalid value (%v)", cards[2].Value)
+		return nil, false
+	}
+	for i := 1; i <= naxis; i++ {
+		keys = append(keys, "NAXIS"+strconv.Itoa(i))
+	}
+	if !v.mandatory(cards, keys...) {
+		return nil, false
+	}
+	for _, card := range cards[3:len(keys)] {
+		if n, ok := card.Value.(int); !ok || n < 0 {
+			v.addf(SeverityError, card.Name, "invalid value (%v)", card.Value)
+		}
+	}
+	return keys, true
+}
+
+func (v *verifier) primary(cards []Card) {
+	if !v.mandatory(cards, "SIMPLE") {
+		return
+	}
+	if cards[0].Value != true {
+		v.addf(SeverityWarning, "SIMPLE", "file does not conform to the FITS standard (SIMPLE = %v)", cards[0].Value)
+	}
+	if _, ok := v.axes(cards); !ok {
+		return
+	}
+	for _, card := range cards {
+		if card.Name == "EXTEND" {
+			v.extend = card.Value == true
+		}
+	}
+}
+
+func (v *verifier) extension(cards []Card) {
+	if !v.mandatory(cards, "XTENSION") {
+		return
+	}
+	if v.ihdu == 1 && !v.extend {
+		v.findings = append(v.findings, Finding{
+			HDU:      0,
+			Keyword:  "EXTEND",
+			Severity: SeverityWarning,
+			Message:  "file holds extensions, but the primary header has no EXTEND = T card",
+		})
+	}
+	xtension, _ := cards[0].Value.(string)
+	xtension = strings.TrimSpace(xtension)
+	keys, ok := v.axes(cards)
+	if !ok {
+		return
+	}
+	nax := len(keys)
+	keys = append(keys, "PCOUNT", "GCOUNT")
+	switch xtension {
+	case "TABLE", "BINTABLE":
+		keys = append(keys, "TFIELDS")
+		if cards[1].Value != 8 {
+			v.addf(SeverityError, "BITPIX", "invalid value for a table (%v)", cards[1].Value)
+		}
+		if cards[2].Value != 2 {
+			v.addf(SeverityError, "NAXIS", "invalid value for a table (%v)", cards[2].Value)
+		}
+	case "IMAGE":
+	default:
+		v.addf(SeverityWarning, "XTENSION", "non-standard extension type %q", xtension)
+	}
+	if !v.mandatory(cards, keys...) {
+		return
+	}
+
+	pcount, gcount := cards[nax].Value, cards[nax+1].Value
+	if n, ok := pcount.(int); !ok || n < 0 || (n != 0 && (xtension == "IMAGE" || xtension == "TABLE")) {
+		v.addf(SeverityError, "PCOUNT", "invalid value for a %s extension (%v)", xtension, pcount)
+	}
+	if n, ok := gcount.(int); !ok || n != 1 {
+		v.addf(SeverityError, "GCOUNT", "invalid value (%v)", gcount)
+	}
+}
+
+// padding checks the fill of the data unit data, of the HDU with the
+// given cards.
+func (v *verifier) padding(cards []Card, data []byte) {
+	size, _ := rawDataSize(cards)
+	fill := byte(0)
+	if len(cards) > 0 && cards[0].Name == "XTENSION" && cards[0].Value == "TABLE" {
+		fill = ' '
+	}
+	for _, b := range data[size:] {
+		if b != fill {
+			v.addf(SeverityWarning, "", "data unit fill is not made of %q bytes", fill)
+			return
+		}
+	}
+}
+
+// checksums checks the CHECKSUM and DATASUM keywords (if any) of the HDU
+// with the given cards and raw header and data unit.
+func (v *verifier) checksums(cards []Card, hdr, data []byte) {
+	var datasum, checksum *Card
+	for i := range cards {
+		switch cards[i].Name {
+		case "DATASUM":
+			datasum = &cards[i]
+		case "CHECKSUM":
+			checksum = &cards[i]
+		}
+	}
+	if datasum == nil && checksum == nil {
+		return
+	}
+
+	var sum onesSum
+	sum.Write(data)
+	dsum := sum.Sum32()
+
+	if datasum != nil {
+		str, _ := datasum.Value.(string)
+		want, err := strconv.ParseUint(strings.TrimSpace(str), 10, 32)
+		switch {
+		case err != nil:
+			v.addf(SeverityError, "DATASUM", "invalid value (%v)", datasum.Value)
+		case uint32(want) != dsum:
+			v.addf(SeverityError, "DATASUM", "data unit checksum mismatch (got=%d, want=%d)", dsum, want)
+		}
+	}
+	if checksum != nil {
+		var hsum onesSum
+		hsum.Write(hdr)
+		if sum := addOnes(hsum.Sum32(), dsum); sum != 0xffffffff && sum != 0 {
+			v.addf(SeverityError, "CHECKSUM", "HDU checksum mismatch")
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	img := NewImage(16, []int{3, 2})
+	err := img.Write([]int16{1, 2, 3, 4, 5, 6})
+	if err != nil {
+		t.Fatalf("could not write image: %v", err)
+	}
+	tbl, err := NewTable("tbl", []Column{{Name: "X", Format: "D"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	x := 42.0
+	err = tbl.Write(&x)
+	if err != nil {
+		t.Fatalf("could not write row: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	f, err := Create(buf)
+	if err != nil {
+		t.Fatalf("could not create file: %v", err)
+	}
+	for _, hdu := range []HDU{img, tbl} {
+		err = f.Write(hdu)
+		if err != nil {
+			t.Fatalf("could not write HDU: %v", err)
+		}
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	// add checksums to the primary HDU.
+	var sum bytes.Buffer
+	src := bytes.NewReader(buf.Bytes())
+	for range 2 {
+		err = CopyHDURaw(&sum, src, sum.Len() == 0)
+		if err != nil {
+			t.Fatalf("could not copy HDU: %v", err)
+		}
+	}
+	valid := sum.Bytes()
+
+	corrupted := bytes.Clone(valid)
+	corrupted[2*BlockSize-1] = 1 // last byte of the padding of the primary HDU
+
+	for _, tc := range []struct {
+		name string
+		raw  []byte
+		want []Finding
+	}{
+		{name: "valid", raw: valid},
+		{
+			name: "empty",
+			raw:  nil,
+			want: []Finding{{HDU: -1, Severity: SeverityError, Message: "empty file"}},
+		},
+		{
+			name: "corrupted",
+			raw:  corrupted,
+			want: []Finding{
+				{HDU: 0, Severity: SeverityWarning, Message: "data unit fill is not made of '\\x00' bytes"},
+				{HDU: 0, Keyword: "DATASUM", Severity: SeverityError, Message: "data unit checksum mismatch (got=589837, want=589836)"},
+				{HDU: 0, Keyword: "CHECKSUM", Severity: SeverityError, Message: "HDU checksum mismatch"},
+			},
+		},
+		{
+			name: "truncated",
+			raw:  valid[:len(valid)-1],
+			want: []Finding{
+				{HDU: 1, Offset: 2 * BlockSize, Severity: SeverityError, Message: "data unit truncated: 1 bytes missing"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Verify(bytes.NewReader(tc.raw))
+			if err != nil {
+				t.Fatalf("could not verify file: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid findings:\ngot= %+v\nwant=%+v", got, tc.want)
+			}
+		})
+	}
+
+	raw, err := os.ReadFile("testdata/file-img2-bitpix+08.fits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Verify(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not verify file: %v", err)
+	}
+	want := []Finding{
+		{HDU: 0, Keyword: "EXTEND", Severity: SeverityWarning, Message: "file holds extensions, but the primary header has no EXTEND = T card"},
+		{HDU: 1, Offset: 5760, Keyword: "PCOUNT", Severity: SeverityError, Message: "mandatory keyword missing or out of order (found IMAGENBR at card #6)"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid findings:\ngot= %+v\nwant=%+v", got, want)
+	}
+}
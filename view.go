@@ -0,0 +1,146 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// ColumnBytes returns the bytes of the i-th column as stored in the table
+// buffer, without copying them, and the number of bytes between the starts
+// of two consecutive rows.
+// The value of row irow starts at offset irow*stride of the returned slice.
+// Values of binary tables are big-endian; variable length array columns
+// hold the array descriptors.
+//
+// The returned slice aliases the table buffer: it must not be modified
+// and is invalidated by writes to the table.
+func (t *Table) ColumnBytes(i int) ([]byte, int) {
+	col := &t.cols[i]
+	if t.nrows == 0 {
+		return nil, t.rowsz
+	}
+	width := col.dtype.dsize * col.dtype.len
+	if col.Type().Kind() == reflect.Slice {
+		width = col.dtype.dsize
+	}
+	end := int(t.nrows-1)*t.rowsz + col.offset + width
+	return t.data[col.offset:end:end], t.rowsz
+}
+
+// ColumnView gives read access to the values of a fixed-width scalar column
+// of a binary table, directly from the table buffer and without allocating.
+//
+// The typed accessors panic if the type of the column does not match the
+// accessor, or if the row index is out of range.
+// A ColumnView is invalidated by writes to the table.
+type ColumnView struct {
+	name   string
+	kind   reflect.Kind
+	data   []byte
+	stride int
+	nrows  int
+}
+
+// View returns a view over the i-th column of the table.
+func (t *Table) View(i int) (ColumnView, error) {
+	if i < 0 || i >= len(t.cols) {
+		return ColumnView{}, fmt.Errorf("fitsio: column index out of range (%d)", i)
+	}
+	col := &t.cols[i]
+	if !t.binary {
+		return ColumnView{}, fmt.Errorf("fitsio: column %q is not a binary table column", col.Name)
+	}
+	kind := col.Type().Kind()
+	if scalarDecoder(kind) == nil {
+		return ColumnView{}, fmt.Errorf("fitsio: column %q is not a fixed-width scalar column (%v)", col.Name, col.Type())
+	}
+	data, stride := t.ColumnBytes(i)
+	return ColumnView{
+		name:   col.Name,
+		kind:   kind,
+		data:   data,
+		stride: stride,
+		nrows:  int(t.nrows),
+	}, nil
+}
+
+// Len returns the number of rows of the view.
+func (v ColumnView) Len() int {
+	return v.nrows
+}
+
+// Kind returns the kind of the Go type of the column.
+func (v ColumnView) Kind() reflect.Kind {
+	return v.kind
+}
+
+func (v ColumnView) at(irow int, kind reflect.Kind) []byte {
+	if v.kind != kind {
+		panic(fmt.Errorf("fitsio: can not read column %q of kind %v as %v", v.name, v.kind, kind))
+	}
+	if irow < 0 || irow >= v.nrows {
+		panic(fmt.Errorf("fitsio: row index out of range (%d)", irow))
+	}
+	return v.data[irow*v.stride:]
+}
+
+// BoolAt returns the value of a logical (L) column at row irow.
+func (v ColumnView) BoolAt(irow int) bool {
+	return decodeBool(v.at(irow, reflect.Bool)[0])
+}
+
+// Uint8At returns the value of a byte (B) column at row irow.
+func (v ColumnView) Uint8At(irow int) uint8 {
+	return v.at(irow, reflect.Uint8)[0]
+}
+
+// Int16At returns the value of a 16-bit integer (I) column at row irow.
+func (v ColumnView) Int16At(irow int) int16 {
+	return int16(binary.BigEndian.Uint16(v.at(irow, reflect.Int16)))
+}
+
+// Int32At returns the value of a 32-bit integer (J) column at row irow.
+func (v ColumnView) Int32At(irow int) int32 {
+	return int32(binary.BigEndian.Uint32(v.at(irow, reflect.Int32)))
+}
+
+// Int64At returns the value of a 64-bit integer (K) column at row irow.
+func (v ColumnView) Int64At(irow int) int64 {
+	return int64(binary.BigEndian.Uint64(v.at(irow, reflect.Int64)))
+}
+
+// Float32At returns the value of a single precision (E) column at row irow.
+func (v ColumnView) Float32At(irow int) float32 {
+	return math.Float32frombits(binary.BigEndian.Uint32(v.at(irow, reflect.Float32)))
+}
+
+// Float64At returns the value of a double precision (D) column at row irow.
+func (v ColumnView) Float64At(irow int) float64 {
+	return math.Float64frombits(binary.BigEndian.Uint64(v.at(irow, reflect.Float64)))
+}
+
+// Complex64At returns the value of a single precision complex (C) column
+// at row irow.
+func (v ColumnView) Complex64At(irow int) complex64 {
+	buf := v.at(irow, reflect.Complex64)
+	return complex(
+		math.Float32frombits(binary.BigEndian.Uint32(buf[0:4])),
+		math.Float32frombits(binary.BigEndian.Uint32(buf[4:8])),
+	)
+}
+
+// Complex128At returns the value of a double precision complex (M) column
+// at row irow.
+func (v ColumnView) Complex128At(irow int) complex128 {
+	buf := v.at(irow, reflect.Complex128)
+	return complex(
+		math.Float64frombits(binary.BigEndian.Uint64(buf[0:8])),
+		math.Float64frombits(binary.BigEndian.Uint64(buf[8:16])),
+	)
+}
@@ -0,0 +1,132 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestColumnView(t *testing.T) {
+	tbl := newPlanTable(t, 10)
+	defer tbl.Close()
+
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read table: %v", err)
+	}
+	var want []planData
+	err = rows.ScanAll(&want)
+	if err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+
+	view := func(name string) ColumnView {
+		t.Helper()
+		v, err := tbl.View(tbl.Index(name))
+		if err != nil {
+			t.Fatalf("could not create view of %q: %v", name, err)
+		}
+		if got, want := v.Len(), int(tbl.NumRows()); got != want {
+			t.Fatalf("invalid view length: got=%d, want=%d", got, want)
+		}
+		return v
+	}
+
+	var (
+		u8   = view("B")
+		i16  = view("I16")
+		i32  = view("I32")
+		i64  = view("I64")
+		f32  = view("F32")
+		f64  = view("F64")
+		c64  = view("C64")
+		c128 = view("C128")
+		vb   = view("Bool")
+	)
+	for i, row := range want {
+		switch {
+		case u8.Uint8At(i) != row.U8,
+			i16.Int16At(i) != row.I16,
+			i32.Int32At(i) != row.I32,
+			i64.Int64At(i) != row.I64,
+			f32.Float32At(i) != row.F32,
+			f64.Float64At(i) != row.F64,
+			c64.Complex64At(i) != row.C64,
+			c128.Complex128At(i) != row.C128,
+			vb.BoolAt(i) != row.Bool:
+			t.Fatalf("row %d: invalid view values", i)
+		}
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		sum := 0.0
+		for i := 0; i < f64.Len(); i++ {
+			sum += f64.Float64At(i)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("view accessors allocate (%v allocs)", allocs)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected a panic reading a float64 column as int32")
+			}
+		}()
+		f64.Int32At(0)
+	}()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected a panic reading an out of range row")
+			}
+		}()
+		f64.Float64At(f64.Len())
+	}()
+
+	for _, name := range []string{"Str", "Arr", "Vla"} {
+		_, err = tbl.View(tbl.Index(name))
+		if err == nil {
+			t.Fatalf("expected an error creating a view of %q", name)
+		}
+	}
+	_, err = tbl.View(-1)
+	if err == nil {
+		t.Fatalf("expected an error creating a view of an invalid column")
+	}
+}
+
+func TestColumnBytes(t *testing.T) {
+	tbl := newPlanTable(t, 10)
+	defer tbl.Close()
+
+	icol := tbl.Index("Arr")
+	raw, stride := tbl.ColumnBytes(icol)
+	if stride != tbl.rowsz {
+		t.Fatalf("invalid stride: got=%d, want=%d", stride, tbl.rowsz)
+	}
+	if got, want := len(raw), 9*stride+3*2; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	for i := 0; i < int(tbl.NumRows()); i++ {
+		want := []byte{0, byte(i), 0, byte(i + 1), 0, byte(i + 2)}
+		if got := raw[i*stride : i*stride+6]; !bytes.Equal(got, want) {
+			t.Fatalf("row %d: invalid bytes: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	empty, err := NewTable("empty", []Column{{Name: "x", Format: "D"}}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer empty.Close()
+	raw, _ = empty.ColumnBytes(0)
+	if raw != nil {
+		t.Fatalf("invalid bytes for an empty table: %v", raw)
+	}
+}
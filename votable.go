@@ -0,0 +1,429 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// voTable is the root element of a VOTable document.
+type voTable struct {
+	XMLName   xml.Name     `xml:"VOTABLE"`
+	Version   string       `xml:"version,attr,omitempty"`
+	Xmlns     string       `xml:"xmlns,attr,omitempty"`
+	Resources []voResource `xml:"RESOURCE"`
+}
+
+type voResource struct {
+	Name      string       `xml:"name,attr,omitempty"`
+	Tables    []voTableElt `xml:"TABLE"`
+	Resources []voResource `xml:"RESOURCE"`
+}
+
+type voTableElt struct {
+	Name   string    `xml:"name,attr,omitempty"`
+	NRows  int64     `xml:"nrows,attr,omitempty"`
+	Fields []voField `xml:"FIELD"`
+	Data   *voData   `xml:"DATA"`
+}
+
+type voField struct {
+	Name        string `xml:"name,attr"`
+	Datatype    string `xml:"datatype,attr"`
+	Arraysize   string `xml:"arraysize,attr,omitempty"`
+	Unit        string `xml:"unit,attr,omitempty"`
+	UCD         string `xml:"ucd,attr,omitempty"`
+	Description string `xml:"DESCRIPTION,omitempty"`
+}
+
+type voData struct {
+	TableData *voTableData `xml:"TABLEDATA"`
+	Binary    *struct{}    `xml:"BINARY"`
+	Binary2   *struct{}    `xml:"BINARY2"`
+	FITS      *struct{}    `xml:"FITS"`
+}
+
+type voTableData struct {
+	Rows []voRow `xml:"TR"`
+}
+
+type voRow struct {
+	Cells []string `xml:"TD"`
+}
+
+// WriteVOTable writes the content of the table t to w, as a VOTable
+// document with a single TABLE element, using the TABLEDATA serialization.
+//
+// Column formats are mapped to VOTable datatypes (L to boolean, B to
+// unsignedByte, I to short, J to int, K to long, E to float, D to double,
+// C to floatComplex, M to doubleComplex and A to char), and the units,
+// UCDs and descriptions of the columns (see ColumnMeta) to the unit and
+// ucd attributes and the DESCRIPTION element of the FIELDs.
+func WriteVOTable(w io.Writer, t *Table) error {
+	var err error
+	if t == nil {
+		return fmt.Errorf("fitsio: nil table")
+	}
+
+	elt := voTableElt{
+		Name:   t.Name(),
+		NRows:  t.NumRows(),
+		Fields: make([]voField, len(t.cols)),
+	}
+	for i := range t.cols {
+		elt.Fields[i], err = voFieldFrom(t, i)
+		if err != nil {
+			return err
+		}
+	}
+
+	rows, err := t.Read(0, t.NumRows())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	data := make([]interface{}, len(t.cols))
+	for i := range t.cols {
+		data[i] = reflect.New(t.cols[i].Type()).Interface()
+	}
+
+	tdata := &voTableData{Rows: make([]voRow, 0, t.NumRows())}
+	for rows.Next() {
+		err = rows.Scan(data...)
+		if err != nil {
+			return err
+		}
+		row := voRow{Cells: make([]string, len(data))}
+		for i := range data {
+			row.Cells[i] = voFormatValue(reflect.ValueOf(data[i]).Elem())
+		}
+		tdata.Rows = append(tdata.Rows, row)
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+	elt.Data = &voData{TableData: tdata}
+
+	doc := voTable{
+		Version:   "1.4",
+		Xmlns:     "http://www.ivoa.net/xml/VOTable/v1.3",
+		Resources: []voResource{{Tables: []voTableElt{elt}}},
+	}
+
+	_, err = io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", " ")
+	err = enc.Encode(doc)
+	if err != nil {
+		return fmt.Errorf("fitsio: could not encode VOTable: %w", err)
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// voFieldFrom returns the VOTable FIELD describing the i-th column of t.
+func voFieldFrom(t *Table, i int) (voField, error) {
+	col := &t.cols[i]
+	meta := t.ColMeta(i)
+	field := voField{
+		Name:        col.Name,
+		Unit:        meta.Unit,
+		UCD:         meta.UCD,
+		Description: meta.Comment,
+	}
+
+	rt := col.Type()
+	switch rt.Kind() {
+	case reflect.String:
+		field.Datatype = "char"
+		field.Arraysize = strconv.Itoa(col.dtype.dsize) + "*"
+		return field, nil
+	case reflect.Array:
+		field.Arraysize = strconv.Itoa(rt.Len())
+		if len(col.Dim) > 1 {
+			dims := make([]string, len(col.Dim))
+			for j, dim := range col.Dim {
+				dims[j] = strconv.FormatInt(dim, 10)
+			}
+			field.Arraysize = strings.Join(dims, "x")
+		}
+		rt = rt.Elem()
+	case reflect.Slice:
+		field.Arraysize = "*"
+		rt = rt.Elem()
+	}
+
+	switch rt.Kind() {
+	case reflect.Bool:
+		field.Datatype = "boolean"
+	case reflect.Uint8:
+		field.Datatype = "unsignedByte"
+	case reflect.Int16:
+		field.Datatype = "short"
+	case reflect.Int32:
+		field.Datatype = "int"
+	case reflect.Int64, reflect.Int:
+		field.Datatype = "long"
+	case reflect.Float32:
+		field.Datatype = "float"
+	case reflect.Float64:
+		field.Datatype = "double"
+	case reflect.Complex64:
+		field.Datatype = "floatComplex"
+	case reflect.Complex128:
+		field.Datatype = "doubleComplex"
+	default:
+		return field, fmt.Errorf("fitsio: no VOTable datatype for column %q (%v)", col.Name, col.Type())
+	}
+	return field, nil
+}
+
+// voFormatValue formats a column value into a VOTable TD content.
+// complex numbers are formatted as their real and imaginary parts,
+// separated by a space.
+func voFormatValue(rv reflect.Value) string {
+	switch rv.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		bits := rv.Type().Bits() / 2
+		c := rv.Complex()
+		return strconv.FormatFloat(real(c), 'g', -1, bits) + " " + strconv.FormatFloat(imag(c), 'g', -1, bits)
+	case reflect.Array, reflect.Slice:
+		elems := make([]string, rv.Len())
+		for i := range elems {
+			elems[i] = voFormatValue(rv.Index(i))
+		}
+		return strings.Join(elems, " ")
+	}
+	return formatValue(rv)
+}
+
+// ReadVOTable reads a VOTable document from r and creates a new binary
+// table from its first TABLE element.
+// Only the TABLEDATA serialization is supported.
+//
+// VOTable datatypes are mapped to column formats as WriteVOTable does;
+// char and unicodeChar fields are stored as strings wide enough for the
+// longest value. Fixed-size arrays are stored as repeated formats (with a
+// TDIM for multidimensional arrays) and variable-size arrays as variable
+// length arrays.
+// Empty TD elements are read as zero values.
+func ReadVOTable(r io.Reader) (*Table, error) {
+	var doc voTable
+	err := xml.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("fitsio: could not decode VOTable: %w", err)
+	}
+
+	elt := findVOTable(doc.Resources)
+	if elt == nil {
+		return nil, fmt.Errorf("fitsio: no TABLE in VOTable")
+	}
+
+	var rows []voRow
+	if elt.Data != nil {
+		if elt.Data.TableData == nil {
+			return nil, fmt.Errorf("fitsio: unsupported VOTable serialization (only TABLEDATA is supported)")
+		}
+		rows = elt.Data.TableData.Rows
+	}
+
+	cols := make([]Column, len(elt.Fields))
+	for i, field := range elt.Fields {
+		cols[i], err = voColumnFrom(field, rows, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tbl, err := NewTable(elt.Name, cols, BINARY_TBL)
+	if err != nil {
+		return nil, err
+	}
+	for i, field := range elt.Fields {
+		err = tbl.SetColMeta(i, ColumnMeta{UCD: field.UCD, Comment: strings.TrimSpace(field.Description)})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data := make([]interface{}, len(cols))
+	for i := range tbl.cols {
+		data[i] = reflect.New(tbl.cols[i].Type()).Interface()
+	}
+	for irow, row := range rows {
+		if len(row.Cells) != len(cols) {
+			return nil, fmt.Errorf(
+				"fitsio: invalid number of VOTable cells in row %d (got=%d, want=%d)",
+				irow, len(row.Cells), len(cols),
+			)
+		}
+		for i := range data {
+			err = voParseValue(reflect.ValueOf(data[i]).Elem(), row.Cells[i])
+			if err != nil {
+				return nil, fmt.Errorf(
+					"fitsio: error parsing VOTable row %d, field %q: %w",
+					irow, cols[i].Name, err,
+				)
+			}
+		}
+		err = tbl.Write(data...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tbl, nil
+}
+
+// findVOTable returns the first TABLE element of the resources, or nil.
+func findVOTable(resources []voResource) *voTableElt {
+	for i := range resources {
+		res := &resources[i]
+		if len(res.Tables) > 0 {
+			return &res.Tables[0]
+		}
+		if elt := findVOTable(res.Resources); elt != nil {
+			return elt
+		}
+	}
+	return nil
+}
+
+// voColumnFrom returns the column holding the values of the i-th field
+// of the rows.
+func voColumnFrom(field voField, rows []voRow, i int) (Column, error) {
+	col := Column{Name: field.Name, Unit: field.Unit}
+
+	var code string
+	switch field.Datatype {
+	case "char", "unicodeChar":
+		width := 1
+		for _, row := range rows {
+			if i < len(row.Cells) && len(row.Cells[i]) > width {
+				width = len(row.Cells[i])
+			}
+		}
+		col.Format = fmt.Sprintf("%dA", width)
+		return col, nil
+	case "boolean":
+		code = "L"
+	case "unsignedByte":
+		code = "B"
+	case "short":
+		code = "I"
+	case "int":
+		code = "J"
+	case "long":
+		code = "K"
+	case "float":
+		code = "E"
+	case "double":
+		code = "D"
+	case "floatComplex":
+		code = "C"
+	case "doubleComplex":
+		code = "M"
+	default:
+		return col, fmt.Errorf("fitsio: unsupported VOTable datatype %q for field %q", field.Datatype, field.Name)
+	}
+
+	size := strings.TrimSpace(field.Arraysize)
+	switch {
+	case size == "" || size == "1":
+		col.Format = code
+	case strings.HasSuffix(size, "*"):
+		col.Format = "P" + code
+	default:
+		repeat := int64(1)
+		for _, tok := range strings.Split(size, "x") {
+			dim, err := strconv.ParseInt(tok, 10, 64)
+			if err != nil || dim <= 0 {
+				return col, fmt.Errorf("fitsio: invalid VOTable arraysize %q for field %q", field.Arraysize, field.Name)
+			}
+			col.Dim = append(col.Dim, dim)
+			repeat *= dim
+		}
+		if len(col.Dim) == 1 {
+			col.Dim = nil
+		}
+		col.Format = strconv.FormatInt(repeat, 10) + code
+	}
+	return col, nil
+}
+
+// voParseValue parses the VOTable TD content str into the value rv.
+// it is the inverse of voFormatValue.
+func voParseValue(rv reflect.Value, str string) error {
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(str)
+		return nil
+	case reflect.Bool:
+		switch strings.TrimSpace(str) {
+		case "1":
+			str = "T"
+		case "0", "?":
+			str = "F"
+		}
+	case reflect.Complex64, reflect.Complex128:
+		toks := strings.Fields(str)
+		switch len(toks) {
+		case 0:
+			rv.SetComplex(0)
+			return nil
+		case 2:
+			bits := rv.Type().Bits() / 2
+			re, err := strconv.ParseFloat(toks[0], bits)
+			if err != nil {
+				return err
+			}
+			im, err := strconv.ParseFloat(toks[1], bits)
+			if err != nil {
+				return err
+			}
+			rv.SetComplex(complex(re, im))
+			return nil
+		}
+		return fmt.Errorf("fitsio: invalid complex value %q", str)
+	case reflect.Array, reflect.Slice:
+		toks := strings.Fields(str)
+		if k := rv.Type().Elem().Kind(); k == reflect.Complex64 || k == reflect.Complex128 {
+			if len(toks)%2 != 0 {
+				return fmt.Errorf("fitsio: invalid complex array %q", str)
+			}
+			for j := 0; j < len(toks)/2; j++ {
+				toks[j] = toks[2*j] + " " + toks[2*j+1]
+			}
+			toks = toks[:len(toks)/2]
+		}
+		if rv.Kind() == reflect.Slice {
+			rv.Set(reflect.MakeSlice(rv.Type(), len(toks), len(toks)))
+		} else if len(toks) != rv.Len() {
+			if len(toks) == 0 {
+				rv.Set(reflect.Zero(rv.Type()))
+				return nil
+			}
+			return fmt.Errorf("fitsio: invalid number of array elements (got=%d, want=%d)", len(toks), rv.Len())
+		}
+		for j, tok := range toks {
+			err := voParseValue(rv.Index(j), tok)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return parseValue(rv, str)
+}
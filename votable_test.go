@@ -0,0 +1,218 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVOTableRoundTrip(t *testing.T) {
+	type rowType struct {
+		Flag bool          `fits:"FLAG"`
+		Byte uint8         `fits:"BYTE"`
+		I16  int16         `fits:"I16"`
+		I32  int32         `fits:"I32"`
+		I64  int64         `fits:"I64"`
+		F32  float32       `fits:"F32"`
+		F64  float64       `fits:"F64"`
+		C64  complex64     `fits:"C64"`
+		C128 complex128    `fits:"C128"`
+		Str  string        `fits:"STR"`
+		Arr  [3]float64    `fits:"ARR"`
+		Img  [6]float32    `fits:"IMG"`
+		VLA  []int32       `fits:"VLA"`
+		CArr [2]complex128 `fits:"CARR"`
+	}
+	cols := []Column{
+		{Name: "FLAG", Format: "L"},
+		{Name: "BYTE", Format: "B"},
+		{Name: "I16", Format: "I"},
+		{Name: "I32", Format: "J"},
+		{Name: "I64", Format: "K"},
+		{Name: "F32", Format: "E", Unit: "m"},
+		{Name: "F64", Format: "D", Unit: "deg"},
+		{Name: "C64", Format: "C"},
+		{Name: "C128", Format: "M"},
+		{Name: "STR", Format: "10A"},
+		{Name: "ARR", Format: "3D"},
+		{Name: "IMG", Format: "6E", Dim: []int64{3, 2}},
+		{Name: "VLA", Format: "PJ"},
+		{Name: "CARR", Format: "2M"},
+	}
+	tbl, err := NewTable("CATALOG", cols, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %+v", err)
+	}
+	defer tbl.Close()
+
+	err = tbl.SetColMeta(6, ColumnMeta{UCD: "pos.eq.ra", Comment: "right ascension"})
+	if err != nil {
+		t.Fatalf("could not set column metadata: %+v", err)
+	}
+
+	want := []rowType{
+		{
+			Flag: true, Byte: 255, I16: -2, I32: 1 << 20, I64: -1 << 40,
+			F32: 1.5, F64: 1.0 / 3, C64: 1 - 2i, C128: -0.5 + 3i,
+			Str: "a <b> & c", Arr: [3]float64{1, 2, 3},
+			Img: [6]float32{1, 2, 3, 4, 5, 6}, VLA: []int32{1, 2},
+			CArr: [2]complex128{1 + 1i, 2 - 2i},
+		},
+		{Str: ""},
+	}
+	for i := range want {
+		err = tbl.Write(&want[i])
+		if err != nil {
+			t.Fatalf("could not write row %d: %+v", i, err)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	err = WriteVOTable(buf, tbl)
+	if err != nil {
+		t.Fatalf("could not write VOTable: %+v", err)
+	}
+	for _, want := range []string{
+		`<FIELD name="F64" datatype="double" unit="deg" ucd="pos.eq.ra">`,
+		`<DESCRIPTION>right ascension</DESCRIPTION>`,
+		`<FIELD name="IMG" datatype="float" arraysize="3x2">`,
+		`<FIELD name="VLA" datatype="int" arraysize="*">`,
+		`<TD>a &lt;b&gt; &amp; c</TD>`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("missing %q in VOTable:\n%s", want, buf.String())
+		}
+	}
+
+	got, err := ReadVOTable(buf)
+	if err != nil {
+		t.Fatalf("could not read VOTable: %+v", err)
+	}
+	defer got.Close()
+
+	if got, want := got.Name(), "CATALOG"; got != want {
+		t.Fatalf("invalid table name: got=%q, want=%q", got, want)
+	}
+	if got, want := got.Col(11).Dim, []int64{3, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid dimensions: got=%v, want=%v", got, want)
+	}
+	if got, want := got.ColMeta(6), (ColumnMeta{Unit: "deg", UCD: "pos.eq.ra", Comment: "right ascension"}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid column metadata:\ngot= %#v\nwant=%#v", got, want)
+	}
+
+	rows, err := got.Read(0, got.NumRows())
+	if err != nil {
+		t.Fatalf("could not read rows: %+v", err)
+	}
+	defer rows.Close()
+	var i int
+	for rows.Next() {
+		var row rowType
+		err = rows.Scan(&row)
+		if err != nil {
+			t.Fatalf("could not scan row %d: %+v", i, err)
+		}
+		if !reflect.DeepEqual(row, want[i]) {
+			t.Fatalf("row %d: invalid round-trip:\ngot= %+v\nwant=%+v", i, row, want[i])
+		}
+		i++
+	}
+	if i != len(want) {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", i, len(want))
+	}
+}
+
+func TestReadVOTable(t *testing.T) {
+	const doc = `<?xml version="1.0"?>
+<VOTABLE version="1.3" xmlns="http://www.ivoa.net/xml/VOTable/v1.3">
+ <RESOURCE name="results">
+  <INFO name="QUERY_STATUS" value="OK"/>
+  <RESOURCE>
+   <TABLE name="stars">
+    <FIELD name="id" datatype="long"/>
+    <FIELD name="name" datatype="char" arraysize="*" ucd="meta.id"/>
+    <FIELD name="det" datatype="boolean"/>
+    <FIELD name="mag" datatype="float" unit="mag"/>
+    <DATA>
+     <TABLEDATA>
+      <TR><TD>1</TD><TD>Vega</TD><TD>1</TD><TD>0.03</TD></TR>
+      <TR><TD>2</TD><TD>Betelgeuse</TD><TD>?</TD><TD></TD></TR>
+     </TABLEDATA>
+    </DATA>
+   </TABLE>
+  </RESOURCE>
+ </RESOURCE>
+</VOTABLE>
+`
+	tbl, err := ReadVOTable(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("could not read VOTable: %+v", err)
+	}
+	defer tbl.Close()
+
+	var formats []string
+	for _, col := range tbl.Cols() {
+		formats = append(formats, col.Format)
+	}
+	if got, want := formats, []string{"K", "10A", "L", "E"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid formats: got=%q, want=%q", got, want)
+	}
+	if got, want := tbl.ColMeta(1).UCD, "meta.id"; got != want {
+		t.Fatalf("invalid UCD: got=%q, want=%q", got, want)
+	}
+	if got, want := tbl.Col(3).Unit, "mag"; got != want {
+		t.Fatalf("invalid unit: got=%q, want=%q", got, want)
+	}
+
+	type star struct {
+		ID   int64   `fits:"id"`
+		Name string  `fits:"name"`
+		Det  bool    `fits:"det"`
+		Mag  float32 `fits:"mag"`
+	}
+	want := []star{{1, "Vega", true, 0.03}, {2, "Betelgeuse", false, 0}}
+	rows, err := tbl.Read(0, tbl.NumRows())
+	if err != nil {
+		t.Fatalf("could not read rows: %+v", err)
+	}
+	defer rows.Close()
+	var got []star
+	for rows.Next() {
+		var s star
+		err = rows.Scan(&s)
+		if err != nil {
+			t.Fatalf("could not scan row: %+v", err)
+		}
+		got = append(got, s)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows:\ngot= %+v\nwant=%+v", got, want)
+	}
+}
+
+func TestReadVOTableErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		doc  string
+	}{
+		{"xml", `<VOTABLE><RESOURCE>`},
+		{"no-table", `<VOTABLE><RESOURCE/></VOTABLE>`},
+		{"binary", `<VOTABLE><RESOURCE><TABLE><FIELD name="x" datatype="int"/><DATA><BINARY/></DATA></TABLE></RESOURCE></VOTABLE>`},
+		{"datatype", `<VOTABLE><RESOURCE><TABLE><FIELD name="x" datatype="bit"/></TABLE></RESOURCE></VOTABLE>`},
+		{"arraysize", `<VOTABLE><RESOURCE><TABLE><FIELD name="x" datatype="int" arraysize="2xa"/></TABLE></RESOURCE></VOTABLE>`},
+		{"cells", `<VOTABLE><RESOURCE><TABLE><FIELD name="x" datatype="int"/><DATA><TABLEDATA><TR><TD>1</TD><TD>2</TD></TR></TABLEDATA></DATA></TABLE></RESOURCE></VOTABLE>`},
+		{"value", `<VOTABLE><RESOURCE><TABLE><FIELD name="x" datatype="int"/><DATA><TABLEDATA><TR><TD>one</TD></TR></TABLEDATA></DATA></TABLE></RESOURCE></VOTABLE>`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ReadVOTable(strings.NewReader(tc.doc))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
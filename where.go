@@ -0,0 +1,140 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// RowView gives read access to the values of a row of a table, directly
+// from the table buffer, as passed to the predicate of Table.ReadWhere.
+//
+// The typed accessors take the index of a column and panic if the Go type
+// of the column does not match the accessor, or if the column index is
+// out of range.
+// A RowView is only valid during the call to the predicate.
+type RowView struct {
+	v    *rowViewer
+	irow int64
+}
+
+// rowViewer holds the decoders of the columns of a table, shared by the
+// RowView values of a ReadWhere query.
+type rowViewer struct {
+	table *Table
+	decs  []func(p unsafe.Pointer, buf []byte)
+}
+
+// ReadWhere returns an iterator over the rows of the table for which pred
+// returns true.
+// pred is called with a view over each row, during the iteration, before
+// the row is decoded by Rows.Scan.
+func (t *Table) ReadWhere(pred func(RowView) bool) (*Rows, error) {
+	if pred == nil {
+		return nil, fmt.Errorf("fitsio: nil ReadWhere predicate")
+	}
+	rows, err := t.Read(0, t.NumRows())
+	if err != nil {
+		return nil, err
+	}
+
+	v := &rowViewer{
+		table: t,
+		decs:  make([]func(p unsafe.Pointer, buf []byte), len(t.cols)),
+	}
+	for i := range t.cols {
+		v.decs[i] = planDecoder(t, &t.cols[i])
+	}
+	rows.pred = func(irow int64) bool {
+		return pred(RowView{v: v, irow: irow})
+	}
+	return rows, nil
+}
+
+// Index returns the index of the row in the table.
+func (r RowView) Index() int64 {
+	return r.irow
+}
+
+// rowValue decodes the value of the icol-th column of the row r.
+func rowValue[T any](r RowView, icol int) T {
+	var (
+		v   T
+		t   = r.v.table
+		col = &t.cols[icol]
+	)
+	if rt := reflect.TypeOf(v); col.Type() != rt {
+		panic(fmt.Errorf("fitsio: can not read column %q of type %v as %v", col.Name, col.Type(), rt))
+	}
+	if dec := r.v.decs[icol]; dec != nil {
+		beg := int(r.irow)*t.rowsz + col.offset
+		dec(unsafe.Pointer(&v), t.data[beg:beg+col.dtype.dsize*col.dtype.len])
+		return v
+	}
+	err := col.read(t, icol, r.irow, &v)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// BoolAt returns the value of the logical (L) column icol.
+func (r RowView) BoolAt(icol int) bool {
+	return rowValue[bool](r, icol)
+}
+
+// Uint8At returns the value of the byte (B) column icol.
+func (r RowView) Uint8At(icol int) uint8 {
+	return rowValue[uint8](r, icol)
+}
+
+// Int16At returns the value of the 16-bit integer (I) column icol.
+func (r RowView) Int16At(icol int) int16 {
+	return rowValue[int16](r, icol)
+}
+
+// Int32At returns the value of the 32-bit integer (J) column icol.
+func (r RowView) Int32At(icol int) int32 {
+	return rowValue[int32](r, icol)
+}
+
+// Int64At returns the value of the 64-bit integer (K) column icol.
+func (r RowView) Int64At(icol int) int64 {
+	return rowValue[int64](r, icol)
+}
+
+// IntAt returns the value of the integer column icol of an ASCII table.
+func (r RowView) IntAt(icol int) int {
+	return rowValue[int](r, icol)
+}
+
+// Float32At returns the value of the single precision (E) column icol.
+func (r RowView) Float32At(icol int) float32 {
+	return rowValue[float32](r, icol)
+}
+
+// Float64At returns the value of the double precision (D) column icol.
+func (r RowView) Float64At(icol int) float64 {
+	return rowValue[float64](r, icol)
+}
+
+// Complex64At returns the value of the single precision complex (C)
+// column icol.
+func (r RowView) Complex64At(icol int) complex64 {
+	return rowValue[complex64](r, icol)
+}
+
+// Complex128At returns the value of the double precision complex (M)
+// column icol.
+func (r RowView) Complex128At(icol int) complex128 {
+	return rowValue[complex128](r, icol)
+}
+
+// StringAt returns the value of the character (A) column icol.
+func (r RowView) StringAt(icol int) string {
+	return rowValue[string](r, icol)
+}
@@ -0,0 +1,151 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fitsio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadWhere(t *testing.T) {
+	type Star struct {
+		ID   int32   `fits:"ID"`
+		Name string  `fits:"NAME"`
+		Mag  float64 `fits:"MAG"`
+		Var  bool    `fits:"VAR"`
+	}
+	stars := []Star{
+		{1, "vega", 0.03, false},
+		{2, "deneb", 1.25, false},
+		{3, "algol", 2.1, true},
+		{4, "mira", 3.5, true},
+		{5, "rigel", 0.13, true},
+	}
+
+	tbl, err := NewTable("stars", []Column{
+		{Name: "ID", Format: "J"},
+		{Name: "NAME", Format: "8A"},
+		{Name: "MAG", Format: "D"},
+		{Name: "VAR", Format: "L"},
+	}, BINARY_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	err = WriteRows(tbl, stars)
+	if err != nil {
+		t.Fatalf("could not write rows: %v", err)
+	}
+
+	var (
+		imag = tbl.Index("MAG")
+		ivar = tbl.Index("VAR")
+		name = tbl.Index("NAME")
+	)
+	rows, err := tbl.ReadWhere(func(r RowView) bool {
+		return r.BoolAt(ivar) && r.Float64At(imag) < 3
+	})
+	if err != nil {
+		t.Fatalf("could not query table: %v", err)
+	}
+	var (
+		got []Star
+		idx []int64
+	)
+	for rows.Next() {
+		var s Star
+		err = rows.Scan(&s)
+		if err != nil {
+			t.Fatalf("could not scan row: %v", err)
+		}
+		got = append(got, s)
+		idx = append(idx, rows.cur)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("iteration error: %v", err)
+	}
+	if want := []Star{stars[2], stars[4]}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows:\ngot= %+v\nwant=%+v", got, want)
+	}
+	if want := []int64{2, 4}; !reflect.DeepEqual(idx, want) {
+		t.Fatalf("invalid row indices: got=%v, want=%v", idx, want)
+	}
+
+	rows, err = tbl.ReadWhere(func(r RowView) bool {
+		return r.StringAt(name) == "mira" || r.Index() == 0
+	})
+	if err != nil {
+		t.Fatalf("could not query table: %v", err)
+	}
+	var all []Star
+	err = rows.ScanAll(&all)
+	if err != nil {
+		t.Fatalf("could not scan rows: %v", err)
+	}
+	if want := []Star{stars[0], stars[3]}; !reflect.DeepEqual(all, want) {
+		t.Fatalf("invalid rows:\ngot= %+v\nwant=%+v", all, want)
+	}
+
+	rows, err = tbl.ReadWhere(func(r RowView) bool { return r.Int64At(0) > 0 })
+	if err != nil {
+		t.Fatalf("could not query table: %v", err)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected a panic for a type mismatch")
+			}
+		}()
+		rows.Next()
+	}()
+
+	_, err = tbl.ReadWhere(nil)
+	if err == nil {
+		t.Fatalf("expected an error for a nil predicate")
+	}
+}
+
+func TestReadWhereASCII(t *testing.T) {
+	tbl, err := NewTable("ascii", []Column{
+		{Name: "ID", Format: "I4"},
+		{Name: "X", Format: "E10.3"},
+	}, ASCII_TBL)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+	defer tbl.Close()
+	for i := 0; i < 6; i++ {
+		var (
+			id = i
+			x  = float64(i) / 2
+		)
+		err = tbl.Write(&id, &x)
+		if err != nil {
+			t.Fatalf("could not write row: %v", err)
+		}
+	}
+
+	rows, err := tbl.ReadWhere(func(r RowView) bool {
+		return r.IntAt(0)%2 == 0 && r.Float64At(1) > 0
+	})
+	if err != nil {
+		t.Fatalf("could not query table: %v", err)
+	}
+	var ids []int
+	for rows.Next() {
+		var (
+			id int
+			x  float64
+		)
+		err = rows.Scan(&id, &x)
+		if err != nil {
+			t.Fatalf("could not scan row: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if want := []int{2, 4}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("invalid rows: got=%v, want=%v", ids, want)
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package xname parses CFITSIO-like extended filenames.
+//
+// An extended filename is a path to a FITS file, optionally followed by
+// an HDU selector and a list of filters, each enclosed in square brackets:
+//
+//	file.fits                     whole file
+//	file.fits[2], file.fits+2     2nd extension
+//	file.fits[GTI]                extension with EXTNAME=GTI
+//	file.fits[SCI,2]              extension with EXTNAME=SCI and EXTVER=2
+//	file.fits[1][col X;Y]         only columns X and Y
+//	file.fits[1][col -PI]         all columns but PI
+//	file.fits[1][#row < 101]      rows filtered by an expression
+//	file.fits[11:50,21:60]        image section
+//	file.fits[events][bin X,Y]    binning specification
+package xname
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Name is a parsed extended filename.
+type Name struct {
+	Path    string   // path to the FITS file
+	HDU     *HDU     // HDU selector, nil if none
+	Cols    []string // column filter. columns prefixed with '-' are excluded
+	Rows    string   // row filter expression
+	Bin     string   // binning specification (e.g. "X,Y")
+	Section string   // image section (e.g. "11:50,21:60")
+}
+
+// HDU selects an HDU, either by index or by name and version.
+type HDU struct {
+	Index   int    // index of the HDU (0 is the primary HDU), -1 when selected by name
+	Name    string // EXTNAME of the HDU
+	Version int    // EXTVER of the HDU, 0 if unspecified
+}
+
+func (hdu HDU) String() string {
+	switch {
+	case hdu.Index >= 0:
+		return strconv.Itoa(hdu.Index)
+	case hdu.Version > 0:
+		return fmt.Sprintf("%s,%d", hdu.Name, hdu.Version)
+	default:
+		return hdu.Name
+	}
+}
+
+var (
+	reHDU     = regexp.MustCompile(`^\s*([A-Za-z0-9_.\-]+)\s*(?:,\s*(\d+)\s*)?$`)
+	reSection = regexp.MustCompile(`^[0-9*:,\-\s]+$`)
+	rePlus    = regexp.MustCompile(`^(.*[^+])\+(\d+)$`)
+)
+
+// Parse parses an extended filename.
+func Parse(name string) (Name, error) {
+	var xn Name
+
+	beg := strings.Index(name, "[")
+	if beg < 0 {
+		beg = len(name)
+	}
+	xn.Path = name[:beg]
+	if xn.Path == "" {
+		return xn, fmt.Errorf("xname: missing file path in %q", name)
+	}
+
+	if m := rePlus.FindStringSubmatch(xn.Path); m != nil {
+		idx, err := strconv.Atoi(m[2])
+		if err != nil {
+			return xn, fmt.Errorf("xname: invalid HDU index in %q: %w", name, err)
+		}
+		xn.Path = m[1]
+		xn.HDU = &HDU{Index: idx}
+	}
+
+	specs, err := split(name[beg:])
+	if err != nil {
+		return xn, fmt.Errorf("xname: %w (name=%q)", err, name)
+	}
+
+	for i, spec := range specs {
+		if i == 0 && xn.HDU == nil {
+			if hdu, ok := parseHDU(spec); ok {
+				xn.HDU = hdu
+				continue
+			}
+		}
+
+		spec = strings.TrimSpace(spec)
+		lower := strings.ToLower(spec)
+		switch {
+		case spec == "":
+			return xn, fmt.Errorf("xname: empty filter in %q", name)
+		case strings.HasPrefix(lower, "col "):
+			xn.Cols = append(xn.Cols, parseCols(spec[len("col "):])...)
+		case lower == "bin" || strings.HasPrefix(lower, "bin "):
+			xn.Bin = strings.TrimSpace(spec[len("bin"):])
+			if xn.Bin == "" {
+				// bin the X and Y columns by default.
+				xn.Bin = "X,Y"
+			}
+		case strings.Contains(spec, ":") && reSection.MatchString(spec):
+			xn.Section = spec
+		default:
+			if xn.Rows != "" {
+				xn.Rows = "(" + xn.Rows + ") && (" + spec + ")"
+				continue
+			}
+			xn.Rows = spec
+		}
+	}
+
+	return xn, nil
+}
+
+// split splits a list of bracket-enclosed specifications.
+func split(str string) ([]string, error) {
+	var specs []string
+	for len(str) > 0 {
+		if str[0] != '[' {
+			return nil, fmt.Errorf("unexpected character %q", str[0])
+		}
+		depth := 0
+		end := -1
+		quote := byte(0)
+	loop:
+		for i := 0; i < len(str); i++ {
+			c := str[i]
+			switch {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+			case c == '"' || c == '\'':
+				quote = c
+			case c == '[':
+				depth++
+			case c == ']':
+				depth--
+				if depth == 0 {
+					end = i
+					break loop
+				}
+			}
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("missing closing ']'")
+		}
+		specs = append(specs, str[1:end])
+		str = str[end+1:]
+	}
+	return specs, nil
+}
+
+func parseHDU(spec string) (*HDU, bool) {
+	m := reHDU.FindStringSubmatch(spec)
+	if m == nil {
+		return nil, false
+	}
+	if idx, err := strconv.Atoi(m[1]); err == nil {
+		if m[2] != "" {
+			return nil, false
+		}
+		return &HDU{Index: idx}, true
+	}
+	hdu := &HDU{Index: -1, Name: m[1]}
+	if m[2] != "" {
+		ver, err := strconv.Atoi(m[2])
+		if err != nil {
+			return nil, false
+		}
+		hdu.Version = ver
+	}
+	return hdu, true
+}
+
+func parseCols(spec string) []string {
+	var cols []string
+	for _, tok := range strings.Split(spec, ";") {
+		if strings.Contains(tok, "=") {
+			cols = append(cols, strings.TrimSpace(tok))
+			continue
+		}
+		for _, col := range strings.Split(tok, ",") {
+			col = strings.TrimSpace(col)
+			if col == "" {
+				continue
+			}
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// Columns applies the column filter to the provided list of column names
+// and returns the names of the selected columns.
+// Column names are matched case-insensitively.
+func (xn Name) Columns(names []string) ([]string, error) {
+	if len(xn.Cols) == 0 {
+		return names, nil
+	}
+
+	find := func(name string) bool {
+		for _, n := range names {
+			if strings.EqualFold(n, name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var (
+		keep []string
+		drop = make(map[string]bool)
+	)
+	for _, col := range xn.Cols {
+		switch {
+		case strings.HasPrefix(col, "-"):
+			col = col[1:]
+			if !find(col) {
+				return nil, fmt.Errorf("xname: unknown column %q", col)
+			}
+			drop[strings.ToUpper(col)] = true
+		case strings.Contains(col, "="):
+			// computed columns are handled by the caller.
+		default:
+			if !find(col) {
+				return nil, fmt.Errorf("xname: unknown column %q", col)
+			}
+			keep = append(keep, col)
+		}
+	}
+
+	var out []string
+	switch {
+	case len(keep) > 0:
+		for _, col := range keep {
+			for _, n := range names {
+				if strings.EqualFold(n, col) && !drop[strings.ToUpper(n)] {
+					out = append(out, n)
+				}
+			}
+		}
+	default:
+		for _, n := range names {
+			if !drop[strings.ToUpper(n)] {
+				out = append(out, n)
+			}
+		}
+	}
+	return out, nil
+}
+
+var reRowCmp = regexp.MustCompile(`^\s*#row\s*(<=|>=|==|=|<|>)\s*(\d+)\s*$`)
+
+// RowRange interprets the row filter as a range of rows [beg, end),
+// with 0-based indices.
+// Only conjunctions of comparisons of the '#row' (1-based) pseudo-column
+// against integer constants are supported, e.g. "#row > 10 && #row <= 20".
+// RowRange returns ok=false if the row filter can not be expressed as a range.
+// When there is no row filter, RowRange returns [0, n).
+func (xn Name) RowRange(n int64) (beg, end int64, ok bool) {
+	beg, end = 0, n
+	if strings.TrimSpace(xn.Rows) == "" {
+		return beg, end, true
+	}
+
+	expr := strings.NewReplacer("(", " ", ")", " ").Replace(xn.Rows)
+	for _, tok := range strings.Split(expr, "&&") {
+		m := reRowCmp.FindStringSubmatch(tok)
+		if m == nil {
+			return 0, 0, false
+		}
+		v, err := strconv.ParseInt(m[2], 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		// #row is 1-based: row index i satisfies #row = i+1.
+		switch m[1] {
+		case "<":
+			end = min64(end, v-1)
+		case "<=":
+			end = min64(end, v)
+		case ">":
+			beg = max64(beg, v)
+		case ">=":
+			beg = max64(beg, v-1)
+		case "=", "==":
+			beg = max64(beg, v-1)
+			end = min64(end, v)
+		}
+	}
+	if beg > n {
+		beg = n
+	}
+	if end < beg {
+		end = beg
+	}
+	return beg, end, true
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,135 @@
+// Copyright ©2026 The astrogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xname
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want Name
+		err  bool
+	}{
+		{name: "file.fits", want: Name{Path: "file.fits"}},
+		{name: "dir/a+b.fits", want: Name{Path: "dir/a+b.fits"}},
+		{name: "file.fits[0]", want: Name{Path: "file.fits", HDU: &HDU{Index: 0}}},
+		{name: "file.fits+2", want: Name{Path: "file.fits", HDU: &HDU{Index: 2}}},
+		{name: "file.fits[GTI]", want: Name{Path: "file.fits", HDU: &HDU{Index: -1, Name: "GTI"}}},
+		{name: "file.fits[SCI, 2]", want: Name{Path: "file.fits", HDU: &HDU{Index: -1, Name: "SCI", Version: 2}}},
+		{
+			name: "tab.fits[1][col X;Y]",
+			want: Name{Path: "tab.fits", HDU: &HDU{Index: 1}, Cols: []string{"X", "Y"}},
+		},
+		{
+			name: "tab.fits[1][col -PI][#row < 101]",
+			want: Name{Path: "tab.fits", HDU: &HDU{Index: 1}, Cols: []string{"-PI"}, Rows: "#row < 101"},
+		},
+		{
+			name: "in.fit[events][col x=.9*y]",
+			want: Name{Path: "in.fit", HDU: &HDU{Index: -1, Name: "events"}, Cols: []string{"x=.9*y"}},
+		},
+		{
+			name: "in.fit[events][pi>35][#row<10]",
+			want: Name{Path: "in.fit", HDU: &HDU{Index: -1, Name: "events"}, Rows: "(pi>35) && (#row<10)"},
+		},
+		{
+			name: "in.fit[events][bin X,Y]",
+			want: Name{Path: "in.fit", HDU: &HDU{Index: -1, Name: "events"}, Bin: "X,Y"},
+		},
+		{
+			name: "in.fit[events][bin]",
+			want: Name{Path: "in.fit", HDU: &HDU{Index: -1, Name: "events"}, Bin: "X,Y"},
+		},
+		{
+			name: "in.fit[11:50,21:60]",
+			want: Name{Path: "in.fit", Section: "11:50,21:60"},
+		},
+		{
+			name: `in.fit[2][regfilter("pow[1].reg")]`,
+			want: Name{Path: "in.fit", HDU: &HDU{Index: 2}, Rows: `regfilter("pow[1].reg")`},
+		},
+		{name: "", err: true},
+		{name: "[1]", err: true},
+		{name: "file.fits[1", err: true},
+		{name: "file.fits[1]x", err: true},
+		{name: "file.fits[1][]", err: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse(test.name)
+			switch {
+			case err != nil && !test.err:
+				t.Fatalf("could not parse name: %v", err)
+			case err == nil && test.err:
+				t.Fatalf("expected an error")
+			case err != nil:
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("invalid parsed name.\ngot= %#v\nwant=%#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestColumns(t *testing.T) {
+	names := []string{"TIME", "X", "Y", "PI"}
+	for _, test := range []struct {
+		cols []string
+		want []string
+		err  bool
+	}{
+		{cols: nil, want: names},
+		{cols: []string{"x", "Y"}, want: []string{"X", "Y"}},
+		{cols: []string{"-PI"}, want: []string{"TIME", "X", "Y"}},
+		{cols: []string{"-PI", "-time"}, want: []string{"X", "Y"}},
+		{cols: []string{"Z"}, err: true},
+	} {
+		xn := Name{Cols: test.cols}
+		got, err := xn.Columns(names)
+		switch {
+		case err != nil && !test.err:
+			t.Fatalf("cols=%q: could not filter columns: %v", test.cols, err)
+		case err == nil && test.err:
+			t.Fatalf("cols=%q: expected an error", test.cols)
+		case err != nil:
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Fatalf("cols=%q: got=%q, want=%q", test.cols, got, test.want)
+		}
+	}
+}
+
+func TestRowRange(t *testing.T) {
+	for _, test := range []struct {
+		rows     string
+		beg, end int64
+		ok       bool
+	}{
+		{rows: "", beg: 0, end: 200, ok: true},
+		{rows: "#row < 101", beg: 0, end: 100, ok: true},
+		{rows: "#row <= 101", beg: 0, end: 101, ok: true},
+		{rows: "#row > 10 && #row <= 20", beg: 10, end: 20, ok: true},
+		{rows: "(#row >= 10) && (#row < 20)", beg: 9, end: 19, ok: true},
+		{rows: "#row == 5", beg: 4, end: 5, ok: true},
+		{rows: "#row > 500", beg: 200, end: 200, ok: true},
+		{rows: "pi > 35", ok: false},
+	} {
+		xn := Name{Rows: test.rows}
+		beg, end, ok := xn.RowRange(200)
+		if ok != test.ok {
+			t.Fatalf("rows=%q: got ok=%v, want=%v", test.rows, ok, test.ok)
+		}
+		if !ok {
+			continue
+		}
+		if beg != test.beg || end != test.end {
+			t.Fatalf("rows=%q: got=[%d, %d), want=[%d, %d)", test.rows, beg, end, test.beg, test.end)
+		}
+	}
+}